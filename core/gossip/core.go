@@ -58,18 +58,19 @@ var (
 
 type dependencies struct {
 	dig.In
-	GossipService    *gossip.Service
-	Broadcaster      *gossip.Broadcaster
-	Requester        *gossip.Requester
-	Storage          *storage.Storage
-	SyncManager      *syncmanager.SyncManager
-	Tangle           *tangle.Tangle
-	SnapshotManager  *snapshot.SnapshotManager
-	ServerMetrics    *metrics.ServerMetrics
-	RequestQueue     gossip.RequestQueue
-	MessageProcessor *gossip.MessageProcessor
-	PeeringManager   *p2p.Manager
-	Host             host.Host
+	GossipService     *gossip.Service
+	Broadcaster       *gossip.Broadcaster
+	Requester         *gossip.Requester
+	Storage           *storage.Storage
+	SyncManager       *syncmanager.SyncManager
+	Tangle            *tangle.Tangle
+	SnapshotManager   *snapshot.SnapshotManager
+	ServerMetrics     *metrics.ServerMetrics
+	RequestQueue      gossip.RequestQueue
+	MessageProcessor  *gossip.MessageProcessor
+	ReputationManager *gossip.ReputationManager
+	PeeringManager    *p2p.Manager
+	Host              host.Host
 }
 
 func provide(c *dig.Container) {
@@ -82,16 +83,17 @@ func provide(c *dig.Container) {
 
 	type msgProcDeps struct {
 		dig.In
-		Storage        *storage.Storage
-		SyncManager    *syncmanager.SyncManager
-		ServerMetrics  *metrics.ServerMetrics
-		RequestQueue   gossip.RequestQueue
-		PeeringManager *p2p.Manager
-		NodeConfig     *configuration.Configuration `name:"nodeConfig"`
-		NetworkID      uint64                       `name:"networkId"`
-		BelowMaxDepth  int                          `name:"belowMaxDepth"`
-		MinPoWScore    float64                      `name:"minPoWScore"`
-		Profile        *profile.Profile
+		Storage           *storage.Storage
+		SyncManager       *syncmanager.SyncManager
+		ServerMetrics     *metrics.ServerMetrics
+		RequestQueue      gossip.RequestQueue
+		PeeringManager    *p2p.Manager
+		ReputationManager *gossip.ReputationManager
+		NodeConfig        *configuration.Configuration `name:"nodeConfig"`
+		NetworkID         uint64                       `name:"networkId"`
+		BelowMaxDepth     int                          `name:"belowMaxDepth"`
+		MinPoWScore       float64                      `name:"minPoWScore"`
+		Profile           *profile.Profile
 	}
 
 	if err := c.Provide(func(deps msgProcDeps) *gossip.MessageProcessor {
@@ -100,6 +102,7 @@ func provide(c *dig.Container) {
 			deps.SyncManager,
 			deps.RequestQueue,
 			deps.PeeringManager,
+			deps.ReputationManager,
 			deps.ServerMetrics,
 			&gossip.Options{
 				MinPoWScore:       deps.MinPoWScore,
@@ -141,12 +144,36 @@ func provide(c *dig.Container) {
 		CorePlugin.LogPanic(err)
 	}
 
+	type reputationManagerDeps struct {
+		dig.In
+		NodeConfig     *configuration.Configuration `name:"nodeConfig"`
+		GossipService  *gossip.Service
+		PeeringManager *p2p.Manager
+	}
+
+	if err := c.Provide(func(deps reputationManagerDeps) *gossip.ReputationManager {
+		return gossip.NewReputationManager(
+			deps.GossipService,
+			deps.PeeringManager,
+			gossip.WithReputationLogger(logger.NewLogger("Reputation")),
+			gossip.WithReputationThresholds(
+				deps.NodeConfig.Int64(CfgP2PGossipReputationDeprioritizeThreshold),
+				deps.NodeConfig.Int64(CfgP2PGossipReputationDisconnectThreshold),
+			),
+			gossip.WithReputationRequestTimeout(deps.NodeConfig.Duration(CfgP2PGossipReputationRequestTimeout)),
+			gossip.WithReputationRecoveryInterval(deps.NodeConfig.Duration(CfgP2PGossipReputationRecoveryInterval)),
+		)
+	}); err != nil {
+		CorePlugin.LogPanic(err)
+	}
+
 	type requesterDeps struct {
 		dig.In
-		NodeConfig    *configuration.Configuration `name:"nodeConfig"`
-		Storage       *storage.Storage
-		GossipService *gossip.Service
-		RequestQueue  gossip.RequestQueue
+		NodeConfig        *configuration.Configuration `name:"nodeConfig"`
+		Storage           *storage.Storage
+		GossipService     *gossip.Service
+		RequestQueue      gossip.RequestQueue
+		ReputationManager *gossip.ReputationManager
 	}
 
 	if err := c.Provide(func(deps requesterDeps) *gossip.Requester {
@@ -154,8 +181,15 @@ func provide(c *dig.Container) {
 			deps.Storage,
 			deps.GossipService,
 			deps.RequestQueue,
+			deps.ReputationManager,
 			gossip.WithRequesterDiscardRequestsOlderThan(deps.NodeConfig.Duration(CfgRequestsDiscardOlderThan)),
-			gossip.WithRequesterPendingRequestReEnqueueInterval(deps.NodeConfig.Duration(CfgRequestsPendingReEnqueueInterval)))
+			gossip.WithRequesterPendingRequestReEnqueueInterval(deps.NodeConfig.Duration(CfgRequestsPendingReEnqueueInterval)),
+			gossip.WithRequesterMaxInFlightPerPeer(deps.NodeConfig.Int(CfgRequestsMaxInFlightPerPeer)),
+			gossip.WithRequesterHedging(
+				deps.NodeConfig.Int(CfgRequestsMaxHedgedRequests),
+				deps.NodeConfig.Duration(CfgRequestsHedgeMinTimeout),
+				deps.NodeConfig.Duration(CfgRequestsHedgeMaxTimeout),
+			))
 	}); err != nil {
 		CorePlugin.LogPanic(err)
 	}
@@ -187,6 +221,12 @@ func configure() {
 		return deps.SnapshotManager.IsSnapshottingOrPruning() || deps.Tangle.IsReceiveTxWorkerPoolBusy()
 	})
 
+	// forget the requests sent to disconnected peers
+	deps.PeeringManager.Events.Disconnected.Attach(events.NewClosure(func(peerOptErr *p2p.PeerOptError) {
+		deps.RequestQueue.RemovePeer(peerOptErr.Peer.ID)
+		deps.ReputationManager.PeerDisconnected(peerOptErr.Peer.ID)
+	}))
+
 	// register event handlers for messages
 	deps.GossipService.Events.ProtocolStarted.Attach(events.NewClosure(func(proto *gossip.Protocol) {
 		addMessageEventHandlers(proto)
@@ -270,6 +310,12 @@ func run() {
 		CorePlugin.LogPanicf("failed to start worker: %s", err)
 	}
 
+	if err := CorePlugin.Daemon().BackgroundWorker("RequestHedger", func(ctx context.Context) {
+		deps.Requester.RunRequestHedger(ctx)
+	}, shutdown.PriorityRequestsProcessor); err != nil {
+		CorePlugin.LogPanicf("failed to start worker: %s", err)
+	}
+
 	if err := CorePlugin.Daemon().BackgroundWorker("BroadcastQueue", func(ctx context.Context) {
 		CorePlugin.LogInfo("Running BroadcastQueue")
 		onBroadcastMessage := events.NewClosure(deps.Broadcaster.Broadcast)
@@ -289,6 +335,14 @@ func run() {
 		CorePlugin.LogPanicf("failed to start worker: %s", err)
 	}
 
+	if err := CorePlugin.Daemon().BackgroundWorker("ReputationManager", func(ctx context.Context) {
+		CorePlugin.LogInfo("Running ReputationManager")
+		deps.ReputationManager.Run(ctx)
+		CorePlugin.LogInfo("Stopped ReputationManager")
+	}, shutdown.PriorityHeartbeats); err != nil {
+		CorePlugin.LogPanicf("failed to start worker: %s", err)
+	}
+
 	if err := CorePlugin.Daemon().BackgroundWorker("HeartbeatBroadcaster", func(ctx context.Context) {
 		ticker := timeutil.NewTicker(checkHeartbeats, checkHeartbeatsInterval, ctx)
 		ticker.WaitForGracefulShutdown()
@@ -331,6 +385,7 @@ func checkHeartbeats() {
 
 		// close the connection to static connected peers, so they will be moved into reconnect pool to reestablish the connection
 		CorePlugin.LogInfof("closing connection to peer %s because we didn't receive heartbeats anymore", proto.PeerID.ShortString())
+		deps.ReputationManager.Record(proto.PeerID, gossip.ReputationEventStaleHeartbeat)
 		peersToReconnect[proto.PeerID] = struct{}{}
 		return true
 	})
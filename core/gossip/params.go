@@ -13,12 +13,28 @@ const (
 	CfgRequestsDiscardOlderThan = "requests.discardOlderThan"
 	// Defines the interval the pending requests are re-enqueued.
 	CfgRequestsPendingReEnqueueInterval = "requests.pendingReEnqueueInterval"
+	// Defines the maximum amount of requests which are awaiting an answer from a single peer.
+	CfgRequestsMaxInFlightPerPeer = "requests.maxInFlightPerPeer"
+	// Defines the maximum amount of additional peers a request is sent to if the asked peers don't answer in time.
+	CfgRequestsMaxHedgedRequests = "requests.maxHedgedRequests"
+	// Defines the lower bound of the adaptive time to wait for an answer before a request is sent to another peer.
+	CfgRequestsHedgeMinTimeout = "requests.hedgeMinTimeout"
+	// Defines the upper bound of the adaptive time to wait for an answer before a request is sent to another peer.
+	CfgRequestsHedgeMaxTimeout = "requests.hedgeMaxTimeout"
 	// Defines the maximum amount of unknown peers a gossip protocol connection is established to.
 	CfgP2PGossipUnknownPeersLimit = "p2p.gossip.unknownPeersLimit"
 	// Defines the read timeout for subsequent reads.
 	CfgP2PGossipStreamReadTimeout = "p2p.gossip.streamReadTimeout"
 	// Defines the write timeout for writes to the stream.
 	CfgP2PGossipStreamWriteTimeout = "p2p.gossip.streamWriteTimeout"
+	// Defines the reputation score below which a peer is only used for requests if no other peer has the data.
+	CfgP2PGossipReputationDeprioritizeThreshold = "p2p.gossip.reputation.deprioritizeThreshold"
+	// Defines the reputation score below which the connection to a not static peer is dropped.
+	CfgP2PGossipReputationDisconnectThreshold = "p2p.gossip.reputation.disconnectThreshold"
+	// Defines the time after which a sent request is considered as unanswered.
+	CfgP2PGossipReputationRequestTimeout = "p2p.gossip.reputation.requestTimeout"
+	// Defines the interval in which the reputation score of a peer moves one point towards zero.
+	CfgP2PGossipReputationRecoveryInterval = "p2p.gossip.reputation.recoveryInterval"
 )
 
 var params = &node.PluginParams{
@@ -27,9 +43,17 @@ var params = &node.PluginParams{
 			fs := flag.NewFlagSet("", flag.ContinueOnError)
 			fs.Duration(CfgRequestsDiscardOlderThan, 15*time.Second, "the maximum time a request stays in the request queue")
 			fs.Duration(CfgRequestsPendingReEnqueueInterval, 5*time.Second, "the interval the pending requests are re-enqueued")
+			fs.Int(CfgRequestsMaxInFlightPerPeer, 200, "the maximum amount of requests which are awaiting an answer from a single peer")
+			fs.Int(CfgRequestsMaxHedgedRequests, 1, "the maximum amount of additional peers a request is sent to if the asked peers don't answer in time")
+			fs.Duration(CfgRequestsHedgeMinTimeout, 500*time.Millisecond, "the lower bound of the adaptive time to wait for an answer before a request is sent to another peer")
+			fs.Duration(CfgRequestsHedgeMaxTimeout, 3*time.Second, "the upper bound of the adaptive time to wait for an answer before a request is sent to another peer")
 			fs.Int(CfgP2PGossipUnknownPeersLimit, 4, "maximum amount of unknown peers a gossip protocol connection is established to")
 			fs.Duration(CfgP2PGossipStreamReadTimeout, 60*time.Second, "the read timeout for reads from the gossip stream")
 			fs.Duration(CfgP2PGossipStreamWriteTimeout, 10*time.Second, "the write timeout for writes to the gossip stream")
+			fs.Int64(CfgP2PGossipReputationDeprioritizeThreshold, -20, "the reputation score below which a peer is only used for requests if no other peer has the data")
+			fs.Int64(CfgP2PGossipReputationDisconnectThreshold, -50, "the reputation score below which the connection to a not static peer is dropped")
+			fs.Duration(CfgP2PGossipReputationRequestTimeout, 10*time.Second, "the time after which a sent request is considered as unanswered")
+			fs.Duration(CfgP2PGossipReputationRecoveryInterval, 1*time.Minute, "the interval in which the reputation score of a peer moves one point towards zero")
 			return fs
 		}(),
 	},
@@ -11,6 +11,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/host"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
 	"go.uber.org/dig"
 
 	"github.com/gohornet/hornet/pkg/database"
@@ -46,6 +47,7 @@ type dependencies struct {
 	Host                 host.Host
 	NodeConfig           *configuration.Configuration `name:"nodeConfig"`
 	PeerStoreContainer   *p2p.PeerStoreContainer
+	AddressBook          *p2p.AddressBook
 	PeeringConfig        *configuration.Configuration `name:"peeringConfig"`
 	PeeringConfigManager *p2p.ConfigManager
 }
@@ -86,6 +88,8 @@ func provide(c *dig.Container) {
 	type p2presult struct {
 		dig.Out
 		PeerStoreContainer *p2p.PeerStoreContainer
+		AddressBook        *p2p.AddressBook
+		ConnectionGater    *p2p.ConnectionGater
 		NodePrivateKey     crypto.PrivKey `name:"nodePrivateKey"`
 		Host               host.Host
 	}
@@ -101,6 +105,7 @@ func provide(c *dig.Container) {
 			CorePlugin.LogPanic(err)
 		}
 		res.PeerStoreContainer = peerStoreContainer
+		res.AddressBook = peerStoreContainer.AddressBook()
 
 		// TODO: temporary migration logic
 		// this should be removed after some time / hornet versions (20.08.21: muXxer)
@@ -132,6 +137,28 @@ Your node identity private key can now be found at "%s".
 			CorePlugin.LogInfof(`loaded existing private key for peer identity from "%s"`, privKeyFilePath)
 		}
 
+		allowList, err := p2p.ParseCIDRs(deps.NodeConfig.Strings(CfgP2PGaterAllowList))
+		if err != nil {
+			CorePlugin.LogPanicf("invalid allow list: %s", err)
+		}
+
+		denyList, err := p2p.ParseCIDRs(deps.NodeConfig.Strings(CfgP2PGaterDenyList))
+		if err != nil {
+			CorePlugin.LogPanicf("invalid deny list: %s", err)
+		}
+
+		connectionGater := p2p.NewConnectionGater(
+			p2p.WithConnectionGaterLogger(logger.NewLogger("ConnectionGater")),
+			p2p.WithConnectionGaterAllowList(allowList),
+			p2p.WithConnectionGaterDenyList(denyList),
+			p2p.WithConnectionGaterInboundSubnetLimits(
+				deps.NodeConfig.Int(CfgP2PGaterMaxInboundPerIPv4Subnet),
+				deps.NodeConfig.Int(CfgP2PGaterMaxInboundPerIPv6Subnet),
+			),
+			p2p.WithConnectionGaterDefaultBanDuration(deps.NodeConfig.Duration(CfgP2PGaterDefaultBanDuration)),
+		)
+		res.ConnectionGater = connectionGater
+
 		createdHost, err := libp2p.New(libp2p.Identity(privKey),
 			libp2p.ListenAddrStrings(deps.P2PBindMultiAddresses...),
 			libp2p.Peerstore(peerStoreContainer.Peerstore()),
@@ -142,10 +169,13 @@ Your node identity private key can now be found at "%s".
 				time.Minute,
 			)),
 			libp2p.NATPortMap(),
+			libp2p.ConnectionGater(connectionGater),
 		)
 		if err != nil {
 			CorePlugin.LogPanicf("unable to initialize peer: %s", err)
 		}
+		// the gater keeps track of the inbound connections per subnet
+		createdHost.Network().Notify(connectionGater)
 		res.Host = createdHost
 
 		return res
@@ -156,6 +186,7 @@ Your node identity private key can now be found at "%s".
 	type mngDeps struct {
 		dig.In
 		Host                      host.Host
+		AddressBook               *p2p.AddressBook
 		Config                    *configuration.Configuration `name:"nodeConfig"`
 		AutopeeringRunAsEntryNode bool                         `name:"autopeeringRunAsEntryNode"`
 	}
@@ -165,6 +196,7 @@ Your node identity private key can now be found at "%s".
 			return p2p.NewManager(deps.Host,
 				p2p.WithManagerLogger(logger.NewLogger("P2P-Manager")),
 				p2p.WithManagerReconnectInterval(deps.Config.Duration(CfgP2PReconnectInterval), 1*time.Second),
+				p2p.WithManagerAddressBook(deps.AddressBook),
 			)
 		}
 		return nil
@@ -275,6 +307,7 @@ func run() {
 		CorePlugin.LogInfof("listening on: %s", deps.Host.Addrs())
 		go deps.PeeringManager.Start(ctx)
 		connectConfigKnownPeers()
+		connectAddressBookPeers()
 		<-ctx.Done()
 		if err := deps.Host.Peerstore().Close(); err != nil {
 			CorePlugin.LogError("unable to cleanly closing peer store: %s", err)
@@ -302,3 +335,30 @@ func connectConfigKnownPeers() {
 		}
 	}
 }
+
+// connects to the peers which were added via the API and are persisted in the address book.
+func connectAddressBookPeers() {
+	peers, err := deps.AddressBook.Peers()
+	if err != nil {
+		CorePlugin.LogWarnf("unable to load peers from the address book: %s", err)
+		return
+	}
+
+	for _, p := range peers {
+		multiAddr, err := multiaddr.NewMultiaddr(p.MultiAddress)
+		if err != nil {
+			CorePlugin.LogWarnf("invalid peer address in the address book: %s", err)
+			continue
+		}
+
+		addrInfo, err := peer.AddrInfoFromP2pAddr(multiAddr)
+		if err != nil {
+			CorePlugin.LogWarnf("invalid peer address info in the address book: %s", err)
+			continue
+		}
+
+		if err = deps.PeeringManager.ConnectPeer(addrInfo, p2p.PeerRelationKnown, p.Alias); err != nil && !errors.Is(err, p2p.ErrPeerInManagerAlready) {
+			CorePlugin.LogInfof("can't connect to peer (%s): %s", multiAddr.String(), err)
+		}
+	}
+}
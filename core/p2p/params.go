@@ -21,6 +21,16 @@ const (
 	CfgP2PDatabasePath = "p2p.db.path"
 	// Defines the time to wait before trying to reconnect to a disconnected peer.
 	CfgP2PReconnectInterval = "p2p.reconnectInterval"
+	// Defines the subnets to which connections are restricted (CIDR notation, empty = all addresses are allowed).
+	CfgP2PGaterAllowList = "p2p.gater.allowList"
+	// Defines the subnets from and to which connections are rejected (CIDR notation).
+	CfgP2PGaterDenyList = "p2p.gater.denyList"
+	// Defines the maximum amount of inbound connections per IPv4 /24 subnet (0 = unlimited).
+	CfgP2PGaterMaxInboundPerIPv4Subnet = "p2p.gater.maxInboundPerIPv4Subnet"
+	// Defines the maximum amount of inbound connections per IPv6 /48 subnet (0 = unlimited).
+	CfgP2PGaterMaxInboundPerIPv6Subnet = "p2p.gater.maxInboundPerIPv6Subnet"
+	// Defines the default duration of bans added via the API.
+	CfgP2PGaterDefaultBanDuration = "p2p.gater.defaultBanDuration"
 	// Defines the static peers this node should retain a connection to (config file).
 	CfgP2PPeers = "p2p.peers"
 	// Defines the aliases of the static peers (must be the same length like CfgP2PPeers) (CLI).
@@ -39,6 +49,11 @@ var params = &node.PluginParams{
 			fs.String(CfgP2PIdentityPrivKey, "", "private key used to derive the node identity (optional)")
 			fs.String(CfgP2PDatabasePath, "p2pstore", "the path to the p2p database")
 			fs.Duration(CfgP2PReconnectInterval, 30*time.Second, "the time to wait before trying to reconnect to a disconnected peer")
+			fs.StringSlice(CfgP2PGaterAllowList, []string{}, "the subnets to which connections are restricted (CIDR notation, empty = all addresses are allowed)")
+			fs.StringSlice(CfgP2PGaterDenyList, []string{}, "the subnets from and to which connections are rejected (CIDR notation)")
+			fs.Int(CfgP2PGaterMaxInboundPerIPv4Subnet, 10, "the maximum amount of inbound connections per IPv4 /24 subnet (0 = unlimited)")
+			fs.Int(CfgP2PGaterMaxInboundPerIPv6Subnet, 10, "the maximum amount of inbound connections per IPv6 /48 subnet (0 = unlimited)")
+			fs.Duration(CfgP2PGaterDefaultBanDuration, time.Hour, "the default duration of bans added via the API")
 			return fs
 		}(),
 		"peeringConfig": func() *flag.FlagSet {
@@ -22,6 +22,7 @@ import (
 	"github.com/gohornet/hornet/plugins/participation"
 	"github.com/gohornet/hornet/plugins/profiling"
 	"github.com/gohornet/hornet/plugins/prometheus"
+	"github.com/gohornet/hornet/plugins/promoter"
 	"github.com/gohornet/hornet/plugins/receipt"
 	"github.com/gohornet/hornet/plugins/restapi"
 	restapiv1 "github.com/gohornet/hornet/plugins/restapi/v1"
@@ -63,6 +64,7 @@ func main() {
 			debug.Plugin,
 			faucet.Plugin,
 			participation.Plugin,
+			promoter.Plugin,
 		}...),
 	)
 }
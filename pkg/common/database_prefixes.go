@@ -8,5 +8,6 @@ const (
 	StorePrefixSnapshot             byte = 5
 	StorePrefixUnreferencedMessages byte = 6
 	StorePrefixIndexation           byte = 7
+	StorePrefixReferencedMessages   byte = 8
 	StorePrefixHealth               byte = 255
 )
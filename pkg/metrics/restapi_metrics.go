@@ -8,4 +8,10 @@ import (
 type RestAPIMetrics struct {
 	// The total number HTTP request errors.
 	HTTPRequestErrorCounter atomic.Uint32
+	// The total number of HTTP requests that were rejected by the rate limit per IP.
+	HTTPRequestRateLimitedIPCounter atomic.Uint32
+	// The total number of HTTP requests that were rejected by the rate limit per JWT subject.
+	HTTPRequestRateLimitedJWTCounter atomic.Uint32
+	// The total number of HTTP requests that were rejected by the rate limit of expensive routes.
+	HTTPRequestRateLimitedExpensiveCounter atomic.Uint32
 }
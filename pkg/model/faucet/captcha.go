@@ -0,0 +1,109 @@
+package faucet
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrCaptchaInvalid is returned when a captcha token could not be verified.
+	ErrCaptchaInvalid = errors.New("invalid captcha")
+)
+
+// CaptchaVerifier verifies the captcha token a client solved before requesting funds.
+type CaptchaVerifier interface {
+	// Verify checks the given captcha token of the client with the given IP.
+	// It returns ErrCaptchaInvalid if the token is not valid.
+	Verify(ctx context.Context, token string, remoteIP string) error
+}
+
+// StubCaptchaVerifier is a CaptchaVerifier that accepts a single static token.
+// It is meant for tests and private networks.
+type StubCaptchaVerifier struct {
+	token string
+}
+
+// NewStubCaptchaVerifier creates a new StubCaptchaVerifier that accepts the given token.
+func NewStubCaptchaVerifier(token string) *StubCaptchaVerifier {
+	return &StubCaptchaVerifier{token: token}
+}
+
+// Verify checks whether the given token matches the static token.
+func (v *StubCaptchaVerifier) Verify(_ context.Context, token string, _ string) error {
+	if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(v.token)) != 1 {
+		return ErrCaptchaInvalid
+	}
+	return nil
+}
+
+// siteVerifyResponse is the response of a captcha site verify API.
+type siteVerifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// SiteVerifyCaptchaVerifier is a CaptchaVerifier that uses the "siteverify" API
+// shared by hCaptcha, reCAPTCHA and Turnstile.
+type SiteVerifyCaptchaVerifier struct {
+	verifyURL  string
+	secret     string
+	httpClient *http.Client
+}
+
+// NewSiteVerifyCaptchaVerifier creates a new SiteVerifyCaptchaVerifier that verifies tokens with the given secret at the given URL.
+func NewSiteVerifyCaptchaVerifier(verifyURL string, secret string, timeout time.Duration) *SiteVerifyCaptchaVerifier {
+	return &SiteVerifyCaptchaVerifier{
+		verifyURL:  verifyURL,
+		secret:     secret,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Verify checks the given token at the site verify API.
+func (v *SiteVerifyCaptchaVerifier) Verify(ctx context.Context, token string, remoteIP string) error {
+	if token == "" {
+		return ErrCaptchaInvalid
+	}
+
+	form := url.Values{}
+	form.Set("secret", v.secret)
+	form.Set("response", token)
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("captcha verification request failed: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("captcha verification request failed: status %d", res.StatusCode)
+	}
+
+	response := &siteVerifyResponse{}
+	if err := json.NewDecoder(res.Body).Decode(response); err != nil {
+		return fmt.Errorf("captcha verification response is invalid: %w", err)
+	}
+
+	if !response.Success {
+		return fmt.Errorf("%w: %s", ErrCaptchaInvalid, strings.Join(response.ErrorCodes, ", "))
+	}
+
+	return nil
+}
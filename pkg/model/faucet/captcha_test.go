@@ -0,0 +1,42 @@
+package faucet_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/faucet"
+)
+
+func TestStubCaptchaVerifier(t *testing.T) {
+	verifier := faucet.NewStubCaptchaVerifier("valid")
+
+	require.NoError(t, verifier.Verify(context.Background(), "valid", "127.0.0.1"))
+	require.ErrorIs(t, verifier.Verify(context.Background(), "invalid", "127.0.0.1"), faucet.ErrCaptchaInvalid)
+	require.ErrorIs(t, verifier.Verify(context.Background(), "", "127.0.0.1"), faucet.ErrCaptchaInvalid)
+}
+
+func TestSiteVerifyCaptchaVerifier(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "secret", r.PostForm.Get("secret"))
+		require.Equal(t, "127.0.0.1", r.PostForm.Get("remoteip"))
+
+		response := map[string]interface{}{"success": r.PostForm.Get("response") == "valid"}
+		if r.PostForm.Get("response") != "valid" {
+			response["error-codes"] = []string{"invalid-input-response"}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+
+	verifier := faucet.NewSiteVerifyCaptchaVerifier(server.URL, "secret", time.Second)
+
+	require.NoError(t, verifier.Verify(context.Background(), "valid", "127.0.0.1"))
+	require.ErrorIs(t, verifier.Verify(context.Background(), "invalid", "127.0.0.1"), faucet.ErrCaptchaInvalid)
+}
@@ -0,0 +1,162 @@
+package faucet
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math/bits"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/syncutils"
+)
+
+const (
+	// the size of the random part of a challenge.
+	challengeRandomSize = 16
+	// the size of the truncated HMAC that authenticates a challenge.
+	challengeMACSize = 16
+	// the size of a challenge (random + expiry + MAC).
+	challengeSize = challengeRandomSize + 8 + challengeMACSize
+)
+
+var (
+	// ErrChallengeInvalid is returned when a challenge was not issued by the faucet.
+	ErrChallengeInvalid = errors.New("invalid challenge")
+	// ErrChallengeExpired is returned when a challenge is expired.
+	ErrChallengeExpired = errors.New("challenge expired")
+	// ErrChallengeAlreadyUsed is returned when a challenge was already used for another request.
+	ErrChallengeAlreadyUsed = errors.New("challenge already used")
+	// ErrChallengeInsufficientWork is returned when the nonce does not satisfy the difficulty of the challenge.
+	ErrChallengeInsufficientWork = errors.New("insufficient proof of work")
+)
+
+// PoWChallenge is a hashcash-style challenge a client has to solve before requesting funds.
+type PoWChallenge struct {
+	// Challenge is the hex encoded challenge.
+	Challenge string `json:"challenge"`
+	// Difficulty is the amount of leading zero bits the hash of the solution must have.
+	Difficulty int `json:"difficulty"`
+	// ExpiresAt is the unix timestamp at which the challenge expires.
+	ExpiresAt int64 `json:"expiresAt"`
+}
+
+// PoWChallenger issues and verifies proof of work challenges.
+// A solution is a nonce for which SHA-256(challenge || bech32 address || nonce as little endian uint64)
+// has at least the requested amount of leading zero bits.
+// Challenges are authenticated with a secret that is generated at startup, so they don't need to be stored.
+// Every challenge can only be used once.
+type PoWChallenger struct {
+	// lock used to secure the used challenges.
+	syncutils.Mutex
+
+	// the secret used to authenticate the issued challenges.
+	secret []byte
+	// the amount of leading zero bits the hash of a solution must have.
+	difficulty int
+	// the duration after which an issued challenge expires.
+	validity time.Duration
+	// the used challenges and their expiry.
+	usedChallenges map[string]time.Time
+}
+
+// NewPoWChallenger creates a new PoWChallenger with the given difficulty in leading zero bits.
+func NewPoWChallenger(difficulty int, validity time.Duration) (*PoWChallenger, error) {
+	secret := make([]byte, sha256.Size)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	return &PoWChallenger{
+		secret:         secret,
+		difficulty:     difficulty,
+		validity:       validity,
+		usedChallenges: make(map[string]time.Time),
+	}, nil
+}
+
+// Issue creates a new challenge that expires after the configured validity.
+func (c *PoWChallenger) Issue(now time.Time) (*PoWChallenge, error) {
+	expiresAt := now.Add(c.validity)
+
+	challenge := make([]byte, challengeRandomSize+8, challengeSize)
+	if _, err := rand.Read(challenge[:challengeRandomSize]); err != nil {
+		return nil, err
+	}
+	binary.LittleEndian.PutUint64(challenge[challengeRandomSize:], uint64(expiresAt.Unix()))
+	challenge = append(challenge, c.mac(challenge)...)
+
+	return &PoWChallenge{
+		Challenge:  hex.EncodeToString(challenge),
+		Difficulty: c.difficulty,
+		ExpiresAt:  expiresAt.Unix(),
+	}, nil
+}
+
+// Verify checks the solution of the given challenge for the given bech32 address and marks the challenge as used.
+func (c *PoWChallenger) Verify(challengeHex string, bech32Addr string, nonce uint64, now time.Time) error {
+	challenge, err := hex.DecodeString(challengeHex)
+	if err != nil || len(challenge) != challengeSize {
+		return ErrChallengeInvalid
+	}
+
+	if !hmac.Equal(challenge[challengeRandomSize+8:], c.mac(challenge[:challengeRandomSize+8])) {
+		return ErrChallengeInvalid
+	}
+
+	expiresAt := time.Unix(int64(binary.LittleEndian.Uint64(challenge[challengeRandomSize:])), 0)
+	if !now.Before(expiresAt) {
+		return ErrChallengeExpired
+	}
+
+	if PoWChallengeLeadingZeros(challenge, bech32Addr, nonce) < c.difficulty {
+		return ErrChallengeInsufficientWork
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	// forget about challenges that can't be used anymore anyway
+	for usedChallenge, usedExpiresAt := range c.usedChallenges {
+		if !now.Before(usedExpiresAt) {
+			delete(c.usedChallenges, usedChallenge)
+		}
+	}
+
+	if _, used := c.usedChallenges[challengeHex]; used {
+		return ErrChallengeAlreadyUsed
+	}
+	c.usedChallenges[challengeHex] = expiresAt
+
+	return nil
+}
+
+// mac returns the truncated HMAC of the given challenge data.
+func (c *PoWChallenger) mac(data []byte) []byte {
+	h := hmac.New(sha256.New, c.secret)
+	_, _ = h.Write(data)
+	return h.Sum(nil)[:challengeMACSize]
+}
+
+// PoWChallengeLeadingZeros returns the amount of leading zero bits of the hash of the given solution.
+func PoWChallengeLeadingZeros(challenge []byte, bech32Addr string, nonce uint64) int {
+	nonceBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(nonceBytes, nonce)
+
+	h := sha256.New()
+	_, _ = h.Write(challenge)
+	_, _ = h.Write([]byte(bech32Addr))
+	_, _ = h.Write(nonceBytes)
+
+	var zeros int
+	for _, b := range h.Sum(nil) {
+		zeros += bits.LeadingZeros8(b)
+		if b != 0 {
+			break
+		}
+	}
+	return zeros
+}
@@ -0,0 +1,82 @@
+package faucet_test
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/faucet"
+)
+
+const challengeTestAddress = "atoi1qzt0nhsf38nh6rs4p6zs5knqp6psgha9wsv74uajqgjmwc75ugupx3y7x0r"
+
+// solveChallenge searches a nonce that satisfies the difficulty of the given challenge.
+func solveChallenge(t *testing.T, challenge *faucet.PoWChallenge, bech32Addr string) uint64 {
+	challengeBytes, err := hex.DecodeString(challenge.Challenge)
+	require.NoError(t, err)
+
+	for nonce := uint64(0); ; nonce++ {
+		if faucet.PoWChallengeLeadingZeros(challengeBytes, bech32Addr, nonce) >= challenge.Difficulty {
+			return nonce
+		}
+	}
+}
+
+func TestPoWChallenger(t *testing.T) {
+	challenger, err := faucet.NewPoWChallenger(12, time.Minute)
+	require.NoError(t, err)
+
+	now := time.Unix(1_600_000_000, 0)
+
+	challenge, err := challenger.Issue(now)
+	require.NoError(t, err)
+	require.Equal(t, 12, challenge.Difficulty)
+	require.Equal(t, now.Add(time.Minute).Unix(), challenge.ExpiresAt)
+
+	nonce := solveChallenge(t, challenge, challengeTestAddress)
+
+	// the solution is bound to the address
+	challengeBytes, err := hex.DecodeString(challenge.Challenge)
+	require.NoError(t, err)
+	otherNonce := nonce
+	for faucet.PoWChallengeLeadingZeros(challengeBytes, challengeTestAddress+"x", otherNonce) >= challenge.Difficulty {
+		otherNonce++
+	}
+	require.ErrorIs(t, challenger.Verify(challenge.Challenge, challengeTestAddress+"x", otherNonce, now), faucet.ErrChallengeInsufficientWork)
+
+	// expired challenges are rejected
+	require.ErrorIs(t, challenger.Verify(challenge.Challenge, challengeTestAddress, nonce, now.Add(time.Minute)), faucet.ErrChallengeExpired)
+
+	require.NoError(t, challenger.Verify(challenge.Challenge, challengeTestAddress, nonce, now))
+
+	// challenges can only be used once
+	require.ErrorIs(t, challenger.Verify(challenge.Challenge, challengeTestAddress, nonce, now), faucet.ErrChallengeAlreadyUsed)
+}
+
+func TestPoWChallengerInvalidChallenge(t *testing.T) {
+	challenger, err := faucet.NewPoWChallenger(0, time.Minute)
+	require.NoError(t, err)
+
+	otherChallenger, err := faucet.NewPoWChallenger(0, time.Minute)
+	require.NoError(t, err)
+
+	now := time.Unix(1_600_000_000, 0)
+
+	// challenges of other faucets are rejected
+	challenge, err := otherChallenger.Issue(now)
+	require.NoError(t, err)
+	require.ErrorIs(t, challenger.Verify(challenge.Challenge, challengeTestAddress, 0, now), faucet.ErrChallengeInvalid)
+
+	// modified challenges are rejected
+	challenge, err = challenger.Issue(now)
+	require.NoError(t, err)
+	challengeBytes, err := hex.DecodeString(challenge.Challenge)
+	require.NoError(t, err)
+	challengeBytes[0] ^= 0xFF
+	require.ErrorIs(t, challenger.Verify(hex.EncodeToString(challengeBytes), challengeTestAddress, 0, now), faucet.ErrChallengeInvalid)
+
+	require.ErrorIs(t, challenger.Verify("invalid", challengeTestAddress, 0, now), faucet.ErrChallengeInvalid)
+	require.NoError(t, challenger.Verify(challenge.Challenge, challengeTestAddress, 0, now))
+}
@@ -51,28 +51,49 @@ type Events struct {
 }
 
 // queueItem is an item for the faucet requests queue.
+// It also tracks the state of the request after it left the queue.
 type queueItem struct {
+	ID             string
 	Bech32         string
 	Amount         uint64
 	Ed25519Address *iotago.Ed25519Address
+	State          RequestState
+	MessageID      hornet.MessageID
+	MilestoneIndex milestone.Index
+	FailureReason  string
+	EnqueuedAt     time.Time
+	UpdatedAt      time.Time
 }
 
 // pendingTransaction holds info about a sent transaction that is pending.
 type pendingTransaction struct {
 	MessageID   hornet.MessageID
 	QueuedItems []*queueItem
+	Wallet      *faucetWallet
+}
+
+// FaucetAddressInfo defines the balance of a single address of the faucet.
+type FaucetAddressInfo struct {
+	// The bech32 address.
+	Address string `json:"address"`
+	// The confirmed balance of the address.
+	Balance uint64 `json:"balance"`
 }
 
 // FaucetInfoResponse defines the response of a GET RouteFaucetInfo REST API call.
 type FaucetInfoResponse struct {
-	// The bech32 address of the faucet.
+	// The bech32 address of the first wallet of the faucet.
 	Address string `json:"address"`
 	// The remaining balance of faucet.
 	Balance uint64 `json:"balance"`
+	// The confirmed balances of all addresses of the faucet.
+	Addresses []*FaucetAddressInfo `json:"addresses"`
 }
 
 // FaucetEnqueueResponse defines the response of a POST RouteFaucetEnqueue REST API call.
 type FaucetEnqueueResponse struct {
+	// The ID of the request, which can be used to query its status.
+	ID string `json:"id"`
 	// The bech32 address.
 	Address string `json:"address"`
 	// The number of waiting requests in the queue.
@@ -99,10 +120,10 @@ type Faucet struct {
 	belowMaxDepth milestone.Index
 	// used to get the outputs.
 	utxoManager *utxo.Manager
-	// the address of the faucet.
-	address *iotago.Ed25519Address
-	// used to sign the faucet transactions.
-	addressSigner iotago.AddressSigner
+	// the wallets the faucet spends funds from.
+	wallets []*faucetWallet
+	// the index of the wallet that is tried first for the next transaction.
+	nextWalletIndex int
 	// used to get valid tips for new faucet messages.
 	tipselFunc TipselFunc
 	// used to do the PoW for the faucet messages.
@@ -125,11 +146,10 @@ type Faucet struct {
 	flushQueue chan struct{}
 	// pendingTransactionsMap is a map of sent transactions that are pending.
 	pendingTransactionsMap map[string]*pendingTransaction
-	// the message ID of the last sent faucet message.
-	lastMessageID hornet.MessageID
-	// the latest unused UTXO output that may not be confirmed yet but can be reused in new transactions.
-	// this is used to issue multiple transactions without waiting for the confirmation by milestones.
-	lastRemainderOutput *utxo.Output
+	// map with all known requests per request ID.
+	requests map[string]*queueItem
+	// the known requests in the order they were enqueued.
+	requestHistory []*queueItem
 }
 
 // the default options applied to the faucet.
@@ -142,20 +162,24 @@ var defaultOptions = []Option{
 	WithIndexationMessage("HORNET FAUCET"),
 	WithBatchTimeout(2 * time.Second),
 	WithPowWorkerCount(0),
+	WithConsolidationThreshold(iotago.MaxInputsCount),
+	WithRequestHistorySize(1000),
 }
 
 // Options define options for the faucet.
 type Options struct {
 	// the logger used to log events.
-	logger            *logger.Logger
-	hrpNetworkPrefix  iotago.NetworkPrefix
-	amount            uint64
-	smallAmount       uint64
-	maxAddressBalance uint64
-	maxOutputCount    int
-	indexationMessage []byte
-	batchTimeout      time.Duration
-	powWorkerCount    int
+	logger                 *logger.Logger
+	hrpNetworkPrefix       iotago.NetworkPrefix
+	amount                 uint64
+	smallAmount            uint64
+	maxAddressBalance      uint64
+	maxOutputCount         int
+	indexationMessage      []byte
+	batchTimeout           time.Duration
+	powWorkerCount         int
+	consolidationThreshold int
+	requestHistorySize     int
 }
 
 // applies the given Option.
@@ -245,10 +269,35 @@ func WithPowWorkerCount(powWorkerCount int) Option {
 	}
 }
 
+// WithConsolidationThreshold defines the amount of unspent outputs on a wallet address
+// at which the faucet sweeps them into a single output while the wallet has no pending transactions.
+func WithConsolidationThreshold(consolidationThreshold int) Option {
+	return func(opts *Options) {
+		if consolidationThreshold > iotago.MaxInputsCount {
+			consolidationThreshold = iotago.MaxInputsCount
+		}
+		if consolidationThreshold < 2 {
+			consolidationThreshold = 2
+		}
+		opts.consolidationThreshold = consolidationThreshold
+	}
+}
+
+// WithRequestHistorySize defines the amount of finished requests the faucet keeps track of.
+func WithRequestHistorySize(requestHistorySize int) Option {
+	return func(opts *Options) {
+		if requestHistorySize < 0 {
+			requestHistorySize = 0
+		}
+		opts.requestHistorySize = requestHistorySize
+	}
+}
+
 // Option is a function setting a faucet option.
 type Option func(opts *Options)
 
-// New creates a new faucet instance.
+// New creates a new faucet instance that spends funds from the given wallets.
+// At least one wallet must be given.
 func New(
 	daemon daemon.Daemon,
 	dbStorage *storage.Storage,
@@ -256,17 +305,25 @@ func New(
 	networkID uint64,
 	belowMaxDepth int,
 	utxoManager *utxo.Manager,
-	address *iotago.Ed25519Address,
-	addressSigner iotago.AddressSigner,
+	wallets []*Wallet,
 	tipselFunc TipselFunc,
 	powHandler *pow.Handler,
 	sendMessageFunc SendMessageFunc,
 	opts ...Option) *Faucet {
 
+	if len(wallets) == 0 {
+		panic(ErrNoWalletsGiven)
+	}
+
 	options := &Options{}
 	options.apply(defaultOptions...)
 	options.apply(opts...)
 
+	faucetWallets := make([]*faucetWallet, len(wallets))
+	for i, wallet := range wallets {
+		faucetWallets[i] = &faucetWallet{Wallet: wallet}
+	}
+
 	faucet := &Faucet{
 		daemon:          daemon,
 		storage:         dbStorage,
@@ -274,8 +331,7 @@ func New(
 		networkID:       networkID,
 		belowMaxDepth:   milestone.Index(belowMaxDepth),
 		utxoManager:     utxoManager,
-		address:         address,
-		addressSigner:   addressSigner,
+		wallets:         faucetWallets,
 		tipselFunc:      tipselFunc,
 		powHandler:      powHandler,
 		sendMessageFunc: sendMessageFunc,
@@ -298,8 +354,13 @@ func (f *Faucet) init() {
 	f.queueMap = make(map[string]*queueItem)
 	f.flushQueue = make(chan struct{})
 	f.pendingTransactionsMap = make(map[string]*pendingTransaction)
-	f.lastMessageID = nil
-	f.lastRemainderOutput = nil
+	f.requests = make(map[string]*queueItem)
+	f.requestHistory = nil
+	f.nextWalletIndex = 0
+	for _, wallet := range f.wallets {
+		wallet.balance = 0
+		wallet.resetChain()
+	}
 }
 
 // NetworkPrefix returns the used network prefix.
@@ -307,11 +368,23 @@ func (f *Faucet) NetworkPrefix() iotago.NetworkPrefix {
 	return f.opts.hrpNetworkPrefix
 }
 
-// Info returns the used faucet address and remaining balance.
+// Info returns the used faucet addresses and the remaining balance.
 func (f *Faucet) Info() (*FaucetInfoResponse, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	addresses := make([]*FaucetAddressInfo, len(f.wallets))
+	for i, wallet := range f.wallets {
+		addresses[i] = &FaucetAddressInfo{
+			Address: wallet.Address.Bech32(f.opts.hrpNetworkPrefix),
+			Balance: wallet.balance,
+		}
+	}
+
 	return &FaucetInfoResponse{
-		Address: f.address.Bech32(f.opts.hrpNetworkPrefix),
-		Balance: f.faucetBalance,
+		Address:   addresses[0].Address,
+		Balance:   f.faucetBalance,
+		Addresses: addresses,
 	}, nil
 }
 
@@ -348,17 +421,29 @@ func (f *Faucet) Enqueue(bech32Addr string) (*FaucetEnqueueResponse, error) {
 		return nil, errors.WithMessage(echo.ErrInternalServerError, "Faucet does not have enough funds to process your request. Please try again later!")
 	}
 
+	requestID, err := newRequestID()
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "Creating the request ID failed: %s", err)
+	}
+
+	now := time.Now()
 	request := &queueItem{
+		ID:             requestID,
 		Bech32:         bech32Addr,
 		Amount:         amount,
 		Ed25519Address: ed25519Addr,
+		State:          RequestStateQueued,
+		EnqueuedAt:     now,
+		UpdatedAt:      now,
 	}
 
 	select {
 	case f.queue <- request:
 		f.faucetBalance -= amount
 		f.queueMap[bech32Addr] = request
+		f.addRequestToHistoryWithoutLocking(request)
 		return &FaucetEnqueueResponse{
+			ID:              requestID,
 			Address:         bech32Addr,
 			WaitingRequests: len(f.queueMap),
 		}, nil
@@ -401,24 +486,17 @@ func (f *Faucet) clearRequestWithoutLocking(request *queueItem) {
 	delete(f.queueMap, request.Bech32)
 }
 
-// clearRequestsWithoutLocking clears the old requests from the map.
-// this is necessary to be able to send new requests to the same addresses.
-// write lock must be acquired outside.
-func (f *Faucet) clearRequestsWithoutLocking(batchedRequests []*queueItem) {
-	for _, request := range batchedRequests {
-		f.clearRequestWithoutLocking(request)
-	}
-}
-
 // readdRequestsWithoutLocking adds old requests back to the queue.
 // write lock must be acquired outside.
 func (f *Faucet) readdRequestsWithoutLocking(batchedRequests []*queueItem) {
 	for _, request := range batchedRequests {
 		select {
 		case f.queue <- request:
+			request.MessageID = nil
+			request.setState(RequestStateQueued)
 		default:
 			// queue full => no way to readd it, delete it from the map as well so user are able to send a new request
-			f.clearRequestWithoutLocking(request)
+			f.failRequestWithoutLocking(request, "faucet queue is full")
 		}
 	}
 }
@@ -472,8 +550,8 @@ func (f *Faucet) createMessage(ctx context.Context, txPayload serializer.Seriali
 	return msg, nil
 }
 
-// buildTransactionPayload creates a signed transaction payload with all UTXO and batched requests.
-func (f *Faucet) buildTransactionPayload(unspentOutputs []*utxo.Output, batchedRequests []*queueItem) (*iotago.Transaction, *iotago.UTXOInput, uint64, error) {
+// buildTransactionPayload creates a signed transaction payload with all UTXO of the wallet and batched requests.
+func (f *Faucet) buildTransactionPayload(wallet *faucetWallet, unspentOutputs []*utxo.Output, batchedRequests []*queueItem) (*iotago.Transaction, *iotago.UTXOInput, uint64, error) {
 
 	txBuilder := iotago.NewTransactionBuilder()
 	txBuilder.AddIndexationPayload(&iotago.Indexation{Index: f.opts.indexationMessage, Data: nil})
@@ -481,11 +559,11 @@ func (f *Faucet) buildTransactionPayload(unspentOutputs []*utxo.Output, batchedR
 	outputCount := 0
 	var remainderAmount int64 = 0
 
-	// collect all unspent output of the faucet address
+	// collect all unspent output of the wallet address
 	for _, unspentOutput := range unspentOutputs {
 		outputCount++
 		remainderAmount += int64(unspentOutput.Amount())
-		txBuilder.AddInput(&iotago.ToBeSignedUTXOInput{Address: f.address, Input: unspentOutput.UTXOInput()})
+		txBuilder.AddInput(&iotago.ToBeSignedUTXOInput{Address: wallet.Address, Input: unspentOutput.UTXOInput()})
 	}
 
 	// add all requests as outputs
@@ -514,10 +592,10 @@ func (f *Faucet) buildTransactionPayload(unspentOutputs []*utxo.Output, batchedR
 	}
 
 	if remainderAmount > 0 {
-		txBuilder.AddOutput(&iotago.SigLockedSingleOutput{Address: f.address, Amount: uint64(remainderAmount)})
+		txBuilder.AddOutput(&iotago.SigLockedSingleOutput{Address: wallet.Address, Amount: uint64(remainderAmount)})
 	}
 
-	txPayload, err := txBuilder.Build(f.addressSigner)
+	txPayload, err := txBuilder.Build(wallet.AddressSigner)
 	if err != nil {
 		return nil, nil, 0, err
 	}
@@ -542,7 +620,7 @@ func (f *Faucet) buildTransactionPayload(unspentOutputs []*utxo.Output, batchedR
 		sigLock := output.(*iotago.SigLockedSingleOutput)
 		ed25519Addr := sigLock.Address.(*iotago.Ed25519Address)
 
-		if bytes.Equal(ed25519Addr[:], f.address[:]) {
+		if bytes.Equal(ed25519Addr[:], wallet.Address[:]) {
 			// found the remainder address in the outputs
 			found = true
 			remainderOutput.TransactionOutputIndex = outputIndex
@@ -558,10 +636,10 @@ func (f *Faucet) buildTransactionPayload(unspentOutputs []*utxo.Output, batchedR
 	return txPayload, remainderOutput, uint64(remainderAmount), nil
 }
 
-// sendFaucetMessage creates a faucet transaction payload and remembers the last sent messageID and the lastRemainderOutput.
-func (f *Faucet) sendFaucetMessage(ctx context.Context, unspentOutputs []*utxo.Output, batchedRequests []*queueItem, tip ...hornet.MessageID) error {
+// sendFaucetMessage creates a faucet transaction payload and remembers the last sent messageID and the lastRemainderOutput of the wallet.
+func (f *Faucet) sendFaucetMessage(ctx context.Context, wallet *faucetWallet, unspentOutputs []*utxo.Output, batchedRequests []*queueItem, tip ...hornet.MessageID) error {
 
-	txPayload, remainderIotaGoOutput, remainderAmount, err := f.buildTransactionPayload(unspentOutputs, batchedRequests)
+	txPayload, remainderIotaGoOutput, remainderAmount, err := f.buildTransactionPayload(wallet, unspentOutputs, batchedRequests)
 	if err != nil {
 		return fmt.Errorf("build transaction payload failed, error: %w", err)
 	}
@@ -576,14 +654,18 @@ func (f *Faucet) sendFaucetMessage(ctx context.Context, unspentOutputs []*utxo.O
 	}
 
 	f.Lock()
-	f.lastMessageID = msg.MessageID()
-	f.addPendingTransactionWithoutLocking(&pendingTransaction{MessageID: msg.MessageID(), QueuedItems: batchedRequests})
+	for _, request := range batchedRequests {
+		request.MessageID = msg.MessageID()
+		request.setState(RequestStateBatched)
+	}
+	wallet.lastMessageID = msg.MessageID()
+	f.addPendingTransactionWithoutLocking(&pendingTransaction{MessageID: msg.MessageID(), QueuedItems: batchedRequests, Wallet: wallet})
 	if remainderIotaGoOutput != nil {
 		remainderIotaGoOutputID := remainderIotaGoOutput.ID()
-		f.lastRemainderOutput = utxo.CreateOutput(&remainderIotaGoOutputID, msg.MessageID(), iotago.OutputSigLockedSingleOutput, f.address, uint64(remainderAmount))
+		wallet.lastRemainderOutput = utxo.CreateOutput(&remainderIotaGoOutputID, msg.MessageID(), iotago.OutputSigLockedSingleOutput, wallet.Address, uint64(remainderAmount))
 	} else {
 		// no funds remaining => no remainder output
-		f.lastRemainderOutput = nil
+		wallet.lastRemainderOutput = nil
 	}
 	f.Unlock()
 
@@ -641,12 +723,13 @@ CollectValues:
 	return batchedRequests, nil
 }
 
-// processRequestsWithoutLocking processes all possible requests considering the maximum transaction size and the remaining funds of the faucet.
+// processRequestsWithoutLocking processes all possible requests considering the maximum transaction size and the remaining funds of the wallet.
 // write lock must be acquired outside.
 func (f *Faucet) processRequestsWithoutLocking(collectedRequestsCounter int, amount uint64, batchedRequests []*queueItem) []*queueItem {
 	processedBatchedRequests := []*queueItem{}
 	unprocessedBatchedRequests := []*queueItem{}
 	nodeAlmostSynced := f.syncManager.IsNodeAlmostSynced()
+	maxWalletBalance := f.maxWalletBalanceWithoutLocking()
 
 	for i := range batchedRequests {
 		request := batchedRequests[i]
@@ -664,8 +747,15 @@ func (f *Faucet) processRequestsWithoutLocking(collectedRequestsCounter int, amo
 		}
 
 		if amount < request.Amount {
+			if request.Amount <= maxWalletBalance {
+				// not enough funds left in this transaction, but the request can be processed by another wallet
+				// or after the pending transactions got confirmed => re-add it to the queue
+				unprocessedBatchedRequests = append(unprocessedBatchedRequests, request)
+				continue
+			}
+
 			// not enough funds to process this request => ignore the request
-			f.clearRequestWithoutLocking(request)
+			f.failRequestWithoutLocking(request, "faucet does not have enough funds")
 			continue
 		}
 
@@ -680,20 +770,216 @@ func (f *Faucet) processRequestsWithoutLocking(collectedRequestsCounter int, amo
 	return processedBatchedRequests
 }
 
-// RunFaucetLoop collects unspent outputs on the faucet address and batches the requests from the queue.
+// maxWalletBalanceWithoutLocking returns the highest confirmed balance of all wallets.
+// write lock must be acquired outside.
+func (f *Faucet) maxWalletBalanceWithoutLocking() uint64 {
+	var maxBalance uint64
+	for _, wallet := range f.wallets {
+		if wallet.balance > maxBalance {
+			maxBalance = wallet.balance
+		}
+	}
+	return maxBalance
+}
+
+// nextWalletWithoutLocking selects the wallet for the next transaction that is able to pay at least the given amount.
+// Wallets without pending transactions are preferred, so that new transactions don't need to be chained
+// to unconfirmed ones. Otherwise a wallet with a pending remainder output is used.
+// The wallets are rotated to spread the transactions evenly.
+// write lock must be acquired outside.
+func (f *Faucet) nextWalletWithoutLocking(minAmount uint64) *faucetWallet {
+
+	selectWallet := func(filter func(wallet *faucetWallet) bool) *faucetWallet {
+		for i := 0; i < len(f.wallets); i++ {
+			walletIndex := (f.nextWalletIndex + i) % len(f.wallets)
+			if wallet := f.wallets[walletIndex]; filter(wallet) {
+				f.nextWalletIndex = (walletIndex + 1) % len(f.wallets)
+				return wallet
+			}
+		}
+		return nil
+	}
+
+	if wallet := selectWallet(func(wallet *faucetWallet) bool {
+		return wallet.idle() && wallet.balance >= minAmount
+	}); wallet != nil {
+		return wallet
+	}
+
+	if wallet := selectWallet(func(wallet *faucetWallet) bool {
+		return wallet.lastRemainderOutput != nil && wallet.lastRemainderOutput.Amount() >= minAmount
+	}); wallet != nil {
+		return wallet
+	}
+
+	return selectWallet(func(_ *faucetWallet) bool { return true })
+}
+
+// collectUnspentOutputsWithoutLocking collects the outputs of the wallet that can be spent in the next transaction.
+// write lock must be acquired outside.
+func (f *Faucet) collectUnspentOutputsWithoutLocking(wallet *faucetWallet, maxResultCount int) ([]*utxo.Output, uint64, error) {
+	if wallet.lastRemainderOutput != nil {
+		// the lastRemainderOutput is reused as input in the next transaction, even if it was not yet referenced by a milestone.
+		// this is done to increase the throughput of the faucet in high load situations.
+		// we can't collect unspent outputs, as long as the lastRemainderOutput was not confirmed,
+		// since it's creating transaction could also have consumed the same UTXOs.
+		return []*utxo.Output{wallet.lastRemainderOutput}, wallet.lastRemainderOutput.Amount(), nil
+	}
+
+	unspentOutputs, err := f.utxoManager.UnspentOutputs(utxo.FilterAddress(wallet.Address), utxo.ReadLockLedger(false), utxo.MaxResultCount(maxResultCount), utxo.FilterOutputType(iotago.OutputSigLockedSingleOutput))
+	if err != nil {
+		return nil, 0, common.CriticalError(fmt.Errorf("reading unspent outputs failed: %s, error: %w", wallet.Address.Bech32(f.opts.hrpNetworkPrefix), err))
+	}
+
+	var amount uint64 = 0
+	for _, unspentOutput := range unspentOutputs {
+		amount += unspentOutput.Amount()
+	}
+	return unspentOutputs, amount, nil
+}
+
+// processRequests sends a transaction for the batched requests from the next wallet.
+func (f *Faucet) processRequests(ctx context.Context, batchedRequests []*queueItem) error {
+
+	if len(batchedRequests) == 0 {
+		// no need to send funds
+		return ErrNothingToProcess
+	}
+
+	prepareTransaction := func() (*faucetWallet, []*utxo.Output, []*queueItem, hornet.MessageIDs, error) {
+		// first we need to read lock the ledger, to be sure that there is no confirmation ongoing
+		f.utxoManager.ReadLockLedger()
+		defer f.utxoManager.ReadUnlockLedger()
+
+		// there must be a lock between collectUnspentOutputsWithoutLocking and "tipselection", otherwise the chaining may fail
+		f.Lock()
+		defer f.Unlock()
+
+		minAmount := batchedRequests[0].Amount
+		for _, request := range batchedRequests {
+			if request.Amount < minAmount {
+				minAmount = request.Amount
+			}
+		}
+
+		wallet := f.nextWalletWithoutLocking(minAmount)
+
+		unspentOutputs, amount, err := f.collectUnspentOutputsWithoutLocking(wallet, f.opts.maxOutputCount-2)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+
+		// if a lastMessageID exists, we need to reference it to chain the transactions of the wallet in the correct order for whiteflag.
+		// lastMessageID is reset by ApplyConfirmation in case the last message of the wallet is not confirmed and below max depth.
+		var tips hornet.MessageIDs
+		if wallet.lastMessageID != nil {
+			tip := make(hornet.MessageID, len(wallet.lastMessageID))
+			copy(tip, wallet.lastMessageID)
+			tips = append(tips, tip)
+		}
+
+		processableRequests := f.processRequestsWithoutLocking(len(unspentOutputs), amount, batchedRequests)
+		if len(processableRequests) == 0 {
+			return nil, nil, nil, nil, ErrNothingToProcess
+		}
+
+		return wallet, unspentOutputs, processableRequests, tips, nil
+	}
+
+	wallet, unspentOutputs, processableRequests, tips, err := prepareTransaction()
+	if err != nil {
+		return err
+	}
+
+	if err := f.sendFaucetMessage(ctx, wallet, unspentOutputs, processableRequests, tips...); err != nil {
+		if common.IsCriticalError(err) == nil {
+			f.Lock()
+			f.readdRequestsWithoutLocking(processableRequests)
+			f.Unlock()
+		}
+		return err
+	}
+
+	return nil
+}
+
+// consolidateWallets sweeps the unspent outputs of every wallet without pending transactions into a single output,
+// if the amount of unspent outputs reached the consolidation threshold.
+func (f *Faucet) consolidateWallets(ctx context.Context) error {
+
+	collectConsolidationOutputs := func(wallet *faucetWallet) ([]*utxo.Output, error) {
+		// first we need to read lock the ledger, to be sure that there is no confirmation ongoing
+		f.utxoManager.ReadLockLedger()
+		defer f.utxoManager.ReadUnlockLedger()
+
+		f.Lock()
+		defer f.Unlock()
+
+		if !wallet.idle() {
+			// the pending transactions of the wallet already sweep its outputs
+			return nil, nil
+		}
+
+		unspentOutputs, _, err := f.collectUnspentOutputsWithoutLocking(wallet, iotago.MaxInputsCount)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(unspentOutputs) < f.opts.consolidationThreshold {
+			return nil, nil
+		}
+
+		return unspentOutputs, nil
+	}
+
+	for _, wallet := range f.wallets {
+		unspentOutputs, err := collectConsolidationOutputs(wallet)
+		if err != nil {
+			return err
+		}
+
+		if len(unspentOutputs) == 0 {
+			continue
+		}
+
+		if err := f.sendFaucetMessage(ctx, wallet, unspentOutputs, nil); err != nil {
+			return fmt.Errorf("consolidation of %s failed, error: %w", wallet.Address.Bech32(f.opts.hrpNetworkPrefix), err)
+		}
+	}
+
+	return nil
+}
+
+// RunFaucetLoop collects unspent outputs on the faucet wallets and batches the requests from the queue.
 func (f *Faucet) RunFaucetLoop(ctx context.Context, initDoneCallback func()) error {
 
 	// set initial faucet balance
-	faucetBalance, _, err := f.utxoManager.AddressBalanceWithoutLocking(f.address)
+	f.Lock()
+	faucetBalance, err := f.updateWalletBalancesWithoutLocking()
 	if err != nil {
-		return common.CriticalError(fmt.Errorf("reading faucet address balance failed: %s, error: %s", f.address.Bech32(f.opts.hrpNetworkPrefix), err))
+		f.Unlock()
+		return err
 	}
 	f.faucetBalance = faucetBalance
+	f.Unlock()
 
 	if initDoneCallback != nil {
 		initDoneCallback()
 	}
 
+	handleError := func(err error) error {
+		if err == nil || err == ErrNothingToProcess {
+			return nil
+		}
+		if common.IsCriticalError(err) != nil {
+			// error is a critical error
+			// => stop the faucet
+			return err
+		}
+		f.logSoftError(err)
+		return nil
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -707,99 +993,41 @@ func (f *Faucet) RunFaucetLoop(ctx context.Context, initDoneCallback func()) err
 				if err == common.ErrOperationAborted {
 					return nil
 				}
-				if common.IsCriticalError(err) != nil {
-					// error is a critical error
-					// => stop the faucet
+				if err := handleError(err); err != nil {
 					return err
 				}
-				f.logSoftError(err)
 				continue
 			}
 
-			collectUnspentOutputsWithoutLocking := func() ([]*utxo.Output, uint64, error) {
-				if f.lastRemainderOutput != nil {
-					// the lastRemainderOutput is reused as input in the next transaction, even if it was not yet referenced by a milestone.
-					// this is done to increase the throughput of the faucet in high load situations.
-					// we can't collect unspent outputs, as long as the lastRemainderOutput was not confirmed,
-					// since it's creating transaction could also have consumed the same UTXOs.
-					return []*utxo.Output{f.lastRemainderOutput}, f.lastRemainderOutput.Amount(), nil
-				}
-
-				unspentOutputs, err := f.utxoManager.UnspentOutputs(utxo.FilterAddress(f.address), utxo.ReadLockLedger(false), utxo.MaxResultCount(f.opts.maxOutputCount-2), utxo.FilterOutputType(iotago.OutputSigLockedSingleOutput))
-				if err != nil {
-					return nil, 0, common.CriticalError(fmt.Errorf("reading unspent outputs failed: %s, error: %w", f.address.Bech32(f.opts.hrpNetworkPrefix), err))
-				}
-
-				var amount uint64 = 0
-				for _, unspentOutput := range unspentOutputs {
-					amount += unspentOutput.Amount()
-				}
-				return unspentOutputs, amount, nil
+			if err := handleError(f.processRequests(ctx, batchedRequests)); err != nil {
+				return err
 			}
 
-			processRequests := func() ([]*utxo.Output, []*queueItem, hornet.MessageIDs, error) {
-				// first we need to read lock the ledger, to be sure that there is no confirmation ongoing
-				f.utxoManager.ReadLockLedger()
-				defer f.utxoManager.ReadUnlockLedger()
-
-				// there must be a lock between collectUnspentOutputsWithoutLocking and "tipselection", otherwise the chaining may fail
-				f.Lock()
-				defer f.Unlock()
-
-				unspentOutputs, amount, err := collectUnspentOutputsWithoutLocking()
-				if err != nil {
-					return nil, nil, nil, err
-				}
-
-				if len(unspentOutputs) < 2 && len(batchedRequests) == 0 {
-					// no need to sweep or send funds
-					return nil, nil, nil, ErrNothingToProcess
-				}
-
-				// if a lastMessageID exists, we need to reference it to chain the transactions in the correct order for whiteflag.
-				// lastMessageID is reset by ApplyConfirmation in case the last faucet message is not confirmed and below max depth.
-				var tips hornet.MessageIDs
-				if f.lastMessageID != nil {
-					tip := make(hornet.MessageID, len(f.lastMessageID))
-					copy(tip, f.lastMessageID)
-					tips = append(tips, tip)
-				}
-
-				processableRequests := f.processRequestsWithoutLocking(len(unspentOutputs), amount, batchedRequests)
-
-				return unspentOutputs, processableRequests, tips, nil
-			}
-
-			unspentOutputs, processableRequests, tips, err := processRequests()
-			if err != nil {
-				if err != ErrNothingToProcess {
-					if common.IsCriticalError(err) != nil {
-						// error is a critical error
-						// => stop the faucet
-						return err
-					}
-					f.logSoftError(err)
-				}
-				continue
+			if err := handleError(f.consolidateWallets(ctx)); err != nil {
+				return err
 			}
+		}
+	}
+}
 
-			if err := f.sendFaucetMessage(ctx, unspentOutputs, processableRequests, tips...); err != nil {
-				if common.IsCriticalError(err) != nil {
-					// error is a critical error
-					// => stop the faucet
-					return err
-				}
-				f.readdRequestsWithoutLocking(processableRequests)
-				f.logSoftError(err)
-				continue
-			}
+// updateWalletBalancesWithoutLocking reads the confirmed balances of all wallets and returns the total balance.
+// write lock must be acquired outside.
+func (f *Faucet) updateWalletBalancesWithoutLocking() (uint64, error) {
+	var totalBalance uint64
+	for _, wallet := range f.wallets {
+		balance, _, err := f.utxoManager.AddressBalanceWithoutLocking(wallet.Address)
+		if err != nil {
+			return 0, common.CriticalError(fmt.Errorf("reading faucet address balance failed: %s, error: %s", wallet.Address.Bech32(f.opts.hrpNetworkPrefix), err))
 		}
+		wallet.balance = balance
+		totalBalance += balance
 	}
+	return totalBalance, nil
 }
 
 // ApplyConfirmation applies new milestone confirmations to the faucet.
 // Pending transactions are checked for their current state and either removed, readded, or left pending.
-// If a conflict is found, all remaining pending transactions are readded to the queue.
+// If a conflict is found, all remaining pending transactions of the affected wallet are readded to the queue.
 // no need to ReadLockLedger, because this function should be called from milestone confirmation event anyway.
 func (f *Faucet) ApplyConfirmation(confirmation *whiteflag.Confirmation) error {
 	if confirmation == nil {
@@ -809,24 +1037,26 @@ func (f *Faucet) ApplyConfirmation(confirmation *whiteflag.Confirmation) error {
 	f.Lock()
 	defer f.Unlock()
 
-	conflicting := false
+	// the wallets whose transaction chain contains a conflict
+	conflictingWallets := make(map[*faucetWallet]struct{})
 	cmi := confirmation.MilestoneIndex
 
 	// check pending transactions for confirmation
 	for _, msgID := range confirmation.Mutations.MessagesIncludedWithTransactions {
 		if pendingTx, pending := f.pendingTransactionsMap[msgID.ToMapKey()]; pending {
 			// transaction was confirmed => delete the requests and the pending transaction
-			f.clearRequestsWithoutLocking(pendingTx.QueuedItems)
+			f.confirmRequestsWithoutLocking(pendingTx.QueuedItems, cmi)
 			f.clearPendingTransactionWithoutLocking(msgID)
 
-			if f.lastMessageID != nil && bytes.Equal(f.lastMessageID[:], msgID[:]) {
-				// the latest message got confirmed, reset the lastMessageID
-				f.lastMessageID = nil
+			wallet := pendingTx.Wallet
+			if wallet.lastMessageID != nil && bytes.Equal(wallet.lastMessageID[:], msgID[:]) {
+				// the latest message of the wallet got confirmed, reset the lastMessageID
+				wallet.lastMessageID = nil
 			}
 
-			if f.lastRemainderOutput != nil && bytes.Equal(f.lastRemainderOutput.MessageID()[:], msgID[:]) {
-				// the latest transaction got confirmed, reset the lastRemainderOutput
-				f.lastRemainderOutput = nil
+			if wallet.lastRemainderOutput != nil && bytes.Equal(wallet.lastRemainderOutput.MessageID()[:], msgID[:]) {
+				// the latest transaction of the wallet got confirmed, reset the lastRemainderOutput
+				wallet.lastRemainderOutput = nil
 			}
 		}
 	}
@@ -835,7 +1065,7 @@ func (f *Faucet) ApplyConfirmation(confirmation *whiteflag.Confirmation) error {
 	for _, conflict := range confirmation.Mutations.MessagesExcludedWithConflictingTransactions {
 		if pendingTx, pending := f.pendingTransactionsMap[conflict.MessageID.ToMapKey()]; pending {
 			// transaction was conflicting => readd the items to the queue and delete the pending transaction
-			conflicting = true
+			conflictingWallets[pendingTx.Wallet] = struct{}{}
 			f.readdRequestsWithoutLocking(pendingTx.QueuedItems)
 			f.clearPendingTransactionWithoutLocking(conflict.MessageID)
 		}
@@ -847,25 +1077,25 @@ func (f *Faucet) ApplyConfirmation(confirmation *whiteflag.Confirmation) error {
 		cachedMsgMeta := f.storage.CachedMessageMetadataOrNil(msgID) // meta +1
 		if cachedMsgMeta == nil {
 			// message unknown => delete the requests and the pending transaction
-			conflicting = true
-			f.clearRequestsWithoutLocking(pendingTx.QueuedItems)
+			conflictingWallets[pendingTx.Wallet] = struct{}{}
+			f.failRequestsWithoutLocking(pendingTx.QueuedItems, "faucet message is unknown")
 			f.clearPendingTransactionWithoutLocking(msgID)
 			return
 		}
 		defer cachedMsgMeta.Release(true)
 
 		metadata := cachedMsgMeta.Metadata()
-		if metadata.IsReferenced() {
+		if referenced, referencedIndex := metadata.ReferencedWithIndex(); referenced {
 			if metadata.IsConflictingTx() {
 				// transaction was conflicting => readd the items to the queue and delete the pending transaction
-				conflicting = true
+				conflictingWallets[pendingTx.Wallet] = struct{}{}
 				f.readdRequestsWithoutLocking(pendingTx.QueuedItems)
 				f.clearPendingTransactionWithoutLocking(msgID)
 				return
 			}
 
 			// transaction was confirmed => delete the requests and the pending transaction
-			f.clearRequestsWithoutLocking(pendingTx.QueuedItems)
+			f.confirmRequestsWithoutLocking(pendingTx.QueuedItems, referencedIndex)
 			f.clearPendingTransactionWithoutLocking(msgID)
 			return
 		}
@@ -874,7 +1104,7 @@ func (f *Faucet) ApplyConfirmation(confirmation *whiteflag.Confirmation) error {
 		_, ocri, err := dag.ConeRootIndexes(f.daemon.ContextStopped(), f.storage, cachedMsgMeta.Retain(), cmi)
 		if err != nil {
 			// an error occurred => readd the items to the queue and delete the pending transaction
-			conflicting = true
+			conflictingWallets[pendingTx.Wallet] = struct{}{}
 			f.readdRequestsWithoutLocking(pendingTx.QueuedItems)
 			f.clearPendingTransactionWithoutLocking(msgID)
 			return
@@ -882,7 +1112,7 @@ func (f *Faucet) ApplyConfirmation(confirmation *whiteflag.Confirmation) error {
 
 		if (cmi - ocri) > milestone.Index(f.belowMaxDepth) {
 			// below max depth => readd the items to the queue and delete the pending transaction
-			conflicting = true
+			conflictingWallets[pendingTx.Wallet] = struct{}{}
 			f.readdRequestsWithoutLocking(pendingTx.QueuedItems)
 			f.clearPendingTransactionWithoutLocking(msgID)
 		}
@@ -893,16 +1123,20 @@ func (f *Faucet) ApplyConfirmation(confirmation *whiteflag.Confirmation) error {
 		checkPendingMessageMetadata(pendingTx)
 	}
 
-	if conflicting {
-		// there was a conflict in the chain
-		// => reset the lastMessageID and lastRemainderOutput to collect outputs and reissue all pending transactions
-		f.lastMessageID = nil
-		f.lastRemainderOutput = nil
-
+	if len(conflictingWallets) > 0 {
+		// there was a conflict in the chain of a wallet
+		// => reset the lastMessageID and lastRemainderOutput of the wallet to collect outputs and reissue all its pending transactions
 		for _, pendingTx := range f.pendingTransactionsMap {
+			if _, conflicting := conflictingWallets[pendingTx.Wallet]; !conflicting {
+				continue
+			}
 			f.readdRequestsWithoutLocking(pendingTx.QueuedItems)
 			f.clearPendingTransactionWithoutLocking(pendingTx.MessageID)
 		}
+
+		for wallet := range conflictingWallets {
+			wallet.resetChain()
+		}
 	}
 
 	// calculate total balance of all pending requests
@@ -913,9 +1147,9 @@ func (f *Faucet) ApplyConfirmation(confirmation *whiteflag.Confirmation) error {
 
 	// recalculate the current faucet balance
 	// no need to lock since we are in the milestone confirmation anyway
-	faucetBalance, _, err := f.utxoManager.AddressBalanceWithoutLocking(f.address)
+	faucetBalance, err := f.updateWalletBalancesWithoutLocking()
 	if err != nil {
-		return common.CriticalError(fmt.Errorf("reading faucet address balance failed: %s, error: %s", f.address.Bech32(f.opts.hrpNetworkPrefix), err))
+		return err
 	}
 
 	if faucetBalance < pendingRequestsBalance {
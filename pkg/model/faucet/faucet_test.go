@@ -7,6 +7,7 @@ import (
 
 	iotago "github.com/iotaledger/iota.go/v2"
 
+	"github.com/gohornet/hornet/pkg/model/faucet"
 	"github.com/gohornet/hornet/pkg/model/faucet/test"
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/milestone"
@@ -431,7 +432,9 @@ func TestCollectFaucetFunds(t *testing.T) {
 		faucetAmount,
 		faucetSmallAmount,
 		faucetMaxAddressBalance,
-		false)
+		false,
+		// collect the funds as soon as a second output exists
+		faucet.WithConsolidationThreshold(2))
 	defer env.Cleanup()
 	require.NotNil(t, env)
 
@@ -485,3 +488,139 @@ func TestCollectFaucetFunds(t *testing.T) {
 
 	env.AssertAddressUTXOCount(env.FaucetWallet.Address(), 1)
 }
+
+func TestMultipleWallets(t *testing.T) {
+	// requests are spread over the wallets of the faucet
+
+	var faucetWalletBalance uint64 = 100_000_000    // 100 Mi
+	var wallet1Balance uint64 = 0                   //  0  i
+	var wallet2Balance uint64 = 0                   //  0  i
+	var wallet3Balance uint64 = 0                   //  0  i
+	var faucetAmount uint64 = 10_000_000            // 10 Mi
+	var faucetSmallAmount uint64 = 1_000_000        //  1 Mi
+	var faucetMaxAddressBalance uint64 = 20_000_000 // 20 Mi
+
+	env := test.NewFaucetTestEnvWithWallets(t,
+		[]uint64{faucetWalletBalance, faucetWalletBalance},
+		wallet1Balance,
+		wallet2Balance,
+		wallet3Balance,
+		faucetAmount,
+		faucetSmallAmount,
+		faucetMaxAddressBalance,
+		false)
+	defer env.Cleanup()
+	require.NotNil(t, env)
+
+	faucetBalance := 2 * faucetWalletBalance
+	env.AssertFaucetBalance(faucetBalance)
+	env.AssertFaucetAddressBalances(faucetWalletBalance, faucetWalletBalance)
+
+	// the first request is paid by the first wallet
+	tips1, err := env.RequestFunds(env.Wallet1)
+	require.NoError(t, err)
+
+	// the second request is paid by the second wallet, because the first one has a pending transaction
+	tips2, err := env.RequestFunds(env.Wallet2)
+	require.NoError(t, err)
+
+	// the third request is chained to the pending transaction of the first wallet
+	tips3, err := env.RequestFunds(env.Wallet3)
+	require.NoError(t, err)
+
+	_, _ = env.IssueMilestone(append(append(tips1, tips2...), tips3...)...)
+
+	faucetBalance -= 3 * faucetAmount
+	env.AssertFaucetBalance(faucetBalance)
+	env.AssertFaucetAddressBalances(faucetWalletBalance-2*faucetAmount, faucetWalletBalance-faucetAmount)
+	env.TestEnv.AssertLedgerBalance(env.FaucetWallets[0], faucetWalletBalance-2*faucetAmount)
+	env.TestEnv.AssertLedgerBalance(env.FaucetWallets[1], faucetWalletBalance-faucetAmount)
+	env.TestEnv.AssertLedgerBalance(env.Wallet1, faucetAmount)
+	env.TestEnv.AssertLedgerBalance(env.Wallet2, faucetAmount)
+	env.TestEnv.AssertLedgerBalance(env.Wallet3, faucetAmount)
+}
+
+func TestMultipleWalletsInsufficientWalletBalance(t *testing.T) {
+	// requests are paid by a wallet with enough funds
+
+	var faucetWallet1Balance uint64 = 5_000_000     //   5 Mi
+	var faucetWallet2Balance uint64 = 100_000_000   // 100 Mi
+	var wallet1Balance uint64 = 0                   //  0  i
+	var wallet2Balance uint64 = 0                   //  0  i
+	var wallet3Balance uint64 = 0                   //  0  i
+	var faucetAmount uint64 = 10_000_000            // 10 Mi
+	var faucetSmallAmount uint64 = 1_000_000        //  1 Mi
+	var faucetMaxAddressBalance uint64 = 20_000_000 // 20 Mi
+
+	env := test.NewFaucetTestEnvWithWallets(t,
+		[]uint64{faucetWallet1Balance, faucetWallet2Balance},
+		wallet1Balance,
+		wallet2Balance,
+		wallet3Balance,
+		faucetAmount,
+		faucetSmallAmount,
+		faucetMaxAddressBalance,
+		false)
+	defer env.Cleanup()
+	require.NotNil(t, env)
+
+	err := env.RequestFundsAndIssueMilestone(env.Wallet1)
+	require.NoError(t, err)
+
+	env.AssertFaucetBalance(faucetWallet1Balance + faucetWallet2Balance - faucetAmount)
+	env.AssertFaucetAddressBalances(faucetWallet1Balance, faucetWallet2Balance-faucetAmount)
+	env.TestEnv.AssertLedgerBalance(env.Wallet1, faucetAmount)
+}
+
+func TestConsolidation(t *testing.T) {
+	// the faucet consolidates the outputs of its wallets if the threshold is reached
+
+	var faucetBalance uint64 = 1_000_000_000        //  1 Gi
+	var wallet1Balance uint64 = 0                   //  0  i
+	var wallet2Balance uint64 = 0                   //  0  i
+	var wallet3Balance uint64 = 0                   //  0  i
+	var faucetAmount uint64 = 10_000_000            // 10 Mi
+	var faucetSmallAmount uint64 = 1_000_000        //  1 Mi
+	var faucetMaxAddressBalance uint64 = 20_000_000 // 20 Mi
+
+	env := test.NewFaucetTestEnv(t,
+		faucetBalance,
+		wallet1Balance,
+		wallet2Balance,
+		wallet3Balance,
+		faucetAmount,
+		faucetSmallAmount,
+		faucetMaxAddressBalance,
+		false,
+		faucet.WithConsolidationThreshold(3))
+	defer env.Cleanup()
+	require.NotNil(t, env)
+
+	env.AssertAddressUTXOCount(env.FaucetWallet.Address(), 1)
+
+	for i := 0; i < 2; i++ {
+		message := env.TestEnv.NewMessageBuilder().
+			LatestMilestonesAsParents().
+			FromWallet(env.GenesisWallet).
+			ToWallet(env.FaucetWallet).
+			Amount(faucetAmount).
+			Build().
+			Store().
+			BookOnWallets()
+
+		// Confirming milestone at message
+		_, _ = env.IssueMilestone(message.StoredMessageID())
+	}
+
+	faucetBalance += 2 * faucetAmount
+	env.AssertFaucetBalance(faucetBalance)
+	env.AssertAddressUTXOCount(env.FaucetWallet.Address(), 3)
+
+	// the threshold is reached, so the outputs are consolidated
+	err := env.FlushRequestsAndConfirmNewFaucetMessage()
+	require.NoError(t, err)
+
+	env.AssertAddressUTXOCount(env.FaucetWallet.Address(), 1)
+	env.AssertFaucetBalance(faucetBalance)
+	env.TestEnv.AssertLedgerBalance(env.FaucetWallet, faucetBalance)
+}
@@ -0,0 +1,201 @@
+package faucet
+
+import (
+	"encoding/binary"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/syncutils"
+)
+
+const (
+	// RateLimiterDatabaseDirectoryName is the name of the faucet rate limiter database directory inside the database path of the node.
+	RateLimiterDatabaseDirectoryName = "faucet"
+
+	rateLimiterStoreKeyPrefixIP     byte = 0
+	rateLimiterStoreKeyPrefixSubnet byte = 1
+
+	// the size of a serialized rate limit window (window end + request count).
+	rateLimitWindowSize = 8 + 4
+)
+
+var (
+	// ErrRateLimitExceeded is returned when an IP or its subnet exceeded the allowed amount of faucet requests.
+	ErrRateLimitExceeded = errors.New("rate limit exceeded")
+
+	// IPv4 addresses are throttled per /24 subnet.
+	subnetMaskIPv4 = net.CIDRMask(24, 8*net.IPv4len)
+	// IPv6 addresses are throttled per /64 subnet, which is the smallest subnet usually assigned to a single host.
+	subnetMaskIPv6 = net.CIDRMask(64, 8*net.IPv6len)
+)
+
+// RateLimit defines the maximum amount of requests within an interval.
+// A MaxRequests of 0 disables the limit.
+type RateLimit struct {
+	// MaxRequests is the maximum amount of requests within the interval.
+	MaxRequests int
+	// Interval is the duration of the cooldown window that starts with the first request.
+	Interval time.Duration
+}
+
+// rateLimitWindow is the persisted state of a rate limit for a single IP or subnet.
+type rateLimitWindow struct {
+	end   time.Time
+	count uint32
+}
+
+// RateLimiter throttles faucet requests per IP and per subnet.
+// The cooldown windows are persisted, so they survive restarts of the node.
+type RateLimiter struct {
+	// lock used to secure the state of the windows.
+	syncutils.Mutex
+
+	// the store the cooldown windows are persisted in.
+	store kvstore.KVStore
+	// the limit for single IP addresses.
+	ipLimit RateLimit
+	// the limit for whole subnets.
+	subnetLimit RateLimit
+}
+
+// NewRateLimiter creates a new RateLimiter that persists its cooldown windows in the given store.
+func NewRateLimiter(store kvstore.KVStore, ipLimit RateLimit, subnetLimit RateLimit) *RateLimiter {
+	return &RateLimiter{
+		store:       store,
+		ipLimit:     ipLimit,
+		subnetLimit: subnetLimit,
+	}
+}
+
+// Allow checks whether a request of the given IP is allowed at the given time and counts it if so.
+// If the IP or its subnet exceeded its limit, ErrRateLimitExceeded and the remaining cooldown are returned.
+func (r *RateLimiter) Allow(ip net.IP, now time.Time) (time.Duration, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	ipKey := rateLimiterIPKey(ip)
+	subnetKey := rateLimiterSubnetKey(ip)
+
+	ipWindow, err := r.window(ipKey, r.ipLimit, now)
+	if err != nil {
+		return 0, err
+	}
+
+	subnetWindow, err := r.window(subnetKey, r.subnetLimit, now)
+	if err != nil {
+		return 0, err
+	}
+
+	// both limits are checked before any request is counted, so denied requests do not extend the cooldown.
+	var cooldown time.Duration
+	if r.ipLimit.MaxRequests > 0 && ipWindow.count >= uint32(r.ipLimit.MaxRequests) {
+		cooldown = ipWindow.end.Sub(now)
+	}
+	if r.subnetLimit.MaxRequests > 0 && subnetWindow.count >= uint32(r.subnetLimit.MaxRequests) {
+		if subnetCooldown := subnetWindow.end.Sub(now); subnetCooldown > cooldown {
+			cooldown = subnetCooldown
+		}
+	}
+	if cooldown > 0 {
+		return cooldown, ErrRateLimitExceeded
+	}
+
+	if r.ipLimit.MaxRequests > 0 {
+		if err := r.storeWindow(ipKey, ipWindow); err != nil {
+			return 0, err
+		}
+	}
+	if r.subnetLimit.MaxRequests > 0 {
+		if err := r.storeWindow(subnetKey, subnetWindow); err != nil {
+			return 0, err
+		}
+	}
+
+	return 0, nil
+}
+
+// PruneExpired removes all cooldown windows that ended before the given time.
+func (r *RateLimiter) PruneExpired(now time.Time) error {
+	r.Lock()
+	defer r.Unlock()
+
+	var expiredKeys []kvstore.Key
+	if err := r.store.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		window, err := rateLimitWindowFromBytes(value)
+		if err != nil || !now.Before(window.end) {
+			expiredKeys = append(expiredKeys, key)
+		}
+		return true
+	}); err != nil {
+		return err
+	}
+
+	for _, key := range expiredKeys {
+		if err := r.store.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// window returns the cooldown window for the given key including the current request.
+// A new window is started if there is no window yet or the last one already ended.
+func (r *RateLimiter) window(key []byte, limit RateLimit, now time.Time) (*rateLimitWindow, error) {
+	if limit.MaxRequests <= 0 {
+		return &rateLimitWindow{}, nil
+	}
+
+	value, err := r.store.Get(key)
+	if err != nil {
+		if !errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, err
+		}
+		return &rateLimitWindow{end: now.Add(limit.Interval)}, nil
+	}
+
+	window, err := rateLimitWindowFromBytes(value)
+	if err != nil || !now.Before(window.end) {
+		// invalid or expired windows are replaced with a new one
+		return &rateLimitWindow{end: now.Add(limit.Interval)}, nil
+	}
+
+	return window, nil
+}
+
+// storeWindow counts the current request and persists the window.
+func (r *RateLimiter) storeWindow(key []byte, window *rateLimitWindow) error {
+	value := make([]byte, rateLimitWindowSize)
+	binary.LittleEndian.PutUint64(value[:8], uint64(window.end.UnixNano()))
+	binary.LittleEndian.PutUint32(value[8:], window.count+1)
+
+	return r.store.Set(key, value)
+}
+
+func rateLimitWindowFromBytes(value []byte) (*rateLimitWindow, error) {
+	if len(value) != rateLimitWindowSize {
+		return nil, errors.New("invalid rate limit window length")
+	}
+
+	return &rateLimitWindow{
+		end:   time.Unix(0, int64(binary.LittleEndian.Uint64(value[:8]))),
+		count: binary.LittleEndian.Uint32(value[8:]),
+	}, nil
+}
+
+func rateLimiterIPKey(ip net.IP) []byte {
+	if ipv4 := ip.To4(); ipv4 != nil {
+		ip = ipv4
+	}
+	return append([]byte{rateLimiterStoreKeyPrefixIP}, ip...)
+}
+
+func rateLimiterSubnetKey(ip net.IP) []byte {
+	if ipv4 := ip.To4(); ipv4 != nil {
+		return append([]byte{rateLimiterStoreKeyPrefixSubnet}, ipv4.Mask(subnetMaskIPv4)...)
+	}
+	return append([]byte{rateLimiterStoreKeyPrefixSubnet}, ip.To16().Mask(subnetMaskIPv6)...)
+}
@@ -0,0 +1,148 @@
+package faucet_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/faucet"
+	"github.com/gohornet/hornet/pkg/restapi"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+)
+
+func TestRateLimiter(t *testing.T) {
+	store := mapdb.NewMapDB()
+
+	limiter := faucet.NewRateLimiter(store,
+		faucet.RateLimit{MaxRequests: 1, Interval: time.Hour},
+		faucet.RateLimit{MaxRequests: 3, Interval: 2 * time.Hour},
+	)
+
+	now := time.Unix(1_600_000_000, 0)
+
+	_, err := limiter.Allow(net.ParseIP("10.0.0.1"), now)
+	require.NoError(t, err)
+
+	// the same IP is throttled
+	cooldown, err := limiter.Allow(net.ParseIP("10.0.0.1"), now.Add(10*time.Minute))
+	require.ErrorIs(t, err, faucet.ErrRateLimitExceeded)
+	require.Equal(t, 50*time.Minute, cooldown)
+
+	// other IPs of the same subnet are allowed until the subnet limit is reached
+	_, err = limiter.Allow(net.ParseIP("10.0.0.2"), now)
+	require.NoError(t, err)
+	_, err = limiter.Allow(net.ParseIP("10.0.0.3"), now)
+	require.NoError(t, err)
+
+	cooldown, err = limiter.Allow(net.ParseIP("10.0.0.4"), now.Add(time.Hour))
+	require.ErrorIs(t, err, faucet.ErrRateLimitExceeded)
+	require.Equal(t, time.Hour, cooldown)
+
+	// other subnets are not affected
+	_, err = limiter.Allow(net.ParseIP("10.0.1.1"), now)
+	require.NoError(t, err)
+
+	// the IP window ended, but the subnet is still throttled
+	_, err = limiter.Allow(net.ParseIP("10.0.0.1"), now.Add(time.Hour))
+	require.ErrorIs(t, err, faucet.ErrRateLimitExceeded)
+
+	// the cooldown windows are persisted
+	limiter = faucet.NewRateLimiter(store,
+		faucet.RateLimit{MaxRequests: 1, Interval: time.Hour},
+		faucet.RateLimit{MaxRequests: 3, Interval: 2 * time.Hour},
+	)
+	_, err = limiter.Allow(net.ParseIP("10.0.1.1"), now.Add(30*time.Minute))
+	require.ErrorIs(t, err, faucet.ErrRateLimitExceeded)
+
+	// all windows ended
+	_, err = limiter.Allow(net.ParseIP("10.0.0.1"), now.Add(2*time.Hour))
+	require.NoError(t, err)
+}
+
+func TestRateLimiterIPv6(t *testing.T) {
+	limiter := faucet.NewRateLimiter(mapdb.NewMapDB(),
+		faucet.RateLimit{},
+		faucet.RateLimit{MaxRequests: 1, Interval: time.Hour},
+	)
+
+	now := time.Unix(1_600_000_000, 0)
+
+	_, err := limiter.Allow(net.ParseIP("2001:db8:1:1::1"), now)
+	require.NoError(t, err)
+
+	// the /64 subnet is throttled
+	_, err = limiter.Allow(net.ParseIP("2001:db8:1:1::2"), now)
+	require.ErrorIs(t, err, faucet.ErrRateLimitExceeded)
+
+	_, err = limiter.Allow(net.ParseIP("2001:db8:1:2::1"), now)
+	require.NoError(t, err)
+}
+
+func TestRateLimiterPruneExpired(t *testing.T) {
+	store := mapdb.NewMapDB()
+
+	limiter := faucet.NewRateLimiter(store,
+		faucet.RateLimit{MaxRequests: 1, Interval: time.Hour},
+		faucet.RateLimit{MaxRequests: 1, Interval: 2 * time.Hour},
+	)
+
+	now := time.Unix(1_600_000_000, 0)
+
+	_, err := limiter.Allow(net.ParseIP("10.0.0.1"), now)
+	require.NoError(t, err)
+
+	countEntries := func() int {
+		var count int
+		require.NoError(t, store.IterateKeys([]byte{}, func(_ []byte) bool {
+			count++
+			return true
+		}))
+		return count
+	}
+	require.Equal(t, 2, countEntries())
+
+	// only the IP window ended
+	require.NoError(t, limiter.PruneExpired(now.Add(time.Hour)))
+	require.Equal(t, 1, countEntries())
+
+	require.NoError(t, limiter.PruneExpired(now.Add(2*time.Hour)))
+	require.Equal(t, 0, countEntries())
+}
+
+func TestRateLimiterForgedForwardedFor(t *testing.T) {
+	limiter := faucet.NewRateLimiter(mapdb.NewMapDB(),
+		faucet.RateLimit{MaxRequests: 1, Interval: time.Hour},
+		faucet.RateLimit{MaxRequests: 3, Interval: 2 * time.Hour},
+	)
+
+	ipExtractor, err := restapi.IPExtractor(nil)
+	require.NoError(t, err)
+
+	clientIP := func(remoteAddr string, forwardedFor string) net.IP {
+		req := httptest.NewRequest(http.MethodPost, "/api/plugins/faucet/v1/enqueue", nil)
+		req.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+		}
+		return net.ParseIP(ipExtractor(req))
+	}
+
+	now := time.Unix(1_600_000_000, 0)
+
+	// the request of the client is proxied by the faucet website, which appends the real client IP
+	_, err = limiter.Allow(clientIP("127.0.0.1:50000", "1.2.3.4, 85.1.1.1"), now)
+	require.NoError(t, err)
+
+	// a forged X-Forwarded-For header does not result in a fresh window
+	_, err = limiter.Allow(clientIP("127.0.0.1:50001", "5.6.7.8, 85.1.1.1"), now)
+	require.ErrorIs(t, err, faucet.ErrRateLimitExceeded)
+
+	// a forged header sent directly to the node is ignored as well
+	_, err = limiter.Allow(clientIP("85.1.1.1:50002", "9.9.9.9"), now)
+	require.ErrorIs(t, err, faucet.ErrRateLimitExceeded)
+}
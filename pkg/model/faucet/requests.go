@@ -0,0 +1,205 @@
+package faucet
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+)
+
+// RequestState is the state of a faucet request.
+type RequestState string
+
+const (
+	// RequestStateQueued means the request is waiting in the queue of the faucet.
+	RequestStateQueued RequestState = "queued"
+	// RequestStateBatched means the request was added to a faucet message that is not confirmed yet.
+	RequestStateBatched RequestState = "batched"
+	// RequestStateConfirmed means the faucet message that pays the request was confirmed by a milestone.
+	RequestStateConfirmed RequestState = "confirmed"
+	// RequestStateFailed means the request was dropped without being paid.
+	RequestStateFailed RequestState = "failed"
+)
+
+// the size of the random request IDs.
+const requestIDSize = 16
+
+// FaucetRequestResponse defines the status of a single faucet request.
+type FaucetRequestResponse struct {
+	// The ID of the request.
+	ID string `json:"id"`
+	// The bech32 address of the request.
+	Address string `json:"address"`
+	// The amount of funds the address receives.
+	Amount uint64 `json:"amount"`
+	// The state of the request (queued, batched, confirmed or failed).
+	State RequestState `json:"state"`
+	// The hex encoded message ID of the faucet message that pays the request, if it was batched.
+	MessageID string `json:"messageId,omitempty"`
+	// The index of the milestone that confirmed the faucet message, if it was confirmed.
+	MilestoneIndex milestone.Index `json:"milestoneIndex,omitempty"`
+	// The reason why the request failed.
+	FailureReason string `json:"failureReason,omitempty"`
+	// The unix timestamp at which the request was enqueued.
+	EnqueuedAt int64 `json:"enqueuedAt"`
+	// The unix timestamp of the last state change of the request.
+	UpdatedAt int64 `json:"updatedAt"`
+}
+
+// FaucetRequestHistoryResponse defines the response of a GET RouteFaucetRequests REST API call.
+type FaucetRequestHistoryResponse struct {
+	// The position of the first returned request, starting with the newest one.
+	Offset int `json:"offset"`
+	// The maximum amount of returned requests.
+	Limit int `json:"limit"`
+	// The amount of returned requests.
+	Count int `json:"count"`
+	// The total amount of known requests that match the filter.
+	Total int `json:"total"`
+	// The requests, newest first.
+	Requests []*FaucetRequestResponse `json:"requests"`
+}
+
+// newRequestID returns a new random request ID.
+func newRequestID() (string, error) {
+	id := make([]byte, requestIDSize)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// setState changes the state of the request.
+func (r *queueItem) setState(state RequestState) {
+	r.State = state
+	r.UpdatedAt = time.Now()
+}
+
+// isFinal returns whether the request will not change its state anymore.
+func (r *queueItem) isFinal() bool {
+	return r.State == RequestStateConfirmed || r.State == RequestStateFailed
+}
+
+// response returns the status of the request.
+func (r *queueItem) response() *FaucetRequestResponse {
+	response := &FaucetRequestResponse{
+		ID:             r.ID,
+		Address:        r.Bech32,
+		Amount:         r.Amount,
+		State:          r.State,
+		MilestoneIndex: r.MilestoneIndex,
+		FailureReason:  r.FailureReason,
+		EnqueuedAt:     r.EnqueuedAt.Unix(),
+		UpdatedAt:      r.UpdatedAt.Unix(),
+	}
+	if r.MessageID != nil {
+		response.MessageID = r.MessageID.ToHex()
+	}
+	return response
+}
+
+// addRequestToHistoryWithoutLocking tracks a new request.
+// If the history is full, the oldest requests in a final state are forgotten.
+// write lock must be acquired outside.
+func (f *Faucet) addRequestToHistoryWithoutLocking(request *queueItem) {
+	f.requests[request.ID] = request
+	f.requestHistory = append(f.requestHistory, request)
+
+	for len(f.requestHistory) > f.opts.requestHistorySize {
+		evicted := false
+		for i, oldRequest := range f.requestHistory {
+			if !oldRequest.isFinal() {
+				continue
+			}
+			delete(f.requests, oldRequest.ID)
+			f.requestHistory = append(f.requestHistory[:i], f.requestHistory[i+1:]...)
+			evicted = true
+			break
+		}
+
+		if !evicted {
+			// all requests are still in progress, they are forgotten as soon as they are finished
+			break
+		}
+	}
+}
+
+// confirmRequestsWithoutLocking marks the requests as confirmed by the given milestone and removes them from the queue map.
+// write lock must be acquired outside.
+func (f *Faucet) confirmRequestsWithoutLocking(requests []*queueItem, msIndex milestone.Index) {
+	for _, request := range requests {
+		request.MilestoneIndex = msIndex
+		request.setState(RequestStateConfirmed)
+		f.clearRequestWithoutLocking(request)
+	}
+}
+
+// failRequestWithoutLocking marks the request as failed and removes it from the queue map,
+// so that users are able to send a new request.
+// write lock must be acquired outside.
+func (f *Faucet) failRequestWithoutLocking(request *queueItem, reason string) {
+	request.FailureReason = reason
+	request.setState(RequestStateFailed)
+	f.clearRequestWithoutLocking(request)
+}
+
+// failRequestsWithoutLocking marks the requests as failed and removes them from the queue map.
+// write lock must be acquired outside.
+func (f *Faucet) failRequestsWithoutLocking(requests []*queueItem, reason string) {
+	for _, request := range requests {
+		f.failRequestWithoutLocking(request, reason)
+	}
+}
+
+// RequestStatus returns the status of the request with the given ID.
+func (f *Faucet) RequestStatus(requestID string) (*FaucetRequestResponse, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	request, exists := f.requests[requestID]
+	if !exists {
+		return nil, errors.WithMessagef(echo.ErrNotFound, "request not found: %s", requestID)
+	}
+
+	return request.response(), nil
+}
+
+// RequestHistory returns the known requests, newest first.
+// If a bech32 address is given, only the requests for this address are returned.
+func (f *Faucet) RequestHistory(bech32Addr string, offset int, limit int) (*FaucetRequestHistoryResponse, error) {
+
+	if bech32Addr != "" {
+		if _, err := f.parseBech32Address(bech32Addr); err != nil {
+			return nil, err
+		}
+	}
+
+	f.Lock()
+	defer f.Unlock()
+
+	requests := []*FaucetRequestResponse{}
+	total := 0
+	for i := len(f.requestHistory) - 1; i >= 0; i-- {
+		request := f.requestHistory[i]
+		if bech32Addr != "" && request.Bech32 != bech32Addr {
+			continue
+		}
+
+		if total >= offset && len(requests) < limit {
+			requests = append(requests, request.response())
+		}
+		total++
+	}
+
+	return &FaucetRequestHistoryResponse{
+		Offset:   offset,
+		Limit:    limit,
+		Count:    len(requests),
+		Total:    total,
+		Requests: requests,
+	}, nil
+}
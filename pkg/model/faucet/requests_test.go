@@ -0,0 +1,183 @@
+package faucet_test
+
+import (
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	iotago "github.com/iotaledger/iota.go/v2"
+
+	"github.com/gohornet/hornet/pkg/model/faucet"
+	"github.com/gohornet/hornet/pkg/model/faucet/test"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+)
+
+func TestRequestStates(t *testing.T) {
+	// a request moves from queued to batched to confirmed
+
+	var faucetBalance uint64 = 1_000_000_000        //  1 Gi
+	var faucetAmount uint64 = 10_000_000            // 10 Mi
+	var faucetSmallAmount uint64 = 1_000_000        //  1 Mi
+	var faucetMaxAddressBalance uint64 = 20_000_000 // 20 Mi
+
+	env := test.NewFaucetTestEnv(t,
+		faucetBalance,
+		0,
+		0,
+		0,
+		faucetAmount,
+		faucetSmallAmount,
+		faucetMaxAddressBalance,
+		false)
+	defer env.Cleanup()
+	require.NotNil(t, env)
+
+	_, err := env.Faucet.RequestStatus("unknown")
+	require.ErrorIs(t, err, echo.ErrNotFound)
+
+	tips, err := env.RequestFunds(env.Wallet1)
+	require.NoError(t, err)
+	require.Len(t, tips, 1)
+
+	history, err := env.Faucet.RequestHistory("", 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, history.Total)
+	require.Len(t, history.Requests, 1)
+
+	requestID := history.Requests[0].ID
+	status, err := env.Faucet.RequestStatus(requestID)
+	require.NoError(t, err)
+	require.Equal(t, env.Wallet1.Address().Bech32(iotago.PrefixTestnet), status.Address)
+	require.Equal(t, faucetAmount, status.Amount)
+	require.Equal(t, faucet.RequestStateBatched, status.State)
+	require.Equal(t, tips[0].ToHex(), status.MessageID)
+	require.Zero(t, status.MilestoneIndex)
+
+	_, _ = env.IssueMilestone(tips...)
+
+	status, err = env.Faucet.RequestStatus(requestID)
+	require.NoError(t, err)
+	require.Equal(t, faucet.RequestStateConfirmed, status.State)
+	require.Equal(t, tips[0].ToHex(), status.MessageID)
+	require.Equal(t, milestone.Index(5), status.MilestoneIndex)
+	require.Empty(t, status.FailureReason)
+}
+
+func TestRequestHistory(t *testing.T) {
+	// the history is sorted newest first and can be filtered by address
+
+	var faucetBalance uint64 = 1_000_000_000        //  1 Gi
+	var faucetAmount uint64 = 10_000_000            // 10 Mi
+	var faucetSmallAmount uint64 = 1_000_000        //  1 Mi
+	var faucetMaxAddressBalance uint64 = 20_000_000 // 20 Mi
+
+	env := test.NewFaucetTestEnv(t,
+		faucetBalance,
+		0,
+		0,
+		0,
+		faucetAmount,
+		faucetSmallAmount,
+		faucetMaxAddressBalance,
+		false)
+	defer env.Cleanup()
+	require.NotNil(t, env)
+
+	wallets := []string{
+		env.Wallet1.Address().Bech32(iotago.PrefixTestnet),
+		env.Wallet2.Address().Bech32(iotago.PrefixTestnet),
+		env.Wallet3.Address().Bech32(iotago.PrefixTestnet),
+	}
+
+	var requestIDs []string
+	for _, wallet := range wallets {
+		response, err := env.Faucet.Enqueue(wallet)
+		require.NoError(t, err)
+		require.NotEmpty(t, response.ID)
+		requestIDs = append(requestIDs, response.ID)
+
+		status, err := env.Faucet.RequestStatus(response.ID)
+		require.NoError(t, err)
+		require.Equal(t, faucet.RequestStateQueued, status.State)
+		require.Empty(t, status.MessageID)
+	}
+
+	history, err := env.Faucet.RequestHistory("", 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, history.Total)
+	require.Equal(t, 3, history.Count)
+	for i, request := range history.Requests {
+		require.Equal(t, requestIDs[len(requestIDs)-1-i], request.ID)
+	}
+
+	history, err = env.Faucet.RequestHistory("", 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, 3, history.Total)
+	require.Equal(t, 1, history.Count)
+	require.Equal(t, requestIDs[1], history.Requests[0].ID)
+
+	history, err = env.Faucet.RequestHistory("", 3, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, history.Total)
+	require.Empty(t, history.Requests)
+
+	history, err = env.Faucet.RequestHistory(wallets[2], 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, history.Total)
+	require.Equal(t, requestIDs[2], history.Requests[0].ID)
+	require.Equal(t, wallets[2], history.Requests[0].Address)
+
+	_, err = env.Faucet.RequestHistory("invalid", 0, 10)
+	require.Error(t, err)
+
+	err = env.FlushRequestsAndConfirmNewFaucetMessage()
+	require.NoError(t, err)
+
+	history, err = env.Faucet.RequestHistory("", 0, 10)
+	require.NoError(t, err)
+	for _, request := range history.Requests {
+		require.Equal(t, faucet.RequestStateConfirmed, request.State)
+	}
+}
+
+func TestRequestHistorySize(t *testing.T) {
+	// only the newest finished requests are kept
+
+	var faucetBalance uint64 = 1_000_000_000        //  1 Gi
+	var faucetAmount uint64 = 10_000_000            // 10 Mi
+	var faucetSmallAmount uint64 = 1_000_000        //  1 Mi
+	var faucetMaxAddressBalance uint64 = 20_000_000 // 20 Mi
+
+	env := test.NewFaucetTestEnv(t,
+		faucetBalance,
+		0,
+		0,
+		0,
+		faucetAmount,
+		faucetSmallAmount,
+		faucetMaxAddressBalance,
+		false,
+		faucet.WithRequestHistorySize(1))
+	defer env.Cleanup()
+	require.NotNil(t, env)
+
+	err := env.RequestFundsAndIssueMilestone(env.Wallet1)
+	require.NoError(t, err)
+
+	history, err := env.Faucet.RequestHistory("", 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, history.Total)
+	firstRequestID := history.Requests[0].ID
+
+	err = env.RequestFundsAndIssueMilestone(env.Wallet2)
+	require.NoError(t, err)
+
+	history, err = env.Faucet.RequestHistory("", 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, history.Total)
+	require.Equal(t, env.Wallet2.Address().Bech32(iotago.PrefixTestnet), history.Requests[0].Address)
+
+	_, err = env.Faucet.RequestStatus(firstRequestID)
+	require.ErrorIs(t, err, echo.ErrNotFound)
+}
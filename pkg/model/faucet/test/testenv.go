@@ -51,6 +51,7 @@ type FaucetTestEnv struct {
 
 	GenesisWallet *utils.HDWallet
 	FaucetWallet  *utils.HDWallet
+	FaucetWallets []*utils.HDWallet
 	Wallet1       *utils.HDWallet
 	Wallet2       *utils.HDWallet
 	Wallet3       *utils.HDWallet
@@ -68,10 +69,41 @@ func NewFaucetTestEnv(t *testing.T,
 	faucetAmount uint64,
 	faucetSmallAmount uint64,
 	faucetMaxAddressBalance uint64,
-	assertSteps bool) *FaucetTestEnv {
+	assertSteps bool,
+	opts ...faucet.Option) *FaucetTestEnv {
+
+	return NewFaucetTestEnvWithWallets(t,
+		[]uint64{faucetBalance},
+		wallet1Balance,
+		wallet2Balance,
+		wallet3Balance,
+		faucetAmount,
+		faucetSmallAmount,
+		faucetMaxAddressBalance,
+		assertSteps,
+		opts...)
+}
+
+// NewFaucetTestEnvWithWallets creates a faucet test environment with a faucet wallet for every given faucet balance.
+// The faucet wallets are derived from the same seed.
+func NewFaucetTestEnvWithWallets(t *testing.T,
+	faucetBalances []uint64,
+	wallet1Balance uint64,
+	wallet2Balance uint64,
+	wallet3Balance uint64,
+	faucetAmount uint64,
+	faucetSmallAmount uint64,
+	faucetMaxAddressBalance uint64,
+	assertSteps bool,
+	opts ...faucet.Option) *FaucetTestEnv {
+
+	require.Greater(t, len(faucetBalances), 0)
 
 	genesisWallet := utils.NewHDWallet("Genesis", genesisSeed, 0)
-	faucetWallet := utils.NewHDWallet("Faucet", faucetSeed, 0)
+	faucetWallets := make([]*utils.HDWallet, len(faucetBalances))
+	for i := range faucetBalances {
+		faucetWallets[i] = utils.NewHDWallet(fmt.Sprintf("Faucet%d", i), faucetSeed, uint64(i))
+	}
 	seed1Wallet := utils.NewHDWallet("Seed1", seed1, 0)
 	seed2Wallet := utils.NewHDWallet("Seed2", seed2, 0)
 	seed3Wallet := utils.NewHDWallet("Seed3", seed3, 0)
@@ -90,11 +122,17 @@ func NewFaucetTestEnv(t *testing.T,
 	messagesCount := 0
 
 	// Fund Faucet
-	if faucetBalance > 0 {
-		messageA := te.NewMessageBuilder("A").
+	var totalFaucetBalance uint64
+	for i, faucetBalance := range faucetBalances {
+		totalFaucetBalance += faucetBalance
+		if faucetBalance == 0 {
+			continue
+		}
+
+		messageA := te.NewMessageBuilder(fmt.Sprintf("A%d", i)).
 			Parents(hornet.MessageIDs{lastMessageID, te.Milestones[1].Milestone().MessageID}).
 			FromWallet(genesisWallet).
-			ToWallet(faucetWallet).
+			ToWallet(faucetWallets[i]).
 			Amount(faucetBalance).
 			Build().
 			Store().
@@ -161,8 +199,10 @@ func NewFaucetTestEnv(t *testing.T,
 		require.Equal(t, 1, confStats.MessagesExcludedWithoutTransactions) // the milestone
 
 		// Verify balances
-		te.AssertWalletBalance(genesisWallet, iotago.TokenSupply-faucetBalance-wallet1Balance-wallet2Balance-wallet3Balance)
-		te.AssertWalletBalance(faucetWallet, faucetBalance)
+		te.AssertWalletBalance(genesisWallet, iotago.TokenSupply-totalFaucetBalance-wallet1Balance-wallet2Balance-wallet3Balance)
+		for i, faucetBalance := range faucetBalances {
+			te.AssertWalletBalance(faucetWallets[i], faucetBalance)
+		}
 		te.AssertWalletBalance(seed1Wallet, wallet1Balance)
 		te.AssertWalletBalance(seed2Wallet, wallet2Balance)
 		te.AssertWalletBalance(seed3Wallet, wallet3Balance)
@@ -239,6 +279,9 @@ func NewFaucetTestEnv(t *testing.T,
 		return nil
 	}
 
+	wallets, err := faucet.WalletsFromSeed(faucetSeed, len(faucetBalances))
+	require.NoError(t, err)
+
 	f := faucet.New(
 		defaultDaemon,
 		te.Storage(),
@@ -246,19 +289,20 @@ func NewFaucetTestEnv(t *testing.T,
 		te.NetworkID(),
 		int(te.BelowMaxDepth()),
 		te.UTXOManager(),
-		faucetWallet.Address(),
-		faucetWallet.AddressSigner(),
+		wallets,
 		tipselFunc,
 		te.PoWHandler,
 		storeMessageFunc,
-		faucet.WithHRPNetworkPrefix(iotago.PrefixTestnet),
-		faucet.WithAmount(faucetAmount),
-		faucet.WithSmallAmount(faucetSmallAmount),
-		faucet.WithMaxAddressBalance(faucetMaxAddressBalance),
-		faucet.WithMaxOutputCount(faucetMaxOutputCount),
-		faucet.WithIndexationMessage(faucetIndexationMessage),
-		faucet.WithBatchTimeout(faucetBatchTimeout),
-		faucet.WithPowWorkerCount(faucetPowWorkerCount),
+		append([]faucet.Option{
+			faucet.WithHRPNetworkPrefix(iotago.PrefixTestnet),
+			faucet.WithAmount(faucetAmount),
+			faucet.WithSmallAmount(faucetSmallAmount),
+			faucet.WithMaxAddressBalance(faucetMaxAddressBalance),
+			faucet.WithMaxOutputCount(faucetMaxOutputCount),
+			faucet.WithIndexationMessage(faucetIndexationMessage),
+			faucet.WithBatchTimeout(faucetBatchTimeout),
+			faucet.WithPowWorkerCount(faucetPowWorkerCount),
+		}, opts...)...,
 	)
 
 	faucetCtx, faucetCtxCancel := context.WithCancel(context.Background())
@@ -289,7 +333,8 @@ func NewFaucetTestEnv(t *testing.T,
 		t:               t,
 		TestEnv:         te,
 		GenesisWallet:   genesisWallet,
-		FaucetWallet:    faucetWallet,
+		FaucetWallet:    faucetWallets[0],
+		FaucetWallets:   faucetWallets,
 		Wallet1:         seed1Wallet,
 		Wallet2:         seed2Wallet,
 		Wallet3:         seed3Wallet,
@@ -311,13 +356,19 @@ func (env *FaucetTestEnv) Cleanup() {
 
 func (env *FaucetTestEnv) processFaucetRequests(preFlushFunc func() error) (hornet.MessageIDs, error) {
 
-	wg := sync.WaitGroup{}
-	wg.Add(1)
-
+	var tipsLock sync.Mutex
 	var tips hornet.MessageIDs
+	chanIssued := make(chan struct{}, 1)
+
 	onFaucetIssuedMessage := events.NewClosure(func(messageID hornet.MessageID) {
+		tipsLock.Lock()
+		defer tipsLock.Unlock()
+
 		tips = append(tips, messageID)
-		wg.Done()
+		select {
+		case chanIssued <- struct{}{}:
+		default:
+		}
 	})
 	env.Faucet.Events.IssuedMessage.Attach(onFaucetIssuedMessage)
 	defer env.Faucet.Events.IssuedMessage.Detach(onFaucetIssuedMessage)
@@ -330,20 +381,16 @@ func (env *FaucetTestEnv) processFaucetRequests(preFlushFunc func() error) (horn
 
 	env.Faucet.FlushRequests()
 
-	chanDone := make(chan struct{})
-
-	go func() {
-		wg.Wait()
-		close(chanDone)
-	}()
-
 	select {
-	case <-chanDone:
+	case <-chanIssued:
 	case <-time.After(1 * time.Second):
 		env.t.Error("attachment of faucet message took too long")
 	}
 
-	return tips, nil
+	tipsLock.Lock()
+	defer tipsLock.Unlock()
+
+	return append(hornet.MessageIDs{}, tips...), nil
 }
 
 // RequestFunds sends requests to the faucet and waits until the next faucet message is issued.
@@ -407,6 +454,16 @@ func (env *FaucetTestEnv) AssertFaucetBalance(expected uint64) {
 	require.Equal(env.t, expected, faucetInfo.Balance)
 }
 
+func (env *FaucetTestEnv) AssertFaucetAddressBalances(expected ...uint64) {
+	faucetInfo, err := env.Faucet.Info()
+	require.NoError(env.t, err)
+	require.Len(env.t, faucetInfo.Addresses, len(expected))
+	for i, faucetWallet := range env.FaucetWallets {
+		require.Equal(env.t, faucetWallet.Address().Bech32(iotago.PrefixTestnet), faucetInfo.Addresses[i].Address)
+		require.Equal(env.t, expected[i], faucetInfo.Addresses[i].Balance)
+	}
+}
+
 func (env *FaucetTestEnv) AssertAddressUTXOCount(address iotago.Address, expected int) {
 	utxoCount := 0
 	env.TestEnv.UTXOManager().ForEachUnspentOutput(func(output *utxo.Output) bool {
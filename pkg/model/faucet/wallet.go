@@ -0,0 +1,92 @@
+package faucet
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/wollac/iota-crypto-demo/pkg/bip32path"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	iotago "github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+)
+
+const (
+	// the BIP32 path used to derive the wallets of the faucet from a seed.
+	walletPathString = "44'/4218'/0'/%d'"
+)
+
+var (
+	// ErrNoWalletsGiven is returned when the faucet is created without wallets.
+	ErrNoWalletsGiven = errors.New("no wallets given")
+)
+
+// Wallet is an address the faucet spends funds from.
+type Wallet struct {
+	// the address of the wallet.
+	Address *iotago.Ed25519Address
+	// used to sign the transactions of the wallet.
+	AddressSigner iotago.AddressSigner
+}
+
+// NewWallet creates a new Wallet for the given private key.
+func NewWallet(privateKey ed25519.PrivateKey) *Wallet {
+	address := iotago.AddressFromEd25519PubKey(privateKey.Public().(ed25519.PublicKey))
+	return &Wallet{
+		Address:       &address,
+		AddressSigner: iotago.NewInMemoryAddressSigner(iotago.NewAddressKeysForEd25519Address(&address, privateKey)),
+	}
+}
+
+// WalletsFromSeed derives the given amount of wallets from the seed by using slip10.
+// The wallet with index i is derived with the BIP32 path 44'/4218'/0'/i'.
+func WalletsFromSeed(seed []byte, count int) ([]*Wallet, error) {
+	if count < 1 {
+		return nil, ErrNoWalletsGiven
+	}
+
+	wallets := make([]*Wallet, count)
+	for i := 0; i < count; i++ {
+		path, err := bip32path.ParsePath(fmt.Sprintf(walletPathString, i))
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := slip10.DeriveKeyFromPath(seed, slip10.Ed25519(), path)
+		if err != nil {
+			return nil, fmt.Errorf("deriving wallet %d failed: %w", i, err)
+		}
+
+		_, privateKey := slip10.Ed25519Key(key)
+		wallets[i] = NewWallet(ed25519.PrivateKey(privateKey))
+	}
+
+	return wallets, nil
+}
+
+// faucetWallet holds the state of a Wallet within the faucet.
+// every wallet chains its own transactions, so pending transactions of one wallet do not block the others.
+type faucetWallet struct {
+	*Wallet
+
+	// the confirmed balance of the wallet address.
+	balance uint64
+	// the message ID of the last sent message of the wallet.
+	lastMessageID hornet.MessageID
+	// the latest unused UTXO output of the wallet that may not be confirmed yet but can be reused in new transactions.
+	// this is used to issue multiple transactions without waiting for the confirmation by milestones.
+	lastRemainderOutput *utxo.Output
+}
+
+// idle returns whether the wallet has no pending transaction chain.
+func (w *faucetWallet) idle() bool {
+	return w.lastMessageID == nil && w.lastRemainderOutput == nil
+}
+
+// resetChain forgets about the pending transaction chain of the wallet.
+func (w *faucetWallet) resetChain() {
+	w.lastMessageID = nil
+	w.lastRemainderOutput = nil
+}
@@ -0,0 +1,355 @@
+package migrator
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/iota.go/encoding/t5b1"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+// ReceiptAuditIssueKind defines the kind of an inconsistency found during a receipt audit.
+type ReceiptAuditIssueKind string
+
+const (
+	// ReceiptAuditIssueGap is a missing receipt, receipt backup, migrated output or treasury output.
+	ReceiptAuditIssueGap ReceiptAuditIssueKind = "gap"
+	// ReceiptAuditIssueDuplicate is a migrated funds entry or final receipt that exists more than once.
+	ReceiptAuditIssueDuplicate ReceiptAuditIssueKind = "duplicate"
+	// ReceiptAuditIssueMismatch is an amount or content that does not match the receipt.
+	ReceiptAuditIssueMismatch ReceiptAuditIssueKind = "mismatch"
+)
+
+// ReceiptAuditIssue is an inconsistency found during a receipt audit.
+type ReceiptAuditIssue struct {
+	// Kind is the kind of the inconsistency.
+	Kind ReceiptAuditIssueKind
+	// MilestoneIndex is the index of the milestone which included the affected receipt.
+	MilestoneIndex milestone.Index
+	// MigratedAt is the migrated at index of the affected receipt.
+	MigratedAt uint32
+	// Description describes the inconsistency.
+	Description string
+}
+
+func (i *ReceiptAuditIssue) String() string {
+	return fmt.Sprintf("%s: receipt (milestone %d, migrated_at %d): %s", i.Kind, i.MilestoneIndex, i.MigratedAt, i.Description)
+}
+
+// ReceiptAuditReport is the result of a receipt audit.
+type ReceiptAuditReport struct {
+	// ReceiptsCount is the amount of receipts stored in the database.
+	ReceiptsCount int
+	// BackupsCount is the amount of audited receipt backups.
+	BackupsCount int
+	// PrunedCount is the amount of receipts whose milestone diff was already pruned.
+	// The migrated outputs and the treasury mutation of those receipts can't be audited.
+	PrunedCount int
+	// MigratedEntriesCount is the amount of migrated funds entries in all receipts.
+	MigratedEntriesCount int
+	// MigratedAmount is the sum of the deposits of all migrated funds entries.
+	MigratedAmount uint64
+	// Issues are the found inconsistencies.
+	Issues []*ReceiptAuditIssue
+}
+
+func (r *ReceiptAuditReport) addIssue(kind ReceiptAuditIssueKind, rt *utxo.ReceiptTuple, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, &ReceiptAuditIssue{
+		Kind:           kind,
+		MilestoneIndex: rt.MilestoneIndex,
+		MigratedAt:     rt.Receipt.MigratedAt,
+		Description:    fmt.Sprintf(format, args...),
+	})
+}
+
+// tailTransactionHashString returns the tail transaction hash of the entry in trytes, or hex encoded if it is no valid T5B1.
+func tailTransactionHashString(entry *iotago.MigratedFundsEntry) string {
+	trytes, err := t5b1.DecodeToTrytes(entry.TailTransactionHash[:])
+	if err != nil {
+		return hex.EncodeToString(entry.TailTransactionHash[:])
+	}
+	return trytes
+}
+
+// AuditReceipts reconciles the receipts stored in the ledger with the migrated outputs and treasury mutations
+// of the milestones which included them, checks that the treasury outputs form a continuous chain and that
+// no migrated funds entry was migrated twice.
+// If backups is not nil, the stored receipts are also compared with the given receipt backups.
+func AuditReceipts(utxoManager *utxo.Manager, backups []*utxo.ReceiptTuple) (*ReceiptAuditReport, error) {
+	utxoManager.ReadLockLedger()
+	defer utxoManager.ReadUnlockLedger()
+
+	var receiptTuples []*utxo.ReceiptTuple
+	if err := utxoManager.ForEachReceiptTuple(func(rt *utxo.ReceiptTuple) bool {
+		receiptTuples = append(receiptTuples, rt)
+		return true
+	}, utxo.ReadLockLedger(false)); err != nil {
+		return nil, fmt.Errorf("unable to read receipts: %w", err)
+	}
+	sort.SliceStable(receiptTuples, func(i, j int) bool {
+		return receiptTuples[i].MilestoneIndex < receiptTuples[j].MilestoneIndex
+	})
+
+	spentTreasuryOutputs := make(map[iotago.MilestoneID]*utxo.TreasuryOutput)
+	if err := utxoManager.ForEachSpentTreasuryOutput(func(output *utxo.TreasuryOutput) bool {
+		spentTreasuryOutputs[output.MilestoneID] = output
+		return true
+	}, utxo.ReadLockLedger(false)); err != nil {
+		return nil, fmt.Errorf("unable to read spent treasury outputs: %w", err)
+	}
+
+	report := &ReceiptAuditReport{
+		ReceiptsCount: len(receiptTuples),
+		BackupsCount:  len(backups),
+	}
+
+	// the tail transaction hashes of all migrated funds entries and the milestone which migrated them
+	migratedEntries := make(map[string]milestone.Index)
+	// the treasury inputs of all receipts
+	consumedTreasuryOutputs := make(map[iotago.MilestoneID]struct{})
+
+	var prev *utxo.ReceiptTuple
+	var batchFinalized bool
+	// the treasury output created by the previous receipt, nil if unknown
+	var prevTreasuryOutput *iotago.TreasuryOutput
+	// the milestone ID of the treasury output created by the previous receipt, nil if unknown
+	var prevTreasuryMilestoneID *iotago.MilestoneID
+
+	for _, rt := range receiptTuples {
+		r := rt.Receipt
+
+		// every batch of receipts with the same migrated at index must be finalized before the next batch starts
+		if prev != nil {
+			switch {
+			case r.MigratedAt < prev.Receipt.MigratedAt:
+				report.addIssue(ReceiptAuditIssueMismatch, rt, "migrated at index is lower than the one of the previous receipt (%d)", prev.Receipt.MigratedAt)
+			case r.MigratedAt > prev.Receipt.MigratedAt:
+				if !batchFinalized {
+					report.addIssue(ReceiptAuditIssueGap, rt, "batch of the previous receipt (migrated_at %d) was never finalized", prev.Receipt.MigratedAt)
+				}
+				batchFinalized = false
+			case batchFinalized:
+				report.addIssue(ReceiptAuditIssueDuplicate, rt, "batch was already finalized by the previous receipt")
+			}
+		}
+		if r.Final {
+			batchFinalized = true
+		}
+		prev = rt
+
+		var migratedAmount uint64
+		for _, seri := range r.Funds {
+			entry := seri.(*iotago.MigratedFundsEntry)
+			migratedAmount += entry.Deposit
+			report.MigratedEntriesCount++
+
+			k := string(entry.TailTransactionHash[:])
+			if msIndex, has := migratedEntries[k]; has {
+				report.addIssue(ReceiptAuditIssueDuplicate, rt, "entry %s was already migrated by the receipt in milestone %d", tailTransactionHashString(entry), msIndex)
+				continue
+			}
+			migratedEntries[k] = rt.MilestoneIndex
+		}
+		report.MigratedAmount += migratedAmount
+
+		treasuryTx, ok := r.Transaction.(*iotago.TreasuryTransaction)
+		if !ok {
+			report.addIssue(ReceiptAuditIssueMismatch, rt, "receipt contains no treasury transaction")
+			prevTreasuryOutput = nil
+			prevTreasuryMilestoneID = nil
+			continue
+		}
+		treasuryInput, ok := treasuryTx.Input.(*iotago.TreasuryInput)
+		if !ok {
+			report.addIssue(ReceiptAuditIssueMismatch, rt, "treasury transaction contains no treasury input")
+			prevTreasuryOutput = nil
+			prevTreasuryMilestoneID = nil
+			continue
+		}
+		treasuryOutput, ok := treasuryTx.Output.(*iotago.TreasuryOutput)
+		if !ok {
+			report.addIssue(ReceiptAuditIssueMismatch, rt, "treasury transaction contains no treasury output")
+			prevTreasuryOutput = nil
+			prevTreasuryMilestoneID = nil
+			continue
+		}
+		consumedTreasuryOutputs[*treasuryInput] = struct{}{}
+
+		// the treasury transaction must consume the treasury output created by the previous receipt
+		if prevTreasuryOutput != nil {
+			if prevTreasuryOutput.Amount < migratedAmount || prevTreasuryOutput.Amount-migratedAmount != treasuryOutput.Amount {
+				report.addIssue(ReceiptAuditIssueMismatch, rt, "previous treasury amount %d minus migrated amount %d does not equal the new treasury amount %d", prevTreasuryOutput.Amount, migratedAmount, treasuryOutput.Amount)
+			}
+		}
+		if prevTreasuryMilestoneID != nil && *prevTreasuryMilestoneID != *treasuryInput {
+			report.addIssue(ReceiptAuditIssueGap, rt, "treasury input %s is not the treasury output %s created by the previous receipt", hex.EncodeToString(treasuryInput[:]), hex.EncodeToString(prevTreasuryMilestoneID[:]))
+		}
+		prevTreasuryOutput = treasuryOutput
+		prevTreasuryMilestoneID = nil
+
+		hasDiff, err := utxoManager.HasMilestoneDiffWithoutLocking(rt.MilestoneIndex)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read milestone diff %d: %w", rt.MilestoneIndex, err)
+		}
+		if !hasDiff {
+			report.PrunedCount++
+			continue
+		}
+
+		diff, err := utxoManager.MilestoneDiffWithoutLocking(rt.MilestoneIndex)
+		if err != nil {
+			if errors.Is(err, kvstore.ErrKeyNotFound) {
+				// the diff exists, but the outputs or treasury outputs it references don't
+				report.addIssue(ReceiptAuditIssueGap, rt, "milestone diff references outputs which do not exist")
+				continue
+			}
+			return nil, fmt.Errorf("unable to read milestone diff %d: %w", rt.MilestoneIndex, err)
+		}
+
+		if diff.TreasuryOutput == nil || diff.SpentTreasuryOutput == nil {
+			report.addIssue(ReceiptAuditIssueGap, rt, "milestone diff contains no treasury mutation")
+			continue
+		}
+		msID := diff.TreasuryOutput.MilestoneID
+		prevTreasuryMilestoneID = &msID
+
+		tm, err := utxo.ReceiptToTreasuryMutation(r, diff.SpentTreasuryOutput, &msID)
+		if err != nil {
+			return nil, err
+		}
+		if tm.NewOutput.Amount != diff.TreasuryOutput.Amount {
+			report.addIssue(ReceiptAuditIssueMismatch, rt, "new treasury amount %d does not equal the stored treasury output amount %d", tm.NewOutput.Amount, diff.TreasuryOutput.Amount)
+		}
+		if tm.SpentOutput.MilestoneID != *treasuryInput {
+			report.addIssue(ReceiptAuditIssueMismatch, rt, "treasury input %s does not equal the spent treasury output %s", hex.EncodeToString(treasuryInput[:]), hex.EncodeToString(tm.SpentOutput.MilestoneID[:]))
+		}
+		if tm.SpentOutput.Amount < migratedAmount || tm.SpentOutput.Amount-migratedAmount != tm.NewOutput.Amount {
+			report.addIssue(ReceiptAuditIssueMismatch, rt, "spent treasury amount %d minus migrated amount %d does not equal the new treasury amount %d", tm.SpentOutput.Amount, migratedAmount, tm.NewOutput.Amount)
+		}
+
+		migratedOutputs, err := utxo.ReceiptToOutputs(r, nil, &msID)
+		if err != nil {
+			return nil, err
+		}
+
+		diffOutputs := make(map[iotago.UTXOInputID]*utxo.Output, len(diff.Outputs))
+		for _, output := range diff.Outputs {
+			diffOutputs[*output.OutputID()] = output
+		}
+
+		for _, migratedOutput := range migratedOutputs {
+			output, has := diffOutputs[*migratedOutput.OutputID()]
+			if !has {
+				report.addIssue(ReceiptAuditIssueGap, rt, "migrated output %s does not exist", hex.EncodeToString(migratedOutput.OutputID()[:]))
+				continue
+			}
+			if !bytes.Equal(output.AddressBytes(), migratedOutput.AddressBytes()) || output.Amount() != migratedOutput.Amount() {
+				report.addIssue(ReceiptAuditIssueMismatch, rt, "migrated output %s does not match the migrated funds entry", hex.EncodeToString(migratedOutput.OutputID()[:]))
+			}
+		}
+	}
+
+	// the latest receipt must have created the unspent treasury output.
+	// ledgers without receipts don't need to contain a treasury output.
+	if prev != nil {
+		unspentTreasuryOutput, err := utxoManager.UnspentTreasuryOutputWithoutLocking()
+		if err != nil {
+			return nil, err
+		}
+
+		if prevTreasuryOutput != nil && prevTreasuryOutput.Amount != unspentTreasuryOutput.Amount {
+			report.addIssue(ReceiptAuditIssueMismatch, prev, "new treasury amount %d does not equal the unspent treasury output amount %d", prevTreasuryOutput.Amount, unspentTreasuryOutput.Amount)
+		}
+		if prevTreasuryMilestoneID != nil && *prevTreasuryMilestoneID != unspentTreasuryOutput.MilestoneID {
+			report.addIssue(ReceiptAuditIssueGap, prev, "unspent treasury output %s was not created by the latest receipt", hex.EncodeToString(unspentTreasuryOutput.MilestoneID[:]))
+		}
+	}
+
+	// every spent treasury output must have been consumed by a receipt
+	var unconsumedTreasuryOutputs []*utxo.TreasuryOutput
+	for msID, output := range spentTreasuryOutputs {
+		if _, consumed := consumedTreasuryOutputs[msID]; !consumed {
+			unconsumedTreasuryOutputs = append(unconsumedTreasuryOutputs, output)
+		}
+	}
+	sort.Slice(unconsumedTreasuryOutputs, func(i, j int) bool {
+		return bytes.Compare(unconsumedTreasuryOutputs[i].MilestoneID[:], unconsumedTreasuryOutputs[j].MilestoneID[:]) < 0
+	})
+	for _, output := range unconsumedTreasuryOutputs {
+		report.Issues = append(report.Issues, &ReceiptAuditIssue{
+			Kind:        ReceiptAuditIssueGap,
+			Description: fmt.Sprintf("spent treasury output %s (amount %d) was not consumed by any receipt", hex.EncodeToString(output.MilestoneID[:]), output.Amount),
+		})
+	}
+
+	if backups != nil {
+		if err := auditReceiptBackups(report, receiptTuples, backups); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// auditReceiptBackups compares the stored receipts with their backups.
+func auditReceiptBackups(report *ReceiptAuditReport, receiptTuples []*utxo.ReceiptTuple, backups []*utxo.ReceiptTuple) error {
+	receiptKey := func(rt *utxo.ReceiptTuple) string {
+		return fmt.Sprintf(receiptFilePattern, rt.Receipt.MigratedAt, rt.MilestoneIndex)
+	}
+
+	backupsMap := make(map[string]*utxo.ReceiptTuple, len(backups))
+	for _, backup := range backups {
+		backupsMap[receiptKey(backup)] = backup
+	}
+
+	receiptsMap := make(map[string]struct{}, len(receiptTuples))
+	for _, rt := range receiptTuples {
+		receiptsMap[receiptKey(rt)] = struct{}{}
+
+		backup, has := backupsMap[receiptKey(rt)]
+		if !has {
+			report.addIssue(ReceiptAuditIssueGap, rt, "no backup exists")
+			continue
+		}
+
+		receiptBytes, err := rt.Receipt.Serialize(serializer.DeSeriModeNoValidation)
+		if err != nil {
+			return fmt.Errorf("unable to serialize receipt: %w", err)
+		}
+
+		backupBytes, err := backup.Receipt.Serialize(serializer.DeSeriModeNoValidation)
+		if err != nil {
+			return fmt.Errorf("unable to serialize receipt backup: %w", err)
+		}
+
+		if !bytes.Equal(receiptBytes, backupBytes) {
+			report.addIssue(ReceiptAuditIssueMismatch, rt, "backup does not match the stored receipt")
+		}
+	}
+
+	if len(receiptTuples) == 0 {
+		// all receipts could have been pruned
+		return nil
+	}
+
+	for _, backup := range backups {
+		if _, has := receiptsMap[receiptKey(backup)]; has {
+			continue
+		}
+
+		// receipts below the oldest stored receipt could have been pruned
+		if backup.MilestoneIndex >= receiptTuples[0].MilestoneIndex {
+			report.addIssue(ReceiptAuditIssueGap, backup, "backup exists, but the receipt is not stored in the database")
+		}
+	}
+
+	return nil
+}
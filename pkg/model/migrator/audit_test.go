@@ -0,0 +1,229 @@
+package migrator_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/migrator"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/serializer"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+func newAuditTestLedger(t *testing.T) *utxo.Manager {
+	utxoManager := utxo.New(mapdb.NewMapDB())
+	require.NoError(t, utxoManager.StoreUnspentTreasuryOutput(&utxo.TreasuryOutput{MilestoneID: iotago.MilestoneID{1}, Amount: 1_000_000_000}))
+	return utxoManager
+}
+
+// newAuditTestReceipt creates a receipt which consumes the current unspent treasury output.
+func newAuditTestReceipt(t *testing.T, utxoManager *utxo.Manager, migratedAt uint32, final bool, entries []*iotago.MigratedFundsEntry) *iotago.Receipt {
+	unspentTreasuryOutput, err := utxoManager.UnspentTreasuryOutputWithoutLocking()
+	require.NoError(t, err)
+
+	receipt := &iotago.Receipt{MigratedAt: migratedAt, Final: final}
+	for _, entry := range entries {
+		receipt.Funds = append(receipt.Funds, entry)
+	}
+
+	input := &iotago.TreasuryInput{}
+	copy(input[:], unspentTreasuryOutput.MilestoneID[:])
+	receipt.Transaction = &iotago.TreasuryTransaction{Input: input, Output: &iotago.TreasuryOutput{Amount: unspentTreasuryOutput.Amount - receipt.Sum()}}
+
+	return receipt
+}
+
+// applyAuditTestReceipt applies the receipt to the ledger like the white-flag confirmation of the milestone with the given index would do.
+func applyAuditTestReceipt(t *testing.T, utxoManager *utxo.Manager, msIndex milestone.Index, receipt *iotago.Receipt, withOutputs bool) {
+	msID := &iotago.MilestoneID{byte(msIndex), 0xff}
+
+	unspentTreasuryOutput, err := utxoManager.UnspentTreasuryOutputWithoutLocking()
+	require.NoError(t, err)
+
+	var outputs utxo.Outputs
+	if withOutputs {
+		outputs, err = utxo.ReceiptToOutputs(receipt, hornet.NullMessageID(), msID)
+		require.NoError(t, err)
+	}
+
+	tm, err := utxo.ReceiptToTreasuryMutation(receipt, unspentTreasuryOutput, msID)
+	require.NoError(t, err)
+
+	require.NoError(t, utxoManager.ApplyConfirmationWithoutLocking(msIndex, outputs, nil, tm, &utxo.ReceiptTuple{Receipt: receipt, MilestoneIndex: msIndex}))
+}
+
+func backupAuditTestReceipts(t *testing.T, utxoManager *utxo.Manager) []*utxo.ReceiptTuple {
+	dir, err := ioutil.TempDir("", "migrator_audit_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	rs := migrator.NewReceiptService(nil, utxoManager, false, true, false, dir)
+	require.NoError(t, rs.Init())
+
+	require.NoError(t, utxoManager.ForEachReceiptTuple(func(rt *utxo.ReceiptTuple) bool {
+		require.NoError(t, rs.Backup(rt))
+		return true
+	}))
+
+	backups, err := migrator.LoadReceiptBackups(dir)
+	require.NoError(t, err)
+	return backups
+}
+
+func TestAuditReceipts(t *testing.T) {
+	utxoManager := newAuditTestLedger(t)
+
+	applyAuditTestReceipt(t, utxoManager, 2, newAuditTestReceipt(t, utxoManager, 10, false, migratedFundsEntries(0, 2)), true)
+	applyAuditTestReceipt(t, utxoManager, 3, newAuditTestReceipt(t, utxoManager, 10, true, migratedFundsEntries(2, 1)), true)
+	applyAuditTestReceipt(t, utxoManager, 4, newAuditTestReceipt(t, utxoManager, 12, true, migratedFundsEntries(3, 2)), true)
+
+	backups := backupAuditTestReceipts(t, utxoManager)
+
+	report, err := migrator.AuditReceipts(utxoManager, backups)
+	require.NoError(t, err)
+	require.Empty(t, report.Issues)
+	require.Equal(t, 3, report.ReceiptsCount)
+	require.Equal(t, 3, report.BackupsCount)
+	require.Zero(t, report.PrunedCount)
+	require.Equal(t, 5, report.MigratedEntriesCount)
+	require.EqualValues(t, 5_000_000, report.MigratedAmount)
+
+	// without backups only the ledger is audited
+	report, err = migrator.AuditReceipts(utxoManager, nil)
+	require.NoError(t, err)
+	require.Empty(t, report.Issues)
+	require.Zero(t, report.BackupsCount)
+}
+
+func TestAuditReceiptsWithoutTreasury(t *testing.T) {
+	// ledgers of networks without a migration don't contain a treasury output
+	utxoManager := utxo.New(mapdb.NewMapDB())
+
+	report, err := migrator.AuditReceipts(utxoManager, nil)
+	require.NoError(t, err)
+	require.Empty(t, report.Issues)
+	require.Zero(t, report.ReceiptsCount)
+	require.Zero(t, report.MigratedEntriesCount)
+}
+
+func TestAuditReceiptsIssues(t *testing.T) {
+
+	type issue struct {
+		kind           migrator.ReceiptAuditIssueKind
+		milestoneIndex milestone.Index
+	}
+
+	tests := []struct {
+		name   string
+		apply  func(t *testing.T, utxoManager *utxo.Manager)
+		issues []issue
+	}{
+		{
+			name: "batch not finalized",
+			apply: func(t *testing.T, utxoManager *utxo.Manager) {
+				applyAuditTestReceipt(t, utxoManager, 2, newAuditTestReceipt(t, utxoManager, 10, false, migratedFundsEntries(0, 2)), true)
+				applyAuditTestReceipt(t, utxoManager, 3, newAuditTestReceipt(t, utxoManager, 12, true, migratedFundsEntries(2, 1)), true)
+			},
+			issues: []issue{{migrator.ReceiptAuditIssueGap, 3}},
+		},
+		{
+			name: "batch finalized twice",
+			apply: func(t *testing.T, utxoManager *utxo.Manager) {
+				applyAuditTestReceipt(t, utxoManager, 2, newAuditTestReceipt(t, utxoManager, 10, true, migratedFundsEntries(0, 2)), true)
+				applyAuditTestReceipt(t, utxoManager, 3, newAuditTestReceipt(t, utxoManager, 10, true, migratedFundsEntries(2, 1)), true)
+			},
+			issues: []issue{{migrator.ReceiptAuditIssueDuplicate, 3}},
+		},
+		{
+			name: "duplicate entry",
+			apply: func(t *testing.T, utxoManager *utxo.Manager) {
+				applyAuditTestReceipt(t, utxoManager, 2, newAuditTestReceipt(t, utxoManager, 10, true, migratedFundsEntries(0, 2)), true)
+				applyAuditTestReceipt(t, utxoManager, 3, newAuditTestReceipt(t, utxoManager, 12, true, migratedFundsEntries(1, 2)), true)
+			},
+			issues: []issue{{migrator.ReceiptAuditIssueDuplicate, 3}},
+		},
+		{
+			name: "treasury amount mismatch",
+			apply: func(t *testing.T, utxoManager *utxo.Manager) {
+				applyAuditTestReceipt(t, utxoManager, 2, newAuditTestReceipt(t, utxoManager, 10, true, migratedFundsEntries(0, 2)), true)
+				receipt := newAuditTestReceipt(t, utxoManager, 12, true, migratedFundsEntries(2, 1))
+				receipt.Transaction.(*iotago.TreasuryTransaction).Output.(*iotago.TreasuryOutput).Amount++
+				applyAuditTestReceipt(t, utxoManager, 3, receipt, true)
+			},
+			issues: []issue{{migrator.ReceiptAuditIssueMismatch, 3}, {migrator.ReceiptAuditIssueMismatch, 3}},
+		},
+		{
+			name: "missing migrated outputs",
+			apply: func(t *testing.T, utxoManager *utxo.Manager) {
+				applyAuditTestReceipt(t, utxoManager, 2, newAuditTestReceipt(t, utxoManager, 10, true, migratedFundsEntries(0, 2)), false)
+			},
+			issues: []issue{{migrator.ReceiptAuditIssueGap, 2}, {migrator.ReceiptAuditIssueGap, 2}},
+		},
+		{
+			name: "treasury input mismatch",
+			apply: func(t *testing.T, utxoManager *utxo.Manager) {
+				applyAuditTestReceipt(t, utxoManager, 2, newAuditTestReceipt(t, utxoManager, 10, true, migratedFundsEntries(0, 2)), true)
+				receipt := newAuditTestReceipt(t, utxoManager, 12, true, migratedFundsEntries(2, 1))
+				receipt.Transaction.(*iotago.TreasuryTransaction).Input = &iotago.TreasuryInput{2}
+				applyAuditTestReceipt(t, utxoManager, 3, receipt, true)
+			},
+			// the treasury output created by the first receipt is never consumed by a receipt
+			issues: []issue{{migrator.ReceiptAuditIssueGap, 3}, {migrator.ReceiptAuditIssueMismatch, 3}, {migrator.ReceiptAuditIssueGap, 0}},
+		},
+		{
+			name: "treasury output missing",
+			apply: func(t *testing.T, utxoManager *utxo.Manager) {
+				applyAuditTestReceipt(t, utxoManager, 2, newAuditTestReceipt(t, utxoManager, 10, true, migratedFundsEntries(0, 2)), true)
+				// the treasury output created by the receipt is replaced
+				require.NoError(t, utxoManager.StoreUnspentTreasuryOutput(&utxo.TreasuryOutput{MilestoneID: iotago.MilestoneID{2}, Amount: 998_000_000}))
+			},
+			issues: []issue{{migrator.ReceiptAuditIssueGap, 2}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			utxoManager := newAuditTestLedger(t)
+			test.apply(t, utxoManager)
+
+			report, err := migrator.AuditReceipts(utxoManager, nil)
+			require.NoError(t, err)
+			require.Len(t, report.Issues, len(test.issues), "%v", report.Issues)
+			for i, issue := range test.issues {
+				require.Equal(t, issue.kind, report.Issues[i].Kind, report.Issues[i].String())
+				require.Equal(t, issue.milestoneIndex, report.Issues[i].MilestoneIndex, report.Issues[i].String())
+			}
+		})
+	}
+}
+
+func TestAuditReceiptBackups(t *testing.T) {
+	utxoManager := newAuditTestLedger(t)
+
+	applyAuditTestReceipt(t, utxoManager, 2, newAuditTestReceipt(t, utxoManager, 10, true, migratedFundsEntries(0, 2)), true)
+	applyAuditTestReceipt(t, utxoManager, 3, newAuditTestReceipt(t, utxoManager, 12, true, migratedFundsEntries(2, 1)), true)
+
+	backups := backupAuditTestReceipts(t, utxoManager)
+	require.Len(t, backups, 2)
+
+	// the backup of the first receipt differs, the backup of the second receipt is missing
+	// and there is a backup of a receipt which is not stored in the database
+	backups[0].Receipt.Funds = serializer.Serializables{migratedFundsEntries(0, 1)[0]}
+	backups[1].MilestoneIndex = 4
+
+	report, err := migrator.AuditReceipts(utxoManager, backups)
+	require.NoError(t, err)
+	require.Len(t, report.Issues, 3, "%v", report.Issues)
+	require.Equal(t, migrator.ReceiptAuditIssueMismatch, report.Issues[0].Kind)
+	require.Equal(t, milestone.Index(2), report.Issues[0].MilestoneIndex)
+	require.Equal(t, migrator.ReceiptAuditIssueGap, report.Issues[1].Kind)
+	require.Equal(t, milestone.Index(3), report.Issues[1].MilestoneIndex)
+	require.Equal(t, migrator.ReceiptAuditIssueGap, report.Issues[2].Kind)
+	require.Equal(t, milestone.Index(4), report.Issues[2].MilestoneIndex)
+}
@@ -0,0 +1,128 @@
+package migrator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/utils"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+var (
+	// ErrInvalidMigrationRecording is returned when the content of a migration recording is invalid.
+	ErrInvalidMigrationRecording = errors.New("invalid migration recording")
+)
+
+// RecordedMigration holds the migrated funds confirmed by a single legacy milestone.
+type RecordedMigration struct {
+	// MilestoneIndex is the index of the legacy milestone which confirmed the migrations.
+	MilestoneIndex uint32 `json:"milestoneIndex"`
+	// Entries are the migrated funds confirmed by the legacy milestone.
+	Entries []*iotago.MigratedFundsEntry `json:"entries"`
+}
+
+// MigrationRecording is a recording of migrated funds of a legacy network.
+type MigrationRecording struct {
+	// LatestMilestoneIndex is the index of the latest legacy milestone that was recorded.
+	// If it is zero, the highest milestone index of the recorded migrations is used.
+	LatestMilestoneIndex uint32 `json:"latestMilestoneIndex"`
+	// Migrations are the recorded batches of migrated funds.
+	Migrations []*RecordedMigration `json:"migrations"`
+}
+
+// LoadMigrationRecording loads a migration recording from the given JSON file.
+func LoadMigrationRecording(filePath string) (*MigrationRecording, error) {
+	recording := &MigrationRecording{}
+	if err := utils.ReadJSONFromFile(filePath, recording); err != nil {
+		return nil, err
+	}
+	return recording, nil
+}
+
+// FileQueryer is a Queryer which replays the migrated funds of a MigrationRecording
+// instead of querying them from a legacy node.
+type FileQueryer struct {
+	latestIndex uint32
+	// the sorted indexes of all milestones containing migrations.
+	indexes    []uint32
+	migrations map[uint32][]*iotago.MigratedFundsEntry
+}
+
+// NewFileQueryer creates a new FileQueryer for the given recording.
+func NewFileQueryer(recording *MigrationRecording) (*FileQueryer, error) {
+	q := &FileQueryer{
+		latestIndex: recording.LatestMilestoneIndex,
+		indexes:     make([]uint32, 0, len(recording.Migrations)),
+		migrations:  make(map[uint32][]*iotago.MigratedFundsEntry),
+	}
+
+	for _, migration := range recording.Migrations {
+		if migration.MilestoneIndex == 0 {
+			return nil, fmt.Errorf("%w: milestone index must not be zero", ErrInvalidMigrationRecording)
+		}
+		if _, exists := q.migrations[migration.MilestoneIndex]; exists {
+			return nil, fmt.Errorf("%w: milestone index %d recorded more than once", ErrInvalidMigrationRecording, migration.MilestoneIndex)
+		}
+		if recording.LatestMilestoneIndex != 0 && migration.MilestoneIndex > recording.LatestMilestoneIndex {
+			return nil, fmt.Errorf("%w: milestone index %d is above the latest milestone index %d", ErrInvalidMigrationRecording, migration.MilestoneIndex, recording.LatestMilestoneIndex)
+		}
+		if len(migration.Entries) == 0 {
+			// milestones without migrations are not of interest
+			continue
+		}
+
+		q.migrations[migration.MilestoneIndex] = migration.Entries
+		q.indexes = append(q.indexes, migration.MilestoneIndex)
+		if migration.MilestoneIndex > q.latestIndex {
+			q.latestIndex = migration.MilestoneIndex
+		}
+	}
+	sort.Slice(q.indexes, func(i, j int) bool { return q.indexes[i] < q.indexes[j] })
+
+	return q, nil
+}
+
+// NewFileQueryerFromFile creates a new FileQueryer for the recording stored in the given JSON file.
+func NewFileQueryerFromFile(filePath string) (*FileQueryer, error) {
+	recording, err := LoadMigrationRecording(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return NewFileQueryer(recording)
+}
+
+// LatestMilestoneIndex returns the index of the latest recorded legacy milestone.
+func (q *FileQueryer) LatestMilestoneIndex() uint32 {
+	return q.latestIndex
+}
+
+// FirstMilestoneIndex returns the index of the first legacy milestone containing migrations.
+// It returns zero if the recording does not contain any migrations.
+func (q *FileQueryer) FirstMilestoneIndex() uint32 {
+	if len(q.indexes) == 0 {
+		return 0
+	}
+	return q.indexes[0]
+}
+
+// QueryMigratedFunds returns the recorded migrated funds of the given milestone.
+func (q *FileQueryer) QueryMigratedFunds(milestoneIndex uint32) ([]*iotago.MigratedFundsEntry, error) {
+	if milestoneIndex > q.latestIndex {
+		return nil, fmt.Errorf("milestone %d is above the latest recorded milestone %d", milestoneIndex, q.latestIndex)
+	}
+	return q.migrations[milestoneIndex], nil
+}
+
+// QueryNextMigratedFunds returns the recorded migrated funds of the first milestone with index greater or equal
+// than startIndex that contains migrations.
+// If there are no more recorded migrations, it returns the latest recorded milestone index, like a legacy node
+// would do if there are currently no more migrations.
+func (q *FileQueryer) QueryNextMigratedFunds(startIndex uint32) (uint32, []*iotago.MigratedFundsEntry, error) {
+	i := sort.Search(len(q.indexes), func(i int) bool { return q.indexes[i] >= startIndex })
+	if i < len(q.indexes) {
+		return q.indexes[i], q.migrations[q.indexes[i]], nil
+	}
+	return q.latestIndex, nil, nil
+}
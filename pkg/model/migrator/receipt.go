@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"sort"
 
 	"github.com/pkg/errors"
 
@@ -41,18 +42,19 @@ type ReceiptService struct {
 	// Whether the service should ignore soft errors.
 	IgnoreSoftErrors bool
 	backupFolder     string
-	validator        *Validator
+	queryer          Queryer
 	utxoManager      *utxo.Manager
 }
 
 // NewReceiptService creates a new ReceiptService.
-func NewReceiptService(validator *Validator, utxoManager *utxo.Manager, validationEnabled bool, backupEnabled bool, ignoreSoftErrors bool, backupFolder string) *ReceiptService {
+// The queryer is used to fetch the migrated funds the receipts are validated against.
+func NewReceiptService(queryer Queryer, utxoManager *utxo.Manager, validationEnabled bool, backupEnabled bool, ignoreSoftErrors bool, backupFolder string) *ReceiptService {
 	return &ReceiptService{
 		ValidationEnabled: validationEnabled,
 		IgnoreSoftErrors:  ignoreSoftErrors,
 		BackupEnabled:     backupEnabled,
 		utxoManager:       utxoManager,
-		validator:         validator,
+		queryer:           queryer,
 		backupFolder:      backupFolder,
 	}
 }
@@ -85,6 +87,50 @@ func (rs *ReceiptService) Backup(r *utxo.ReceiptTuple) error {
 	return nil
 }
 
+// LoadReceiptBackups loads all receipts stored by Backup in the given folder,
+// sorted by the index of the milestone which included them.
+func LoadReceiptBackups(backupFolder string) ([]*utxo.ReceiptTuple, error) {
+	files, err := ioutil.ReadDir(backupFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	receiptTuples := make([]*utxo.ReceiptTuple, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		var migratedAt, msIndex uint32
+		if _, err := fmt.Sscanf(file.Name(), receiptFilePattern, &migratedAt, &msIndex); err != nil {
+			// not a receipt backup
+			continue
+		}
+
+		receiptJSON, err := ioutil.ReadFile(path.Join(backupFolder, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		receipt := &iotago.Receipt{}
+		if err := receipt.UnmarshalJSON(receiptJSON); err != nil {
+			return nil, fmt.Errorf("unable to parse receipt backup %s: %w", file.Name(), err)
+		}
+
+		if receipt.MigratedAt != migratedAt {
+			return nil, fmt.Errorf("receipt backup %s has migrated at index %d", file.Name(), receipt.MigratedAt)
+		}
+
+		receiptTuples = append(receiptTuples, &utxo.ReceiptTuple{Receipt: receipt, MilestoneIndex: milestone.Index(msIndex)})
+	}
+
+	sort.SliceStable(receiptTuples, func(i, j int) bool {
+		return receiptTuples[i].MilestoneIndex < receiptTuples[j].MilestoneIndex
+	})
+
+	return receiptTuples, nil
+}
+
 // ValidateWithoutLocking validates the given receipt against data fetched from a legacy node.
 // The UTXO ledger should be locked outside of this function.
 // If the receipt has the final flag set to true, then the entire batch of receipts with the same migrated_at index
@@ -108,7 +154,7 @@ func (rs *ReceiptService) ValidateWithoutLocking(r *iotago.Receipt) error {
 
 func (rs *ReceiptService) validateAgainstWhiteFlagData(r *iotago.Receipt) error {
 	// validate
-	wfEntries, err := rs.validator.QueryMigratedFunds(r.MigratedAt)
+	wfEntries, err := rs.queryer.QueryMigratedFunds(r.MigratedAt)
 	if err != nil {
 		return fmt.Errorf("unable to query migrated funds from legacy node for receipt validation: %w", err)
 	}
@@ -0,0 +1,111 @@
+package migrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+var (
+	// errRecordingExhausted is returned by the replayQueryer when all recorded milestones were queried.
+	errRecordingExhausted = errors.New("all recorded milestones were queried")
+)
+
+// replayQueryer wraps a FileQueryer and returns an error once all recorded milestones were queried,
+// which terminates the MigratorService.
+type replayQueryer struct {
+	*FileQueryer
+}
+
+func (q *replayQueryer) QueryNextMigratedFunds(startIndex uint32) (uint32, []*iotago.MigratedFundsEntry, error) {
+	if startIndex > q.latestIndex {
+		return 0, nil, errRecordingExhausted
+	}
+	return q.FileQueryer.QueryNextMigratedFunds(startIndex)
+}
+
+// GenerateReceipts runs a MigratorService against the given recording and returns all receipts
+// a coordinator would issue, each containing at most receiptMaxEntries entries.
+// The returned receipts do not contain a treasury transaction.
+func GenerateReceipts(ctx context.Context, queryer *FileQueryer, receiptMaxEntries int) ([]*iotago.Receipt, error) {
+	startIndex := queryer.FirstMilestoneIndex()
+	if startIndex == 0 {
+		// nothing was migrated
+		return nil, nil
+	}
+
+	// the state of the service is never persisted during the replay
+	service := NewService(&replayQueryer{FileQueryer: queryer}, "", receiptMaxEntries)
+	if err := service.InitState(&startIndex, nil); err != nil {
+		return nil, err
+	}
+
+	var serviceErr error
+	serviceDone := make(chan struct{})
+	go func() {
+		defer close(serviceDone)
+		service.Start(ctx, func(err error) bool {
+			if !errors.Is(err, errRecordingExhausted) {
+				serviceErr = err
+			}
+			return false
+		})
+	}()
+
+	var receipts []*iotago.Receipt
+	for receipt := service.waitForReceipt(); receipt != nil; receipt = service.waitForReceipt() {
+		receipts = append(receipts, receipt)
+	}
+
+	// the service closes the migrations channel when it stops, so there are no pending results left
+	<-serviceDone
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+	return receipts, ctx.Err()
+}
+
+// ReceiptReplayer validates receipts against a migration recording using a ReceiptService
+// backed by an in-memory UTXO ledger, without the need of a legacy node or a database.
+type ReceiptReplayer struct {
+	utxoManager    *utxo.Manager
+	receiptService *ReceiptService
+}
+
+// NewReceiptReplayer creates a new ReceiptReplayer which validates receipts against the given queryer.
+func NewReceiptReplayer(queryer Queryer) *ReceiptReplayer {
+	utxoManager := utxo.New(mapdb.NewMapDB())
+	return &ReceiptReplayer{
+		utxoManager:    utxoManager,
+		receiptService: NewReceiptService(queryer, utxoManager, true, false, false, ""),
+	}
+}
+
+// Validate validates the given receipt like a node would do for a receipt contained in the milestone with the given index.
+// Valid receipts are stored in the ledger, so that the validation of the final receipt of a batch takes them into account.
+// Receipts without a treasury transaction are stored with an empty one, because the treasury is not part of the validation.
+// The given receipt is not modified.
+func (rr *ReceiptReplayer) Validate(r *iotago.Receipt, msIndex milestone.Index) error {
+	if err := rr.receiptService.ValidateWithoutLocking(r); err != nil {
+		return err
+	}
+
+	storedReceipt := r
+	if r.Transaction == nil {
+		// a receipt can't be stored without a treasury transaction
+		receiptCopy := *r
+		receiptCopy.Transaction = &iotago.TreasuryTransaction{Input: &iotago.TreasuryInput{}, Output: &iotago.TreasuryOutput{}}
+		storedReceipt = &receiptCopy
+	}
+
+	if err := rr.utxoManager.ApplyConfirmationWithoutLocking(msIndex, nil, nil, nil, &utxo.ReceiptTuple{Receipt: storedReceipt, MilestoneIndex: msIndex}); err != nil {
+		return fmt.Errorf("unable to store receipt: %w", err)
+	}
+	return nil
+}
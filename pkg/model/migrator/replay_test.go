@@ -0,0 +1,196 @@
+package migrator_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/migrator"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/iotaledger/hive.go/serializer"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+func migratedFundsEntries(first byte, count int) []*iotago.MigratedFundsEntry {
+	entries := make([]*iotago.MigratedFundsEntry, count)
+	for i := 0; i < count; i++ {
+		entries[i] = &iotago.MigratedFundsEntry{
+			TailTransactionHash: iotago.LegacyTailTransactionHash{first + byte(i)},
+			Address:             &iotago.Ed25519Address{first + byte(i)},
+			Deposit:             1_000_000,
+		}
+	}
+	return entries
+}
+
+func newTestRecording() *migrator.MigrationRecording {
+	return &migrator.MigrationRecording{
+		LatestMilestoneIndex: 10,
+		Migrations: []*migrator.RecordedMigration{
+			{MilestoneIndex: 7, Entries: migratedFundsEntries(10, 1)},
+			{MilestoneIndex: 3, Entries: migratedFundsEntries(0, 5)},
+			{MilestoneIndex: 5, Entries: nil},
+		},
+	}
+}
+
+func TestFileQueryer(t *testing.T) {
+	recording := newTestRecording()
+
+	recordingJSON, err := json.Marshal(recording)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "migrator_replay_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	recordingPath := filepath.Join(dir, "migrations.json")
+	require.NoError(t, ioutil.WriteFile(recordingPath, recordingJSON, 0644))
+
+	q, err := migrator.NewFileQueryerFromFile(recordingPath)
+	require.NoError(t, err)
+	require.EqualValues(t, 10, q.LatestMilestoneIndex())
+	require.EqualValues(t, 3, q.FirstMilestoneIndex())
+
+	entries, err := q.QueryMigratedFunds(3)
+	require.NoError(t, err)
+	require.Equal(t, recording.Migrations[1].Entries, entries)
+
+	entries, err = q.QueryMigratedFunds(5)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	_, err = q.QueryMigratedFunds(11)
+	require.Error(t, err)
+
+	msIndex, entries, err := q.QueryNextMigratedFunds(1)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, msIndex)
+	require.Len(t, entries, 5)
+
+	msIndex, entries, err = q.QueryNextMigratedFunds(4)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, msIndex)
+	require.Equal(t, recording.Migrations[0].Entries, entries)
+
+	msIndex, entries, err = q.QueryNextMigratedFunds(8)
+	require.NoError(t, err)
+	require.EqualValues(t, 10, msIndex)
+	require.Empty(t, entries)
+}
+
+func TestFileQueryerInvalidRecording(t *testing.T) {
+	_, err := migrator.NewFileQueryer(&migrator.MigrationRecording{
+		Migrations: []*migrator.RecordedMigration{{MilestoneIndex: 0, Entries: migratedFundsEntries(0, 1)}},
+	})
+	require.ErrorIs(t, err, migrator.ErrInvalidMigrationRecording)
+
+	_, err = migrator.NewFileQueryer(&migrator.MigrationRecording{
+		Migrations: []*migrator.RecordedMigration{
+			{MilestoneIndex: 1, Entries: migratedFundsEntries(0, 1)},
+			{MilestoneIndex: 1, Entries: migratedFundsEntries(1, 1)},
+		},
+	})
+	require.ErrorIs(t, err, migrator.ErrInvalidMigrationRecording)
+
+	_, err = migrator.NewFileQueryer(&migrator.MigrationRecording{
+		LatestMilestoneIndex: 1,
+		Migrations:           []*migrator.RecordedMigration{{MilestoneIndex: 2, Entries: migratedFundsEntries(0, 1)}},
+	})
+	require.ErrorIs(t, err, migrator.ErrInvalidMigrationRecording)
+
+	// without a latest milestone index, the highest recorded index is used
+	q, err := migrator.NewFileQueryer(&migrator.MigrationRecording{
+		Migrations: []*migrator.RecordedMigration{{MilestoneIndex: 2, Entries: migratedFundsEntries(0, 1)}},
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, q.LatestMilestoneIndex())
+}
+
+func TestReplayGeneratedReceipts(t *testing.T) {
+	q, err := migrator.NewFileQueryer(newTestRecording())
+	require.NoError(t, err)
+
+	receipts, err := migrator.GenerateReceipts(context.Background(), q, 2)
+	require.NoError(t, err)
+	require.Len(t, receipts, 4)
+
+	expected := []struct {
+		migratedAt uint32
+		final      bool
+		entries    int
+	}{
+		{3, false, 2},
+		{3, false, 2},
+		{3, true, 1},
+		{7, true, 1},
+	}
+	for i, receipt := range receipts {
+		require.Equal(t, expected[i].migratedAt, receipt.MigratedAt)
+		require.Equal(t, expected[i].final, receipt.Final)
+		require.Len(t, receipt.Funds, expected[i].entries)
+	}
+
+	replayer := migrator.NewReceiptReplayer(q)
+	for i, receipt := range receipts {
+		require.NoError(t, replayer.Validate(receipt, milestone.Index(i+1)))
+		// the treasury transaction is only added to the stored receipt
+		require.Nil(t, receipt.Transaction)
+	}
+}
+
+func TestReplayInvalidReceipts(t *testing.T) {
+	q, err := migrator.NewFileQueryer(newTestRecording())
+	require.NoError(t, err)
+
+	replayer := migrator.NewReceiptReplayer(q)
+
+	// the final receipt does not contain all migrations of the milestone
+	err = replayer.Validate(&iotago.Receipt{
+		MigratedAt: 3,
+		Final:      true,
+		Funds:      serializer.Serializables{migratedFundsEntries(0, 1)[0]},
+	}, 1)
+	require.ErrorIs(t, err, migrator.ErrInvalidReceiptServiceState)
+
+	// the receipt contains an entry which was not migrated
+	err = replayer.Validate(&iotago.Receipt{
+		MigratedAt: 3,
+		Final:      false,
+		Funds:      serializer.Serializables{migratedFundsEntries(20, 1)[0]},
+	}, 1)
+	require.ErrorIs(t, err, migrator.ErrInvalidReceiptServiceState)
+}
+
+func TestLoadReceiptBackups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "migrator_replay_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	rs := migrator.NewReceiptService(nil, nil, false, true, false, dir)
+	require.NoError(t, rs.Init())
+
+	treasuryTx := &iotago.TreasuryTransaction{Input: &iotago.TreasuryInput{}, Output: &iotago.TreasuryOutput{Amount: 1_000_000_000}}
+	backups := []*utxo.ReceiptTuple{
+		{Receipt: &iotago.Receipt{MigratedAt: 7, Final: true, Funds: serializer.Serializables{migratedFundsEntries(10, 1)[0]}, Transaction: treasuryTx}, MilestoneIndex: 12},
+		{Receipt: &iotago.Receipt{MigratedAt: 3, Final: true, Funds: serializer.Serializables{migratedFundsEntries(0, 1)[0]}, Transaction: treasuryTx}, MilestoneIndex: 11},
+	}
+	for _, rt := range backups {
+		require.NoError(t, rs.Backup(rt))
+	}
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "unrelated.json"), []byte("{}"), 0644))
+
+	receiptTuples, err := migrator.LoadReceiptBackups(dir)
+	require.NoError(t, err)
+	require.Len(t, receiptTuples, 2)
+	require.Equal(t, milestone.Index(11), receiptTuples[0].MilestoneIndex)
+	require.EqualValues(t, 3, receiptTuples[0].Receipt.MigratedAt)
+	require.Equal(t, milestone.Index(12), receiptTuples[1].MilestoneIndex)
+	require.EqualValues(t, 7, receiptTuples[1].Receipt.MigratedAt)
+}
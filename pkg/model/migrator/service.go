@@ -112,6 +112,22 @@ func (s *MigratorService) Receipt() *iotago.Receipt {
 	return createReceipt(result.stopIndex, result.lastBatch, result.migratedFunds)
 }
 
+// waitForReceipt blocks until the next receipt of migrated funds is available.
+// It returns nil once s is stopped.
+// Unlike Receipt, the receive is not guarded by the mutex, because the running service acquires it to query
+// the next migrations. Therefore there must not be other callers of Receipt or waitForReceipt.
+func (s *MigratorService) waitForReceipt() *iotago.Receipt {
+	result, ok := <-s.migrations
+	if !ok {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.updateState(result)
+	return createReceipt(result.stopIndex, result.lastBatch, result.migratedFunds)
+}
+
 // PersistState persists the current state to a file.
 // PersistState must be called when the receipt returned by the last call of Receipt has been send to the network.
 func (s *MigratorService) PersistState(sendingReceipt bool) error {
@@ -0,0 +1,285 @@
+package participation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/serializer"
+)
+
+const (
+	// ChoiceBallotPayloadTypeID defines the choice ballot payload's type ID.
+	ChoiceBallotPayloadTypeID uint32 = 2
+
+	// ChoicesEncodingPrefix is the first byte of the answers of a participation for a ChoiceBallot.
+	// It distinguishes the encoded choices from the answers of a participation for a Ballot.
+	ChoicesEncodingPrefix byte = 0xFF
+
+	// ChoiceParticipationAnswersMaxLength is the maximum length of the answers of a participation for a ChoiceBallot.
+	ChoiceParticipationAnswersMaxLength = 1 + BallotMaxQuestionsCount*(1+QuestionMaxAnswersCount)
+)
+
+// ChoiceBallotMode defines how the choices of a ChoiceBallot are counted.
+type ChoiceBallotMode byte
+
+const (
+	// ChoiceBallotModeRanked ranks the answers of a question by preference. The results are determined by instant runoff.
+	ChoiceBallotModeRanked ChoiceBallotMode = 0
+	// ChoiceBallotModeApproval approves any amount of answers of a question. Every approved answer receives the full voting weight.
+	ChoiceBallotModeApproval ChoiceBallotMode = 1
+)
+
+var (
+	ErrUnknownChoiceBallotMode = errors.New("unknown choice ballot mode")
+	ErrInvalidChoicesEncoding  = errors.New("invalid choices encoding")
+)
+
+// ChoiceBallot can be used to define a voting participation with variable questions,
+// where every question can be answered with multiple choices, either ranked by preference or approved.
+// The answers of a participation for a ChoiceBallot are encoded as the ChoicesEncodingPrefix,
+// followed by the amount of choices per question and the chosen answer values.
+type ChoiceBallot struct {
+	// Mode defines how the choices are counted.
+	Mode ChoiceBallotMode
+	// Questions are the questions of the ballot and their possible answers.
+	Questions serializer.Serializables
+}
+
+func validChoiceBallotMode(mode ChoiceBallotMode) error {
+	switch mode {
+	case ChoiceBallotModeRanked:
+	case ChoiceBallotModeApproval:
+	default:
+		return fmt.Errorf("%w: %d", ErrUnknownChoiceBallotMode, mode)
+	}
+	return nil
+}
+
+func (q *ChoiceBallot) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
+	return serializer.NewDeserializer(data).
+		Skip(serializer.TypeDenotationByteSize, func(err error) error {
+			return fmt.Errorf("unable to skip choice ballot payload ID during deserialization: %w", err)
+		}).
+		ReadByte((*byte)(&q.Mode), func(err error) error {
+			return fmt.Errorf("unable to deserialize choice ballot mode: %w", err)
+		}).
+		ReadSliceOfObjects(func(seri serializer.Serializables) { q.Questions = seri }, deSeriMode, serializer.SeriLengthPrefixTypeAsByte, serializer.TypeDenotationNone, func(_ uint32) (serializer.Serializable, error) {
+			// there is no real selector, so we always return a fresh Question
+			return &Question{}, nil
+		}, questionsArrayRules, func(err error) error {
+			return fmt.Errorf("unable to deserialize participation questions: %w", err)
+		}).
+		AbortIf(func(err error) error {
+			if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
+				return validChoiceBallotMode(q.Mode)
+			}
+			return nil
+		}).
+		Done()
+}
+
+func (q *ChoiceBallot) Serialize(deSeriMode serializer.DeSerializationMode) ([]byte, error) {
+	return serializer.NewSerializer().
+		AbortIf(func(err error) error {
+			if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
+				if err := validChoiceBallotMode(q.Mode); err != nil {
+					return err
+				}
+				if err := questionsArrayRules.CheckBounds(uint(len(q.Questions))); err != nil {
+					return fmt.Errorf("unable to serialize participation questions: %w", err)
+				}
+			}
+			return nil
+		}).
+		WriteNum(ChoiceBallotPayloadTypeID, func(err error) error {
+			return fmt.Errorf("%w: unable to serialize choice ballot payload ID", err)
+		}).
+		WriteByte(byte(q.Mode), func(err error) error {
+			return fmt.Errorf("unable to serialize choice ballot mode: %w", err)
+		}).
+		WriteSliceOfObjects(q.Questions, deSeriMode, serializer.SeriLengthPrefixTypeAsByte, nil, func(err error) error {
+			return fmt.Errorf("unable to serialize participation questions: %w", err)
+		}).
+		Serialize()
+}
+
+func (q *ChoiceBallot) MarshalJSON() ([]byte, error) {
+	j := &jsonChoiceBallot{
+		Type: int(ChoiceBallotPayloadTypeID),
+		Mode: int(q.Mode),
+	}
+	j.Questions = make([]*json.RawMessage, len(q.Questions))
+	for i, question := range q.Questions {
+		jsonQuestion, err := question.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		rawJSONQuestion := json.RawMessage(jsonQuestion)
+		j.Questions[i] = &rawJSONQuestion
+	}
+
+	return json.Marshal(j)
+}
+
+func (q *ChoiceBallot) UnmarshalJSON(bytes []byte) error {
+	j := &jsonChoiceBallot{
+		Type: int(ChoiceBallotPayloadTypeID),
+	}
+	if err := json.Unmarshal(bytes, j); err != nil {
+		return err
+	}
+	seri, err := j.ToSerializable()
+	if err != nil {
+		return err
+	}
+	*q = *seri.(*ChoiceBallot)
+	return nil
+}
+
+// jsonChoiceBallot defines the json representation of a ChoiceBallot.
+type jsonChoiceBallot struct {
+	// Type is the type of the event.
+	Type int `json:"type"`
+	// Mode defines how the choices are counted (0 = ranked, 1 = approval).
+	Mode int `json:"mode"`
+	// Questions are the questions of the ballot and their possible answers.
+	Questions []*json.RawMessage `json:"questions"`
+}
+
+func (j *jsonChoiceBallot) ToSerializable() (serializer.Serializable, error) {
+	payload := &ChoiceBallot{
+		Mode: ChoiceBallotMode(j.Mode),
+	}
+
+	questions := make(serializer.Serializables, len(j.Questions))
+	for i, ele := range j.Questions {
+		question := &Question{}
+
+		rawJSON, err := ele.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("pos %d: %w", i, err)
+		}
+
+		if err := json.Unmarshal(rawJSON, question); err != nil {
+			return nil, fmt.Errorf("pos %d: %w", i, err)
+		}
+
+		questions[i] = question
+	}
+	payload.Questions = questions
+
+	return payload, nil
+}
+
+// EncodeChoices encodes the chosen answer values of every question into the answers of a participation for a ChoiceBallot.
+func EncodeChoices(choices [][]uint8) []byte {
+	answers := []byte{ChoicesEncodingPrefix}
+	for _, questionChoices := range choices {
+		answers = append(answers, byte(len(questionChoices)))
+		answers = append(answers, questionChoices...)
+	}
+	return answers
+}
+
+// DecodeChoices decodes the answers of a participation for a ChoiceBallot into the chosen answer values of every question.
+func DecodeChoices(answers []byte, questionsCount int) ([][]uint8, error) {
+	choices, err := decodeChoices(answers)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(choices) != questionsCount {
+		return nil, fmt.Errorf("%w: expected choices for %d questions, got %d", ErrInvalidChoicesEncoding, questionsCount, len(choices))
+	}
+
+	return choices, nil
+}
+
+// decodeChoices decodes the answers of a participation for a ChoiceBallot into the chosen answer values of every question,
+// without knowing the amount of questions of the ballot.
+func decodeChoices(answers []byte) ([][]uint8, error) {
+	if len(answers) == 0 || answers[0] != ChoicesEncodingPrefix {
+		return nil, fmt.Errorf("%w: missing prefix", ErrInvalidChoicesEncoding)
+	}
+
+	var choices [][]uint8
+	for offset := 1; offset < len(answers); {
+		count := int(answers[offset])
+		offset++
+
+		if count > QuestionMaxAnswersCount || offset+count > len(answers) || len(choices) == BallotMaxQuestionsCount {
+			return nil, ErrInvalidChoicesEncoding
+		}
+
+		choices = append(choices, answers[offset:offset+count])
+		offset += count
+	}
+
+	return choices, nil
+}
+
+// questionChoices holds the counted answer values of a participation for a single question.
+type questionChoices struct {
+	// answerValues are the answer values whose vote balance is changed by the participation.
+	answerValues []uint8
+	// ranking holds the valid ranking of a ranked question, nil otherwise.
+	ranking []byte
+}
+
+// choicesForQuestion validates the chosen answer values of a ChoiceBallot question.
+// Empty choices are counted as skipped, duplicate or unknown answer values as invalid.
+func (q *Question) choicesForQuestion(mode ChoiceBallotMode, choices []uint8) *questionChoices {
+	if len(choices) == 0 {
+		return &questionChoices{answerValues: []uint8{AnswerValueSkipped}}
+	}
+
+	seenValues := make(map[uint8]struct{}, len(choices))
+	for _, choice := range choices {
+		if _, seen := seenValues[choice]; seen || choice == AnswerValueSkipped || q.answerValueForByte(choice) == AnswerValueInvalid {
+			return &questionChoices{answerValues: []uint8{AnswerValueInvalid}}
+		}
+		seenValues[choice] = struct{}{}
+	}
+
+	if mode == ChoiceBallotModeApproval {
+		return &questionChoices{answerValues: append([]uint8{}, choices...)}
+	}
+
+	// the first preference is counted for the answer, the whole ranking for the instant runoff
+	return &questionChoices{
+		answerValues: []uint8{choices[0]},
+		ranking:      append([]byte{}, choices...),
+	}
+}
+
+// questionChoicesForParticipation returns the counted answer values for every question of the event.
+// It returns an error if the answers of the participation do not match the questions of the event.
+func (e *Event) questionChoicesForParticipation(answers []byte) ([]*questionChoices, error) {
+	questions := e.BallotQuestions()
+
+	choiceBallot := e.ChoiceBallot()
+	if choiceBallot == nil {
+		if len(answers) != len(questions) {
+			return nil, fmt.Errorf("expected answers for %d questions, got %d", len(questions), len(answers))
+		}
+
+		result := make([]*questionChoices, len(answers))
+		for idx, answerByte := range answers {
+			result[idx] = &questionChoices{answerValues: []uint8{questions[idx].answerValueForByte(answerByte)}}
+		}
+		return result, nil
+	}
+
+	choices, err := DecodeChoices(answers, len(questions))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*questionChoices, len(choices))
+	for idx, questionChoices := range choices {
+		result[idx] = questions[idx].choicesForQuestion(choiceBallot.Mode, questionChoices)
+	}
+	return result, nil
+}
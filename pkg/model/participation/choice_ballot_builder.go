@@ -0,0 +1,35 @@
+package participation
+
+import (
+	"fmt"
+
+	"github.com/iotaledger/hive.go/serializer"
+)
+
+// NewChoiceBallotBuilder creates a new ChoiceBallotBuilder.
+func NewChoiceBallotBuilder(mode ChoiceBallotMode) *ChoiceBallotBuilder {
+	return &ChoiceBallotBuilder{
+		ballot: &ChoiceBallot{
+			Mode: mode,
+		},
+	}
+}
+
+// ChoiceBallotBuilder is used to easily build up a ChoiceBallot.
+type ChoiceBallotBuilder struct {
+	ballot *ChoiceBallot
+}
+
+// AddQuestion adds the given question to the ChoiceBallot.
+func (qb *ChoiceBallotBuilder) AddQuestion(entry *Question) *ChoiceBallotBuilder {
+	qb.ballot.Questions = append(qb.ballot.Questions, entry)
+	return qb
+}
+
+// Build builds the ChoiceBallot.
+func (qb *ChoiceBallotBuilder) Build() (*ChoiceBallot, error) {
+	if _, err := qb.ballot.Serialize(serializer.DeSeriModePerformValidation); err != nil {
+		return nil, fmt.Errorf("unable to build choice ballot: %w", err)
+	}
+	return qb.ballot, nil
+}
@@ -0,0 +1,192 @@
+package participation_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/participation"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/serializer"
+)
+
+func RandChoiceBallot(mode participation.ChoiceBallotMode, questionCount int) (*participation.ChoiceBallot, []byte) {
+
+	b := &participation.ChoiceBallot{
+		Mode:      mode,
+		Questions: serializer.Serializables{},
+	}
+
+	var questionsBytes [][]byte
+	for i := 0; i < questionCount; i++ {
+		q, bytes := RandValidQuestion()
+		b.Questions = append(b.Questions, q)
+		questionsBytes = append(questionsBytes, bytes)
+	}
+
+	ms := marshalutil.New()
+	ms.WriteUint32(participation.ChoiceBallotPayloadTypeID)
+	ms.WriteUint8(uint8(mode))
+	ms.WriteUint8(uint8(len(questionsBytes)))
+	for _, bytes := range questionsBytes {
+		ms.WriteBytes(bytes)
+	}
+
+	return b, ms.Bytes()
+}
+
+func TestChoiceBallot_Deserialize(t *testing.T) {
+	rankedBallot, rankedBallotData := RandChoiceBallot(participation.ChoiceBallotModeRanked, 1)
+	approvalBallot, approvalBallotData := RandChoiceBallot(participation.ChoiceBallotModeApproval, 1)
+	maxQuestionsBallot, maxQuestionsBallotData := RandChoiceBallot(participation.ChoiceBallotModeRanked, 10)
+	noQuestions, noQuestionsBallotData := RandChoiceBallot(participation.ChoiceBallotModeRanked, 0)
+	tooManyQuestionsBallot, tooManyQuestionsBallotData := RandChoiceBallot(participation.ChoiceBallotModeRanked, 11)
+	unknownModeBallot, unknownModeBallotData := RandChoiceBallot(2, 1)
+
+	tests := []struct {
+		name   string
+		data   []byte
+		target *participation.ChoiceBallot
+		err    error
+	}{
+		{"ranked", rankedBallotData, rankedBallot, nil},
+		{"approval", approvalBallotData, approvalBallot, nil},
+		{"not enough data", rankedBallotData[:len(rankedBallotData)-1], rankedBallot, serializer.ErrDeserializationNotEnoughData},
+		{"max questions", maxQuestionsBallotData, maxQuestionsBallot, nil},
+		{"no questions", noQuestionsBallotData, noQuestions, serializer.ErrArrayValidationMinElementsNotReached},
+		{"too many questions", tooManyQuestionsBallotData, tooManyQuestionsBallot, serializer.ErrArrayValidationMaxElementsExceeded},
+		{"unknown mode", unknownModeBallotData, unknownModeBallot, participation.ErrUnknownChoiceBallotMode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &participation.ChoiceBallot{}
+			bytesRead, err := u.Deserialize(tt.data, serializer.DeSeriModePerformValidation)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+			assert.Equal(t, len(tt.data), bytesRead)
+			assert.EqualValues(t, tt.target, u)
+		})
+	}
+}
+
+func TestChoiceBallot_Serialize(t *testing.T) {
+	rankedBallot, rankedBallotData := RandChoiceBallot(participation.ChoiceBallotModeRanked, 1)
+	approvalBallot, approvalBallotData := RandChoiceBallot(participation.ChoiceBallotModeApproval, 1)
+	maxQuestionsBallot, maxQuestionsBallotData := RandChoiceBallot(participation.ChoiceBallotModeRanked, 10)
+	noQuestions, noQuestionsBallotData := RandChoiceBallot(participation.ChoiceBallotModeRanked, 0)
+	tooManyQuestionsBallot, tooManyQuestionsBallotData := RandChoiceBallot(participation.ChoiceBallotModeRanked, 11)
+	unknownModeBallot, unknownModeBallotData := RandChoiceBallot(2, 1)
+
+	tests := []struct {
+		name   string
+		source *participation.ChoiceBallot
+		target []byte
+		err    error
+	}{
+		{"ranked", rankedBallot, rankedBallotData, nil},
+		{"approval", approvalBallot, approvalBallotData, nil},
+		{"max questions", maxQuestionsBallot, maxQuestionsBallotData, nil},
+		{"no questions", noQuestions, noQuestionsBallotData, serializer.ErrArrayValidationMinElementsNotReached},
+		{"too many questions", tooManyQuestionsBallot, tooManyQuestionsBallotData, serializer.ErrArrayValidationMaxElementsExceeded},
+		{"unknown mode", unknownModeBallot, unknownModeBallotData, participation.ErrUnknownChoiceBallotMode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.source.Serialize(serializer.DeSeriModePerformValidation)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+			assert.EqualValues(t, tt.target, data)
+		})
+	}
+}
+
+func TestChoiceBallot_JSON(t *testing.T) {
+	choiceBallot, _ := RandChoiceBallot(participation.ChoiceBallotModeApproval, 2)
+
+	event := &participation.Event{
+		Name:                   "choice",
+		MilestoneIndexCommence: 1,
+		MilestoneIndexStart:    2,
+		MilestoneIndexEnd:      3,
+		Payload:                choiceBallot,
+	}
+
+	jsonBytes, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	decoded := &participation.Event{}
+	require.NoError(t, json.Unmarshal(jsonBytes, decoded))
+	require.Equal(t, choiceBallot, decoded.ChoiceBallot())
+	require.Len(t, decoded.BallotQuestions(), 2)
+	require.Nil(t, decoded.Ballot())
+
+	data, err := event.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+
+	deserialized := &participation.Event{}
+	_, err = deserialized.Deserialize(data, serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+	require.Equal(t, choiceBallot, deserialized.ChoiceBallot())
+}
+
+func TestDecodeChoices(t *testing.T) {
+	choices := [][]uint8{{1, 2, 3}, {}, {4}}
+	answers := participation.EncodeChoices(choices)
+	require.Equal(t, []byte{participation.ChoicesEncodingPrefix, 3, 1, 2, 3, 0, 1, 4}, answers)
+
+	decoded, err := participation.DecodeChoices(answers, 3)
+	require.NoError(t, err)
+	require.Equal(t, choices, decoded)
+
+	// the amount of questions must match
+	_, err = participation.DecodeChoices(answers, 2)
+	require.ErrorIs(t, err, participation.ErrInvalidChoicesEncoding)
+
+	// the choices must not exceed the answers
+	_, err = participation.DecodeChoices(answers[:len(answers)-1], 3)
+	require.ErrorIs(t, err, participation.ErrInvalidChoicesEncoding)
+
+	// a question can not have more choices than answers
+	_, err = participation.DecodeChoices(append([]byte{participation.ChoicesEncodingPrefix, participation.QuestionMaxAnswersCount + 1}, make([]byte, participation.QuestionMaxAnswersCount+1)...), 1)
+	require.ErrorIs(t, err, participation.ErrInvalidChoicesEncoding)
+
+	// the prefix is required
+	_, err = participation.DecodeChoices(answers[1:], 3)
+	require.ErrorIs(t, err, participation.ErrInvalidChoicesEncoding)
+}
+
+func TestChoiceParticipationAnswersLength(t *testing.T) {
+	choices := make([][]uint8, participation.BallotMaxQuestionsCount)
+	for i := range choices {
+		choices[i] = []uint8{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	}
+
+	// the encoded choices of a full ballot exceed the answers of a Ballot
+	p := &participation.Participation{EventID: RandEventID(), Answers: participation.EncodeChoices(choices)}
+	require.Len(t, p.Answers, participation.ChoiceParticipationAnswersMaxLength)
+
+	data, err := p.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+
+	deserialized := &participation.Participation{}
+	_, err = deserialized.Deserialize(data, serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+	require.Equal(t, p, deserialized)
+
+	// answers longer than BallotMaxQuestionsCount are only valid for encoded choices
+	p.Answers = append([]byte{0}, p.Answers[1:]...)
+	_, err = p.Serialize(serializer.DeSeriModePerformValidation)
+	require.ErrorIs(t, err, participation.ErrParticipationTooManyAnswers)
+
+	// more questions than a ballot can hold
+	p.Answers = participation.EncodeChoices(append(choices, []uint8{}))
+	_, err = p.Serialize(serializer.DeSeriModePerformValidation)
+	require.ErrorIs(t, err, participation.ErrParticipationTooManyAnswers)
+}
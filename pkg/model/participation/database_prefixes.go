@@ -1,5 +1,10 @@
 package participation
 
+const (
+	// DatabaseDirectoryName is the name of the participation database directory inside the database path of the node.
+	DatabaseDirectoryName = "participation"
+)
+
 const (
 	// Holds the events
 	ParticipationStoreKeyPrefixEvents byte = 0
@@ -18,4 +23,13 @@ const (
 	// Staking
 	ParticipationStoreKeyPrefixStakingAddress            byte = 6
 	ParticipationStoreKeyPrefixStakingTotalParticipation byte = 7
+
+	// Ranked choice voting
+	ParticipationStoreKeyPrefixBallotRankingBalanceForQuestion byte = 8
+
+	// Holds the last milestone index that was searched for event announcements of the registry
+	ParticipationStoreKeyPrefixRegistryScannedIndex byte = 9
+
+	// Holds the instant runoff rounds of ranked choice questions
+	ParticipationStoreKeyPrefixBallotRoundsForQuestion byte = 10
 )
@@ -1,6 +1,7 @@
 package participation
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math"
@@ -35,6 +36,23 @@ var (
 	ErrSerializationStringLengthInvalid = errors.New("invalid string length")
 )
 
+// EventIDFromHex creates a EventID from a hex string representation.
+func EventIDFromHex(hexString string) (EventID, error) {
+
+	b, err := hex.DecodeString(hexString)
+	if err != nil {
+		return NullEventID, err
+	}
+
+	if len(b) != EventIDLength {
+		return NullEventID, fmt.Errorf("unknown eventID length (%d)", len(b))
+	}
+
+	var eventID EventID
+	copy(eventID[:], b)
+	return eventID, nil
+}
+
 // PayloadSelector implements SerializableSelectorFunc for payload types.
 func PayloadSelector(payloadType uint32) (serializer.Serializable, error) {
 	var seri serializer.Serializable
@@ -43,6 +61,8 @@ func PayloadSelector(payloadType uint32) (serializer.Serializable, error) {
 		seri = &Ballot{}
 	case StakingPayloadTypeID:
 		seri = &Staking{}
+	case ChoiceBallotPayloadTypeID:
+		seri = &ChoiceBallot{}
 	default:
 		return nil, fmt.Errorf("%w: type %d", ErrUnknownPayloadType, payloadType)
 	}
@@ -59,7 +79,7 @@ type Event struct {
 	MilestoneIndexStart uint32
 	// MilestoneIndexEnd is the milestone index the event ends.
 	MilestoneIndexEnd uint32
-	// Payload is the payload of the event (ballot/staking).
+	// Payload is the payload of the event (ballot/staking/choice ballot).
 	Payload serializer.Serializable
 	// AdditionalInfo is an additional description text about the event.
 	AdditionalInfo string
@@ -93,6 +113,7 @@ func (e *Event) Deserialize(data []byte, deSeriMode serializer.DeSerializationMo
 			switch ty {
 			case BallotPayloadTypeID:
 			case StakingPayloadTypeID:
+			case ChoiceBallotPayloadTypeID:
 			default:
 				return nil, fmt.Errorf("invalid event payload type ID %d: %w", ty, ErrUnknownPayloadType)
 			}
@@ -206,6 +227,8 @@ func jsonPayloadSelector(ty int) (iotago.JSONSerializable, error) {
 		obj = &jsonBallot{}
 	case StakingPayloadTypeID:
 		obj = &jsonStaking{}
+	case ChoiceBallotPayloadTypeID:
+		obj = &jsonChoiceBallot{}
 	default:
 		return nil, fmt.Errorf("unable to decode payload type from JSON: %w", ErrUnknownPayloadType)
 	}
@@ -222,7 +245,7 @@ type jsonEvent struct {
 	MilestoneIndexStart uint32 `json:"milestoneIndexStart"`
 	// MilestoneIndexEnd is the milestone index the event ends.
 	MilestoneIndexEnd uint32 `json:"milestoneIndexEnd"`
-	// Payload is the payload of the event (ballot/staking).
+	// Payload is the payload of the event (ballot/staking/choice ballot).
 	Payload *json.RawMessage `json:"payload"`
 	// AdditionalInfo is an additional description text about the event.
 	AdditionalInfo string `json:"additionalInfo"`
@@ -262,6 +285,8 @@ func (e *Event) payloadType() uint32 {
 		return BallotPayloadTypeID
 	case *Staking:
 		return StakingPayloadTypeID
+	case *ChoiceBallot:
+		return ChoiceBallotPayloadTypeID
 	default:
 		panic(ErrUnknownPayloadType)
 	}
@@ -277,18 +302,39 @@ func (e *Event) Ballot() *Ballot {
 	}
 }
 
-// BallotQuestions returns the questions contained in the Ballot payload if this participation contains a Ballot.
+// ChoiceBallot returns the ChoiceBallot payload if this participation is for a ChoiceBallot event.
+func (e *Event) ChoiceBallot() *ChoiceBallot {
+	switch payload := e.Payload.(type) {
+	case *ChoiceBallot:
+		return payload
+	default:
+		return nil
+	}
+}
+
+// BallotQuestions returns the questions contained in the Ballot or ChoiceBallot payload if this participation contains a Ballot or ChoiceBallot.
 func (e *Event) BallotQuestions() []*Question {
+	var questions serializer.Serializables
 	switch payload := e.Payload.(type) {
 	case *Ballot:
-		questions := make([]*Question, len(payload.Questions))
-		for i := range payload.Questions {
-			questions[i] = payload.Questions[i].(*Question)
-		}
-		return questions
+		questions = payload.Questions
+	case *ChoiceBallot:
+		questions = payload.Questions
 	default:
 		return nil
 	}
+
+	result := make([]*Question, len(questions))
+	for i := range questions {
+		result[i] = questions[i].(*Question)
+	}
+	return result
+}
+
+// IsRankedChoiceBallot returns true if the event is a ChoiceBallot with ranked choices.
+func (e *Event) IsRankedChoiceBallot() bool {
+	choiceBallot := e.ChoiceBallot()
+	return choiceBallot != nil && choiceBallot.Mode == ChoiceBallotModeRanked
 }
 
 // Staking returns the staking payload if this participation is for a Staking event.
@@ -350,10 +396,9 @@ func (e *Event) IsCountingParticipation(atIndex milestone.Index) bool {
 	return atIndex >= e.StartMilestoneIndex() && atIndex < e.EndMilestoneIndex()
 }
 
-// BallotCanOverflow returns whether a Ballot event can overflow.
+// BallotCanOverflow returns whether a Ballot or ChoiceBallot event can overflow.
 func (e *Event) BallotCanOverflow() bool {
-	ballot := e.Ballot()
-	if ballot == nil {
+	if e.Ballot() == nil && e.ChoiceBallot() == nil {
 		return false
 	}
 
@@ -0,0 +1,138 @@
+package participation
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// RoundAnswerStatus holds the votes of a continuing answer in an instant runoff round.
+type RoundAnswerStatus struct {
+	// Value is the value that identifies this answer.
+	Value uint8 `json:"value"`
+	// Votes is the accumulated voting weight of the rankings that prefer this answer among the continuing answers.
+	Votes uint64 `json:"votes"`
+}
+
+// RoundStatus holds the result of a single instant runoff round of a ranked choice question.
+type RoundStatus struct {
+	// Answers holds the votes of the answers that were continuing in this round.
+	Answers []*RoundAnswerStatus `json:"answers"`
+	// Exhausted is the accumulated voting weight of the rankings that do not contain any continuing answer anymore.
+	Exhausted uint64 `json:"exhausted"`
+	// Eliminated holds the answer values that were eliminated after this round.
+	Eliminated []uint8 `json:"eliminated,omitempty"`
+	// Winner is the answer value that reached the majority of the continuing votes in this round.
+	Winner *uint8 `json:"winner,omitempty"`
+}
+
+// instantRunoffRounds computes the instant runoff rounds for the given answer values and accumulated ranking balances.
+// In every round the answer with a strict majority of the continuing votes wins, otherwise all answers with the
+// fewest votes are eliminated. The rounds stop without a winner if there are no continuing votes or all remaining answers are tied.
+func instantRunoffRounds(answerValues []uint8, rankingBalances map[string]uint64) []*RoundStatus {
+
+	continuing := make(map[uint8]struct{}, len(answerValues))
+	for _, answerValue := range answerValues {
+		continuing[answerValue] = struct{}{}
+	}
+
+	var rounds []*RoundStatus
+	for len(continuing) > 0 {
+		round := &RoundStatus{}
+
+		votes := make(map[uint8]uint64, len(continuing))
+		for ranking, balance := range rankingBalances {
+			exhausted := true
+			for _, answerValue := range []byte(ranking) {
+				if _, ok := continuing[answerValue]; ok {
+					votes[answerValue] += balance
+					exhausted = false
+					break
+				}
+			}
+			if exhausted {
+				round.Exhausted += balance
+			}
+		}
+
+		var total uint64
+		for _, answerValue := range answerValues {
+			if _, ok := continuing[answerValue]; !ok {
+				continue
+			}
+			round.Answers = append(round.Answers, &RoundAnswerStatus{Value: answerValue, Votes: votes[answerValue]})
+			total += votes[answerValue]
+		}
+		rounds = append(rounds, round)
+
+		if total == 0 {
+			break
+		}
+
+		var fewestVotes uint64
+		for i, answer := range round.Answers {
+			if answer.Votes > total/2 {
+				winner := answer.Value
+				round.Winner = &winner
+				return rounds
+			}
+			if i == 0 || answer.Votes < fewestVotes {
+				fewestVotes = answer.Votes
+			}
+		}
+
+		for _, answer := range round.Answers {
+			if answer.Votes == fewestVotes {
+				round.Eliminated = append(round.Eliminated, answer.Value)
+			}
+		}
+
+		if len(round.Eliminated) == len(round.Answers) {
+			// all remaining answers are tied, so there is no winner
+			round.Eliminated = nil
+			break
+		}
+
+		for _, answerValue := range round.Eliminated {
+			delete(continuing, answerValue)
+		}
+	}
+
+	return rounds
+}
+
+// writeRounds writes the deterministic representation of the given rounds to w.
+func writeRounds(w io.Writer, rounds []*RoundStatus) error {
+	for roundIndex, round := range rounds {
+		if err := binary.Write(w, binary.LittleEndian, uint8(roundIndex)); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.LittleEndian, uint8(len(round.Answers))); err != nil {
+			return err
+		}
+		for _, answer := range round.Answers {
+			if err := binary.Write(w, binary.LittleEndian, answer.Value); err != nil {
+				return err
+			}
+			if err := binary.Write(w, binary.LittleEndian, answer.Votes); err != nil {
+				return err
+			}
+		}
+		if err := binary.Write(w, binary.LittleEndian, round.Exhausted); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.LittleEndian, uint8(len(round.Eliminated))); err != nil {
+			return err
+		}
+		if _, err := w.Write(round.Eliminated); err != nil {
+			return err
+		}
+		winner := []byte{0, 0}
+		if round.Winner != nil {
+			winner = []byte{1, *round.Winner}
+		}
+		if _, err := w.Write(winner); err != nil {
+			return err
+		}
+	}
+	return nil
+}
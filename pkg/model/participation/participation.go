@@ -23,6 +23,7 @@ type Participation struct {
 	// EventID is the ID of the event the participation is made for.
 	EventID EventID
 	// Answers holds the IDs of the answers to the questions of the ballot.
+	// For a ChoiceBallot the answers are encoded per question, see EncodeChoices.
 	Answers []byte
 }
 
@@ -36,9 +37,7 @@ func (p *Participation) Deserialize(data []byte, deSeriMode serializer.DeSeriali
 		}).
 		AbortIf(func(err error) error {
 			if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
-				if len(p.Answers) > BallotMaxQuestionsCount {
-					return ErrParticipationTooManyAnswers
-				}
+				return p.validateAnswersLength()
 			}
 			return nil
 		}).
@@ -49,9 +48,7 @@ func (p *Participation) Serialize(deSeriMode serializer.DeSerializationMode) ([]
 	return serializer.NewSerializer().
 		AbortIf(func(err error) error {
 			if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
-				if len(p.Answers) > BallotMaxQuestionsCount {
-					return ErrParticipationTooManyAnswers
-				}
+				return p.validateAnswersLength()
 			}
 			return nil
 		}).
@@ -64,6 +61,25 @@ func (p *Participation) Serialize(deSeriMode serializer.DeSerializationMode) ([]
 		Serialize()
 }
 
+// validateAnswersLength checks that the answers do not exceed the answers a ballot can hold.
+// Only the answers of a participation for a ChoiceBallot can be longer than BallotMaxQuestionsCount,
+// if they contain well-formed encoded choices.
+func (p *Participation) validateAnswersLength() error {
+	if len(p.Answers) <= BallotMaxQuestionsCount {
+		return nil
+	}
+
+	if len(p.Answers) > ChoiceParticipationAnswersMaxLength {
+		return ErrParticipationTooManyAnswers
+	}
+
+	if _, err := decodeChoices(p.Answers); err != nil {
+		return fmt.Errorf("%w: %s", ErrParticipationTooManyAnswers, err)
+	}
+
+	return nil
+}
+
 func (p *Participation) MarshalJSON() ([]byte, error) {
 	j := &jsonParticipation{}
 	j.EventID = hex.EncodeToString(p.EventID[:])
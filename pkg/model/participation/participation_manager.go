@@ -14,6 +14,7 @@ import (
 	"github.com/iotaledger/hive.go/serializer"
 	"github.com/iotaledger/hive.go/syncutils"
 	iotago "github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
 )
 
 var (
@@ -53,6 +54,10 @@ var defaultOptions = []Option{
 type Options struct {
 	// defines the indexation payload to track
 	indexationMessage []byte
+	// defines the indexation of the event registry messages
+	registryIndexation []byte
+	// the public keys that are allowed to sign event announcements of the registry
+	registryPublicKeys []ed25519.PublicKey
 }
 
 // applies the given Option.
@@ -69,6 +74,15 @@ func WithIndexationMessage(indexationMessage string) Option {
 	}
 }
 
+// WithRegistry defines the indexation of the event registry messages and the public keys that are allowed to sign event announcements.
+// Events announced in the registry are imported by ImportRegistryEvents.
+func WithRegistry(indexation string, publicKeys []ed25519.PublicKey) Option {
+	return func(opts *Options) {
+		opts.registryIndexation = []byte(indexation)
+		opts.registryPublicKeys = publicKeys
+	}
+}
+
 // Option is a function setting a ParticipationManager option.
 type Option func(opts *Options)
 
@@ -407,7 +421,7 @@ func (pm *ParticipationManager) applyNewUTXOForEvents(index milestone.Index, new
 		}
 
 		switch event.payloadType() {
-		case BallotPayloadTypeID:
+		case BallotPayloadTypeID, ChoiceBallotPayloadTypeID:
 			// Count the new ballot votes by increasing the current vote balance
 			if err := pm.startCountingBallotAnswers(event, participation, index, depositOutput.Amount(), mutations); err != nil {
 				mutations.Cancel()
@@ -499,7 +513,7 @@ func (pm *ParticipationManager) applySpentUTXOForEvents(index milestone.Index, s
 		}
 
 		switch event.payloadType() {
-		case BallotPayloadTypeID:
+		case BallotPayloadTypeID, ChoiceBallotPayloadTypeID:
 			// Count the spent votes by decreasing the current vote balance
 			if err := pm.stopCountingBallotAnswers(event, participation, index, spent.Output().Amount(), mutations); err != nil {
 				mutations.Cancel()
@@ -593,6 +607,20 @@ func (pm *ParticipationManager) applyNewConfirmedMilestoneIndexForEvents(index m
 			if err := processAnswerValueBalances(questionIndex, AnswerValueInvalid); err != nil {
 				return err
 			}
+
+			if event.IsRankedChoiceBallot() {
+				// Tally the instant runoff rounds with the accumulated rankings of this milestone
+				rankingBalances, err := pm.AccumulatedBallotRankingBalances(eventID, index, questionIndex)
+				if err != nil {
+					mutations.Cancel()
+					return err
+				}
+
+				if err := setBallotRounds(eventID, index, questionIndex, instantRunoffRounds(question.answerValues(), rankingBalances), mutations); err != nil {
+					mutations.Cancel()
+					return err
+				}
+			}
 		}
 
 		staking := event.Staking()
@@ -684,8 +712,8 @@ func filterValidParticipationsForEvents(index milestone.Index, votes []*Particip
 			continue
 		}
 
-		// Check that the amount of answers equals the questions in the ballot
-		if len(vote.Answers) != len(event.BallotQuestions()) {
+		// Check that the answers match the questions in the ballot
+		if _, err := event.questionChoicesForParticipation(vote.Answers); err != nil {
 			continue
 		}
 
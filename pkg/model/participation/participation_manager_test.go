@@ -1,6 +1,7 @@
 package participation_test
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -10,9 +11,11 @@ import (
 	"github.com/gohornet/hornet/pkg/model/participation"
 	"github.com/gohornet/hornet/pkg/model/participation/test"
 	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/testsuite/utils"
 	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/iotaledger/hive.go/serializer"
 	iotago "github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
 )
 
 func TestEventStateHelpers(t *testing.T) {
@@ -1333,6 +1336,392 @@ func TestStakingRewardsCalculatedAfterEventEnded(t *testing.T) {
 	env.AssertRewardBalance(eventID, env.Wallet2.Address(), 1_984_410)
 	env.AssertRewardBalance(eventID, env.Wallet3.Address(), 6_987_470)
 	env.AssertRewardBalance(eventID, env.Wallet4.Address(), 75_000_000)
+
+	export, err := env.ExportResults(eventID)
+	require.NoError(t, err)
+	require.Equal(t, milestone.Index(12), export.MilestoneIndex)
+	require.Empty(t, export.Questions)
+	require.Equal(t, "APUG", export.Staking.Symbol)
+	require.Equal(t, uint64(6_250_000+1_984_410+6_987_470+75_000_000), export.Staking.Rewarded)
+	require.Equal(t, uint64(6_250_000+1_984_410+6_987_470+75_000_000), export.TotalRewards)
+	require.Len(t, export.Rewards, 4)
+	for i := 1; i < len(export.Rewards); i++ {
+		require.Less(t, export.Rewards[i-1].Address, export.Rewards[i].Address)
+	}
+	require.NoError(t, export.Verify())
+
+	// tampered rewards
+	export.Rewards[0].Amount++
+	export.TotalRewards++
+	require.ErrorIs(t, export.Verify(), participation.ErrInvalidResultsExport)
+}
+
+func TestExportResults(t *testing.T) {
+	env := test.NewParticipationTestEnv(t, 1_000_000, 150_000_000, 200_000_000, 300_000_000, false)
+	defer env.Cleanup()
+
+	eventID := env.StoreDefaultEvent(5, 2, 3)
+
+	env.IssueDefaultBallotVoteAndMilestone(eventID, env.Wallet1) // 5
+	env.IssueDefaultBallotVoteAndMilestone(eventID, env.Wallet1) // 6
+	env.IssueMilestone()                                         // 7
+	env.IssueMilestone()                                         // 8
+	env.IssueMilestone()                                         // 9
+
+	// the event did not end yet
+	_, err := env.ExportResults(eventID)
+	require.ErrorIs(t, err, participation.ErrEventNotEnded)
+
+	_, err = env.ExportResults(participation.NullEventID)
+	require.ErrorIs(t, err, participation.ErrEventNotFound)
+
+	env.IssueMilestone() // 10
+
+	export, err := env.ExportResults(eventID)
+	require.NoError(t, err)
+	require.Equal(t, milestone.Index(10), export.MilestoneIndex)
+	require.Nil(t, export.Staking)
+	require.Len(t, export.Questions, 1)
+	require.Equal(t, uint64(1_000), export.Questions[0].Answers[0].Current)
+	require.Equal(t, uint64(3_000), export.Questions[0].Answers[0].Accumulated)
+
+	// the results do not change after the event ended, so the hash stays the same
+	env.IssueDefaultBallotVoteAndMilestone(eventID, env.Wallet2) // 11
+
+	exportAfterEnd, err := env.ExportResults(eventID)
+	require.NoError(t, err)
+	require.Equal(t, export, exportAfterEnd)
+	require.Len(t, export.Hash, 64)
+	require.NoError(t, export.Verify())
+}
+
+func TestExportResultsVerification(t *testing.T) {
+	env := test.NewParticipationTestEnv(t, 1_000_000, 150_000_000, 200_000_000, 300_000_000, false)
+	defer env.Cleanup()
+
+	eventID := env.StoreDefaultEvent(5, 2, 3)
+
+	env.IssueDefaultBallotVoteAndMilestone(eventID, env.Wallet1) // 5
+	env.IssueMilestone()                                         // 6
+	env.IssueMilestone()                                         // 7
+	env.IssueMilestone()                                         // 8
+	env.IssueMilestone()                                         // 9
+	env.IssueMilestone()                                         // 10
+
+	exportResults := func() *participation.ResultsExport {
+		export, err := env.ExportResults(eventID)
+		require.NoError(t, err)
+		require.NoError(t, export.Verify())
+		return export
+	}
+
+	// tampered results
+	export := exportResults()
+	export.Questions[0].Answers[0].Accumulated++
+	require.ErrorIs(t, export.Verify(), participation.ErrInvalidResultsExport)
+
+	// tampered milestone index
+	export = exportResults()
+	export.MilestoneIndex++
+	require.ErrorIs(t, export.Verify(), participation.ErrInvalidResultsExport)
+
+	// tampered signature
+	export = exportResults()
+	export.Signature = export.Signature[2:] + export.Signature[:2]
+	require.ErrorIs(t, export.Verify(), participation.ErrInvalidResultsExport)
+
+	// results signed by another key
+	export = exportResults()
+	_, otherPrivateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	export.PublicKey = hex.EncodeToString(otherPrivateKey.Public().(ed25519.PublicKey))
+	require.ErrorIs(t, export.Verify(), participation.ErrInvalidResultsExport)
+}
+
+func TestRankedChoiceBallot(t *testing.T) {
+	env := test.NewParticipationTestEnv(t, 5_000_000, 150_000_000, 200_000_000, 300_000_000, false)
+	defer env.Cleanup()
+
+	eventID := env.StoreChoiceBallotEvent(participation.ChoiceBallotModeRanked, 5, 2, 2)
+
+	env.IssueMilestone() // 5
+	env.IssueMilestone() // 6
+	env.IssueMilestone() // 7
+
+	rankedVote := func(wallet *utils.HDWallet, ranking ...uint8) *test.SentParticipations {
+		return env.NewParticipationHelper(wallet).
+			WholeWalletBalance().
+			AddParticipation(&participation.Participation{
+				EventID: eventID,
+				Answers: participation.EncodeChoices([][]uint8{ranking}),
+			}).
+			Send()
+	}
+
+	wallet1Vote := rankedVote(env.Wallet1, 2, 2)
+	wallet2Vote := rankedVote(env.Wallet2, 1, 2)
+	wallet3Vote := rankedVote(env.Wallet3, 2, 3)
+	wallet4Vote := rankedVote(env.Wallet4, 3, 1)
+
+	env.IssueMilestone(wallet1Vote.Message().StoredMessageID(), wallet2Vote.Message().StoredMessageID(), wallet3Vote.Message().StoredMessageID(), wallet4Vote.Message().StoredMessageID()) // 8
+
+	// the first preference is counted for the answers, duplicate rankings are invalid
+	env.AssertEventParticipationStatus(eventID, 4, 0)
+	env.AssertBallotAnswerStatusAtConfirmedMilestoneIndex(eventID, 150_000, 150_000, 0, 1)
+	env.AssertBallotAnswerStatusAtConfirmedMilestoneIndex(eventID, 200_000, 200_000, 0, 2)
+	env.AssertBallotAnswerStatusAtConfirmedMilestoneIndex(eventID, 300_000, 300_000, 0, 3)
+	env.AssertBallotAnswerStatusAtConfirmedMilestoneIndex(eventID, 5_000, 5_000, 0, participation.AnswerValueInvalid)
+
+	env.IssueMilestone() // 9
+
+	status, err := env.ParticipationManager().EventStatus(eventID)
+	require.NoError(t, err)
+	require.Equal(t, "ended", status.Status)
+	require.Len(t, status.Questions, 1)
+
+	rounds := status.Questions[0].Rounds
+	require.Len(t, rounds, 2)
+
+	// round 1: no majority, "Hornet" has the fewest votes
+	require.Equal(t, []*participation.RoundAnswerStatus{
+		{Value: 1, Votes: 300_000},
+		{Value: 2, Votes: 400_000},
+		{Value: 3, Votes: 600_000},
+	}, rounds[0].Answers)
+	require.Equal(t, []uint8{1}, rounds[0].Eliminated)
+	require.Nil(t, rounds[0].Winner)
+
+	// round 2: the votes for "Hornet" are transferred to "Bee"
+	require.Equal(t, []*participation.RoundAnswerStatus{
+		{Value: 2, Votes: 700_000},
+		{Value: 3, Votes: 600_000},
+	}, rounds[1].Answers)
+	require.Zero(t, rounds[1].Exhausted)
+	require.NotNil(t, rounds[1].Winner)
+	require.Equal(t, uint8(2), *rounds[1].Winner)
+
+	export, err := env.ExportResults(eventID)
+	require.NoError(t, err)
+	require.Equal(t, rounds, export.Questions[0].Rounds)
+
+	// the rounds tallied at previous milestones are kept
+	status, err = env.ParticipationManager().EventStatus(eventID, 8)
+	require.NoError(t, err)
+	rounds = status.Questions[0].Rounds
+	require.Len(t, rounds, 2)
+	require.Equal(t, []*participation.RoundAnswerStatus{
+		{Value: 1, Votes: 150_000},
+		{Value: 2, Votes: 200_000},
+		{Value: 3, Votes: 300_000},
+	}, rounds[0].Answers)
+	require.Equal(t, []*participation.RoundAnswerStatus{
+		{Value: 2, Votes: 350_000},
+		{Value: 3, Votes: 300_000},
+	}, rounds[1].Answers)
+	require.Equal(t, uint8(2), *rounds[1].Winner)
+}
+
+func TestRankedChoiceBallotExhaustedRankings(t *testing.T) {
+	env := test.NewParticipationTestEnv(t, 5_000_000, 150_000_000, 200_000_000, 300_000_000, false)
+	defer env.Cleanup()
+
+	eventID := env.StoreChoiceBallotEvent(participation.ChoiceBallotModeRanked, 5, 2, 1)
+
+	env.IssueMilestone() // 5
+	env.IssueMilestone() // 6
+	env.IssueMilestone() // 7
+
+	var messageIDs hornet.MessageIDs
+	for _, vote := range []struct {
+		wallet  *utils.HDWallet
+		ranking []uint8
+	}{
+		{env.Wallet2, []uint8{1}},
+		{env.Wallet3, []uint8{2, 1}},
+		{env.Wallet4, []uint8{3}},
+	} {
+		sent := env.NewParticipationHelper(vote.wallet).
+			WholeWalletBalance().
+			AddParticipation(&participation.Participation{
+				EventID: eventID,
+				Answers: participation.EncodeChoices([][]uint8{vote.ranking}),
+			}).
+			Send()
+		messageIDs = append(messageIDs, sent.Message().StoredMessageID())
+	}
+
+	env.IssueMilestone(messageIDs...) // 8
+
+	status, err := env.ParticipationManager().EventStatus(eventID)
+	require.NoError(t, err)
+
+	rounds := status.Questions[0].Rounds
+	require.Len(t, rounds, 2)
+
+	// round 1: "Hornet" has the fewest votes
+	require.Zero(t, rounds[0].Exhausted)
+	require.Equal(t, []uint8{1}, rounds[0].Eliminated)
+
+	// round 2: the ranking of wallet 2 is exhausted, so "Wasp" has the majority of the continuing votes
+	require.Equal(t, []*participation.RoundAnswerStatus{
+		{Value: 2, Votes: 200_000},
+		{Value: 3, Votes: 300_000},
+	}, rounds[1].Answers)
+	require.Equal(t, uint64(150_000), rounds[1].Exhausted)
+	require.NotNil(t, rounds[1].Winner)
+	require.Equal(t, uint8(3), *rounds[1].Winner)
+}
+
+func TestRankedChoiceBallotTie(t *testing.T) {
+	env := test.NewParticipationTestEnv(t, 5_000_000, 150_000_000, 150_000_000, 300_000_000, false)
+	defer env.Cleanup()
+
+	eventID := env.StoreChoiceBallotEvent(participation.ChoiceBallotModeRanked, 5, 2, 1)
+
+	env.IssueMilestone() // 5
+	env.IssueMilestone() // 6
+	env.IssueMilestone() // 7
+
+	var messageIDs hornet.MessageIDs
+	for _, vote := range []struct {
+		wallet  *utils.HDWallet
+		ranking []uint8
+	}{
+		{env.Wallet2, []uint8{1}},
+		{env.Wallet3, []uint8{2}},
+	} {
+		sent := env.NewParticipationHelper(vote.wallet).
+			WholeWalletBalance().
+			AddParticipation(&participation.Participation{
+				EventID: eventID,
+				Answers: participation.EncodeChoices([][]uint8{vote.ranking}),
+			}).
+			Send()
+		messageIDs = append(messageIDs, sent.Message().StoredMessageID())
+	}
+
+	env.IssueMilestone(messageIDs...) // 8
+
+	status, err := env.ParticipationManager().EventStatus(eventID)
+	require.NoError(t, err)
+
+	rounds := status.Questions[0].Rounds
+	require.Len(t, rounds, 2)
+
+	// round 1: "Wasp" did not receive any votes
+	require.Equal(t, []uint8{3}, rounds[0].Eliminated)
+
+	// round 2: "Hornet" and "Bee" are tied, so there is no winner
+	require.Nil(t, rounds[1].Eliminated)
+	require.Nil(t, rounds[1].Winner)
+}
+
+func TestRankedChoiceBallotAccumulatedRankings(t *testing.T) {
+	env := test.NewParticipationTestEnv(t, 5_000_000, 150_000_000, 200_000_000, 300_000_000, false)
+	defer env.Cleanup()
+
+	eventID := env.StoreChoiceBallotEvent(participation.ChoiceBallotModeRanked, 5, 2, 4)
+
+	env.IssueMilestone() // 5
+	env.IssueMilestone() // 6
+
+	rankedVote := func(wallet *utils.HDWallet, ranking ...uint8) *test.SentParticipations {
+		return env.NewParticipationHelper(wallet).
+			WholeWalletBalance().
+			AddParticipation(&participation.Participation{
+				EventID: eventID,
+				Answers: participation.EncodeChoices([][]uint8{ranking}),
+			}).
+			Send()
+	}
+
+	wallet1Vote := rankedVote(env.Wallet1, 1, 2)
+	env.IssueMilestone(wallet1Vote.Message().StoredMessageID()) // 7
+
+	wallet2Vote := rankedVote(env.Wallet2, 1, 2)
+	env.IssueMilestone(wallet2Vote.Message().StoredMessageID()) // 8
+
+	wallet3Vote := rankedVote(env.Wallet3, 2, 3)
+	env.IssueMilestone(wallet3Vote.Message().StoredMessageID()) // 9
+
+	cancelVote := env.CancelParticipations(env.Wallet1)
+	env.IssueMilestone(cancelVote.StoredMessageID()) // 10
+
+	env.IssueMilestone() // 11
+	env.IssueMilestone() // 12
+
+	ranking12 := string([]byte{1, 2})
+	ranking23 := string([]byte{2, 3})
+
+	// the rankings are only accumulated while the participations are counted (milestone 8 to 11)
+	for _, expected := range []struct {
+		index    milestone.Index
+		balances map[string]uint64
+	}{
+		{7, map[string]uint64{}},
+		{8, map[string]uint64{ranking12: 155_000}},
+		{9, map[string]uint64{ranking12: 310_000, ranking23: 200_000}},
+		{10, map[string]uint64{ranking12: 460_000, ranking23: 400_000}},
+		{11, map[string]uint64{ranking12: 610_000, ranking23: 600_000}},
+		{12, map[string]uint64{ranking12: 610_000, ranking23: 600_000}},
+	} {
+		balances, err := env.ParticipationManager().AccumulatedBallotRankingBalances(eventID, expected.index, 0)
+		require.NoError(t, err)
+		require.Equal(t, expected.balances, balances, "milestone %d", expected.index)
+	}
+
+	// the first preferences of the rankings match the accumulated answers
+	env.AssertBallotAnswerStatus(eventID, 11, 150_000, 610_000, 0, 1)
+	env.AssertBallotAnswerStatus(eventID, 11, 200_000, 600_000, 0, 2)
+}
+
+func TestApprovalChoiceBallot(t *testing.T) {
+	env := test.NewParticipationTestEnv(t, 5_000_000, 150_000_000, 200_000_000, 300_000_000, false)
+	defer env.Cleanup()
+
+	eventID := env.StoreChoiceBallotEvent(participation.ChoiceBallotModeApproval, 5, 2, 2)
+
+	env.IssueMilestone() // 5
+	env.IssueMilestone() // 6
+	env.IssueMilestone() // 7
+
+	approvalVote := func(wallet *utils.HDWallet, answers []byte) *test.SentParticipations {
+		return env.NewParticipationHelper(wallet).
+			WholeWalletBalance().
+			AddParticipation(&participation.Participation{
+				EventID: eventID,
+				Answers: answers,
+			}).
+			Send()
+	}
+
+	wallet1Vote := approvalVote(env.Wallet1, []byte{3, 1})
+	wallet2Vote := approvalVote(env.Wallet2, participation.EncodeChoices([][]uint8{{1, 2}}))
+	wallet3Vote := approvalVote(env.Wallet3, participation.EncodeChoices([][]uint8{{2}}))
+	wallet4Vote := approvalVote(env.Wallet4, participation.EncodeChoices([][]uint8{{}}))
+
+	env.IssueMilestone(wallet1Vote.Message().StoredMessageID(), wallet2Vote.Message().StoredMessageID(), wallet3Vote.Message().StoredMessageID(), wallet4Vote.Message().StoredMessageID()) // 8
+
+	// the malformed choices of wallet 1 are not counted
+	env.AssertInvalidParticipation(eventID, wallet1Vote)
+	env.AssertEventParticipationStatus(eventID, 3, 0)
+
+	// every approved answer receives the full voting weight
+	env.AssertBallotAnswerStatusAtConfirmedMilestoneIndex(eventID, 150_000, 150_000, 0, 1)
+	env.AssertBallotAnswerStatusAtConfirmedMilestoneIndex(eventID, 350_000, 350_000, 0, 2)
+	env.AssertBallotAnswerStatusAtConfirmedMilestoneIndex(eventID, 0, 0, 0, 3)
+	env.AssertBallotAnswerStatusAtConfirmedMilestoneIndex(eventID, 300_000, 300_000, 0, participation.AnswerValueSkipped)
+
+	cancelParticipation := env.CancelParticipations(env.Wallet2)
+	env.IssueMilestone(cancelParticipation.StoredMessageID()) // 9
+
+	env.AssertEventParticipationStatus(eventID, 0, 3)
+	env.AssertBallotAnswerStatusAtConfirmedMilestoneIndex(eventID, 0, 150_000, 0, 1)
+	env.AssertBallotAnswerStatusAtConfirmedMilestoneIndex(eventID, 200_000, 550_000, 0, 2)
+
+	status, err := env.ParticipationManager().EventStatus(eventID)
+	require.NoError(t, err)
+	require.Nil(t, status.Questions[0].Rounds)
 }
 
 func TestMultipleParticipationsAreNotCounted(t *testing.T) {
@@ -1,6 +1,9 @@
 package participation
 
 import (
+	"bytes"
+	"sort"
+
 	"github.com/pkg/errors"
 
 	"github.com/gohornet/hornet/pkg/model/hornet"
@@ -442,21 +445,40 @@ func setAccumulatedBallotVoteBalanceForQuestionAndAnswer(eventID EventID, milest
 }
 
 func (pm *ParticipationManager) startCountingBallotAnswers(event *Event, vote *Participation, milestone milestone.Index, amount uint64, mutations kvstore.BatchedMutations) error {
-	questions := event.BallotQuestions()
-	for idx, answerByte := range vote.Answers {
+	// We already verified, that the answers match the questions in the ballot, so this should not fail
+	choices, err := event.questionChoicesForParticipation(vote.Answers)
+	if err != nil {
+		return err
+	}
+
+	voteCount := amount / BallotDenominator
+
+	for idx, questionChoices := range choices {
 		questionIndex := uint8(idx)
-		// We already verified, that there are exactly as many answers as questions in the ballot, so no need to check here again
-		answerValue := questions[idx].answerValueForByte(answerByte)
 
-		currentVoteBalance, err := pm.CurrentBallotVoteBalanceForQuestionAndAnswer(vote.EventID, milestone, questionIndex, answerValue)
+		for _, answerValue := range questionChoices.answerValues {
+			currentVoteBalance, err := pm.CurrentBallotVoteBalanceForQuestionAndAnswer(vote.EventID, milestone, questionIndex, answerValue)
+			if err != nil {
+				return err
+			}
+
+			currentVoteBalance += voteCount
+
+			if err := setCurrentBallotVoteBalanceForQuestionAndAnswer(vote.EventID, milestone, questionIndex, answerValue, currentVoteBalance, mutations); err != nil {
+				return err
+			}
+		}
+
+		if questionChoices.ranking == nil {
+			continue
+		}
+
+		currentRankingBalance, err := pm.currentBallotRankingBalance(vote.EventID, milestone, questionIndex, questionChoices.ranking)
 		if err != nil {
 			return err
 		}
 
-		voteCount := amount / BallotDenominator
-		currentVoteBalance += voteCount
-
-		if err := setCurrentBallotVoteBalanceForQuestionAndAnswer(vote.EventID, milestone, questionIndex, answerValue, currentVoteBalance, mutations); err != nil {
+		if err := setCurrentBallotRankingBalance(vote.EventID, milestone, questionIndex, questionChoices.ranking, currentRankingBalance+voteCount, mutations); err != nil {
 			return err
 		}
 	}
@@ -464,31 +486,344 @@ func (pm *ParticipationManager) startCountingBallotAnswers(event *Event, vote *P
 }
 
 func (pm *ParticipationManager) stopCountingBallotAnswers(event *Event, vote *Participation, milestone milestone.Index, amount uint64, mutations kvstore.BatchedMutations) error {
-	questions := event.BallotQuestions()
-	for idx, answerByte := range vote.Answers {
+	// We already verified, that the answers match the questions in the ballot, so this should not fail
+	choices, err := event.questionChoicesForParticipation(vote.Answers)
+	if err != nil {
+		return err
+	}
+
+	voteCount := amount / BallotDenominator
+
+	for idx, questionChoices := range choices {
 		questionIndex := uint8(idx)
-		// We already verified, that there are exactly as many answers as questions in the ballot, so no need to check here again
-		answerValue := questions[idx].answerValueForByte(answerByte)
 
-		currentVoteBalance, err := pm.CurrentBallotVoteBalanceForQuestionAndAnswer(vote.EventID, milestone, questionIndex, answerValue)
+		for _, answerValue := range questionChoices.answerValues {
+			currentVoteBalance, err := pm.CurrentBallotVoteBalanceForQuestionAndAnswer(vote.EventID, milestone, questionIndex, answerValue)
+			if err != nil {
+				return err
+			}
+
+			if currentVoteBalance < voteCount {
+				// currentVoteBalance can't be less than 0
+				return ErrInvalidCurrentBallotVoteBalance
+			}
+			currentVoteBalance -= voteCount
+
+			if err := setCurrentBallotVoteBalanceForQuestionAndAnswer(vote.EventID, milestone, questionIndex, answerValue, currentVoteBalance, mutations); err != nil {
+				return err
+			}
+		}
+
+		if questionChoices.ranking == nil {
+			continue
+		}
+
+		currentRankingBalance, err := pm.currentBallotRankingBalance(vote.EventID, milestone, questionIndex, questionChoices.ranking)
 		if err != nil {
 			return err
 		}
 
-		voteCount := amount / BallotDenominator
-		if currentVoteBalance < voteCount {
-			// currentVoteBalance can't be less than 0
+		if currentRankingBalance < voteCount {
+			// currentRankingBalance can't be less than 0
 			return ErrInvalidCurrentBallotVoteBalance
 		}
-		currentVoteBalance -= voteCount
 
-		if err := setCurrentBallotVoteBalanceForQuestionAndAnswer(vote.EventID, milestone, questionIndex, answerValue, currentVoteBalance, mutations); err != nil {
+		if err := setCurrentBallotRankingBalance(vote.EventID, milestone, questionIndex, questionChoices.ranking, currentRankingBalance-voteCount, mutations); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// Ranked choice ballot rankings
+// The balance of a ranking is only stored for the milestones at which it changes,
+// the accumulated balances are derived from these changes.
+
+func ballotRankingBalanceKeyPrefix(eventID EventID) []byte {
+	m := marshalutil.New(33)
+	m.WriteByte(ParticipationStoreKeyPrefixBallotRankingBalanceForQuestion) // 1 byte
+	m.WriteBytes(eventID[:])                                                // 32 bytes
+	return m.Bytes()
+}
+
+func ballotRankingBalanceKeyPrefixForQuestion(eventID EventID, questionIndex uint8) []byte {
+	m := marshalutil.New(34)
+	m.WriteBytes(ballotRankingBalanceKeyPrefix(eventID)) // 33 bytes
+	m.WriteUint8(questionIndex)                          // 1 byte
+	return m.Bytes()
+}
+
+func ballotRankingBalanceKeyPrefixForRanking(eventID EventID, questionIndex uint8, ranking []byte) []byte {
+	m := marshalutil.New(35 + len(ranking))
+	m.WriteBytes(ballotRankingBalanceKeyPrefixForQuestion(eventID, questionIndex)) // 34 bytes
+	m.WriteUint8(uint8(len(ranking)))                                              // 1 byte
+	m.WriteBytes(ranking)                                                          // up to QuestionMaxAnswersCount bytes
+	return m.Bytes()
+}
+
+func ballotRankingBalanceKey(eventID EventID, questionIndex uint8, ranking []byte, milestone milestone.Index) []byte {
+	m := marshalutil.New(39 + len(ranking))
+	m.WriteBytes(ballotRankingBalanceKeyPrefixForRanking(eventID, questionIndex, ranking)) // 35 bytes + ranking
+	m.WriteUint32(uint32(milestone))                                                       // 4 bytes
+	return m.Bytes()
+}
+
+// rankingBalanceChange holds the balance of a ranking from the given milestone on.
+type rankingBalanceChange struct {
+	milestone milestone.Index
+	balance   uint64
+}
+
+// ballotRankingBalanceChanges returns the balance changes of all rankings of a question, keyed by the ranking and sorted by milestone.
+func (pm *ParticipationManager) ballotRankingBalanceChanges(eventID EventID, questionIdx uint8) (map[string][]*rankingBalanceChange, error) {
+	prefix := ballotRankingBalanceKeyPrefixForQuestion(eventID, questionIdx)
+
+	changes := make(map[string][]*rankingBalanceChange)
+	var innerErr error
+	if err := pm.participationStore.Iterate(prefix, func(key kvstore.Key, value kvstore.Value) bool {
+		m := marshalutil.New(key[len(prefix):])
+
+		rankingLength, err := m.ReadUint8()
+		if err != nil {
+			innerErr = err
+			return false
+		}
+		ranking, err := m.ReadBytes(int(rankingLength))
+		if err != nil {
+			innerErr = err
+			return false
+		}
+		msIndex, err := m.ReadUint32()
+		if err != nil {
+			innerErr = err
+			return false
+		}
+		balance, err := marshalutil.New(value).ReadUint64()
+		if err != nil {
+			innerErr = err
+			return false
+		}
+
+		changes[string(ranking)] = append(changes[string(ranking)], &rankingBalanceChange{milestone: milestone.Index(msIndex), balance: balance})
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	for _, rankingChanges := range changes {
+		sort.Slice(rankingChanges, func(i, j int) bool {
+			return rankingChanges[i].milestone < rankingChanges[j].milestone
+		})
+	}
+
+	return changes, nil
+}
+
+// currentBallotRankingBalance returns the balance of the ranking of a question at the given milestone.
+func (pm *ParticipationManager) currentBallotRankingBalance(eventID EventID, index milestone.Index, questionIdx uint8, ranking []byte) (uint64, error) {
+	prefix := ballotRankingBalanceKeyPrefixForRanking(eventID, questionIdx, ranking)
+
+	var balance uint64
+	var latestChange milestone.Index
+	var innerErr error
+	if err := pm.participationStore.Iterate(prefix, func(key kvstore.Key, value kvstore.Value) bool {
+		msIndex, err := marshalutil.New(key[len(prefix):]).ReadUint32()
+		if err != nil {
+			innerErr = err
+			return false
+		}
+
+		changeIndex := milestone.Index(msIndex)
+		if changeIndex > index || changeIndex < latestChange {
+			// the change is not the latest change at the given milestone
+			return true
+		}
+
+		if balance, err = marshalutil.New(value).ReadUint64(); err != nil {
+			innerErr = err
+			return false
+		}
+		latestChange = changeIndex
+		return true
+	}); err != nil {
+		return 0, err
+	}
+
+	return balance, innerErr
+}
+
+func setCurrentBallotRankingBalance(eventID EventID, milestone milestone.Index, questionIdx uint8, ranking []byte, current uint64, mutations kvstore.BatchedMutations) error {
+	ms := marshalutil.New(8)
+	ms.WriteUint64(current)
+	return mutations.Set(ballotRankingBalanceKey(eventID, questionIdx, ranking, milestone), ms.Bytes())
+}
+
+// AccumulatedBallotRankingBalances returns the accumulated balances of all rankings of a ranked choice question at the given milestone, keyed by the ranking.
+// The balance of a ranking is accumulated for every milestone in which the participations are counted.
+func (pm *ParticipationManager) AccumulatedBallotRankingBalances(eventID EventID, index milestone.Index, questionIdx uint8) (map[string]uint64, error) {
+	event := pm.Event(eventID)
+	if event == nil {
+		return nil, ErrEventNotFound
+	}
+
+	changes, err := pm.ballotRankingBalanceChanges(eventID, questionIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the participations are counted from the milestone after the start until the end of the event
+	lastCountedIndex := index
+	if lastCountedIndex > event.EndMilestoneIndex() {
+		lastCountedIndex = event.EndMilestoneIndex()
+	}
+
+	balances := make(map[string]uint64)
+	for ranking, rankingChanges := range changes {
+		var accumulated uint64
+		for i, change := range rankingChanges {
+			from := change.milestone
+			if from <= event.StartMilestoneIndex() {
+				from = event.StartMilestoneIndex() + 1
+			}
+
+			to := lastCountedIndex
+			if i+1 < len(rankingChanges) && rankingChanges[i+1].milestone <= to {
+				to = rankingChanges[i+1].milestone - 1
+			}
+
+			if from > to {
+				continue
+			}
+			accumulated += change.balance * uint64(to-from+1)
+		}
+
+		if accumulated > 0 {
+			balances[ranking] = accumulated
+		}
+	}
+
+	return balances, nil
+}
+
+// Ranked choice ballot rounds
+// The instant runoff rounds of a ranked choice question are tallied and stored for every milestone in which the event accepts participations.
+
+func ballotRoundsKeyPrefix(eventID EventID) []byte {
+	m := marshalutil.New(33)
+	m.WriteByte(ParticipationStoreKeyPrefixBallotRoundsForQuestion) // 1 byte
+	m.WriteBytes(eventID[:])                                        // 32 bytes
+	return m.Bytes()
+}
+
+func ballotRoundsKeyForQuestion(eventID EventID, milestone milestone.Index, questionIndex uint8) []byte {
+	m := marshalutil.New(38)
+	m.WriteBytes(ballotRoundsKeyPrefix(eventID)) // 33 bytes
+	m.WriteUint32(uint32(milestone))             // 4 bytes
+	m.WriteUint8(questionIndex)                  // 1 byte
+	return m.Bytes()
+}
+
+func roundsBytes(rounds []*RoundStatus) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := buf.WriteByte(uint8(len(rounds))); err != nil {
+		return nil, err
+	}
+	if err := writeRounds(&buf, rounds); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func roundsFromBytes(bytes []byte) ([]*RoundStatus, error) {
+	m := marshalutil.New(bytes)
+
+	roundsCount, err := m.ReadUint8()
+	if err != nil {
+		return nil, err
+	}
+
+	rounds := make([]*RoundStatus, roundsCount)
+	for i := range rounds {
+		// skip the round index
+		if _, err := m.ReadUint8(); err != nil {
+			return nil, err
+		}
+
+		round := &RoundStatus{}
+
+		answersCount, err := m.ReadUint8()
+		if err != nil {
+			return nil, err
+		}
+		for j := 0; j < int(answersCount); j++ {
+			value, err := m.ReadUint8()
+			if err != nil {
+				return nil, err
+			}
+			votes, err := m.ReadUint64()
+			if err != nil {
+				return nil, err
+			}
+			round.Answers = append(round.Answers, &RoundAnswerStatus{Value: value, Votes: votes})
+		}
+
+		if round.Exhausted, err = m.ReadUint64(); err != nil {
+			return nil, err
+		}
+
+		eliminatedCount, err := m.ReadUint8()
+		if err != nil {
+			return nil, err
+		}
+		if eliminatedCount > 0 {
+			if round.Eliminated, err = m.ReadBytes(int(eliminatedCount)); err != nil {
+				return nil, err
+			}
+		}
+
+		hasWinner, err := m.ReadBool()
+		if err != nil {
+			return nil, err
+		}
+		winner, err := m.ReadUint8()
+		if err != nil {
+			return nil, err
+		}
+		if hasWinner {
+			round.Winner = &winner
+		}
+
+		rounds[i] = round
+	}
+
+	return rounds, nil
+}
+
+// ballotRounds returns the instant runoff rounds of a ranked choice question tallied at the given milestone.
+// It returns nil if no rounds were tallied at this milestone.
+func (pm *ParticipationManager) ballotRounds(eventID EventID, milestone milestone.Index, questionIdx uint8) ([]*RoundStatus, error) {
+	value, err := pm.participationStore.Get(ballotRoundsKeyForQuestion(eventID, milestone, questionIdx))
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return roundsFromBytes(value)
+}
+
+func setBallotRounds(eventID EventID, milestone milestone.Index, questionIdx uint8, rounds []*RoundStatus, mutations kvstore.BatchedMutations) error {
+	value, err := roundsBytes(rounds)
+	if err != nil {
+		return err
+	}
+	return mutations.Set(ballotRoundsKeyForQuestion(eventID, milestone, questionIdx), value)
+}
+
 // Staking
 
 func stakingKeyForEventPrefix(eventID EventID) []byte {
@@ -699,6 +1034,12 @@ func (pm *ParticipationManager) clearStorageForEventID(eventID EventID) error {
 	if err := pm.participationStore.DeletePrefix(accumulatedBallotVoteBalanceKeyPrefix(eventID)); err != nil {
 		return err
 	}
+	if err := pm.participationStore.DeletePrefix(ballotRankingBalanceKeyPrefix(eventID)); err != nil {
+		return err
+	}
+	if err := pm.participationStore.DeletePrefix(ballotRoundsKeyPrefix(eventID)); err != nil {
+		return err
+	}
 	if err := pm.participationStore.DeletePrefix(stakingKeyForEventPrefix(eventID)); err != nil {
 		return err
 	}
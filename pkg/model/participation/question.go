@@ -188,6 +188,15 @@ func (q *Question) QuestionAnswers() []*Answer {
 	return answers
 }
 
+// answerValues returns the values of the possible answers for a Question
+func (q *Question) answerValues() []uint8 {
+	values := make([]uint8, len(q.Answers))
+	for i := range q.Answers {
+		values[i] = q.Answers[i].(*Answer).Value
+	}
+	return values
+}
+
 // answerValueForByte checks if the given value is a valid answer and maps any other values to AnswerValueInvalid
 func (q *Question) answerValueForByte(byteValue byte) uint8 {
 	if byteValue == 0 {
@@ -0,0 +1,283 @@
+package participation
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/serializer"
+	iotago "github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+)
+
+var (
+	ErrEventAnnouncementUnknownPublicKey    = errors.New("event announcement is signed by an unknown public key")
+	ErrEventAnnouncementInvalidSignature    = errors.New("event announcement signature is invalid")
+	ErrEventAnnouncementMissingEventPayload = errors.New("event announcement does not contain an event")
+)
+
+// EventAnnouncement is a signed event definition that is published on the tangle in an indexation payload.
+// The signature is computed over the ID of the event.
+type EventAnnouncement struct {
+	// PublicKey is the public key of the registry key that signed the event.
+	PublicKey serializer.ArrayOf32Bytes
+	// Signature is the ed25519 signature of the event ID.
+	Signature serializer.ArrayOf64Bytes
+	// Event is the announced event.
+	Event *Event
+}
+
+// NewEventAnnouncement creates a new EventAnnouncement for the given event and signs it with the given private key.
+func NewEventAnnouncement(event *Event, privateKey ed25519.PrivateKey) (*EventAnnouncement, error) {
+	eventID, err := event.ID()
+	if err != nil {
+		return nil, err
+	}
+
+	announcement := &EventAnnouncement{
+		Event: event,
+	}
+	copy(announcement.PublicKey[:], privateKey.Public().(ed25519.PublicKey))
+	copy(announcement.Signature[:], ed25519.Sign(privateKey, eventID[:]))
+
+	return announcement, nil
+}
+
+// Verify checks that the announcement is signed by one of the given public keys.
+func (a *EventAnnouncement) Verify(publicKeys []ed25519.PublicKey) error {
+	if a.Event == nil {
+		return ErrEventAnnouncementMissingEventPayload
+	}
+
+	var publicKey ed25519.PublicKey
+	for _, key := range publicKeys {
+		if bytes.Equal(key, a.PublicKey[:]) {
+			publicKey = key
+			break
+		}
+	}
+	if publicKey == nil {
+		return ErrEventAnnouncementUnknownPublicKey
+	}
+
+	eventID, err := a.Event.ID()
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(publicKey, eventID[:], a.Signature[:]) {
+		return ErrEventAnnouncementInvalidSignature
+	}
+
+	return nil
+}
+
+func (a *EventAnnouncement) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
+	var eventBytes []byte
+	return serializer.NewDeserializer(data).
+		ReadArrayOf32Bytes(&a.PublicKey, func(err error) error {
+			return fmt.Errorf("unable to deserialize event announcement public key: %w", err)
+		}).
+		ReadArrayOf64Bytes(&a.Signature, func(err error) error {
+			return fmt.Errorf("unable to deserialize event announcement signature: %w", err)
+		}).
+		ReadVariableByteSlice(&eventBytes, serializer.SeriLengthPrefixTypeAsUint16, func(err error) error {
+			return fmt.Errorf("unable to deserialize event announcement event: %w", err)
+		}, iotago.MessageBinSerializedMaxSize).
+		ConsumedAll(func(leftOver int, err error) error {
+			return fmt.Errorf("%w: unable to deserialize event announcement: %d bytes are still available", err, leftOver)
+		}).
+		AbortIf(func(err error) error {
+			event := &Event{}
+			if _, err := event.Deserialize(eventBytes, deSeriMode); err != nil {
+				return fmt.Errorf("unable to deserialize event announcement event: %w", err)
+			}
+			a.Event = event
+			return nil
+		}).
+		Done()
+}
+
+func (a *EventAnnouncement) Serialize(deSeriMode serializer.DeSerializationMode) ([]byte, error) {
+	if a.Event == nil {
+		return nil, ErrEventAnnouncementMissingEventPayload
+	}
+
+	eventBytes, err := a.Event.Serialize(deSeriMode)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize event announcement event: %w", err)
+	}
+
+	return serializer.NewSerializer().
+		WriteBytes(a.PublicKey[:], func(err error) error {
+			return fmt.Errorf("unable to serialize event announcement public key: %w", err)
+		}).
+		WriteBytes(a.Signature[:], func(err error) error {
+			return fmt.Errorf("unable to serialize event announcement signature: %w", err)
+		}).
+		WriteVariableByteSlice(eventBytes, serializer.SeriLengthPrefixTypeAsUint16, func(err error) error {
+			return fmt.Errorf("unable to serialize event announcement event: %w", err)
+		}).
+		Serialize()
+}
+
+// EventAnnouncementRejectedFunc is called for every event announcement of the registry that could not be imported.
+type EventAnnouncementRejectedFunc func(messageID hornet.MessageID, err error)
+
+// RegistryEnabled returns whether the ParticipationManager follows an event registry.
+func (pm *ParticipationManager) RegistryEnabled() bool {
+	return len(pm.opts.registryIndexation) > 0
+}
+
+// ImportRegistryEvents imports the events announced in the registry indexation messages referenced by the given milestone.
+// Announcements that are not signed by one of the registry keys, contain invalid events or events that
+// can not be tracked anymore are passed to onRejected. Already known events are ignored.
+// Past participation of the imported events is calculated the same way as for events added via StoreEvent.
+func (pm *ParticipationManager) ImportRegistryEvents(index milestone.Index, onRejected EventAnnouncementRejectedFunc) ([]EventID, error) {
+	if !pm.RegistryEnabled() {
+		return nil, nil
+	}
+
+	count, exists, err := pm.storage.ReferencedMessagesCount(index)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	referencedMessages, err := pm.storage.ReferencedMessages(index, 0, count)
+	if err != nil {
+		return nil, err
+	}
+
+	var eventIDs []EventID
+	for _, referencedMessage := range referencedMessages {
+		if referencedMessage.InclusionState != storage.LedgerInclusionStateNoTransaction {
+			continue
+		}
+
+		data, err := pm.registryDataFromMessage(referencedMessage.MessageID)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			// not a registry message
+			continue
+		}
+
+		announcement := &EventAnnouncement{}
+		if _, err := announcement.Deserialize(data, serializer.DeSeriModePerformValidation); err != nil {
+			onRejected(referencedMessage.MessageID, err)
+			continue
+		}
+
+		if err := announcement.Verify(pm.opts.registryPublicKeys); err != nil {
+			onRejected(referencedMessage.MessageID, err)
+			continue
+		}
+
+		eventID, err := pm.StoreEvent(announcement.Event)
+		if err != nil {
+			if errors.Is(err, ErrParticipationEventAlreadyExists) {
+				continue
+			}
+			onRejected(referencedMessage.MessageID, err)
+			continue
+		}
+		eventIDs = append(eventIDs, eventID)
+	}
+
+	return eventIDs, nil
+}
+
+// RegistryScannedIndex returns the last confirmed milestone index that was searched for event announcements of the registry.
+// It returns false if the registry was never searched.
+func (pm *ParticipationManager) RegistryScannedIndex() (milestone.Index, bool, error) {
+	value, err := pm.participationStore.Get([]byte{ParticipationStoreKeyPrefixRegistryScannedIndex})
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+
+	index, err := marshalutil.New(value).ReadUint32()
+	if err != nil {
+		return 0, false, err
+	}
+
+	return milestone.Index(index), true, nil
+}
+
+func (pm *ParticipationManager) storeRegistryScannedIndex(index milestone.Index) error {
+	m := marshalutil.New(4)
+	m.WriteUint32(uint32(index))
+	return pm.participationStore.Set([]byte{ParticipationStoreKeyPrefixRegistryScannedIndex}, m.Bytes())
+}
+
+// ImportRegistryEventsUntil imports the events announced in the registry for all milestones after
+// the last searched milestone up to the given confirmed milestone index.
+// If the registry was never searched before, the search starts after the given milestone.
+// Already pruned milestones are skipped. The last searched milestone index is persisted after every milestone,
+// so that the search continues at the milestone that failed or was not searched yet, even after a restart.
+func (pm *ParticipationManager) ImportRegistryEventsUntil(confirmedMilestoneIndex milestone.Index, onImported func(eventID EventID), onRejected EventAnnouncementRejectedFunc) error {
+	if !pm.RegistryEnabled() {
+		return nil
+	}
+
+	scannedIndex, found, err := pm.RegistryScannedIndex()
+	if err != nil {
+		return err
+	}
+	if !found {
+		// announcements referenced before the registry was enabled are not imported
+		return pm.storeRegistryScannedIndex(confirmedMilestoneIndex)
+	}
+
+	startIndex := scannedIndex + 1
+	if snapshotInfo := pm.storage.SnapshotInfo(); snapshotInfo != nil && startIndex <= snapshotInfo.PruningIndex {
+		// older milestones were already pruned
+		startIndex = snapshotInfo.PruningIndex + 1
+	}
+
+	for index := startIndex; index <= confirmedMilestoneIndex; index++ {
+		eventIDs, err := pm.ImportRegistryEvents(index, onRejected)
+		if err != nil {
+			return fmt.Errorf("importing events from the registry for milestone %d failed: %w", index, err)
+		}
+
+		for _, eventID := range eventIDs {
+			onImported(eventID)
+		}
+
+		if err := pm.storeRegistryScannedIndex(index); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// registryDataFromMessage returns the indexation data of the given message, or nil if the message is not a registry message.
+func (pm *ParticipationManager) registryDataFromMessage(messageID hornet.MessageID) ([]byte, error) {
+	cachedMsg := pm.storage.CachedMessageOrNil(messageID) // message +1
+	if cachedMsg == nil {
+		// if the message was referenced, there must be a message
+		return nil, fmt.Errorf("message not found: %s", messageID.ToHex())
+	}
+	defer cachedMsg.Release(true) // message -1
+
+	indexation, ok := cachedMsg.Message().Message().Payload.(*iotago.Indexation)
+	if !ok || !bytes.Equal(indexation.Index, pm.opts.registryIndexation) {
+		return nil, nil
+	}
+
+	return indexation.Data, nil
+}
@@ -0,0 +1,196 @@
+package participation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/participation"
+	"github.com/gohornet/hornet/pkg/model/participation/test"
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+)
+
+const registryIndexation = "REGISTRY"
+
+func TestEventAnnouncement_Serialization(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	otherPublicKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	event, _ := RandEventWithBallot(10, 10)
+
+	announcement, err := participation.NewEventAnnouncement(event, privateKey)
+	require.NoError(t, err)
+	require.NoError(t, announcement.Verify([]ed25519.PublicKey{otherPublicKey, publicKey}))
+	require.ErrorIs(t, announcement.Verify([]ed25519.PublicKey{otherPublicKey}), participation.ErrEventAnnouncementUnknownPublicKey)
+
+	data, err := announcement.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+
+	deserialized := &participation.EventAnnouncement{}
+	bytesRead, err := deserialized.Deserialize(data, serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+	require.Equal(t, len(data), bytesRead)
+	require.Equal(t, announcement, deserialized)
+	require.NoError(t, deserialized.Verify([]ed25519.PublicKey{publicKey}))
+
+	// the signature does not match a modified event
+	deserialized.Event.Name = "modified"
+	require.ErrorIs(t, deserialized.Verify([]ed25519.PublicKey{publicKey}), participation.ErrEventAnnouncementInvalidSignature)
+
+	// trailing data is not allowed
+	_, err = (&participation.EventAnnouncement{}).Deserialize(append(data, 0), serializer.DeSeriModePerformValidation)
+	require.ErrorIs(t, err, serializer.ErrDeserializationNotAllConsumed)
+}
+
+func TestImportRegistryEvents(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	_, otherPrivateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	env := test.NewParticipationTestEnv(t, 5_000_000, 150_000_000, 200_000_000, 300_000_000, false, participation.WithRegistry(registryIndexation, []ed25519.PublicKey{publicKey}))
+	defer env.Cleanup()
+
+	require.True(t, env.ParticipationManager().RegistryEnabled())
+
+	event := env.DefaultEvent(5, 2, 3)
+	eventID, err := event.ID()
+	require.NoError(t, err)
+
+	env.IssueMilestone() // 5
+	env.IssueMilestone() // 6
+
+	// the vote is cast before the event is known to the node
+	env.IssueDefaultBallotVoteAndMilestone(eventID, env.Wallet1) // 7
+	require.Nil(t, env.ParticipationManager().Event(eventID))
+
+	publishAnnouncement := func(data []byte) hornet.MessageID {
+		return env.NewMessageBuilder(registryIndexation).
+			Parents(hornet.MessageIDs{env.LastMilestoneMessageID()}).
+			IndexationData(data).
+			BuildIndexation().
+			Store().
+			StoredMessageID()
+	}
+
+	announcement, err := participation.NewEventAnnouncement(event, privateKey)
+	require.NoError(t, err)
+	announcementData, err := announcement.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+
+	unknownKeyAnnouncement, err := participation.NewEventAnnouncement(env.DefaultEvent(5, 2, 4), otherPrivateKey)
+	require.NoError(t, err)
+	unknownKeyAnnouncementData, err := unknownKeyAnnouncement.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+
+	validMessageID := publishAnnouncement(announcementData)
+	unknownKeyMessageID := publishAnnouncement(unknownKeyAnnouncementData)
+	malformedMessageID := publishAnnouncement([]byte("malformed"))
+
+	env.IssueMilestone(validMessageID, unknownKeyMessageID, malformedMessageID) // 8
+
+	rejected := make(map[string]error)
+	onRejected := func(messageID hornet.MessageID, err error) {
+		rejected[messageID.ToMapKey()] = err
+	}
+
+	eventIDs, err := env.ParticipationManager().ImportRegistryEvents(env.ConfirmedMilestoneIndex(), onRejected)
+	require.NoError(t, err)
+	require.Equal(t, []participation.EventID{eventID}, eventIDs)
+
+	require.Len(t, rejected, 2)
+	require.ErrorIs(t, rejected[unknownKeyMessageID.ToMapKey()], participation.ErrEventAnnouncementUnknownPublicKey)
+	require.Error(t, rejected[malformedMessageID.ToMapKey()])
+
+	// the past participation was calculated for the imported event
+	require.NotNil(t, env.ParticipationManager().Event(eventID))
+	env.AssertEventParticipationStatus(eventID, 1, 0)
+	env.AssertDefaultBallotAnswerStatus(eventID, 5_000, 5_000)
+
+	// already known events are ignored
+	eventIDs, err = env.ParticipationManager().ImportRegistryEvents(env.ConfirmedMilestoneIndex(), func(messageID hornet.MessageID, err error) {})
+	require.NoError(t, err)
+	require.Empty(t, eventIDs)
+
+	env.IssueMilestone() // 9
+	env.AssertDefaultBallotAnswerStatus(eventID, 5_000, 10_000)
+}
+
+func TestImportRegistryEventsAfterRestart(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	env := test.NewParticipationTestEnv(t, 5_000_000, 150_000_000, 200_000_000, 300_000_000, false, participation.WithRegistry(registryIndexation, []ed25519.PublicKey{publicKey}))
+	defer env.Cleanup()
+
+	var imported []participation.EventID
+	onImported := func(eventID participation.EventID) {
+		imported = append(imported, eventID)
+	}
+	onRejected := func(messageID hornet.MessageID, err error) {
+		require.NoError(t, err)
+	}
+
+	assertScannedIndex := func(expected milestone.Index) {
+		scannedIndex, found, err := env.ParticipationManager().RegistryScannedIndex()
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, expected, scannedIndex)
+	}
+
+	_, found, err := env.ParticipationManager().RegistryScannedIndex()
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// the first search starts at the current confirmed milestone
+	require.NoError(t, env.ParticipationManager().ImportRegistryEventsUntil(env.ConfirmedMilestoneIndex(), onImported, onRejected))
+	assertScannedIndex(4)
+
+	env.IssueMilestone() // 5
+	require.NoError(t, env.ParticipationManager().ImportRegistryEventsUntil(env.ConfirmedMilestoneIndex(), onImported, onRejected))
+	assertScannedIndex(5)
+
+	// the event is announced while the node is down
+	event := env.DefaultEvent(10, 2, 3)
+	eventID, err := event.ID()
+	require.NoError(t, err)
+
+	announcement, err := participation.NewEventAnnouncement(event, privateKey)
+	require.NoError(t, err)
+	announcementData, err := announcement.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+
+	announcementMessageID := env.NewMessageBuilder(registryIndexation).
+		Parents(hornet.MessageIDs{env.LastMilestoneMessageID()}).
+		IndexationData(announcementData).
+		BuildIndexation().
+		Store().
+		StoredMessageID()
+
+	env.IssueMilestone(announcementMessageID) // 6
+	env.IssueMilestone()                      // 7
+
+	env.RestartParticipationManager()
+	require.Nil(t, env.ParticipationManager().Event(eventID))
+	assertScannedIndex(5)
+
+	// the search continues after the last searched milestone
+	require.NoError(t, env.ParticipationManager().ImportRegistryEventsUntil(env.ConfirmedMilestoneIndex(), onImported, onRejected))
+	require.Equal(t, []participation.EventID{eventID}, imported)
+	require.NotNil(t, env.ParticipationManager().Event(eventID))
+	assertScannedIndex(7)
+
+	// searched milestones are not searched again after another restart
+	env.RestartParticipationManager()
+	require.NotNil(t, env.ParticipationManager().Event(eventID))
+	require.NoError(t, env.ParticipationManager().ImportRegistryEventsUntil(env.ConfirmedMilestoneIndex(), onImported, onRejected))
+	require.Len(t, imported, 1)
+	assertScannedIndex(7)
+}
@@ -0,0 +1,222 @@
+package participation
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	iotago "github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+)
+
+var (
+	// ErrEventNotEnded is returned if the results of an event are exported before the event ended.
+	ErrEventNotEnded = errors.New("the event did not end yet")
+	// ErrInvalidResultsExport is returned if the exported results do not match their hash or signature.
+	ErrInvalidResultsExport = errors.New("invalid results export")
+)
+
+// StakingRewardResult holds the final staking reward of an address.
+type StakingRewardResult struct {
+	// Address is the address that received the reward.
+	Address string `json:"address"`
+	// Amount is the rewarded amount of tokens.
+	Amount uint64 `json:"amount"`
+}
+
+// ResultsExport holds the final results of an event, computed at the end milestone index of the event.
+type ResultsExport struct {
+	// EventID is the hex encoded ID of the event.
+	EventID string `json:"eventId"`
+	// MilestoneIndex is the milestone index the results were computed at.
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	// Questions holds the final answer tally of the different questions of a ballot event.
+	Questions []*QuestionStatus `json:"questions,omitempty"`
+	// Staking is the final staking status of a staking event.
+	Staking *StakingStatus `json:"staking,omitempty"`
+	// Rewards holds the final rewards of a staking event that reached the required minimum, ordered by address.
+	Rewards []*StakingRewardResult `json:"rewards,omitempty"`
+	// TotalRewards is the sum of the exported rewards.
+	TotalRewards uint64 `json:"totalRewards,omitempty"`
+	// Hash is the SHA256 hash over the event ID, the milestone index and the deterministic result set.
+	// Nodes that tracked the event compute the same hash.
+	Hash string `json:"hash"`
+	// Signature is the hex encoded ed25519 signature over the hash, the event ID and the milestone index,
+	// created with the identity key of the node that exported the results.
+	Signature string `json:"signature"`
+	// PublicKey is the hex encoded ed25519 public key of the node that exported the results.
+	PublicKey string `json:"publicKey"`
+}
+
+// ExportResults returns the final results of the event with the given eventID, signed with the given private key.
+// The results are computed at the end milestone index of the event, so the event must have ended.
+func (pm *ParticipationManager) ExportResults(eventID EventID, privateKey ed25519.PrivateKey) (*ResultsExport, error) {
+	event := pm.Event(eventID)
+	if event == nil {
+		return nil, ErrEventNotFound
+	}
+
+	index := event.EndMilestoneIndex()
+	if pm.syncManager.ConfirmedMilestoneIndex() < index {
+		return nil, ErrEventNotEnded
+	}
+
+	status, err := pm.EventStatus(eventID, index)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &ResultsExport{
+		EventID:        hex.EncodeToString(eventID[:]),
+		MilestoneIndex: index,
+		Questions:      status.Questions,
+		Staking:        status.Staking,
+	}
+
+	if export.Staking != nil {
+		export.Rewards = make([]*StakingRewardResult, 0)
+		if err := pm.ForEachStakingAddress(eventID, func(address iotago.Address, rewards uint64) bool {
+			export.Rewards = append(export.Rewards, &StakingRewardResult{
+				Address: address.String(),
+				Amount:  rewards,
+			})
+			return true
+		}, FilterRequiredMinimumRewards(true)); err != nil {
+			return nil, err
+		}
+
+		sort.Slice(export.Rewards, func(i int, j int) bool {
+			return export.Rewards[i].Address < export.Rewards[j].Address
+		})
+
+		for _, reward := range export.Rewards {
+			export.TotalRewards += reward.Amount
+		}
+	}
+
+	resultsHash, err := export.computeHash()
+	if err != nil {
+		return nil, err
+	}
+	export.Hash = hex.EncodeToString(resultsHash)
+
+	// sign the results, so that they can be attributed to the node that exported them
+	signature := ed25519.Sign(privateKey, export.signingMessage(eventID, resultsHash))
+	export.Signature = hex.EncodeToString(signature)
+	export.PublicKey = hex.EncodeToString(privateKey.Public().(ed25519.PublicKey))
+
+	return export, nil
+}
+
+// computeHash computes the sha256 over the event ID, the milestone index and the result set in a deterministic order.
+func (e *ResultsExport) computeHash() ([]byte, error) {
+	eventID, err := EventIDFromHex(e.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	resultsHash := sha256.New()
+	if _, err := resultsHash.Write(eventID[:]); err != nil {
+		return nil, err
+	}
+	if err := binary.Write(resultsHash, binary.LittleEndian, e.MilestoneIndex); err != nil {
+		return nil, err
+	}
+
+	for questionIndex, question := range e.Questions {
+		if err := binary.Write(resultsHash, binary.LittleEndian, uint8(questionIndex)); err != nil {
+			return nil, err
+		}
+		for _, answer := range question.Answers {
+			if err := binary.Write(resultsHash, binary.LittleEndian, answer.Value); err != nil {
+				return nil, err
+			}
+			if err := binary.Write(resultsHash, binary.LittleEndian, answer.Current); err != nil {
+				return nil, err
+			}
+			if err := binary.Write(resultsHash, binary.LittleEndian, answer.Accumulated); err != nil {
+				return nil, err
+			}
+		}
+		if err := writeRounds(resultsHash, question.Rounds); err != nil {
+			return nil, err
+		}
+	}
+
+	if e.Staking != nil {
+		if _, err := resultsHash.Write([]byte(e.Staking.Symbol)); err != nil {
+			return nil, err
+		}
+		if err := binary.Write(resultsHash, binary.LittleEndian, e.Staking.Staked); err != nil {
+			return nil, err
+		}
+		if err := binary.Write(resultsHash, binary.LittleEndian, e.Staking.Rewarded); err != nil {
+			return nil, err
+		}
+
+		var totalRewards uint64
+		for _, reward := range e.Rewards {
+			if _, err := resultsHash.Write([]byte(reward.Address)); err != nil {
+				return nil, err
+			}
+			if err := binary.Write(resultsHash, binary.LittleEndian, reward.Amount); err != nil {
+				return nil, err
+			}
+			totalRewards += reward.Amount
+		}
+
+		if totalRewards != e.TotalRewards {
+			return nil, fmt.Errorf("%w: total rewards %d do not match the sum of the rewards %d", ErrInvalidResultsExport, e.TotalRewards, totalRewards)
+		}
+	}
+
+	return resultsHash.Sum(nil), nil
+}
+
+// signingMessage returns the message that is signed by the node that exported the results.
+func (e *ResultsExport) signingMessage(eventID EventID, resultsHash []byte) []byte {
+	message := make([]byte, 0, len(resultsHash)+EventIDLength+4)
+	message = append(message, resultsHash...)
+	message = append(message, eventID[:]...)
+	index := make([]byte, 4)
+	binary.LittleEndian.PutUint32(index, uint32(e.MilestoneIndex))
+	return append(message, index...)
+}
+
+// Verify recomputes the hash over the exported results and checks that the signature was created
+// over the hash, the event ID and the milestone index by the included public key.
+func (e *ResultsExport) Verify() error {
+	eventID, err := EventIDFromHex(e.EventID)
+	if err != nil {
+		return fmt.Errorf("%w: invalid event ID: %s", ErrInvalidResultsExport, err)
+	}
+
+	resultsHash, err := e.computeHash()
+	if err != nil {
+		return err
+	}
+	if hex.EncodeToString(resultsHash) != e.Hash {
+		return fmt.Errorf("%w: the hash does not match the results", ErrInvalidResultsExport)
+	}
+
+	publicKey, err := hex.DecodeString(e.PublicKey)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: invalid public key", ErrInvalidResultsExport)
+	}
+
+	signature, err := hex.DecodeString(e.Signature)
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return fmt.Errorf("%w: invalid signature", ErrInvalidResultsExport)
+	}
+
+	if !ed25519.Verify(publicKey, e.signingMessage(eventID, resultsHash), signature) {
+		return fmt.Errorf("%w: the signature does not match the results", ErrInvalidResultsExport)
+	}
+
+	return nil
+}
@@ -22,6 +22,8 @@ type AnswerStatus struct {
 type QuestionStatus struct {
 	// Answers holds the status of the answers.
 	Answers []*AnswerStatus `json:"answers"`
+	// Rounds holds the instant runoff rounds calculated from the accumulated rankings of a ranked choice question.
+	Rounds []*RoundStatus `json:"rounds,omitempty"`
 }
 
 // StakingStatus holds the status of a staking.
@@ -137,6 +139,22 @@ func (pm *ParticipationManager) EventStatus(eventID EventID, milestone ...milest
 		}
 		questionStatus.Answers = append(questionStatus.Answers, invalidValue)
 
+		if event.IsRankedChoiceBallot() {
+			rounds, err := pm.ballotRounds(eventID, index, questionIndex)
+			if err != nil {
+				return nil, err
+			}
+			if rounds == nil {
+				// the rounds are only tallied while the event accepts participations, so no votes were counted yet
+				rounds = instantRunoffRounds(question.answerValues(), nil)
+			}
+
+			questionStatus.Rounds = rounds
+			if err := writeRounds(statusHash, questionStatus.Rounds); err != nil {
+				return nil, err
+			}
+		}
+
 		status.Questions = append(status.Questions, questionStatus)
 	}
 
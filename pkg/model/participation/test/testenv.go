@@ -18,6 +18,7 @@ import (
 	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	iotago "github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
 )
 
 const (
@@ -32,6 +33,9 @@ var (
 	seed3, _       = hex.DecodeString("d5353ceeed380ab89a0f6abe4630c2091acc82617c0edd4ff10bd60bba89e2ed30805ef095b989c2bf208a474f8748d11d954aade374380422d4d812b6f1da90")
 	seed4, _       = hex.DecodeString("bd6fe09d8a309ca309c5db7b63513240490109cd0ac6b123551e9da0d5c8916c4a5a4f817e4b4e9df89885ce1af0986da9f1e56b65153c2af1e87ab3b11dabb4")
 
+	nodeSeed, _    = hex.DecodeString("6b3d8e1f0a2c4e5d7f9a1b3c5d7e9f0a2b4c6d8e0f1a3b5c7d9e1f2a4b6c8d0e")
+	nodePrivateKey = ed25519.NewKeyFromSeed(nodeSeed)
+
 	MinPoWScore   = 100.0
 	BelowMaxDepth = 15
 )
@@ -47,10 +51,11 @@ type ParticipationTestEnv struct {
 	Wallet4       *utils.HDWallet
 
 	participationStore kvstore.KVStore
+	participationOpts  []participation.Option
 	rm                 *participation.ParticipationManager
 }
 
-func NewParticipationTestEnv(t *testing.T, wallet1Balance uint64, wallet2Balance uint64, wallet3Balance uint64, wallet4Balance uint64, assertSteps bool) *ParticipationTestEnv {
+func NewParticipationTestEnv(t *testing.T, wallet1Balance uint64, wallet2Balance uint64, wallet3Balance uint64, wallet4Balance uint64, assertSteps bool, opts ...participation.Option) *ParticipationTestEnv {
 
 	genesisWallet := utils.NewHDWallet("Genesis", genesisSeed, 0)
 	seed1Wallet := utils.NewHDWallet("Seed1", seed1, 0)
@@ -125,47 +130,65 @@ func NewParticipationTestEnv(t *testing.T, wallet1Balance uint64, wallet2Balance
 		te.AssertWalletBalance(seed4Wallet, wallet4Balance)
 	}
 
-	store := mapdb.NewMapDB()
+	env := &ParticipationTestEnv{
+		t:                  t,
+		te:                 te,
+		GenesisWallet:      genesisWallet,
+		Wallet1:            seed1Wallet,
+		Wallet2:            seed2Wallet,
+		Wallet3:            seed3Wallet,
+		Wallet4:            seed4Wallet,
+		participationStore: mapdb.NewMapDB(),
+		participationOpts:  append([]participation.Option{participation.WithIndexationMessage(ParticipationIndexation)}, opts...),
+	}
+	env.loadParticipationManager()
+
+	return env
+}
 
+// loadParticipationManager creates a new ParticipationManager on the participation store of the environment.
+func (env *ParticipationTestEnv) loadParticipationManager() {
 	pm, err := participation.NewManager(
-		te.Storage(),
-		te.SyncManager(),
-		store,
-		participation.WithIndexationMessage(ParticipationIndexation),
+		env.te.Storage(),
+		env.te.SyncManager(),
+		env.participationStore,
+		env.participationOpts...,
 	)
-	require.NoError(t, err)
+	require.NoError(env.t, err)
 
 	// Connect the callbacks from the testsuite to the ParticipationManager
-	te.ConfigureUTXOCallbacks(
+	env.te.ConfigureUTXOCallbacks(
 		func(index milestone.Index, output *utxo.Output) {
-			require.NoError(t, pm.ApplyNewUTXO(index, output))
+			require.NoError(env.t, pm.ApplyNewUTXO(index, output))
 		},
 		func(index milestone.Index, spent *utxo.Spent) {
-			require.NoError(t, pm.ApplySpentUTXO(index, spent))
+			require.NoError(env.t, pm.ApplySpentUTXO(index, spent))
 		},
 		nil,
 		func(index milestone.Index) {
-			require.NoError(t, pm.ApplyNewConfirmedMilestoneIndex(index))
+			require.NoError(env.t, pm.ApplyNewConfirmedMilestoneIndex(index))
 		},
 	)
 
-	return &ParticipationTestEnv{
-		t:                  t,
-		te:                 te,
-		GenesisWallet:      genesisWallet,
-		Wallet1:            seed1Wallet,
-		Wallet2:            seed2Wallet,
-		Wallet3:            seed3Wallet,
-		Wallet4:            seed4Wallet,
-		participationStore: store,
-		rm:                 pm,
-	}
+	env.rm = pm
 }
 
 func (env *ParticipationTestEnv) ParticipationManager() *participation.ParticipationManager {
 	return env.rm
 }
 
+// RestartParticipationManager replaces the ParticipationManager with a new one on the same participation store,
+// like it happens on a restart of the node.
+func (env *ParticipationTestEnv) RestartParticipationManager() {
+	require.NoError(env.t, env.rm.CloseDatabase())
+	env.loadParticipationManager()
+}
+
+// ExportResults exports the results of the given event, signed with the identity key of the test node.
+func (env *ParticipationTestEnv) ExportResults(eventID participation.EventID) (*participation.ResultsExport, error) {
+	return env.rm.ExportResults(eventID, nodePrivateKey)
+}
+
 func (env *ParticipationTestEnv) ConfirmedMilestoneIndex() milestone.Index {
 	return env.te.SyncManager().ConfirmedMilestoneIndex()
 }
@@ -230,6 +253,43 @@ func (env *ParticipationTestEnv) StoreDefaultEvent(commenceMilestoneIndex milest
 	return eventID
 }
 
+func (env *ParticipationTestEnv) StoreChoiceBallotEvent(mode participation.ChoiceBallotMode, commenceMilestoneIndex milestone.Index, startPhaseDuration uint32, holdingDuration uint32) participation.EventID {
+
+	eventCommenceIndex := commenceMilestoneIndex
+	eventStartIndex := eventCommenceIndex + milestone.Index(startPhaseDuration)
+	eventEndIndex := eventStartIndex + milestone.Index(holdingDuration)
+
+	eventBuilder := participation.NewEventBuilder("HORNET mascot", eventCommenceIndex, eventStartIndex, eventEndIndex, "Choose the new mascot")
+
+	questionBuilder := participation.NewQuestionBuilder("Which mascot do you prefer?", "-")
+	for i, text := range []string{"Hornet", "Bee", "Wasp"} {
+		questionBuilder.AddAnswer(&participation.Answer{
+			Value:          uint8(i + 1),
+			Text:           text,
+			AdditionalInfo: "-",
+		})
+	}
+
+	question, err := questionBuilder.Build()
+	require.NoError(env.t, err)
+
+	payload, err := participation.NewChoiceBallotBuilder(mode).AddQuestion(question).Build()
+	require.NoError(env.t, err)
+
+	eventBuilder.Payload(payload)
+
+	event, err := eventBuilder.Build()
+	require.NoError(env.t, err)
+
+	eventID, err := env.rm.StoreEvent(event)
+	require.NoError(env.t, err)
+
+	// Check the stored event is still there
+	require.NotNil(env.t, env.rm.Event(eventID))
+
+	return eventID
+}
+
 func (env *ParticipationTestEnv) SendParticipations(wallet *utils.HDWallet, amount uint64, participations []*participation.Participation) *SentParticipations {
 	return env.NewParticipationHelper(wallet).Amount(amount).AddParticipations(participations).Send()
 }
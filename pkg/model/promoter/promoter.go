@@ -0,0 +1,631 @@
+package promoter
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/common"
+	"github.com/gohornet/hornet/pkg/dag"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/model/syncmanager"
+	"github.com/gohornet/hornet/pkg/pow"
+	"github.com/gohornet/hornet/pkg/restapi"
+	"github.com/gohornet/hornet/pkg/utils"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/hive.go/syncutils"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+const (
+	// RouteGroupPrefix is the prefix of the REST API routes of the promoter.
+	RouteGroupPrefix = "/api/plugins/promoter"
+
+	// RouteMessages is the route to register messages at the promoter.
+	// GET returns the status of all registered messages.
+	// POST registers a message to get promoted or reattached until it is referenced by a milestone.
+	RouteMessages = "/messages"
+
+	// RouteMessage is the route to access a message registered at the promoter.
+	// GET returns the status of the registered message.
+	// DELETE removes the message from the promoter.
+	RouteMessage = "/messages/:" + restapi.ParameterMessageID
+)
+
+// SendMessageFunc is a function which sends a message to the network.
+type SendMessageFunc = func(msg *storage.Message) error
+
+// TipselFunc selects tips for the promoter.
+type TipselFunc = func() (tips hornet.MessageIDs, err error)
+
+var (
+	// ErrMessageNotFound is returned if the message to register is unknown to the node.
+	ErrMessageNotFound = errors.New("message not found")
+	// ErrMessageNotRegistered is returned if the message was not registered at the promoter.
+	ErrMessageNotRegistered = errors.New("message not registered")
+	// ErrMessageAlreadyRegistered is returned if the message was already registered at the promoter.
+	ErrMessageAlreadyRegistered = errors.New("message already registered")
+	// ErrMaxMessagesReached is returned if the maximum amount of registered messages is reached.
+	ErrMaxMessagesReached = errors.New("maximum amount of registered messages reached")
+	// ErrMaxReattachmentsReached is returned if a message was reattached too often without getting referenced.
+	ErrMaxReattachmentsReached = errors.New("maximum amount of reattachments reached")
+)
+
+// State is the state of a message registered at the promoter.
+type State string
+
+const (
+	// StatePending means the message or its reattachments are not referenced yet.
+	StatePending State = "pending"
+	// StateReferenced means the message or one of its reattachments was referenced by a milestone.
+	StateReferenced State = "referenced"
+	// StateFailed means the promoter gave up on the message.
+	StateFailed State = "failed"
+)
+
+// Events are the events issued by the promoter.
+type Events struct {
+	// Fired when the status of a registered message changed.
+	StatusChanged *events.Event
+	// SoftError is triggered when a soft error is encountered.
+	SoftError *events.Event
+}
+
+// MessageStatusCaller is used to signal a changed MessageStatusResponse.
+func MessageStatusCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*MessageStatusResponse))(params[0].(*MessageStatusResponse))
+}
+
+// MessageStatusResponse defines the status of a message registered at the promoter.
+type MessageStatusResponse struct {
+	// The hex encoded ID of the registered message.
+	MessageID string `json:"messageId"`
+	// The state of the registered message.
+	State State `json:"state"`
+	// The hex encoded IDs of the issued promotion messages.
+	Promotions []string `json:"promotions"`
+	// The hex encoded IDs of the issued reattachments.
+	Reattachments []string `json:"reattachments"`
+	// The hex encoded ID of the message or reattachment that was referenced by a milestone.
+	ReferencedMessageID string `json:"referencedMessageId,omitempty"`
+	// The milestone index that referenced the message or reattachment.
+	ReferencedByMilestoneIndex milestone.Index `json:"referencedByMilestoneIndex,omitempty"`
+	// The reason why the promoter gave up on the message.
+	Error string `json:"error,omitempty"`
+	// The unix timestamp the message was registered at.
+	RegisteredAt int64 `json:"registeredAt"`
+	// The unix timestamp of the last status change.
+	UpdatedAt int64 `json:"updatedAt"`
+}
+
+// entry holds the state of a message registered at the promoter.
+type entry struct {
+	messageID hornet.MessageID
+	// the payload of the message, kept to be able to reattach the message after it was pruned.
+	payload                    serializer.Serializable
+	state                      State
+	promotions                 hornet.MessageIDs
+	reattachments              hornet.MessageIDs
+	referencedMessageID        hornet.MessageID
+	referencedByMilestoneIndex milestone.Index
+	err                        error
+	registeredAt               time.Time
+	updatedAt                  time.Time
+}
+
+// latestAttachment returns the ID of the message or the latest reattachment.
+func (e *entry) latestAttachment() hornet.MessageID {
+	if len(e.reattachments) > 0 {
+		return e.reattachments[len(e.reattachments)-1]
+	}
+	return e.messageID
+}
+
+// status returns the MessageStatusResponse of the entry.
+// lock must be acquired outside.
+func (e *entry) status() *MessageStatusResponse {
+	status := &MessageStatusResponse{
+		MessageID:                  e.messageID.ToHex(),
+		State:                      e.state,
+		Promotions:                 e.promotions.ToHex(),
+		Reattachments:              e.reattachments.ToHex(),
+		ReferencedByMilestoneIndex: e.referencedByMilestoneIndex,
+		RegisteredAt:               e.registeredAt.Unix(),
+		UpdatedAt:                  e.updatedAt.Unix(),
+	}
+
+	if e.referencedMessageID != nil {
+		status.ReferencedMessageID = e.referencedMessageID.ToHex()
+	}
+
+	if e.err != nil {
+		status.Error = e.err.Error()
+	}
+
+	return status
+}
+
+// Promoter promotes or reattaches registered messages until the message
+// or one of its reattachments is referenced by a milestone.
+type Promoter struct {
+	// lock used to secure the state of the promoter.
+	syncutils.Mutex
+	// the logger used to log events.
+	*utils.WrappedLogger
+
+	// used to access the node storage.
+	storage *storage.Storage
+	// used to determine the sync status of the node.
+	syncManager *syncmanager.SyncManager
+	// id of the network the promoter is running in.
+	networkID uint64
+	// belowMaxDepth is the maximum allowed delta
+	// value between OCRI of a given message in relation to the current CMI before it gets lazy.
+	belowMaxDepth milestone.Index
+	// maxDeltaMsgYoungestConeRootIndexToCMI is the maximum allowed delta
+	// value for the YCRI of a given message in relation to the current CMI before it gets lazy.
+	maxDeltaMsgYoungestConeRootIndexToCMI milestone.Index
+	// maxDeltaMsgOldestConeRootIndexToCMI is the maximum allowed delta
+	// value between OCRI of a given message in relation to the current CMI before it gets semi-lazy.
+	maxDeltaMsgOldestConeRootIndexToCMI milestone.Index
+	// used to get non-lazy tips for promotions and reattachments.
+	tipselFunc TipselFunc
+	// used to do the PoW for promotions and reattachments.
+	powHandler *pow.Handler
+	// the function used to send a message.
+	sendMessageFunc SendMessageFunc
+	// holds the promoter options.
+	opts *Options
+
+	// events of the promoter.
+	Events *Events
+
+	// the registered messages.
+	entries map[string]*entry
+	// used to signal the promoter to check the registered messages.
+	checkSignal chan struct{}
+}
+
+// the default options applied to the promoter.
+var defaultOptions = []Option{
+	WithMaxMessages(1000),
+	WithMaxReattachments(10),
+	WithRetention(1 * time.Hour),
+	WithPowWorkerCount(0),
+}
+
+// Options define options for the promoter.
+type Options struct {
+	// the logger used to log events.
+	logger           *logger.Logger
+	maxMessages      int
+	maxReattachments int
+	retention        time.Duration
+	powWorkerCount   int
+}
+
+// applies the given Option.
+func (so *Options) apply(opts ...Option) {
+	for _, opt := range opts {
+		opt(so)
+	}
+}
+
+// WithLogger enables logging within the promoter.
+func WithLogger(logger *logger.Logger) Option {
+	return func(opts *Options) {
+		opts.logger = logger
+	}
+}
+
+// WithMaxMessages defines the maximum amount of messages that can be registered at the same time.
+func WithMaxMessages(maxMessages int) Option {
+	return func(opts *Options) {
+		opts.maxMessages = maxMessages
+	}
+}
+
+// WithMaxReattachments defines how often a message is reattached before the promoter gives up.
+func WithMaxReattachments(maxReattachments int) Option {
+	return func(opts *Options) {
+		opts.maxReattachments = maxReattachments
+	}
+}
+
+// WithRetention defines how long the status of referenced or failed messages is kept.
+func WithRetention(retention time.Duration) Option {
+	return func(opts *Options) {
+		opts.retention = retention
+	}
+}
+
+// WithPowWorkerCount defines the amount of workers used for calculating PoW when issuing promotions and reattachments.
+func WithPowWorkerCount(powWorkerCount int) Option {
+
+	if powWorkerCount == 0 {
+		powWorkerCount = runtime.NumCPU() - 1
+	}
+
+	if powWorkerCount < 1 {
+		powWorkerCount = 1
+	}
+
+	return func(opts *Options) {
+		opts.powWorkerCount = powWorkerCount
+	}
+}
+
+// Option is a function setting a promoter option.
+type Option func(opts *Options)
+
+// New creates a new promoter instance.
+func New(
+	dbStorage *storage.Storage,
+	syncManager *syncmanager.SyncManager,
+	networkID uint64,
+	belowMaxDepth int,
+	maxDeltaMsgYoungestConeRootIndexToCMI int,
+	maxDeltaMsgOldestConeRootIndexToCMI int,
+	tipselFunc TipselFunc,
+	powHandler *pow.Handler,
+	sendMessageFunc SendMessageFunc,
+	opts ...Option) *Promoter {
+
+	options := &Options{}
+	options.apply(defaultOptions...)
+	options.apply(opts...)
+
+	promoter := &Promoter{
+		storage:                               dbStorage,
+		syncManager:                           syncManager,
+		networkID:                             networkID,
+		belowMaxDepth:                         milestone.Index(belowMaxDepth),
+		maxDeltaMsgYoungestConeRootIndexToCMI: milestone.Index(maxDeltaMsgYoungestConeRootIndexToCMI),
+		maxDeltaMsgOldestConeRootIndexToCMI:   milestone.Index(maxDeltaMsgOldestConeRootIndexToCMI),
+		tipselFunc:                            tipselFunc,
+		powHandler:                            powHandler,
+		sendMessageFunc:                       sendMessageFunc,
+		opts:                                  options,
+
+		Events: &Events{
+			StatusChanged: events.NewEvent(MessageStatusCaller),
+			SoftError:     events.NewEvent(events.ErrorCaller),
+		},
+
+		entries:     make(map[string]*entry),
+		checkSignal: make(chan struct{}, 1),
+	}
+	promoter.WrappedLogger = utils.NewWrappedLogger(options.logger)
+
+	return promoter
+}
+
+// Register registers a message at the promoter.
+// The message gets promoted or reattached until the message or one of its reattachments is referenced by a milestone.
+func (p *Promoter) Register(messageID hornet.MessageID) (*MessageStatusResponse, error) {
+
+	if !p.syncManager.IsNodeAlmostSynced() {
+		return nil, common.ErrNodeNotSynced
+	}
+
+	cachedMsg := p.storage.CachedMessageOrNil(messageID) // message +1
+	if cachedMsg == nil {
+		return nil, errors.WithMessagef(ErrMessageNotFound, "message ID: %s", messageID.ToHex())
+	}
+	defer cachedMsg.Release(true)
+
+	p.Lock()
+	defer p.Unlock()
+
+	if _, exists := p.entries[messageID.ToMapKey()]; exists {
+		return nil, errors.WithMessagef(ErrMessageAlreadyRegistered, "message ID: %s", messageID.ToHex())
+	}
+
+	if len(p.entries) >= p.opts.maxMessages {
+		return nil, ErrMaxMessagesReached
+	}
+
+	now := time.Now()
+	e := &entry{
+		messageID:    messageID,
+		payload:      cachedMsg.Message().Message().Payload,
+		state:        StatePending,
+		registeredAt: now,
+		updatedAt:    now,
+	}
+
+	if referenced, msIndex := cachedMsg.Metadata().ReferencedWithIndex(); referenced {
+		e.state = StateReferenced
+		e.referencedMessageID = messageID
+		e.referencedByMilestoneIndex = msIndex
+	}
+
+	p.entries[messageID.ToMapKey()] = e
+	p.TriggerCheck()
+
+	return e.status(), nil
+}
+
+// Deregister removes a registered message from the promoter.
+func (p *Promoter) Deregister(messageID hornet.MessageID) error {
+	p.Lock()
+	defer p.Unlock()
+
+	if _, exists := p.entries[messageID.ToMapKey()]; !exists {
+		return errors.WithMessagef(ErrMessageNotRegistered, "message ID: %s", messageID.ToHex())
+	}
+
+	delete(p.entries, messageID.ToMapKey())
+	return nil
+}
+
+// Status returns the status of a registered message.
+func (p *Promoter) Status(messageID hornet.MessageID) (*MessageStatusResponse, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	e, exists := p.entries[messageID.ToMapKey()]
+	if !exists {
+		return nil, errors.WithMessagef(ErrMessageNotRegistered, "message ID: %s", messageID.ToHex())
+	}
+
+	return e.status(), nil
+}
+
+// Statuses returns the status of all registered messages.
+func (p *Promoter) Statuses() []*MessageStatusResponse {
+	p.Lock()
+	defer p.Unlock()
+
+	statuses := make([]*MessageStatusResponse, 0, len(p.entries))
+	for _, e := range p.entries {
+		statuses = append(statuses, e.status())
+	}
+
+	return statuses
+}
+
+// TriggerCheck signals the promoter to check the registered messages.
+func (p *Promoter) TriggerCheck() {
+	select {
+	case p.checkSignal <- struct{}{}:
+	default:
+		// a check is already pending
+	}
+}
+
+// RunPromoterLoop checks the registered messages every time a check is triggered until the context is done.
+func (p *Promoter) RunPromoterLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-p.checkSignal:
+			p.CheckMessages(ctx)
+		}
+	}
+}
+
+// CheckMessages checks all registered messages and issues promotions or reattachments for lazy messages.
+// Referenced or failed messages are removed after the retention time.
+func (p *Promoter) CheckMessages(ctx context.Context) {
+
+	if !p.syncManager.IsNodeAlmostSynced() {
+		return
+	}
+
+	p.Lock()
+	var pending []*entry
+	for key, e := range p.entries {
+		if e.state == StatePending {
+			pending = append(pending, e)
+			continue
+		}
+
+		if time.Since(e.updatedAt) > p.opts.retention {
+			delete(p.entries, key)
+		}
+	}
+	p.Unlock()
+
+	for _, e := range pending {
+		if err := utils.ReturnErrIfCtxDone(ctx, common.ErrOperationAborted); err != nil {
+			return
+		}
+
+		if err := p.checkMessage(ctx, e); err != nil {
+			if errors.Is(err, common.ErrOperationAborted) {
+				return
+			}
+			p.logSoftError(err)
+		}
+	}
+}
+
+// checkMessage checks whether the message or one of its reattachments was referenced,
+// and promotes or reattaches the message otherwise.
+func (p *Promoter) checkMessage(ctx context.Context, e *entry) error {
+
+	p.Lock()
+	attachments := append(hornet.MessageIDs{e.messageID}, e.reattachments...)
+	p.Unlock()
+
+	for _, messageID := range attachments {
+		referenced, msIndex := p.referencedWithIndex(messageID)
+		if !referenced {
+			continue
+		}
+
+		p.updateEntry(e, func() {
+			e.state = StateReferenced
+			e.referencedMessageID = messageID
+			e.referencedByMilestoneIndex = msIndex
+		})
+		return nil
+	}
+
+	shouldPromote, shouldReattach, err := p.checkTipQuality(ctx, attachments[len(attachments)-1])
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case shouldReattach:
+		if len(attachments)-1 >= p.opts.maxReattachments {
+			p.updateEntry(e, func() {
+				e.state = StateFailed
+				e.err = ErrMaxReattachmentsReached
+			})
+			return nil
+		}
+
+		reattachmentID, err := p.reattach(ctx, e.payload)
+		if err != nil {
+			return errors.WithMessagef(err, "reattaching message %s failed", e.messageID.ToHex())
+		}
+
+		p.updateEntry(e, func() {
+			e.reattachments = append(e.reattachments, reattachmentID)
+		})
+
+	case shouldPromote:
+		promotionID, err := p.promote(ctx, attachments[len(attachments)-1])
+		if err != nil {
+			return errors.WithMessagef(err, "promoting message %s failed", e.messageID.ToHex())
+		}
+
+		p.updateEntry(e, func() {
+			e.promotions = append(e.promotions, promotionID)
+		})
+	}
+
+	return nil
+}
+
+// updateEntry applies the given update to the entry and fires the StatusChanged event.
+func (p *Promoter) updateEntry(e *entry, update func()) {
+	p.Lock()
+	update()
+	e.updatedAt = time.Now()
+	status := e.status()
+	p.Unlock()
+
+	p.Events.StatusChanged.Trigger(status)
+}
+
+// referencedWithIndex returns whether the message was referenced and the index of the referencing milestone.
+func (p *Promoter) referencedWithIndex(messageID hornet.MessageID) (bool, milestone.Index) {
+	cachedMsgMeta := p.storage.CachedMessageMetadataOrNil(messageID) // meta +1
+	if cachedMsgMeta == nil {
+		return false, 0
+	}
+	defer cachedMsgMeta.Release(true)
+
+	return cachedMsgMeta.Metadata().ReferencedWithIndex()
+}
+
+// checkTipQuality determines whether the message should be promoted or reattached.
+func (p *Promoter) checkTipQuality(ctx context.Context, messageID hornet.MessageID) (shouldPromote bool, shouldReattach bool, err error) {
+
+	cachedMsgMeta := p.storage.CachedMessageMetadataOrNil(messageID) // meta +1
+	if cachedMsgMeta == nil {
+		// the message is unknown (e.g. pruned), so it can only be reattached
+		return false, true, nil
+	}
+	defer cachedMsgMeta.Release(true)
+
+	if !cachedMsgMeta.Metadata().IsSolid() {
+		// wait until the message is solid
+		return false, false, nil
+	}
+
+	cmi := p.syncManager.ConfirmedMilestoneIndex()
+	ycri, ocri, err := dag.ConeRootIndexes(ctx, p.storage, cachedMsgMeta.Retain(), cmi) // meta pass +1
+	if err != nil {
+		return false, false, err
+	}
+
+	switch {
+	case (cmi - ocri) > p.belowMaxDepth:
+		// if the OCRI to CMI delta is over belowMaxDepth, then the tip is lazy and should be reattached
+		return false, true, nil
+
+	case (cmi - ycri) > p.maxDeltaMsgYoungestConeRootIndexToCMI:
+		// if the CMI to YCRI delta is over maxDeltaMsgYoungestConeRootIndexToCMI, then the tip is lazy and should be promoted
+		return true, false, nil
+
+	case (cmi - ocri) > p.maxDeltaMsgOldestConeRootIndexToCMI:
+		// if the OCRI to CMI delta is over maxDeltaMsgOldestConeRootIndexToCMI, the tip is semi-lazy and should be promoted
+		return true, false, nil
+	}
+
+	return false, false, nil
+}
+
+// promote issues a message without payload that references the given message and non-lazy tips.
+func (p *Promoter) promote(ctx context.Context, messageID hornet.MessageID) (hornet.MessageID, error) {
+
+	tipselFunc := func() (hornet.MessageIDs, error) {
+		tips, err := p.tipselFunc()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(tips) < iotago.MaxParentsInAMessage {
+			tips = append(tips, messageID)
+		} else {
+			tips[0] = messageID
+		}
+		return tips.RemoveDupsAndSortByLexicalOrder(), nil
+	}
+
+	return p.issueMessage(ctx, nil, tipselFunc)
+}
+
+// reattach issues a new message with fresh non-lazy tips that contains the given payload of the registered message.
+func (p *Promoter) reattach(ctx context.Context, payload serializer.Serializable) (hornet.MessageID, error) {
+	return p.issueMessage(ctx, payload, p.tipselFunc)
+}
+
+// issueMessage creates a new message with the given payload, does the PoW and sends it to the network.
+func (p *Promoter) issueMessage(ctx context.Context, payload serializer.Serializable, tipselFunc TipselFunc) (hornet.MessageID, error) {
+
+	tips, err := tipselFunc()
+	if err != nil {
+		return nil, err
+	}
+
+	iotaMsg := &iotago.Message{
+		NetworkID: p.networkID,
+		Parents:   tips.ToSliceOfArrays(),
+		Payload:   payload,
+	}
+
+	if err := p.powHandler.DoPoW(ctx, iotaMsg, p.opts.powWorkerCount, tipselFunc); err != nil {
+		return nil, err
+	}
+
+	msg, err := storage.NewMessage(iotaMsg, serializer.DeSeriModePerformValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.sendMessageFunc(msg); err != nil {
+		return nil, err
+	}
+
+	return msg.MessageID(), nil
+}
+
+// logSoftError logs a soft error and triggers the event.
+func (p *Promoter) logSoftError(err error) {
+	p.LogWarn(err)
+	p.Events.SoftError.Trigger(err)
+}
@@ -0,0 +1,224 @@
+package promoter_test
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/promoter"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/testsuite"
+	"github.com/gohornet/hornet/pkg/testsuite/utils"
+	"github.com/iotaledger/hive.go/events"
+)
+
+var (
+	genesisSeed, _ = hex.DecodeString("2f54b071657e6644629a40518ba6554de4eee89f0757713005ad26137d80968d05e1ca1bca555d8b4b85a3f4fcf11a6a48d3d628d1ace40f48009704472fc8f9")
+
+	MinPoWScore   = 10.0
+	BelowMaxDepth = 15
+)
+
+func TestPromoteAndReattach(t *testing.T) {
+
+	genesisWallet := utils.NewHDWallet("Genesis", genesisSeed, 0)
+
+	te := testsuite.SetupTestEnvironment(t, genesisWallet.Address(), 2, BelowMaxDepth, MinPoWScore, false)
+	defer te.CleanupTestEnvironment(true)
+
+	var tipselFunc promoter.TipselFunc = func() (tips hornet.MessageIDs, err error) {
+		// issue all promotions and reattachments on the latest milestone
+		return hornet.MessageIDs{te.LastMilestoneMessageID}, nil
+	}
+
+	sendMessageFunc := func(msg *storage.Message) error {
+		_ = te.StoreMessage(msg) // no need to release, since we remember all the messages for later cleanup
+		return nil
+	}
+
+	p := promoter.New(
+		te.Storage(),
+		te.SyncManager(),
+		te.NetworkID(),
+		3, // belowMaxDepth
+		1, // maxDeltaMsgYoungestConeRootIndexToCMI
+		2, // maxDeltaMsgOldestConeRootIndexToCMI
+		tipselFunc,
+		te.PoWHandler,
+		sendMessageFunc,
+		promoter.WithMaxReattachments(1),
+		promoter.WithPowWorkerCount(1),
+	)
+
+	var statusUpdates []*promoter.MessageStatusResponse
+	onStatusChanged := events.NewClosure(func(status *promoter.MessageStatusResponse) {
+		statusUpdates = append(statusUpdates, status)
+	})
+	p.Events.StatusChanged.Attach(onStatusChanged)
+	defer p.Events.StatusChanged.Detach(onStatusChanged)
+
+	_, err := p.Register(hornet.NullMessageID())
+	require.ErrorIs(t, err, promoter.ErrMessageNotFound)
+
+	messageA := te.NewMessageBuilder("A").
+		Parents(hornet.MessageIDs{te.LastMilestoneMessageID}).
+		BuildIndexation().
+		Store()
+
+	status, err := p.Register(messageA.StoredMessageID())
+	require.NoError(t, err)
+	require.Equal(t, promoter.StatePending, status.State)
+
+	_, err = p.Register(messageA.StoredMessageID())
+	require.ErrorIs(t, err, promoter.ErrMessageAlreadyRegistered)
+
+	// the message is not lazy yet
+	p.CheckMessages(context.Background())
+	require.Empty(t, statusUpdates)
+
+	// the message gets lazy and should be promoted
+	te.IssueAndConfirmMilestoneOnTips(hornet.MessageIDs{te.LastMilestoneMessageID}, false)
+	te.IssueAndConfirmMilestoneOnTips(hornet.MessageIDs{te.LastMilestoneMessageID}, false)
+
+	p.CheckMessages(context.Background())
+	require.Len(t, statusUpdates, 1)
+	require.Len(t, statusUpdates[0].Promotions, 1)
+	require.Empty(t, statusUpdates[0].Reattachments)
+
+	// the message gets below max depth and should be reattached
+	te.IssueAndConfirmMilestoneOnTips(hornet.MessageIDs{te.LastMilestoneMessageID}, false)
+	te.IssueAndConfirmMilestoneOnTips(hornet.MessageIDs{te.LastMilestoneMessageID}, false)
+
+	p.CheckMessages(context.Background())
+	require.Len(t, statusUpdates, 2)
+	require.Len(t, statusUpdates[1].Reattachments, 1)
+
+	reattachmentID, err := hornet.MessageIDFromHex(statusUpdates[1].Reattachments[0])
+	require.NoError(t, err)
+
+	// the reattachment gets referenced
+	te.IssueAndConfirmMilestoneOnTips(hornet.MessageIDs{reattachmentID}, false)
+
+	p.CheckMessages(context.Background())
+	require.Len(t, statusUpdates, 3)
+
+	status, err = p.Status(messageA.StoredMessageID())
+	require.NoError(t, err)
+	require.Equal(t, promoter.StateReferenced, status.State)
+	require.Equal(t, reattachmentID.ToHex(), status.ReferencedMessageID)
+	require.Equal(t, te.SyncManager().ConfirmedMilestoneIndex(), status.ReferencedByMilestoneIndex)
+
+	require.NoError(t, p.Deregister(messageA.StoredMessageID()))
+	require.ErrorIs(t, p.Deregister(messageA.StoredMessageID()), promoter.ErrMessageNotRegistered)
+	require.Empty(t, p.Statuses())
+}
+
+func TestMaxReattachments(t *testing.T) {
+
+	genesisWallet := utils.NewHDWallet("Genesis", genesisSeed, 0)
+
+	te := testsuite.SetupTestEnvironment(t, genesisWallet.Address(), 2, BelowMaxDepth, MinPoWScore, false)
+	defer te.CleanupTestEnvironment(true)
+
+	var tipselFunc promoter.TipselFunc = func() (tips hornet.MessageIDs, err error) {
+		return hornet.MessageIDs{te.LastMilestoneMessageID}, nil
+	}
+
+	sendMessageFunc := func(msg *storage.Message) error {
+		_ = te.StoreMessage(msg)
+		return nil
+	}
+
+	p := promoter.New(
+		te.Storage(),
+		te.SyncManager(),
+		te.NetworkID(),
+		1, // belowMaxDepth
+		1, // maxDeltaMsgYoungestConeRootIndexToCMI
+		1, // maxDeltaMsgOldestConeRootIndexToCMI
+		tipselFunc,
+		te.PoWHandler,
+		sendMessageFunc,
+		promoter.WithMaxReattachments(0),
+		promoter.WithPowWorkerCount(1),
+	)
+
+	messageA := te.NewMessageBuilder("A").
+		Parents(hornet.MessageIDs{te.LastMilestoneMessageID}).
+		BuildIndexation().
+		Store()
+
+	_, err := p.Register(messageA.StoredMessageID())
+	require.NoError(t, err)
+
+	te.IssueAndConfirmMilestoneOnTips(hornet.MessageIDs{te.LastMilestoneMessageID}, false)
+	te.IssueAndConfirmMilestoneOnTips(hornet.MessageIDs{te.LastMilestoneMessageID}, false)
+
+	p.CheckMessages(context.Background())
+
+	status, err := p.Status(messageA.StoredMessageID())
+	require.NoError(t, err)
+	require.Equal(t, promoter.StateFailed, status.State)
+	require.Equal(t, promoter.ErrMaxReattachmentsReached.Error(), status.Error)
+	require.Empty(t, status.Reattachments)
+}
+
+func TestReattachPrunedMessage(t *testing.T) {
+
+	genesisWallet := utils.NewHDWallet("Genesis", genesisSeed, 0)
+
+	te := testsuite.SetupTestEnvironment(t, genesisWallet.Address(), 2, BelowMaxDepth, MinPoWScore, false)
+	defer te.CleanupTestEnvironment(true)
+
+	var tipselFunc promoter.TipselFunc = func() (tips hornet.MessageIDs, err error) {
+		return hornet.MessageIDs{te.LastMilestoneMessageID}, nil
+	}
+
+	var sentMessages []*storage.Message
+	sendMessageFunc := func(msg *storage.Message) error {
+		sentMessages = append(sentMessages, msg)
+		_ = te.StoreMessage(msg)
+		return nil
+	}
+
+	p := promoter.New(
+		te.Storage(),
+		te.SyncManager(),
+		te.NetworkID(),
+		3, // belowMaxDepth
+		1, // maxDeltaMsgYoungestConeRootIndexToCMI
+		2, // maxDeltaMsgOldestConeRootIndexToCMI
+		tipselFunc,
+		te.PoWHandler,
+		sendMessageFunc,
+		promoter.WithMaxReattachments(1),
+		promoter.WithPowWorkerCount(1),
+	)
+
+	messageA := te.NewMessageBuilder("A").
+		Parents(hornet.MessageIDs{te.LastMilestoneMessageID}).
+		BuildIndexation().
+		Store()
+
+	_, err := p.Register(messageA.StoredMessageID())
+	require.NoError(t, err)
+
+	// the message gets pruned before it was referenced
+	te.Storage().DeleteMessage(messageA.StoredMessageID())
+	require.Nil(t, te.Storage().CachedMessageOrNil(messageA.StoredMessageID()))
+
+	// the pruned message can still be reattached
+	p.CheckMessages(context.Background())
+
+	status, err := p.Status(messageA.StoredMessageID())
+	require.NoError(t, err)
+	require.Equal(t, promoter.StatePending, status.State)
+	require.Len(t, status.Reattachments, 1)
+
+	require.Len(t, sentMessages, 1)
+	require.Equal(t, status.Reattachments[0], sentMessages[0].MessageID().ToHex())
+	require.Equal(t, messageA.StoredMessage().Message().Payload, sentMessages[0].Message().Payload)
+}
@@ -50,6 +50,30 @@ const (
 	ConflictSemanticValidationFailed = 255
 )
 
+// String returns a human readable description of the conflict.
+func (c Conflict) String() string {
+	switch c {
+	case ConflictNone:
+		return "no conflict"
+	case ConflictInputUTXOAlreadySpent:
+		return "referenced UTXO was already spent"
+	case ConflictInputUTXOAlreadySpentInThisMilestone:
+		return "referenced UTXO was already spent while confirming this milestone"
+	case ConflictInputUTXONotFound:
+		return "referenced UTXO cannot be found"
+	case ConflictInputOutputSumMismatch:
+		return "sum of the inputs and output values does not match"
+	case ConflictInvalidSignature:
+		return "unlock block signature is invalid"
+	case ConflictInvalidDustAllowance:
+		return "dust allowance for the address is invalid"
+	case ConflictSemanticValidationFailed:
+		return "semantic validation failed"
+	default:
+		return fmt.Sprintf("unknown conflict (%d)", c)
+	}
+}
+
 type MessageMetadata struct {
 	objectstorage.StorableObjectFlags
 	syncutils.RWMutex
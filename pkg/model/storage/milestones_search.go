@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+)
+
+var (
+	// ErrMilestoneNotFound is returned if no milestone matches the search criteria.
+	ErrMilestoneNotFound = errors.New("milestone not found")
+)
+
+// MilestoneSearchMode defines which milestone is returned by a search by timestamp.
+type MilestoneSearchMode byte
+
+const (
+	// MilestoneSearchClosest returns the milestone with the timestamp closest to the target timestamp.
+	// If two milestones are equally close, the older one is returned.
+	MilestoneSearchClosest MilestoneSearchMode = iota
+	// MilestoneSearchBefore returns the youngest milestone with a timestamp before or equal to the target timestamp.
+	MilestoneSearchBefore
+	// MilestoneSearchAfter returns the oldest milestone with a timestamp after or equal to the target timestamp.
+	MilestoneSearchAfter
+)
+
+// milestoneTimestamp returns the timestamp of the milestone with the given index.
+func (s *Storage) milestoneTimestamp(index milestone.Index) (time.Time, error) {
+	cachedMilestone := s.CachedMilestoneOrNil(index) // milestone +1
+	if cachedMilestone == nil {
+		return time.Time{}, errors.Wrapf(ErrMilestoneNotFound, "index: %d", index)
+	}
+	defer cachedMilestone.Release(true) // milestone -1
+
+	return cachedMilestone.Milestone().Timestamp, nil
+}
+
+// searchFirstMilestone returns the first index in [startIndex, endIndex] for which the condition is true,
+// or endIndex+1 if there is none. The condition has to be false for all indexes before and true for all indexes after the result.
+func (s *Storage) searchFirstMilestone(startIndex milestone.Index, endIndex milestone.Index, condition func(timestamp time.Time) bool) (milestone.Index, error) {
+	low, high := startIndex, endIndex+1
+	for low < high {
+		mid := low + (high-low)/2
+
+		timestamp, err := s.milestoneTimestamp(mid)
+		if err != nil {
+			return 0, err
+		}
+
+		if condition(timestamp) {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	return low, nil
+}
+
+// SearchMilestoneIndexByTimestamp searches the milestones in the range [startIndex, endIndex] for the given timestamp
+// and returns the index of the milestone selected by the search mode.
+// The timestamps of the milestones are expected to be monotonically increasing and all milestones in the range have to exist in the storage.
+func (s *Storage) SearchMilestoneIndexByTimestamp(timestamp time.Time, startIndex milestone.Index, endIndex milestone.Index, mode MilestoneSearchMode) (milestone.Index, error) {
+
+	if startIndex > endIndex {
+		return 0, ErrMilestoneNotFound
+	}
+
+	switch mode {
+	case MilestoneSearchBefore:
+		// the first milestone after the timestamp is the successor of the searched one
+		after, err := s.searchFirstMilestone(startIndex, endIndex, func(msTimestamp time.Time) bool { return msTimestamp.After(timestamp) })
+		if err != nil {
+			return 0, err
+		}
+		if after == startIndex {
+			return 0, ErrMilestoneNotFound
+		}
+		return after - 1, nil
+
+	case MilestoneSearchAfter:
+		index, err := s.searchFirstMilestone(startIndex, endIndex, func(msTimestamp time.Time) bool { return !msTimestamp.Before(timestamp) })
+		if err != nil {
+			return 0, err
+		}
+		if index > endIndex {
+			return 0, ErrMilestoneNotFound
+		}
+		return index, nil
+
+	case MilestoneSearchClosest:
+		index, err := s.searchFirstMilestone(startIndex, endIndex, func(msTimestamp time.Time) bool { return !msTimestamp.Before(timestamp) })
+		if err != nil {
+			return 0, err
+		}
+
+		switch {
+		case index > endIndex:
+			// all milestones are older than the timestamp
+			return endIndex, nil
+		case index == startIndex:
+			// all milestones are younger than the timestamp
+			return startIndex, nil
+		}
+
+		// compare the first milestone after and the last milestone before the timestamp
+		afterTimestamp, err := s.milestoneTimestamp(index)
+		if err != nil {
+			return 0, err
+		}
+
+		beforeTimestamp, err := s.milestoneTimestamp(index - 1)
+		if err != nil {
+			return 0, err
+		}
+
+		if afterTimestamp.Sub(timestamp) < timestamp.Sub(beforeTimestamp) {
+			return index, nil
+		}
+		return index - 1, nil
+
+	default:
+		return 0, errors.Errorf("unknown milestone search mode: %d", mode)
+	}
+}
@@ -0,0 +1,93 @@
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+)
+
+func TestSearchMilestoneIndexByTimestamp(t *testing.T) {
+
+	dbStorage, err := storage.New(mapdb.NewMapDB(), mapdb.NewMapDB())
+	require.NoError(t, err)
+	defer dbStorage.ShutdownStorages()
+
+	genesis := time.Unix(1600000000, 0)
+
+	// milestones 10-20 every 10 seconds, milestone 15 and 16 share the same timestamp
+	timestamps := make(map[milestone.Index]time.Time)
+	for index := milestone.Index(10); index <= 20; index++ {
+		offset := int64(index-10) * 10
+		if index >= 16 {
+			offset -= 10
+		}
+		timestamps[index] = genesis.Add(time.Duration(offset) * time.Second)
+
+		cachedMilestone, _ := dbStorage.StoreMilestoneIfAbsent(index, hornet.NullMessageID(), timestamps[index])
+		cachedMilestone.Release(true)
+	}
+
+	search := func(timestamp time.Time, mode storage.MilestoneSearchMode) (milestone.Index, error) {
+		return dbStorage.SearchMilestoneIndexByTimestamp(timestamp, 10, 20, mode)
+	}
+
+	// exact matches
+	index, err := search(timestamps[12], storage.MilestoneSearchClosest)
+	require.NoError(t, err)
+	require.Equal(t, milestone.Index(12), index)
+
+	index, err = search(timestamps[15], storage.MilestoneSearchBefore)
+	require.NoError(t, err)
+	require.Equal(t, milestone.Index(16), index)
+
+	index, err = search(timestamps[15], storage.MilestoneSearchAfter)
+	require.NoError(t, err)
+	require.Equal(t, milestone.Index(15), index)
+
+	// between milestones 12 and 13
+	index, err = search(timestamps[12].Add(4*time.Second), storage.MilestoneSearchClosest)
+	require.NoError(t, err)
+	require.Equal(t, milestone.Index(12), index)
+
+	index, err = search(timestamps[12].Add(6*time.Second), storage.MilestoneSearchClosest)
+	require.NoError(t, err)
+	require.Equal(t, milestone.Index(13), index)
+
+	// equally close returns the older milestone
+	index, err = search(timestamps[12].Add(5*time.Second), storage.MilestoneSearchClosest)
+	require.NoError(t, err)
+	require.Equal(t, milestone.Index(12), index)
+
+	index, err = search(timestamps[12].Add(6*time.Second), storage.MilestoneSearchBefore)
+	require.NoError(t, err)
+	require.Equal(t, milestone.Index(12), index)
+
+	index, err = search(timestamps[12].Add(4*time.Second), storage.MilestoneSearchAfter)
+	require.NoError(t, err)
+	require.Equal(t, milestone.Index(13), index)
+
+	// outside of the range
+	index, err = search(genesis.Add(-time.Hour), storage.MilestoneSearchClosest)
+	require.NoError(t, err)
+	require.Equal(t, milestone.Index(10), index)
+
+	index, err = search(genesis.Add(time.Hour), storage.MilestoneSearchClosest)
+	require.NoError(t, err)
+	require.Equal(t, milestone.Index(20), index)
+
+	_, err = search(genesis.Add(-time.Hour), storage.MilestoneSearchBefore)
+	require.ErrorIs(t, err, storage.ErrMilestoneNotFound)
+
+	_, err = search(genesis.Add(time.Hour), storage.MilestoneSearchAfter)
+	require.ErrorIs(t, err, storage.ErrMilestoneNotFound)
+
+	// missing milestones in the searched range
+	_, err = dbStorage.SearchMilestoneIndexByTimestamp(genesis.Add(time.Hour), 10, 30, storage.MilestoneSearchClosest)
+	require.ErrorIs(t, err, storage.ErrMilestoneNotFound)
+}
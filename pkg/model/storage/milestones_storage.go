@@ -190,10 +190,11 @@ func (s *Storage) StoreMilestoneIfAbsent(index milestone.Index, messageID hornet
 	return &CachedMilestone{CachedObject: cachedMs}, newlyAdded
 }
 
-// DeleteMilestone deletes the milestone in the cache/persistence layer.
+// DeleteMilestone deletes the milestone and its referenced messages in the cache/persistence layer.
 // +-0
-func (s *Storage) DeleteMilestone(milestoneIndex milestone.Index) {
+func (s *Storage) DeleteMilestone(milestoneIndex milestone.Index) error {
 	s.milestoneStorage.Delete(databaseKeyForMilestoneIndex(milestoneIndex))
+	return s.DeleteReferencedMessages(milestoneIndex)
 }
 
 // ShutdownMilestoneStorage shuts down milestones storage.
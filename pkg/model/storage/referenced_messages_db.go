@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/common"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/iotaledger/hive.go/kvstore"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+// LedgerInclusionState defines the ledger inclusion state of a message that was referenced by a milestone.
+type LedgerInclusionState byte
+
+const (
+	// LedgerInclusionStateNoTransaction the message does not contain a transaction.
+	LedgerInclusionStateNoTransaction LedgerInclusionState = iota
+	// LedgerInclusionStateIncluded the transaction of the message was applied to the ledger.
+	LedgerInclusionStateIncluded
+	// LedgerInclusionStateConflicting the transaction of the message was conflicting and not applied to the ledger.
+	LedgerInclusionStateConflicting
+)
+
+// String returns the name of the ledger inclusion state as it is used in the REST API.
+func (s LedgerInclusionState) String() string {
+	switch s {
+	case LedgerInclusionStateNoTransaction:
+		return "noTransaction"
+	case LedgerInclusionStateIncluded:
+		return "included"
+	case LedgerInclusionStateConflicting:
+		return "conflicting"
+	default:
+		return fmt.Sprintf("unknown ledger inclusion state: %d", s)
+	}
+}
+
+const (
+	// referencedMessageSerializedLength is the length of a serialized ReferencedMessage (MessageID + inclusion state + conflict).
+	referencedMessageSerializedLength = iotago.MessageIDLength + 1 + 1
+)
+
+// ReferencedMessage is a message that was referenced by a milestone.
+type ReferencedMessage struct {
+	// The ID of the message.
+	MessageID hornet.MessageID
+	// The ledger inclusion state of the message.
+	InclusionState LedgerInclusionState
+	// The reason why the message is marked as conflicting.
+	Conflict Conflict
+}
+
+func (r *ReferencedMessage) bytes() []byte {
+	value := make([]byte, 0, referencedMessageSerializedLength)
+	value = append(value, r.MessageID...)
+	value = append(value, byte(r.InclusionState), byte(r.Conflict))
+	return value
+}
+
+func referencedMessageFromBytes(value []byte) (*ReferencedMessage, error) {
+	if len(value) != referencedMessageSerializedLength {
+		return nil, fmt.Errorf("invalid referenced message length: %d", len(value))
+	}
+
+	return &ReferencedMessage{
+		MessageID:      hornet.MessageIDFromSlice(value[:iotago.MessageIDLength]),
+		InclusionState: LedgerInclusionState(value[iotago.MessageIDLength]),
+		Conflict:       Conflict(value[iotago.MessageIDLength+1]),
+	}, nil
+}
+
+func (s *Storage) configureReferencedMessagesStore(store kvstore.KVStore) {
+	s.referencedMessagesStore = store.WithRealm([]byte{common.StorePrefixReferencedMessages})
+}
+
+// the count of referenced messages of a milestone is stored with the milestone index as key,
+// the referenced messages themselves with the milestone index + their position in the white-flag order.
+func databaseKeyForReferencedMessagesCount(msIndex milestone.Index) []byte {
+	return databaseKeyForMilestoneIndex(msIndex)
+}
+
+func databaseKeyForReferencedMessage(msIndex milestone.Index, position uint32) []byte {
+	key := make([]byte, 8)
+	binary.LittleEndian.PutUint32(key[:4], uint32(msIndex))
+	binary.LittleEndian.PutUint32(key[4:], position)
+	return key
+}
+
+// StoreReferencedMessages stores the messages referenced by the given milestone in the white-flag order.
+// Previously stored referenced messages of the milestone are replaced.
+func (s *Storage) StoreReferencedMessages(msIndex milestone.Index, referencedMessages []*ReferencedMessage) error {
+
+	if err := s.DeleteReferencedMessages(msIndex); err != nil {
+		return err
+	}
+
+	mutations := s.referencedMessagesStore.Batched()
+
+	for position, referencedMessage := range referencedMessages {
+		if err := mutations.Set(databaseKeyForReferencedMessage(msIndex, uint32(position)), referencedMessage.bytes()); err != nil {
+			mutations.Cancel()
+			return errors.Wrap(NewDatabaseError(err), "failed to store referenced message")
+		}
+	}
+
+	countBytes := make([]byte, 4)
+	binary.LittleEndian.PutUint32(countBytes, uint32(len(referencedMessages)))
+	if err := mutations.Set(databaseKeyForReferencedMessagesCount(msIndex), countBytes); err != nil {
+		mutations.Cancel()
+		return errors.Wrap(NewDatabaseError(err), "failed to store referenced messages count")
+	}
+
+	if err := mutations.Commit(); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to store referenced messages")
+	}
+
+	return nil
+}
+
+// ReferencedMessagesCount returns the amount of messages referenced by the given milestone.
+// It returns false if no referenced messages are stored for the milestone.
+func (s *Storage) ReferencedMessagesCount(msIndex milestone.Index) (uint32, bool, error) {
+	value, err := s.referencedMessagesStore.Get(databaseKeyForReferencedMessagesCount(msIndex))
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, errors.Wrap(NewDatabaseError(err), "failed to retrieve referenced messages count")
+	}
+
+	if len(value) != 4 {
+		return 0, false, errors.Wrap(NewDatabaseError(fmt.Errorf("invalid length: %d", len(value))), "failed to convert referenced messages count")
+	}
+
+	return binary.LittleEndian.Uint32(value), true, nil
+}
+
+// ReferencedMessages returns up to "limit" messages referenced by the given milestone in the white-flag order,
+// starting at the given offset.
+func (s *Storage) ReferencedMessages(msIndex milestone.Index, offset uint32, limit uint32) ([]*ReferencedMessage, error) {
+
+	count, exists, err := s.ReferencedMessagesCount(msIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	referencedMessages := make([]*ReferencedMessage, 0)
+	if !exists {
+		return referencedMessages, nil
+	}
+
+	for position := uint64(offset); position < uint64(count) && position < uint64(offset)+uint64(limit); position++ {
+		value, err := s.referencedMessagesStore.Get(databaseKeyForReferencedMessage(msIndex, uint32(position)))
+		if err != nil {
+			return nil, errors.Wrap(NewDatabaseError(err), "failed to retrieve referenced message")
+		}
+
+		referencedMessage, err := referencedMessageFromBytes(value)
+		if err != nil {
+			return nil, errors.Wrap(NewDatabaseError(err), "failed to convert referenced message")
+		}
+
+		referencedMessages = append(referencedMessages, referencedMessage)
+	}
+
+	return referencedMessages, nil
+}
+
+// DeleteReferencedMessages deletes the referenced messages of the given milestone.
+func (s *Storage) DeleteReferencedMessages(msIndex milestone.Index) error {
+	if err := s.referencedMessagesStore.DeletePrefix(databaseKeyForMilestoneIndex(msIndex)); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to delete referenced messages")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+)
+
+func TestReferencedMessages(t *testing.T) {
+
+	dbStorage, err := storage.New(mapdb.NewMapDB(), mapdb.NewMapDB())
+	require.NoError(t, err)
+	defer dbStorage.ShutdownStorages()
+
+	messageID := func(b byte) hornet.MessageID {
+		id := hornet.NullMessageID()
+		id[0] = b
+		return id
+	}
+
+	referencedMessages := []*storage.ReferencedMessage{
+		{MessageID: messageID(1), InclusionState: storage.LedgerInclusionStateIncluded},
+		{MessageID: messageID(2), InclusionState: storage.LedgerInclusionStateConflicting, Conflict: storage.ConflictInputUTXONotFound},
+		{MessageID: messageID(3), InclusionState: storage.LedgerInclusionStateNoTransaction},
+	}
+
+	_, exists, err := dbStorage.ReferencedMessagesCount(5)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	require.NoError(t, dbStorage.StoreReferencedMessages(5, referencedMessages))
+	require.NoError(t, dbStorage.StoreReferencedMessages(6, referencedMessages[:1]))
+
+	count, exists, err := dbStorage.ReferencedMessagesCount(5)
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.EqualValues(t, 3, count)
+
+	stored, err := dbStorage.ReferencedMessages(5, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, referencedMessages, stored)
+
+	stored, err = dbStorage.ReferencedMessages(5, 2, 10)
+	require.NoError(t, err)
+	require.Equal(t, referencedMessages[2:], stored)
+
+	stored, err = dbStorage.ReferencedMessages(5, 3, 10)
+	require.NoError(t, err)
+	require.Empty(t, stored)
+
+	// deleting the milestone also deletes its referenced messages, but not the ones of other milestones
+	require.NoError(t, dbStorage.DeleteMilestone(5))
+
+	_, exists, err = dbStorage.ReferencedMessagesCount(5)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	stored, err = dbStorage.ReferencedMessages(6, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, referencedMessages[:1], stored)
+}
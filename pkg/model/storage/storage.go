@@ -28,7 +28,8 @@ type Storage struct {
 	healthTrackers []*StoreHealthTracker
 
 	// kv storages
-	snapshotStore kvstore.KVStore
+	snapshotStore           kvstore.KVStore
+	referencedMessagesStore kvstore.KVStore
 
 	// object storages
 	childrenStorage             *objectstorage.ObjectStorage
@@ -184,6 +185,7 @@ func (s *Storage) configureStorages(tangleStore kvstore.KVStore, cachesProfile .
 	}
 
 	s.configureSnapshotStore(tangleStore)
+	s.configureReferencedMessagesStore(tangleStore)
 
 	return nil
 }
@@ -176,6 +176,12 @@ func (u *Manager) MilestoneDiffWithoutLocking(msIndex milestone.Index) (*Milesto
 	return diff, nil
 }
 
+// HasMilestoneDiffWithoutLocking returns true if the diff of the given milestone is stored,
+// without loading the outputs and treasury outputs referenced by the diff.
+func (u *Manager) HasMilestoneDiffWithoutLocking(msIndex milestone.Index) (bool, error) {
+	return u.utxoStorage.Has(milestoneDiffKeyForIndex(msIndex))
+}
+
 func (u *Manager) MilestoneDiff(msIndex milestone.Index) (*MilestoneDiff, error) {
 	u.ReadLockLedger()
 	defer u.ReadUnlockLedger()
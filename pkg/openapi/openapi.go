@@ -0,0 +1,120 @@
+package openapi
+
+const (
+	// Version is the version of the OpenAPI specification the documents are based on.
+	Version = "3.0.3"
+
+	// SecuritySchemeJWT is the name of the security scheme for routes that need a JWT.
+	SecuritySchemeJWT = "jwt"
+
+	// ParameterInPath is used for parameters that are part of the route.
+	ParameterInPath = "path"
+	// ParameterInQuery is used for parameters that are passed as query parameters.
+	ParameterInQuery = "query"
+	// ParameterInHeader is used for parameters that are passed as request headers.
+	ParameterInHeader = "header"
+)
+
+// Document is the root object of an OpenAPI document.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       *Info               `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components *Components         `json:"components,omitempty"`
+}
+
+// Info provides metadata about the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem describes the operations available on a single path, keyed by the lowercase HTTP method.
+type PathItem map[string]*Operation
+
+// Operation describes a single API operation on a path.
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter describes a single operation parameter.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// RequestBody describes a single request body.
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response describes a single response of an operation.
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType provides the schema for a content type.
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Components holds the reusable objects of the document.
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme defines a security scheme that can be used by the operations.
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Schema describes a data type.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// PathParameter creates a description for a path parameter.
+func PathParameter(name string, description string) *Parameter {
+	return &Parameter{
+		Name:        name,
+		In:          ParameterInPath,
+		Description: description,
+		Required:    true,
+		Schema:      &Schema{Type: "string"},
+	}
+}
+
+// QueryParameter creates a description for a query parameter of the given schema type.
+func QueryParameter(name string, description string, schemaType string, required bool) *Parameter {
+	return &Parameter{
+		Name:        name,
+		In:          ParameterInQuery,
+		Description: description,
+		Required:    required,
+		Schema:      &Schema{Type: schemaType},
+	}
+}
@@ -0,0 +1,324 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/gohornet/hornet/pkg/restapi"
+)
+
+const (
+	// MIMEApplicationVendorIOTASerializerV1 is the content type of binary encoded messages.
+	MIMEApplicationVendorIOTASerializerV1 = "application/vnd.iota.serializer-v1"
+	// MIMETextEventStream is the content type of server-sent events.
+	MIMETextEventStream = "text/event-stream"
+)
+
+// notFoundHandlerName is the name of the handler that is used by echo for the routes of group middlewares.
+var notFoundHandlerName = runtime.FuncForPC(reflect.ValueOf(echo.NotFoundHandler).Pointer()).Name()
+
+var documentedMethods = map[string]struct{}{
+	http.MethodGet:    {},
+	http.MethodPost:   {},
+	http.MethodPut:    {},
+	http.MethodPatch:  {},
+	http.MethodDelete: {},
+}
+
+// Route describes a REST API route for the OpenAPI document.
+type Route struct {
+	// the HTTP method of the route.
+	Method string
+	// the full path of the route in the echo format (e.g. "/api/v1/messages/:messageID").
+	Path string
+	// a short summary of what the route does.
+	Summary string
+	// the tags of the route. If empty, the tag is derived from the path.
+	Tags []string
+	// the query and header parameters of the route.
+	// Path parameters are added automatically, but can be listed here to add a description.
+	Parameters []*Parameter
+	// the JSON request body of the route (a zero value of the request type).
+	Request interface{}
+	// the content types of the request body. Defaults to "application/json" if a request is set.
+	RequestContentTypes []string
+	// the JSON response of the route (a zero value of the response type), which is wrapped into the "data" envelope.
+	Response interface{}
+	// the status code of a successful response. Defaults to 200.
+	ResponseStatus int
+	// the content type of a non JSON response.
+	ResponseContentType string
+}
+
+// Registry collects the descriptions of the REST API routes.
+type Registry struct {
+	sync.RWMutex
+	routes map[string]*Route
+}
+
+// NewRegistry creates a new registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		routes: make(map[string]*Route),
+	}
+}
+
+func routeKey(method string, path string) string {
+	return fmt.Sprintf("%s %s", method, path)
+}
+
+// Register adds the descriptions of the given routes to the registry.
+func (r *Registry) Register(routes ...*Route) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, route := range routes {
+		r.routes[routeKey(route.Method, route.Path)] = route
+	}
+}
+
+// Route returns the description of the route with the given method and path, or nil if it was not registered.
+func (r *Registry) Route(method string, path string) *Route {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.routes[routeKey(method, path)]
+}
+
+// Document creates the OpenAPI document for the given registered echo routes.
+// Only routes that are registered at the echo instance are included, so routes of disabled plugins are not part of the document.
+// The isPublic function is used to determine whether a route can be called without a JWT.
+func (r *Registry) Document(info *Info, echoRoutes []*echo.Route, isPublic func(path string) bool) *Document {
+
+	generator := newSchemaGenerator()
+	errorSchema := generator.schemaForValue(&restapi.HTTPErrorResponseEnvelope{})
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]PathItem),
+		Components: &Components{
+			SecuritySchemes: map[string]*SecurityScheme{
+				SecuritySchemeJWT: {
+					Type:         "http",
+					Scheme:       "bearer",
+					BearerFormat: "JWT",
+				},
+			},
+		},
+	}
+
+	for _, echoRoute := range echoRoutes {
+		if _, documented := documentedMethods[echoRoute.Method]; !documented {
+			continue
+		}
+
+		if echoRoute.Name == notFoundHandlerName || strings.Contains(echoRoute.Path, "*") {
+			// these routes are only used for middlewares and proxies
+			continue
+		}
+
+		route := r.Route(echoRoute.Method, echoRoute.Path)
+		if route == nil {
+			route = &Route{Method: echoRoute.Method, Path: echoRoute.Path}
+		}
+
+		operation := r.operation(generator, route, errorSchema)
+		if isPublic != nil && !isPublic(route.Path) {
+			operation.Security = []map[string][]string{{SecuritySchemeJWT: {}}}
+		}
+
+		openAPIPath := openAPIPath(route.Path)
+		if _, exists := doc.Paths[openAPIPath]; !exists {
+			doc.Paths[openAPIPath] = make(PathItem)
+		}
+		doc.Paths[openAPIPath][strings.ToLower(route.Method)] = operation
+	}
+
+	doc.Components.Schemas = generator.schemas
+
+	return doc
+}
+
+func (r *Registry) operation(generator *schemaGenerator, route *Route, errorSchema *Schema) *Operation {
+
+	operation := &Operation{
+		OperationID: operationID(route.Method, route.Path),
+		Summary:     route.Summary,
+		Tags:        route.Tags,
+		Parameters:  parameters(route),
+		Responses: map[string]*Response{
+			"default": {
+				Description: "Error",
+				Content: map[string]*MediaType{
+					echo.MIMEApplicationJSON: {Schema: errorSchema},
+				},
+			},
+		},
+	}
+
+	if len(operation.Tags) == 0 {
+		operation.Tags = []string{tagForPath(route.Path)}
+	}
+
+	if route.Request != nil || len(route.RequestContentTypes) > 0 {
+		contentTypes := route.RequestContentTypes
+		if len(contentTypes) == 0 {
+			contentTypes = []string{echo.MIMEApplicationJSON}
+		}
+
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  make(map[string]*MediaType),
+		}
+
+		for _, contentType := range contentTypes {
+			if contentType == echo.MIMEApplicationJSON {
+				operation.RequestBody.Content[contentType] = &MediaType{Schema: generator.schemaForValue(route.Request)}
+				continue
+			}
+			operation.RequestBody.Content[contentType] = &MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+		}
+	}
+
+	status := route.ResponseStatus
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	response := &Response{Description: http.StatusText(status)}
+	switch {
+	case route.ResponseContentType == echo.MIMEOctetStream:
+		response.Content = map[string]*MediaType{
+			route.ResponseContentType: {Schema: &Schema{Type: "string", Format: "binary"}},
+		}
+
+	case route.ResponseContentType == echo.MIMEApplicationJSON:
+		// JSON response without the "data" envelope
+		response.Content = map[string]*MediaType{
+			route.ResponseContentType: {Schema: &Schema{Type: "object"}},
+		}
+
+	case route.ResponseContentType != "":
+		response.Content = map[string]*MediaType{
+			route.ResponseContentType: {Schema: &Schema{Type: "string"}},
+		}
+
+	case route.Response != nil:
+		response.Content = map[string]*MediaType{
+			echo.MIMEApplicationJSON: {
+				Schema: &Schema{
+					Type: "object",
+					Properties: map[string]*Schema{
+						"data": generator.schemaForValue(route.Response),
+					},
+					Required: []string{"data"},
+				},
+			},
+		}
+	}
+	operation.Responses[strconv.Itoa(status)] = response
+
+	return operation
+}
+
+// parameters returns the parameters of the route including all path parameters.
+func parameters(route *Route) []*Parameter {
+
+	var params []*Parameter
+
+	documentedPathParams := make(map[string]*Parameter)
+	for _, param := range route.Parameters {
+		if param.In == ParameterInPath {
+			documentedPathParams[param.Name] = param
+		}
+	}
+
+	for _, segment := range strings.Split(route.Path, "/") {
+		if !strings.HasPrefix(segment, ":") {
+			continue
+		}
+
+		name := strings.TrimPrefix(segment, ":")
+		if param, exists := documentedPathParams[name]; exists {
+			params = append(params, param)
+			continue
+		}
+
+		params = append(params, &Parameter{
+			Name:     name,
+			In:       ParameterInPath,
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+
+	for _, param := range route.Parameters {
+		if param.In != ParameterInPath {
+			params = append(params, param)
+		}
+	}
+
+	return params
+}
+
+// openAPIPath converts the echo path parameters (":param") to OpenAPI path parameters ("{param}").
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = fmt.Sprintf("{%s}", strings.TrimPrefix(segment, ":"))
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// operationID creates a unique ID for the operation based on the method and the path,
+// e.g. "GET /api/v1/messages/:messageID/metadata" => "getApiV1MessagesByMessageIDMetadata".
+func operationID(method string, path string) string {
+
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(method))
+
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" {
+			continue
+		}
+
+		if strings.HasPrefix(segment, ":") {
+			sb.WriteString("By")
+			segment = strings.TrimPrefix(segment, ":")
+		}
+
+		for _, part := range strings.FieldsFunc(segment, func(r rune) bool { return r == '-' || r == '.' || r == '_' }) {
+			sb.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+
+	return sb.String()
+}
+
+// tagForPath derives the tag of a route from its path,
+// e.g. "/api/v1/messages" => "messages", "/api/plugins/faucet/info" => "faucet".
+func tagForPath(path string) string {
+
+	segments := strings.FieldsFunc(path, func(r rune) bool { return r == '/' })
+
+	switch {
+	case len(segments) >= 3 && segments[0] == "api" && segments[1] == "plugins":
+		return segments[2]
+	case len(segments) >= 3 && segments[0] == "api":
+		return segments[2]
+	case len(segments) > 0:
+		return segments[len(segments)-1]
+	default:
+		return "root"
+	}
+}
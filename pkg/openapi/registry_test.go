@@ -0,0 +1,136 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/openapi"
+)
+
+type testParent struct {
+	Name string `json:"name"`
+}
+
+type testResponse struct {
+	testParent
+	MessageID string            `json:"messageId"`
+	Index     uint32            `json:"index"`
+	Amount    *uint64           `json:"amount,omitempty"`
+	Parents   []*testResponse   `json:"parents"`
+	Data      []byte            `json:"data"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Ignored   string            `json:"-"`
+	internal  string
+}
+
+type testRequest struct {
+	Address string `json:"address"`
+}
+
+func TestRegistryDocument(t *testing.T) {
+
+	e := echo.New()
+	noop := func(c echo.Context) error { return nil }
+	e.GET("/api/v1/messages/:messageID/metadata", noop)
+	e.POST("/api/plugins/test/requests", noop)
+	e.DELETE("/api/plugins/test/requests/:requestID", noop)
+	e.GET("/api/v1/undocumented", noop)
+
+	// routes of middlewares should not be part of the document
+	e.Group("/mqtt").Use(func(next echo.HandlerFunc) echo.HandlerFunc { return next })
+
+	registry := openapi.NewRegistry()
+	registry.Register(
+		&openapi.Route{
+			Method:   http.MethodGet,
+			Path:     "/api/v1/messages/:messageID/metadata",
+			Summary:  "Returns the metadata of a message.",
+			Response: &testResponse{},
+		},
+		&openapi.Route{
+			Method:         http.MethodPost,
+			Path:           "/api/plugins/test/requests",
+			Summary:        "Creates a request.",
+			Request:        &testRequest{},
+			Response:       &testResponse{},
+			ResponseStatus: http.StatusAccepted,
+			Parameters: []*openapi.Parameter{
+				{Name: "timeout", In: openapi.ParameterInQuery, Schema: &openapi.Schema{Type: "string"}},
+			},
+		},
+		&openapi.Route{
+			Method:         http.MethodDelete,
+			Path:           "/api/plugins/test/requests/:requestID",
+			ResponseStatus: http.StatusNoContent,
+		},
+		// registered, but not part of the echo routes (e.g. disabled plugin)
+		&openapi.Route{
+			Method: http.MethodGet,
+			Path:   "/api/plugins/disabled/info",
+		},
+	)
+
+	doc := registry.Document(&openapi.Info{Title: "Test", Version: "1.0.0"}, e.Routes(), func(path string) bool {
+		return path != "/api/plugins/test/requests/:requestID"
+	})
+
+	require.Equal(t, openapi.Version, doc.OpenAPI)
+	require.Len(t, doc.Paths, 4)
+	require.Contains(t, doc.Paths, "/api/v1/messages/{messageID}/metadata")
+	require.Contains(t, doc.Paths, "/api/plugins/test/requests")
+	require.Contains(t, doc.Paths, "/api/plugins/test/requests/{requestID}")
+	require.Contains(t, doc.Paths, "/api/v1/undocumented")
+
+	metadata := doc.Paths["/api/v1/messages/{messageID}/metadata"]["get"]
+	require.Equal(t, "getApiV1MessagesByMessageIDMetadata", metadata.OperationID)
+	require.Equal(t, []string{"messages"}, metadata.Tags)
+	require.Len(t, metadata.Parameters, 1)
+	require.Equal(t, "messageID", metadata.Parameters[0].Name)
+	require.Equal(t, openapi.ParameterInPath, metadata.Parameters[0].In)
+	require.True(t, metadata.Parameters[0].Required)
+	require.Empty(t, metadata.Security)
+	require.Contains(t, metadata.Responses, "200")
+	require.Contains(t, metadata.Responses, "default")
+
+	dataSchema := metadata.Responses["200"].Content[echo.MIMEApplicationJSON].Schema.Properties["data"]
+	require.Equal(t, "#/components/schemas/openapi_test.testResponse", dataSchema.Ref)
+
+	responseSchema := doc.Components.Schemas["openapi_test.testResponse"]
+	require.NotNil(t, responseSchema)
+	require.ElementsMatch(t, []string{"name", "messageId", "index", "amount", "parents", "data", "labels"}, keys(responseSchema.Properties))
+	require.ElementsMatch(t, []string{"name", "messageId", "index", "parents", "data"}, responseSchema.Required)
+	require.Equal(t, "integer", responseSchema.Properties["index"].Type)
+	require.Equal(t, "string", responseSchema.Properties["data"].Type)
+	require.Equal(t, "byte", responseSchema.Properties["data"].Format)
+	require.Equal(t, "array", responseSchema.Properties["parents"].Type)
+	require.Equal(t, "#/components/schemas/openapi_test.testResponse", responseSchema.Properties["parents"].Items.Ref)
+	require.Equal(t, "string", responseSchema.Properties["labels"].AdditionalProperties.Type)
+
+	create := doc.Paths["/api/plugins/test/requests"]["post"]
+	require.Equal(t, []string{"test"}, create.Tags)
+	require.Contains(t, create.Responses, "202")
+	require.Len(t, create.Parameters, 1)
+	require.Equal(t, "timeout", create.Parameters[0].Name)
+	require.Equal(t, "#/components/schemas/openapi_test.testRequest", create.RequestBody.Content[echo.MIMEApplicationJSON].Schema.Ref)
+
+	remove := doc.Paths["/api/plugins/test/requests/{requestID}"]["delete"]
+	require.Contains(t, remove.Responses, "204")
+	require.Nil(t, remove.Responses["204"].Content)
+	require.Equal(t, []map[string][]string{{openapi.SecuritySchemeJWT: {}}}, remove.Security)
+
+	// the document must be serializable
+	_, err := json.Marshal(doc)
+	require.NoError(t, err)
+}
+
+func keys(m map[string]*openapi.Schema) []string {
+	var result []string
+	for key := range m {
+		result = append(result, key)
+	}
+	return result
+}
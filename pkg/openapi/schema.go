@@ -0,0 +1,219 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemaGenerator generates schemas for go types based on their JSON encoding.
+// Named struct types are collected as reusable component schemas.
+type schemaGenerator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// schemaForValue returns the schema for the type of the given value.
+func (g *schemaGenerator) schemaForValue(value interface{}) *Schema {
+	if value == nil {
+		return &Schema{}
+	}
+	return g.schemaForType(reflect.TypeOf(value))
+}
+
+func minimumZero() *float64 {
+	min := float64(0)
+	return &min
+}
+
+// implements returns whether the type or a pointer to the type implements the given interface.
+func implements(t reflect.Type, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PtrTo(t).Implements(iface)
+}
+
+func (g *schemaGenerator) schemaForType(t reflect.Type) *Schema {
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+
+	case t == rawMessageType:
+		// any JSON value
+		return &Schema{}
+
+	case implements(t, jsonMarshalerType):
+		// the JSON encoding is defined by the type itself (e.g. iota.go payloads)
+		return &Schema{Description: fmt.Sprintf("JSON encoding of %s", t.String())}
+
+	case implements(t, textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+
+	case reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer", Format: "int32", Minimum: minimumZero()}
+
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64", Minimum: minimumZero()}
+
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+
+	case reflect.String:
+		return &Schema{Type: "string"}
+
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// byte slices are base64 encoded
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaForType(t.Elem())}
+
+	case reflect.Array:
+		return &Schema{Type: "array", Items: g.schemaForType(t.Elem())}
+
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaForType(t.Elem())}
+
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + g.componentName(t)}
+
+	default:
+		// interfaces can contain any JSON value
+		return &Schema{}
+	}
+}
+
+// componentName returns the name of the component schema for the named struct type.
+// The schema is generated the first time the type is referenced.
+func (g *schemaGenerator) componentName(t reflect.Type) string {
+
+	if name, exists := g.names[t]; exists {
+		return name
+	}
+
+	name := fmt.Sprintf("%s.%s", path.Base(t.PkgPath()), t.Name())
+	for i := 2; ; i++ {
+		if _, exists := g.schemas[name]; !exists {
+			break
+		}
+		// another type with the same package and type name was already registered
+		name = fmt.Sprintf("%s.%s%d", path.Base(t.PkgPath()), t.Name(), i)
+	}
+
+	// register the name before generating the schema to support recursive types
+	schema := &Schema{}
+	g.names[t] = name
+	g.schemas[name] = schema
+
+	*schema = *g.structSchema(t)
+
+	return name
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) *Schema {
+
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+
+	g.addStructFields(schema, t)
+
+	return schema
+}
+
+func (g *schemaGenerator) addStructFields(schema *Schema, t reflect.Type) {
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		tagParts := strings.Split(tag, ",")
+		name := tagParts[0]
+
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+
+			if fieldType.Kind() == reflect.Struct {
+				// the fields of embedded structs are promoted to the outer object
+				g.addStructFields(schema, fieldType)
+				continue
+			}
+		}
+
+		if field.PkgPath != "" {
+			// unexported field
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		omitEmpty := false
+		asString := false
+		for _, option := range tagParts[1:] {
+			switch option {
+			case "omitempty":
+				omitEmpty = true
+			case "string":
+				asString = true
+			}
+		}
+
+		fieldSchema := g.schemaForType(field.Type)
+		if asString {
+			fieldSchema = &Schema{Type: "string"}
+		}
+
+		schema.Properties[name] = fieldSchema
+		if !omitEmpty {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package p2p
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/kvstore"
+)
+
+const (
+	// the maximum amount of last known good addresses kept per peer.
+	maxGoodAddressesPerPeer = 5
+)
+
+var (
+	// the realm of the address book within the peer store database.
+	addressBookRealm = []byte("addressbook")
+)
+
+var (
+	// ErrAddressBookPeerNotFound is returned if a peer is not part of the address book.
+	ErrAddressBookPeerNotFound = errors.New("peer not found in address book")
+)
+
+// AddressBookEntry holds the persisted information about a peer.
+type AddressBookEntry struct {
+	// The multi address under which the peer was added via the API, including the peer ID.
+	// Peers with a multi address are reconnected on startup.
+	MultiAddress string `json:"multiAddress,omitempty"`
+	// The alias of the peer.
+	Alias string `json:"alias,omitempty"`
+	// The addresses under which a connection to the peer was successfully established, most recent first.
+	GoodAddresses []string `json:"goodAddresses,omitempty"`
+	// The unix timestamp of the last successful connection to the peer.
+	LastConnected int64 `json:"lastConnected,omitempty"`
+}
+
+// AddressBook persists peers added via the API and the last known good addresses of peers.
+type AddressBook struct {
+	// used to serialize read-modify-write operations on the entries.
+	entriesLock sync.Mutex
+	store       kvstore.KVStore
+}
+
+// NewAddressBook creates a new AddressBook which persists its entries in the given store.
+func NewAddressBook(store kvstore.KVStore) *AddressBook {
+	return &AddressBook{store: store}
+}
+
+// returns the entry of the given peer or nil if it doesn't exist.
+// entriesLock must be acquired outside.
+func (ab *AddressBook) entry(peerID peer.ID) (*AddressBookEntry, error) {
+	data, err := ab.store.Get([]byte(peerID))
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read address book entry of peer %s: %w", peerID.ShortString(), err)
+	}
+
+	entry := &AddressBookEntry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, fmt.Errorf("unable to parse address book entry of peer %s: %w", peerID.ShortString(), err)
+	}
+	return entry, nil
+}
+
+// stores the given entry of the given peer or deletes it if it holds no information anymore.
+// entriesLock must be acquired outside.
+func (ab *AddressBook) storeEntry(peerID peer.ID, entry *AddressBookEntry) error {
+	if entry.MultiAddress == "" && len(entry.GoodAddresses) == 0 {
+		return ab.store.Delete([]byte(peerID))
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return ab.store.Set([]byte(peerID), data)
+}
+
+// Entry returns the entry of the given peer.
+func (ab *AddressBook) Entry(peerID peer.ID) (*AddressBookEntry, error) {
+	ab.entriesLock.Lock()
+	defer ab.entriesLock.Unlock()
+
+	entry, err := ab.entry(peerID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, ErrAddressBookPeerNotFound
+	}
+	return entry, nil
+}
+
+// GoodAddresses returns the last known good addresses of the given peer, most recent first.
+func (ab *AddressBook) GoodAddresses(peerID peer.ID) []multiaddr.Multiaddr {
+	entry, err := ab.Entry(peerID)
+	if err != nil {
+		return nil
+	}
+
+	addrs := make([]multiaddr.Multiaddr, 0, len(entry.GoodAddresses))
+	for _, addrStr := range entry.GoodAddresses {
+		addr, err := multiaddr.NewMultiaddr(addrStr)
+		if err != nil {
+			// ignore invalid values in the database
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// AddGoodAddress marks the given address as an address under which a connection to the given peer succeeded.
+func (ab *AddressBook) AddGoodAddress(peerID peer.ID, addr multiaddr.Multiaddr) error {
+	ab.entriesLock.Lock()
+	defer ab.entriesLock.Unlock()
+
+	entry, err := ab.entry(peerID)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		entry = &AddressBookEntry{}
+	}
+
+	addrStr := addr.String()
+	goodAddresses := []string{addrStr}
+	for _, existing := range entry.GoodAddresses {
+		if existing == addrStr {
+			continue
+		}
+		if len(goodAddresses) == maxGoodAddressesPerPeer {
+			break
+		}
+		goodAddresses = append(goodAddresses, existing)
+	}
+	entry.GoodAddresses = goodAddresses
+	entry.LastConnected = time.Now().Unix()
+
+	return ab.storeEntry(peerID, entry)
+}
+
+// AddPeer persists the given peer, so that it is reconnected on startup.
+func (ab *AddressBook) AddPeer(multiAddress multiaddr.Multiaddr, alias string) error {
+	ab.entriesLock.Lock()
+	defer ab.entriesLock.Unlock()
+
+	addrInfo, err := peer.AddrInfoFromP2pAddr(multiAddress)
+	if err != nil {
+		return err
+	}
+
+	entry, err := ab.entry(addrInfo.ID)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		entry = &AddressBookEntry{}
+	}
+
+	entry.MultiAddress = multiAddress.String()
+	entry.Alias = alias
+
+	return ab.storeEntry(addrInfo.ID, entry)
+}
+
+// RemovePeer removes the given peer from the peers which are reconnected on startup.
+// Its last known good addresses are kept.
+func (ab *AddressBook) RemovePeer(peerID peer.ID) error {
+	ab.entriesLock.Lock()
+	defer ab.entriesLock.Unlock()
+
+	entry, err := ab.entry(peerID)
+	if err != nil {
+		return err
+	}
+	if entry == nil || entry.MultiAddress == "" {
+		return ErrAddressBookPeerNotFound
+	}
+
+	entry.MultiAddress = ""
+	entry.Alias = ""
+
+	return ab.storeEntry(peerID, entry)
+}
+
+// Peers returns all peers which were added to the address book.
+func (ab *AddressBook) Peers() ([]*PeerConfig, error) {
+	ab.entriesLock.Lock()
+	defer ab.entriesLock.Unlock()
+
+	var peers []*PeerConfig
+	var innerErr error
+	if err := ab.store.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		entry := &AddressBookEntry{}
+		if err := json.Unmarshal(value, entry); err != nil {
+			innerErr = fmt.Errorf("unable to parse address book entry: %w", err)
+			return false
+		}
+
+		if entry.MultiAddress == "" {
+			return true
+		}
+
+		peers = append(peers, &PeerConfig{
+			MultiAddress: entry.MultiAddress,
+			Alias:        entry.Alias,
+		})
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	return peers, nil
+}
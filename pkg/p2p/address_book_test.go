@@ -0,0 +1,59 @@
+package p2p_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/p2p"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+)
+
+func TestAddressBook(t *testing.T) {
+	addressBook := p2p.NewAddressBook(mapdb.NewMapDB())
+
+	peerID, err := peer.Decode("12D3KooWHjcCgWPnUEP8wNdbL2fx63Cmosk16xyZ25iUZagxmHb4")
+	require.NoError(t, err)
+
+	multiAddr, err := multiaddr.NewMultiaddr(fmt.Sprintf("/dns4/node01.example.com/tcp/15600/p2p/%s", peerID))
+	require.NoError(t, err)
+
+	// unknown peers have no entry
+	_, err = addressBook.Entry(peerID)
+	require.ErrorIs(t, err, p2p.ErrAddressBookPeerNotFound)
+	require.Empty(t, addressBook.GoodAddresses(peerID))
+
+	require.NoError(t, addressBook.AddPeer(multiAddr, "node01"))
+
+	peers, err := addressBook.Peers()
+	require.NoError(t, err)
+	require.Equal(t, []*p2p.PeerConfig{{MultiAddress: multiAddr.String(), Alias: "node01"}}, peers)
+
+	// the most recent good address comes first and duplicates are removed
+	for i := 1; i <= 7; i++ {
+		addr, err := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/192.0.2.%d/tcp/15600", i))
+		require.NoError(t, err)
+		require.NoError(t, addressBook.AddGoodAddress(peerID, addr))
+	}
+	addr, err := multiaddr.NewMultiaddr("/ip4/192.0.2.5/tcp/15600")
+	require.NoError(t, err)
+	require.NoError(t, addressBook.AddGoodAddress(peerID, addr))
+
+	goodAddresses := addressBook.GoodAddresses(peerID)
+	require.Len(t, goodAddresses, 5)
+	require.Equal(t, "/ip4/192.0.2.5/tcp/15600", goodAddresses[0].String())
+	require.Equal(t, "/ip4/192.0.2.7/tcp/15600", goodAddresses[1].String())
+	require.Equal(t, "/ip4/192.0.2.3/tcp/15600", goodAddresses[4].String())
+
+	// removing the peer keeps the good addresses
+	require.NoError(t, addressBook.RemovePeer(peerID))
+	require.ErrorIs(t, addressBook.RemovePeer(peerID), p2p.ErrAddressBookPeerNotFound)
+
+	peers, err = addressBook.Peers()
+	require.NoError(t, err)
+	require.Empty(t, peers)
+	require.Len(t, addressBook.GoodAddresses(peerID), 5)
+}
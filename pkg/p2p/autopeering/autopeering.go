@@ -7,6 +7,8 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -23,6 +25,7 @@ import (
 	"github.com/iotaledger/hive.go/autopeering/selection"
 	"github.com/iotaledger/hive.go/autopeering/server"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/iputils"
 	"github.com/iotaledger/hive.go/logger"
@@ -283,10 +286,79 @@ func parseEntryNode(entryNodeMultiAddrStr string, preferIPv6 bool) (entryNode *p
 	return peer.NewPeer(identity.New(*pubKey), ip, services), nil
 }
 
+// NeighborRejectedCaller is used to signal rejected neighbors.
+func NeighborRejectedCaller(handler interface{}, params ...interface{}) {
+	handler.(func(id identity.ID, reason error))(params[0].(identity.ID), params[1].(error))
+}
+
+// AutopeeringManagerEvents are events fired by the AutopeeringManager.
+type AutopeeringManagerEvents struct {
+	// Fired when a neighbor was rejected because it didn't pass the acceptance filters.
+	NeighborRejected *events.Event
+}
+
+// AutopeeringManagerOptions define options for an AutopeeringManager.
+type AutopeeringManagerOptions struct {
+	// the peers which are never accepted as neighbors.
+	excludedPeers map[peer2.ID]struct{}
+	// the subnets of which peers are never accepted as neighbors.
+	excludedSubnets []*net.IPNet
+	// the duration for which rejected neighbors are not accepted again.
+	rejectionDuration time.Duration
+}
+
+var defaultAutopeeringManagerOptions = []AutopeeringManagerOption{
+	WithAutopeeringRejectionDuration(30 * time.Minute),
+}
+
+// AutopeeringManagerOption is a function setting an AutopeeringManagerOptions option.
+type AutopeeringManagerOption func(opts *AutopeeringManagerOptions)
+
+// WithAutopeeringExcludedPeers defines the peers which are never accepted as neighbors.
+func WithAutopeeringExcludedPeers(peerIDs []peer2.ID) AutopeeringManagerOption {
+	return func(opts *AutopeeringManagerOptions) {
+		opts.excludedPeers = make(map[peer2.ID]struct{}, len(peerIDs))
+		for _, peerID := range peerIDs {
+			opts.excludedPeers[peerID] = struct{}{}
+		}
+	}
+}
+
+// WithAutopeeringExcludedSubnets defines the subnets of which peers are never accepted as neighbors.
+func WithAutopeeringExcludedSubnets(subnets []*net.IPNet) AutopeeringManagerOption {
+	return func(opts *AutopeeringManagerOptions) {
+		opts.excludedSubnets = subnets
+	}
+}
+
+// WithAutopeeringRejectionDuration defines the duration for which rejected neighbors are not accepted again.
+func WithAutopeeringRejectionDuration(rejectionDuration time.Duration) AutopeeringManagerOption {
+	return func(opts *AutopeeringManagerOptions) {
+		opts.rejectionDuration = rejectionDuration
+	}
+}
+
+// applies the given AutopeeringManagerOption.
+func (amo *AutopeeringManagerOptions) apply(opts ...AutopeeringManagerOption) {
+	for _, opt := range opts {
+		opt(amo)
+	}
+}
+
 type AutopeeringManager struct {
 	// the logger used to log events.
 	*utils.WrappedLogger
 
+	// Events happening around the AutopeeringManager.
+	Events *AutopeeringManagerEvents
+
+	// the options of the manager.
+	opts *AutopeeringManagerOptions
+	// used to access rejectedNeighbors.
+	rejectedNeighborsLock sync.Mutex
+	// the neighbors which were rejected and the time until they are not accepted again.
+	rejectedNeighbors map[identity.ID]time.Time
+
 	// bindAddress is the bind address for autopeering.
 	bindAddress string
 	// entryNodes are the entry nodes for autopeering.
@@ -303,10 +375,19 @@ type AutopeeringManager struct {
 	selectionProtocol *selection.Protocol
 }
 
-func NewAutopeeringManager(log *logger.Logger, bindAddress string, entryNodes []string, preferIPv6 bool, p2pServiceKey service.Key) *AutopeeringManager {
+func NewAutopeeringManager(log *logger.Logger, bindAddress string, entryNodes []string, preferIPv6 bool, p2pServiceKey service.Key, opts ...AutopeeringManagerOption) *AutopeeringManager {
+
+	managerOpts := &AutopeeringManagerOptions{}
+	managerOpts.apply(defaultAutopeeringManagerOptions...)
+	managerOpts.apply(opts...)
 
 	return &AutopeeringManager{
-		WrappedLogger:      utils.NewWrappedLogger(log),
+		WrappedLogger: utils.NewWrappedLogger(log),
+		Events: &AutopeeringManagerEvents{
+			NeighborRejected: events.NewEvent(NeighborRejectedCaller),
+		},
+		opts:               managerOpts,
+		rejectedNeighbors:  make(map[identity.ID]time.Time),
 		bindAddress:        bindAddress,
 		entryNodes:         entryNodes,
 		preferIPv6:         preferIPv6,
@@ -338,6 +419,68 @@ func (a *AutopeeringManager) Discovery() *discover.Protocol {
 	return a.discoveryProtocol
 }
 
+// IsValidNeighbor tells whether the given peer offers a valid peering service,
+// is not excluded from being selected as a neighbor and wasn't rejected lately.
+func (a *AutopeeringManager) IsValidNeighbor(p *peer.Peer) bool {
+	p2pPeering := p.Services().Get(a.p2pServiceKey)
+	if p2pPeering == nil {
+		return false
+	}
+
+	if p2pPeering.Network() != "tcp" || !netutil.IsValidPort(p2pPeering.Port()) {
+		return false
+	}
+
+	for _, subnet := range a.opts.excludedSubnets {
+		if subnet.Contains(p.IP()) {
+			return false
+		}
+	}
+
+	if len(a.opts.excludedPeers) > 0 {
+		peerID, err := HivePeerToPeerID(p)
+		if err != nil {
+			return false
+		}
+		if _, excluded := a.opts.excludedPeers[peerID]; excluded {
+			return false
+		}
+	}
+
+	return !a.isRejected(p.ID())
+}
+
+// tells whether the given neighbor was rejected lately.
+func (a *AutopeeringManager) isRejected(id identity.ID) bool {
+	a.rejectedNeighborsLock.Lock()
+	defer a.rejectedNeighborsLock.Unlock()
+
+	rejectedUntil, rejected := a.rejectedNeighbors[id]
+	if !rejected {
+		return false
+	}
+
+	if time.Now().After(rejectedUntil) {
+		delete(a.rejectedNeighbors, id)
+		return false
+	}
+	return true
+}
+
+// RejectNeighbor removes the given neighbor from the peer selection because it didn't pass the acceptance filters.
+// The neighbor is not accepted again until the rejection duration passed.
+func (a *AutopeeringManager) RejectNeighbor(id identity.ID, reason error) {
+	a.rejectedNeighborsLock.Lock()
+	a.rejectedNeighbors[id] = time.Now().Add(a.opts.rejectionDuration)
+	a.rejectedNeighborsLock.Unlock()
+
+	if a.selectionProtocol != nil {
+		a.selectionProtocol.RemoveNeighbor(id)
+	}
+
+	a.Events.NeighborRejected.Trigger(id, reason)
+}
+
 func (a *AutopeeringManager) Init(localPeerContainer *LocalPeerContainer, initSelection bool) {
 
 	parseEntryNodes := func(entryNodesString []string, preferIPv6 bool) (result []*peer.Peer, err error) {
@@ -374,19 +517,7 @@ func (a *AutopeeringManager) Init(localPeerContainer *LocalPeerContainer, initSe
 		return
 	}
 
-	isValidPeer := func(p *peer.Peer) bool {
-		p2pPeering := p.Services().Get(a.p2pServiceKey)
-		if p2pPeering == nil {
-			return false
-		}
-
-		if p2pPeering.Network() != "tcp" || !netutil.IsValidPort(p2pPeering.Port()) {
-			return false
-		}
-		return true
-	}
-
-	a.selectionProtocol = selection.New(localPeerContainer.Local(), a.discoveryProtocol, selection.Logger(a.LoggerNamed("sel")), selection.NeighborValidator(selection.ValidatorFunc(isValidPeer)))
+	a.selectionProtocol = selection.New(localPeerContainer.Local(), a.discoveryProtocol, selection.Logger(a.LoggerNamed("sel")), selection.NeighborValidator(selection.ValidatorFunc(a.IsValidNeighbor)))
 }
 
 func (a *AutopeeringManager) Run(ctx context.Context) {
@@ -1,13 +1,22 @@
 package autopeering_test
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"testing"
+	"time"
 
+	libp2p "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/stretchr/testify/require"
 
 	"github.com/gohornet/hornet/pkg/p2p/autopeering"
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
 )
 
 func TestMultiAddrAutopeeringProtocol(t *testing.T) {
@@ -22,3 +31,51 @@ func TestMultiAddrAutopeeringProtocol(t *testing.T) {
 
 	require.Equal(t, base58PubKey, extractedBase58PubKey)
 }
+
+func newHivePeer(t *testing.T, ip string, serviceKey service.Key, port int) *peer.Peer {
+	pubKey, _, err := ed25519.GenerateKey()
+	require.NoError(t, err)
+
+	services := service.New()
+	services.Update(service.PeeringKey, "udp", 14626)
+	services.Update(serviceKey, "tcp", port)
+
+	return peer.NewPeer(identity.New(pubKey), net.ParseIP(ip), services)
+}
+
+func TestAutopeeringManagerIsValidNeighbor(t *testing.T) {
+	serviceKey := service.Key("chrysalis-mainnet")
+
+	excludedPeer := newHivePeer(t, "192.0.2.1", serviceKey, 15600)
+	excludedPeerID, err := autopeering.HivePeerToPeerID(excludedPeer)
+	require.NoError(t, err)
+
+	_, excludedSubnet, err := net.ParseCIDR("198.51.100.0/24")
+	require.NoError(t, err)
+
+	manager := autopeering.NewAutopeeringManager(nil, "0.0.0.0:14626", nil, false, serviceKey,
+		autopeering.WithAutopeeringExcludedPeers([]libp2p.ID{excludedPeerID}),
+		autopeering.WithAutopeeringExcludedSubnets([]*net.IPNet{excludedSubnet}),
+		autopeering.WithAutopeeringRejectionDuration(time.Hour),
+	)
+
+	validPeer := newHivePeer(t, "192.0.2.2", serviceKey, 15600)
+	require.True(t, manager.IsValidNeighbor(validPeer))
+
+	// peers without a valid peering service for the network are not accepted
+	require.False(t, manager.IsValidNeighbor(newHivePeer(t, "192.0.2.3", "other-network", 15600)))
+	require.False(t, manager.IsValidNeighbor(newHivePeer(t, "192.0.2.4", serviceKey, 0)))
+
+	// excluded peers and subnets are not accepted
+	require.False(t, manager.IsValidNeighbor(excludedPeer))
+	require.False(t, manager.IsValidNeighbor(newHivePeer(t, "198.51.100.7", serviceKey, 15600)))
+
+	// rejected neighbors are not accepted until the rejection duration passed
+	var rejectedID identity.ID
+	manager.Events.NeighborRejected.Attach(events.NewClosure(func(id identity.ID, _ error) {
+		rejectedID = id
+	}))
+	manager.RejectNeighbor(validPeer.ID(), errors.New("test"))
+	require.Equal(t, validPeer.ID(), rejectedID)
+	require.False(t, manager.IsValidNeighbor(validPeer))
+}
@@ -0,0 +1,542 @@
+package p2p
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/control"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/logger"
+)
+
+const (
+	// the prefix length of the IPv4 subnets on which the inbound connection limit is applied.
+	ipv4SubnetPrefixLength = 24
+	// the prefix length of the IPv6 subnets on which the inbound connection limit is applied.
+	ipv6SubnetPrefixLength = 48
+	// the duration after which the slot reserved for an accepted inbound connection is released
+	// if the connection was not established in the meantime (e.g. because the upgrade failed).
+	pendingInboundTimeout = time.Minute
+)
+
+var (
+	// ErrInvalidBanTarget is returned if a ban target is neither a peer ID, an IP address nor a CIDR.
+	ErrInvalidBanTarget = errors.New("invalid ban target, must be a peer ID, an IP address or a CIDR")
+	// ErrInvalidBanDuration is returned if a ban with a negative duration is added.
+	ErrInvalidBanDuration = errors.New("invalid ban duration, must not be negative")
+	// ErrBanNotFound is returned if a ban does not exist.
+	ErrBanNotFound = errors.New("ban not found")
+)
+
+var defaultConnectionGaterOptions = []ConnectionGaterOption{
+	WithConnectionGaterInboundSubnetLimits(10, 10),
+	WithConnectionGaterDefaultBanDuration(time.Hour),
+}
+
+// ConnectionGaterOptions define options for a ConnectionGater.
+type ConnectionGaterOptions struct {
+	// The logger to use to log events.
+	logger *logger.Logger
+	// Connections from or to addresses within these subnets are always allowed, all others are rejected.
+	// An empty allow list allows all addresses.
+	allowList []*net.IPNet
+	// Connections from or to addresses within these subnets are rejected.
+	denyList []*net.IPNet
+	// The maximum amount of inbound connections per IPv4 /24 subnet (0 = unlimited).
+	maxInboundPerIPv4Subnet int
+	// The maximum amount of inbound connections per IPv6 /48 subnet (0 = unlimited).
+	maxInboundPerIPv6Subnet int
+	// The duration of bans which were added without a duration.
+	defaultBanDuration time.Duration
+}
+
+// ConnectionGaterOption is a function setting a ConnectionGaterOptions option.
+type ConnectionGaterOption func(opts *ConnectionGaterOptions)
+
+// WithConnectionGaterLogger enables logging within the ConnectionGater.
+func WithConnectionGaterLogger(logger *logger.Logger) ConnectionGaterOption {
+	return func(opts *ConnectionGaterOptions) {
+		opts.logger = logger
+	}
+}
+
+// WithConnectionGaterAllowList defines the subnets to which connections are restricted.
+// An empty allow list allows all addresses which are not part of the deny list.
+func WithConnectionGaterAllowList(allowList []*net.IPNet) ConnectionGaterOption {
+	return func(opts *ConnectionGaterOptions) {
+		opts.allowList = allowList
+	}
+}
+
+// WithConnectionGaterDenyList defines the subnets from and to which connections are rejected.
+func WithConnectionGaterDenyList(denyList []*net.IPNet) ConnectionGaterOption {
+	return func(opts *ConnectionGaterOptions) {
+		opts.denyList = denyList
+	}
+}
+
+// WithConnectionGaterInboundSubnetLimits defines the maximum amount of inbound connections
+// per IPv4 /24 and per IPv6 /48 subnet. A limit of 0 disables the corresponding check.
+func WithConnectionGaterInboundSubnetLimits(maxPerIPv4Subnet int, maxPerIPv6Subnet int) ConnectionGaterOption {
+	return func(opts *ConnectionGaterOptions) {
+		opts.maxInboundPerIPv4Subnet = maxPerIPv4Subnet
+		opts.maxInboundPerIPv6Subnet = maxPerIPv6Subnet
+	}
+}
+
+// WithConnectionGaterDefaultBanDuration defines the duration of bans which are added without a duration.
+func WithConnectionGaterDefaultBanDuration(defaultBanDuration time.Duration) ConnectionGaterOption {
+	return func(opts *ConnectionGaterOptions) {
+		opts.defaultBanDuration = defaultBanDuration
+	}
+}
+
+// applies the given ConnectionGaterOption.
+func (cgo *ConnectionGaterOptions) apply(opts ...ConnectionGaterOption) {
+	for _, opt := range opts {
+		opt(cgo)
+	}
+}
+
+// Ban is a temporary ban of a peer or a subnet.
+type Ban struct {
+	// The banned peer, empty if a subnet is banned.
+	PeerID peer.ID
+	// The banned subnet, nil if a peer is banned.
+	IPNet *net.IPNet
+	// The time the ban expires.
+	Expires time.Time
+}
+
+// Target returns the string representation of the banned peer or subnet.
+func (b *Ban) Target() string {
+	if b.IPNet != nil {
+		return b.IPNet.String()
+	}
+	return b.PeerID.String()
+}
+
+// ParseCIDRs parses the given CIDRs. Plain IP addresses are interpreted as single host subnets.
+func ParseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	ipNets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		ipNet, err := parseIPNet(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid CIDR '%s'", cidr)
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets, nil
+}
+
+// parses the given CIDR or IP address into a subnet.
+func parseIPNet(s string) (*net.IPNet, error) {
+	if strings.Contains(s, "/") {
+		_, ipNet, err := net.ParseCIDR(s)
+		return ipNet, err
+	}
+
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, errors.New("invalid IP address")
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
+// an accepted inbound connection which was not established yet.
+type pendingInbound struct {
+	// the subnet in which the slot for the connection was reserved.
+	subnet string
+	// the time after which the reserved slot is released.
+	expires time.Time
+}
+
+// ConnectionGater is a connmgr.ConnectionGater which rejects connections based on
+// allow and deny lists, limits the amount of inbound connections per subnet
+// and holds a list of temporarily banned peers and subnets.
+//
+// The ConnectionGater must be registered as a network.Notifiee on the host's network
+// in order to keep track of the inbound connections per subnet.
+type ConnectionGater struct {
+	// the options of the gater.
+	opts *ConnectionGaterOptions
+	// used to access the inbound connection counts.
+	inboundLock sync.Mutex
+	// the amount of inbound connections per subnet, including the accepted ones which were not established yet.
+	inboundPerSubnet map[string]int
+	// the accepted inbound connections which were not established yet, keyed by their remote address.
+	inboundPending map[string]*pendingInbound
+	// used to access the bans.
+	bansLock sync.RWMutex
+	// the banned peers.
+	bannedPeers map[peer.ID]*Ban
+	// the banned subnets keyed by their string representation.
+	bannedIPNets map[string]*Ban
+}
+
+// NewConnectionGater creates a new ConnectionGater.
+func NewConnectionGater(opts ...ConnectionGaterOption) *ConnectionGater {
+	gaterOpts := &ConnectionGaterOptions{}
+	gaterOpts.apply(defaultConnectionGaterOptions...)
+	gaterOpts.apply(opts...)
+
+	return &ConnectionGater{
+		opts:             gaterOpts,
+		inboundPerSubnet: make(map[string]int),
+		inboundPending:   make(map[string]*pendingInbound),
+		bannedPeers:      make(map[peer.ID]*Ban),
+		bannedIPNets:     make(map[string]*Ban),
+	}
+}
+
+// Ban bans the given target for the given duration.
+// The target can be a peer ID, an IP address or a CIDR.
+// A duration of 0 uses the default ban duration. An existing ban of the same target is replaced.
+func (g *ConnectionGater) Ban(target string, duration time.Duration) (*Ban, error) {
+	if duration < 0 {
+		return nil, ErrInvalidBanDuration
+	}
+	if duration == 0 {
+		duration = g.opts.defaultBanDuration
+	}
+
+	ban := &Ban{Expires: time.Now().Add(duration)}
+
+	if ipNet, err := parseIPNet(target); err == nil {
+		ban.IPNet = ipNet
+	} else {
+		peerID, err := peer.Decode(target)
+		if err != nil {
+			return nil, ErrInvalidBanTarget
+		}
+		ban.PeerID = peerID
+	}
+
+	g.bansLock.Lock()
+	defer g.bansLock.Unlock()
+
+	if ban.IPNet != nil {
+		g.bannedIPNets[ban.IPNet.String()] = ban
+	} else {
+		g.bannedPeers[ban.PeerID] = ban
+	}
+
+	return ban, nil
+}
+
+// Unban removes the ban of the given target.
+func (g *ConnectionGater) Unban(target string) error {
+	g.bansLock.Lock()
+	defer g.bansLock.Unlock()
+
+	if ipNet, err := parseIPNet(target); err == nil {
+		key := ipNet.String()
+		if ban, exists := g.bannedIPNets[key]; exists && !ban.expired(time.Now()) {
+			delete(g.bannedIPNets, key)
+			return nil
+		}
+		return ErrBanNotFound
+	}
+
+	peerID, err := peer.Decode(target)
+	if err != nil {
+		return ErrInvalidBanTarget
+	}
+
+	if ban, exists := g.bannedPeers[peerID]; exists && !ban.expired(time.Now()) {
+		delete(g.bannedPeers, peerID)
+		return nil
+	}
+	return ErrBanNotFound
+}
+
+// Bans returns all active bans ordered by their expiration time.
+// Expired bans are removed.
+func (g *ConnectionGater) Bans() []*Ban {
+	g.bansLock.Lock()
+	defer g.bansLock.Unlock()
+
+	now := time.Now()
+	bans := make([]*Ban, 0, len(g.bannedPeers)+len(g.bannedIPNets))
+	for peerID, ban := range g.bannedPeers {
+		if ban.expired(now) {
+			delete(g.bannedPeers, peerID)
+			continue
+		}
+		bans = append(bans, ban)
+	}
+	for key, ban := range g.bannedIPNets {
+		if ban.expired(now) {
+			delete(g.bannedIPNets, key)
+			continue
+		}
+		bans = append(bans, ban)
+	}
+
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].Expires.Before(bans[j].Expires)
+	})
+
+	return bans
+}
+
+// IsPeerBanned tells whether the given peer is currently banned.
+func (g *ConnectionGater) IsPeerBanned(peerID peer.ID) bool {
+	g.bansLock.RLock()
+	defer g.bansLock.RUnlock()
+
+	ban, exists := g.bannedPeers[peerID]
+	return exists && !ban.expired(time.Now())
+}
+
+// IsIPBanned tells whether the given IP address is part of a currently banned subnet.
+func (g *ConnectionGater) IsIPBanned(ip net.IP) bool {
+	g.bansLock.RLock()
+	defer g.bansLock.RUnlock()
+
+	now := time.Now()
+	for _, ban := range g.bannedIPNets {
+		if !ban.expired(now) && ban.IPNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// tells whether the ban is expired at the given time.
+func (b *Ban) expired(now time.Time) bool {
+	return !now.Before(b.Expires)
+}
+
+// tells whether connections from or to the given IP address are allowed by the allow and deny lists and the bans.
+func (g *ConnectionGater) isIPAllowed(ip net.IP) bool {
+	for _, ipNet := range g.opts.denyList {
+		if ipNet.Contains(ip) {
+			return false
+		}
+	}
+
+	if len(g.opts.allowList) > 0 {
+		allowed := false
+		for _, ipNet := range g.opts.allowList {
+			if ipNet.Contains(ip) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+
+	return !g.IsIPBanned(ip)
+}
+
+// returns the key of the subnet the given IP address belongs to and the inbound limit for this subnet.
+// loopback addresses are not limited.
+func (g *ConnectionGater) subnetLimit(ip net.IP) (string, int) {
+	if ip.IsLoopback() {
+		return "", 0
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.Mask(net.CIDRMask(ipv4SubnetPrefixLength, 32)).String(), g.opts.maxInboundPerIPv4Subnet
+	}
+	return ip.Mask(net.CIDRMask(ipv6SubnetPrefixLength, 128)).String(), g.opts.maxInboundPerIPv6Subnet
+}
+
+// logs the rejection of a connection.
+func (g *ConnectionGater) logRejected(format string, args ...interface{}) {
+	if g.opts.logger == nil {
+		return
+	}
+	g.opts.logger.Debugf(format, args...)
+}
+
+// InterceptPeerDial rejects dials to banned peers.
+func (g *ConnectionGater) InterceptPeerDial(p peer.ID) bool {
+	if g.IsPeerBanned(p) {
+		g.logRejected("rejected dial to banned peer %s", p.ShortString())
+		return false
+	}
+	return true
+}
+
+// InterceptAddrDial rejects dials to addresses which are not allowed.
+func (g *ConnectionGater) InterceptAddrDial(p peer.ID, addr multiaddr.Multiaddr) bool {
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		// addresses without an IP (e.g. relays) can't be checked
+		return true
+	}
+
+	if !g.isIPAllowed(ip) {
+		g.logRejected("rejected dial to peer %s on address %s", p.ShortString(), addr)
+		return false
+	}
+	return true
+}
+
+// InterceptAccept rejects inbound connections from addresses which are not allowed
+// or from subnets which reached their inbound connection limit.
+func (g *ConnectionGater) InterceptAccept(connAddrs network.ConnMultiaddrs) bool {
+	remoteAddr := connAddrs.RemoteMultiaddr()
+
+	ip, err := manet.ToIP(remoteAddr)
+	if err != nil {
+		return true
+	}
+
+	if !g.isIPAllowed(ip) {
+		g.logRejected("rejected inbound connection from %s", remoteAddr)
+		return false
+	}
+
+	subnet, limit := g.subnetLimit(ip)
+	if limit == 0 {
+		return true
+	}
+
+	g.inboundLock.Lock()
+	defer g.inboundLock.Unlock()
+
+	g.releaseExpiredPendingInbound(time.Now())
+
+	if g.inboundPerSubnet[subnet] >= limit {
+		g.logRejected("rejected inbound connection from %s, limit of %d connections in subnet %s reached", remoteAddr, limit, subnet)
+		return false
+	}
+
+	// reserve the slot right away, otherwise concurrently accepted connections could exceed the limit.
+	// the slot is taken over by the connection in Connected or released if the upgrade fails.
+	g.inboundPerSubnet[subnet]++
+	g.inboundPending[remoteAddr.String()] = &pendingInbound{subnet: subnet, expires: time.Now().Add(pendingInboundTimeout)}
+
+	return true
+}
+
+// InterceptSecured rejects inbound connections of banned peers.
+func (g *ConnectionGater) InterceptSecured(dir network.Direction, p peer.ID, connAddrs network.ConnMultiaddrs) bool {
+	if dir != network.DirInbound {
+		// outbound connections were already checked in InterceptPeerDial
+		return true
+	}
+
+	if g.IsPeerBanned(p) {
+		g.logRejected("rejected inbound connection from banned peer %s", p.ShortString())
+		g.releasePendingInbound(connAddrs.RemoteMultiaddr())
+		return false
+	}
+	return true
+}
+
+// InterceptUpgraded allows all upgraded connections.
+func (g *ConnectionGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+// decreases the inbound connection count of the given subnet.
+// the caller must hold the inboundLock.
+func (g *ConnectionGater) decreaseInboundCount(subnet string) {
+	count := g.inboundPerSubnet[subnet] - 1
+	if count <= 0 {
+		delete(g.inboundPerSubnet, subnet)
+		return
+	}
+	g.inboundPerSubnet[subnet] = count
+}
+
+// releases the slots of accepted inbound connections which were not established in time.
+// the caller must hold the inboundLock.
+func (g *ConnectionGater) releaseExpiredPendingInbound(now time.Time) {
+	for remoteAddr, pending := range g.inboundPending {
+		if now.Before(pending.expires) {
+			continue
+		}
+		delete(g.inboundPending, remoteAddr)
+		g.decreaseInboundCount(pending.subnet)
+	}
+}
+
+// releases the slot reserved for the accepted inbound connection from the given address.
+func (g *ConnectionGater) releasePendingInbound(remoteAddr multiaddr.Multiaddr) {
+	g.inboundLock.Lock()
+	defer g.inboundLock.Unlock()
+
+	pending, exists := g.inboundPending[remoteAddr.String()]
+	if !exists {
+		return
+	}
+	delete(g.inboundPending, remoteAddr.String())
+	g.decreaseInboundCount(pending.subnet)
+}
+
+// returns the subnet of the given inbound connection if inbound connections of this subnet are limited.
+func (g *ConnectionGater) limitedInboundSubnet(conn network.Conn) (string, bool) {
+	if conn.Stat().Direction != network.DirInbound {
+		return "", false
+	}
+
+	ip, err := manet.ToIP(conn.RemoteMultiaddr())
+	if err != nil {
+		return "", false
+	}
+
+	subnet, limit := g.subnetLimit(ip)
+	return subnet, limit != 0
+}
+
+// Listen is called when the network starts listening on an address.
+func (g *ConnectionGater) Listen(network.Network, multiaddr.Multiaddr) {}
+
+// ListenClose is called when the network stops listening on an address.
+func (g *ConnectionGater) ListenClose(network.Network, multiaddr.Multiaddr) {}
+
+// Connected is called when a connection is opened.
+func (g *ConnectionGater) Connected(_ network.Network, conn network.Conn) {
+	subnet, limited := g.limitedInboundSubnet(conn)
+	if !limited {
+		return
+	}
+
+	g.inboundLock.Lock()
+	defer g.inboundLock.Unlock()
+
+	remoteAddr := conn.RemoteMultiaddr().String()
+	if _, exists := g.inboundPending[remoteAddr]; exists {
+		// the slot was already reserved in InterceptAccept
+		delete(g.inboundPending, remoteAddr)
+		return
+	}
+	g.inboundPerSubnet[subnet]++
+}
+
+// Disconnected is called when a connection is closed.
+func (g *ConnectionGater) Disconnected(_ network.Network, conn network.Conn) {
+	subnet, limited := g.limitedInboundSubnet(conn)
+	if !limited {
+		return
+	}
+
+	g.inboundLock.Lock()
+	defer g.inboundLock.Unlock()
+
+	g.decreaseInboundCount(subnet)
+}
+
+// OpenedStream is called when a stream is opened.
+func (g *ConnectionGater) OpenedStream(network.Network, network.Stream) {}
+
+// ClosedStream is called when a stream is closed.
+func (g *ConnectionGater) ClosedStream(network.Network, network.Stream) {}
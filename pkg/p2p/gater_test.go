@@ -0,0 +1,160 @@
+package p2p_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/p2p"
+)
+
+type testConnMultiaddrs struct {
+	remote multiaddr.Multiaddr
+}
+
+func (c *testConnMultiaddrs) LocalMultiaddr() multiaddr.Multiaddr {
+	return multiaddr.StringCast("/ip4/127.0.0.1/tcp/15600")
+}
+
+func (c *testConnMultiaddrs) RemoteMultiaddr() multiaddr.Multiaddr {
+	return c.remote
+}
+
+// testConn only implements the functions of network.Conn used by the ConnectionGater.
+type testConn struct {
+	network.Conn
+	remote    multiaddr.Multiaddr
+	direction network.Direction
+}
+
+func (c *testConn) RemoteMultiaddr() multiaddr.Multiaddr {
+	return c.remote
+}
+
+func (c *testConn) Stat() network.Stat {
+	return network.Stat{Direction: c.direction}
+}
+
+func TestConnectionGaterAllowDenyList(t *testing.T) {
+	allowList, err := p2p.ParseCIDRs([]string{"10.0.0.0/8", "2001:db8::/32"})
+	require.NoError(t, err)
+	denyList, err := p2p.ParseCIDRs([]string{"10.1.0.0/16", "2001:db8::1"})
+	require.NoError(t, err)
+
+	_, err = p2p.ParseCIDRs([]string{"10.0.0.0/33"})
+	require.Error(t, err)
+
+	gater := p2p.NewConnectionGater(
+		p2p.WithConnectionGaterAllowList(allowList),
+		p2p.WithConnectionGaterDenyList(denyList),
+	)
+
+	for addr, allowed := range map[string]bool{
+		"/ip4/10.0.0.1/tcp/15600":       true,
+		"/ip4/10.1.0.1/tcp/15600":       false,
+		"/ip4/192.0.2.1/tcp/15600":      false,
+		"/ip6/2001:db8::2/tcp/15600":    true,
+		"/ip6/2001:db8::1/tcp/15600":    false,
+		"/dns4/example.com/tcp/15600":   true,
+		"/ip6/2001:db9::1/udp/14626":    false,
+		"/ip4/10.255.255.255/tcp/15600": true,
+	} {
+		ma := multiaddr.StringCast(addr)
+		require.Equal(t, allowed, gater.InterceptAddrDial("", ma), addr)
+		require.Equal(t, allowed, gater.InterceptAccept(&testConnMultiaddrs{remote: ma}), addr)
+	}
+}
+
+func TestConnectionGaterBans(t *testing.T) {
+	gater := p2p.NewConnectionGater()
+
+	peerID, err := peer.Decode("12D3KooWHjcCgWPnUEP8wNdbL2fx63Cmosk16xyZ25iUZagxmHb4")
+	require.NoError(t, err)
+	addr := multiaddr.StringCast("/ip4/192.0.2.1/tcp/15600")
+
+	_, err = gater.Ban("invalid", time.Minute)
+	require.ErrorIs(t, err, p2p.ErrInvalidBanTarget)
+	_, err = gater.Ban(peerID.String(), -time.Minute)
+	require.ErrorIs(t, err, p2p.ErrInvalidBanDuration)
+
+	// a ban without a duration uses the default ban duration
+	ban, err := gater.Ban(peerID.String(), 0)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(time.Hour), ban.Expires, time.Minute)
+
+	require.False(t, gater.InterceptPeerDial(peerID))
+	require.False(t, gater.InterceptSecured(network.DirInbound, peerID, &testConnMultiaddrs{remote: addr}))
+
+	ban, err = gater.Ban("192.0.2.7/24", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, "192.0.2.0/24", ban.Target())
+
+	require.False(t, gater.InterceptAddrDial(peerID, addr))
+	require.False(t, gater.InterceptAccept(&testConnMultiaddrs{remote: addr}))
+
+	bans := gater.Bans()
+	require.Len(t, bans, 2)
+	require.Equal(t, "192.0.2.0/24", bans[0].Target())
+	require.Equal(t, peerID.String(), bans[1].Target())
+
+	require.NoError(t, gater.Unban(peerID.String()))
+	require.ErrorIs(t, gater.Unban(peerID.String()), p2p.ErrBanNotFound)
+	require.NoError(t, gater.Unban("192.0.2.0/24"))
+
+	require.True(t, gater.InterceptPeerDial(peerID))
+	require.True(t, gater.InterceptAccept(&testConnMultiaddrs{remote: addr}))
+
+	// expired bans are ignored and removed
+	_, err = gater.Ban("192.0.2.1", time.Nanosecond)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	require.True(t, gater.InterceptAccept(&testConnMultiaddrs{remote: addr}))
+	require.Empty(t, gater.Bans())
+}
+
+func TestConnectionGaterInboundSubnetLimits(t *testing.T) {
+	gater := p2p.NewConnectionGater(p2p.WithConnectionGaterInboundSubnetLimits(2, 1))
+
+	accept := func(addr string) bool {
+		return gater.InterceptAccept(&testConnMultiaddrs{remote: multiaddr.StringCast(addr)})
+	}
+	connect := func(addr string, direction network.Direction) network.Conn {
+		conn := &testConn{remote: multiaddr.StringCast(addr), direction: direction}
+		gater.Connected(nil, conn)
+		return conn
+	}
+
+	require.True(t, accept("/ip4/192.0.2.1/tcp/15600"))
+	connect("/ip4/192.0.2.1/tcp/15600", network.DirInbound)
+	// outbound connections are not counted
+	connect("/ip4/192.0.2.2/tcp/15600", network.DirOutbound)
+
+	// accepted connections reserve their slot before they are established
+	require.True(t, accept("/ip4/192.0.2.3/tcp/15600"))
+	require.False(t, accept("/ip4/192.0.2.4/tcp/15600"))
+	conn := connect("/ip4/192.0.2.3/tcp/15600", network.DirInbound)
+	require.False(t, accept("/ip4/192.0.2.5/tcp/15600"))
+	// other subnets are not affected
+	require.True(t, accept("/ip4/192.0.3.1/tcp/15600"))
+	// loopback addresses are not limited
+	require.True(t, accept("/ip4/127.0.0.1/tcp/15600"))
+
+	gater.Disconnected(nil, conn)
+	require.True(t, accept("/ip4/192.0.2.5/tcp/15600"))
+
+	// the slot of a connection which fails the upgrade is released
+	peerID, err := peer.Decode("12D3KooWHjcCgWPnUEP8wNdbL2fx63Cmosk16xyZ25iUZagxmHb4")
+	require.NoError(t, err)
+	_, err = gater.Ban(peerID.String(), time.Minute)
+	require.NoError(t, err)
+	require.False(t, gater.InterceptSecured(network.DirInbound, peerID, &testConnMultiaddrs{remote: multiaddr.StringCast("/ip4/192.0.2.5/tcp/15600")}))
+	require.True(t, accept("/ip4/192.0.2.6/tcp/15600"))
+
+	connect("/ip6/2001:db8:1:1::1/tcp/15600", network.DirInbound)
+	require.False(t, accept("/ip6/2001:db8:1:2::1/tcp/15600"))
+	require.True(t, accept("/ip6/2001:db8:2::1/tcp/15600"))
+}
@@ -39,8 +39,9 @@ var (
 
 // PeerStoreContainer is a container for a libp2p peer store.
 type PeerStoreContainer struct {
-	store     kvstore.KVStore
-	peerStore peerstore.Peerstore
+	store       kvstore.KVStore
+	peerStore   peerstore.Peerstore
+	addressBook *AddressBook
 }
 
 // Peerstore returns the libp2p peer store from the container.
@@ -48,6 +49,11 @@ func (psc *PeerStoreContainer) Peerstore() peerstore.Peerstore {
 	return psc.peerStore
 }
 
+// AddressBook returns the address book persisted in the peer store database.
+func (psc *PeerStoreContainer) AddressBook() *AddressBook {
+	return psc.addressBook
+}
+
 // Flush persists all outstanding write operations to disc.
 func (psc *PeerStoreContainer) Flush() error {
 	return psc.store.Flush()
@@ -86,8 +92,9 @@ func NewPeerStoreContainer(peerStorePath string, dbEngine database.Engine, creat
 	}
 
 	return &PeerStoreContainer{
-		store:     store,
-		peerStore: peerStore,
+		store:       store,
+		peerStore:   peerStore,
+		addressBook: NewAddressBook(store.WithRealm(addressBookRealm)),
 	}, nil
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/multiformats/go-multiaddr"
+	madns "github.com/multiformats/go-multiaddr-dns"
 	"github.com/pkg/errors"
 
 	"github.com/gohornet/hornet/pkg/utils"
@@ -25,6 +26,8 @@ const (
 	PeerConnectivityProtectionTag = "peering-manager"
 
 	connTimeout = 5 * time.Second
+	// the timeout for resolving the DNS based addresses of a peer.
+	dnsResolveTimeout = 5 * time.Second
 )
 
 var (
@@ -153,6 +156,8 @@ type ManagerOptions struct {
 	reconnectInterval time.Duration
 	// The randomized jitter applied to the reconnect interval.
 	reconnectIntervalJitter time.Duration
+	// The address book used to persist the last known good addresses of peers.
+	addressBook *AddressBook
 }
 
 // ManagerOption is a function setting a ManagerOptions option.
@@ -174,6 +179,15 @@ func WithManagerReconnectInterval(interval time.Duration, jitter time.Duration)
 	}
 }
 
+// WithManagerAddressBook defines the address book in which the Manager stores
+// the last known good addresses of known peers and from which it takes them
+// if the addresses of a known peer can't be resolved.
+func WithManagerAddressBook(addressBook *AddressBook) ManagerOption {
+	return func(opts *ManagerOptions) {
+		opts.addressBook = addressBook
+	}
+}
+
 // applies the given ManagerOption.
 func (mo *ManagerOptions) apply(opts ...ManagerOption) {
 	for _, opt := range opts {
@@ -226,6 +240,7 @@ func NewManager(host host.Host, opts ...ManagerOption) *Manager {
 		reconnectChan:      make(chan *reconnectmsg, 100),
 		forEachChan:        make(chan *foreachmsg, 10),
 		callChan:           make(chan *callmsg, 10),
+		goodAddressChan:    make(chan *goodaddressmsg, 100),
 	}
 	peeringManager.WrappedLogger = utils.NewWrappedLogger(peeringManager.opts.logger)
 	peeringManager.registerLoggerOnEvents()
@@ -262,6 +277,8 @@ type Manager struct {
 	reconnectChan      chan *reconnectmsg
 	forEachChan        chan *foreachmsg
 	callChan           chan *callmsg
+	// the last known good addresses which are written to the address book outside of the event loop.
+	goodAddressChan chan *goodaddressmsg
 }
 
 // Start starts the Manager's event loop.
@@ -272,6 +289,9 @@ func (m *Manager) Start(ctx context.Context) {
 
 	m.Events.StateChange.Trigger(ManagerStateStarted)
 
+	// write the address book outside of the event loop
+	go m.addressBookWriter(ctx)
+
 	// run the event loop machinery
 	m.eventLoop(ctx)
 
@@ -345,8 +365,11 @@ func (m *Manager) ConnectPeer(addrInfo *peer.AddrInfo, peerRelation PeerRelation
 	if len(alias) > 0 {
 		al = alias[0]
 	}
+	// the addresses are resolved before the event loop is involved, because resolving them might take a while
+	resolvedAddrInfo := m.resolveAddrs(*addrInfo)
+
 	back := make(chan error)
-	m.connectPeerChan <- &connectpeermsg{addrInfo: addrInfo, peerRelation: peerRelation, back: back, alias: al}
+	m.connectPeerChan <- &connectpeermsg{addrInfo: addrInfo, resolvedAddrInfo: resolvedAddrInfo, peerRelation: peerRelation, back: back, alias: al}
 	return <-back
 }
 
@@ -479,10 +502,12 @@ func (m *Manager) Call(peerID peer.ID, f PeerFunc) {
 }
 
 type connectpeermsg struct {
-	addrInfo     *peer.AddrInfo
-	peerRelation PeerRelation
-	alias        string
-	back         chan error
+	addrInfo *peer.AddrInfo
+	// the addresses of the peer with resolved DNS based addresses.
+	resolvedAddrInfo peer.AddrInfo
+	peerRelation     PeerRelation
+	alias            string
+	back             chan error
 }
 
 type connectionmsg struct {
@@ -524,6 +549,13 @@ type isallowedrequestmsg struct {
 
 type reconnectmsg struct {
 	peerID peer.ID
+	// the addresses of the peer with resolved DNS based addresses.
+	resolvedAddrInfo peer.AddrInfo
+}
+
+type goodaddressmsg struct {
+	peerID peer.ID
+	addr   multiaddr.Multiaddr
 }
 
 type foreachmsg struct {
@@ -550,7 +582,7 @@ func (m *Manager) eventLoop(ctx context.Context) {
 			return
 
 		case connectPeerMsg := <-m.connectPeerChan:
-			err := m.connectPeer(connectPeerMsg.addrInfo, connectPeerMsg.peerRelation, connectPeerMsg.alias)
+			err := m.connectPeer(connectPeerMsg.addrInfo, connectPeerMsg.resolvedAddrInfo, connectPeerMsg.peerRelation, connectPeerMsg.alias)
 			if err != nil {
 				m.Events.Error.Trigger(fmt.Errorf("error connect to %s (%v): %w", connectPeerMsg.addrInfo.ID.ShortString(), connectPeerMsg.addrInfo.Addrs, err))
 			}
@@ -587,7 +619,7 @@ func (m *Manager) eventLoop(ctx context.Context) {
 			isAllowedReqMsg.back <- allowed
 
 		case reconnectMsg := <-m.reconnectChan:
-			reconnect, err := m.reconnectPeer(reconnectMsg.peerID)
+			reconnect, err := m.reconnectPeer(reconnectMsg.peerID, reconnectMsg.resolvedAddrInfo)
 			if err != nil {
 				m.Events.Error.Trigger(fmt.Errorf("error reconnect %s: %w", reconnectMsg.peerID.ShortString(), err))
 				continue
@@ -605,6 +637,7 @@ func (m *Manager) eventLoop(ctx context.Context) {
 			p := m.peers[connectedMsg.conn.RemotePeer()]
 			m.addPeerAsUnknownIfAbsent(connectedMsg.conn)
 			if p != nil {
+				m.storeGoodAddress(p, connectedMsg.conn)
 				m.resetReconnect(p.ID)
 				if !p.connectedEventCalled {
 					m.Events.Connected.Trigger(p, connectedMsg.conn)
@@ -640,7 +673,8 @@ func (m *Manager) eventLoop(ctx context.Context) {
 
 // connects to the given peer if it isn't already connected and if its relation is PeerRelationKnown,
 // then the connection to the peer is further protected from trimming.
-func (m *Manager) connectPeer(addrInfo *peer.AddrInfo, relation PeerRelation, alias string) error {
+// the peer keeps its unresolved addresses, so that they are resolved again on reconnects.
+func (m *Manager) connectPeer(addrInfo *peer.AddrInfo, resolvedAddrInfo peer.AddrInfo, relation PeerRelation, alias string) error {
 	if _, has := m.peers[addrInfo.ID]; has {
 		return ErrPeerInManagerAlready
 	}
@@ -657,7 +691,7 @@ func (m *Manager) connectPeer(addrInfo *peer.AddrInfo, relation PeerRelation, al
 	m.peers[addrInfo.ID] = p
 	m.Events.Connect.Trigger(p)
 
-	return m.connect(*addrInfo)
+	return m.connect(resolvedAddrInfo)
 }
 
 // disconnects and removes the given peer from the Manager.
@@ -755,13 +789,20 @@ func (m *Manager) scheduleReconnectIfKnown(peerID peer.ID) {
 	}
 	p.connectedEventCalled = false
 
+	addrInfo := peer.AddrInfo{ID: peerID, Addrs: p.Addrs}
 	delay := m.opts.reconnectDelay()
 	p.reconnectTimer = time.AfterFunc(delay, func() {
 		if m.stopped.IsSet() {
 			return
 		}
 
-		m.reconnectChan <- &reconnectmsg{peerID: peerID}
+		// the addresses are resolved before the event loop is involved, because resolving them might take a while
+		resolvedAddrInfo := m.resolveAddrs(addrInfo)
+		if m.stopped.IsSet() {
+			return
+		}
+
+		m.reconnectChan <- &reconnectmsg{peerID: peerID, resolvedAddrInfo: resolvedAddrInfo}
 	})
 	m.Events.ScheduledReconnect.Trigger(p, delay)
 }
@@ -780,9 +821,9 @@ func (m *Manager) resetReconnect(peerID peer.ID) {
 	}
 }
 
-// reconnect peer does a connection attempt to the given peer but only
-// if its relation is PeerRelationKnown.
-func (m *Manager) reconnectPeer(peerID peer.ID) (bool, error) {
+// reconnect peer does a connection attempt to the given peer with the given resolved addresses
+// but only if its relation is PeerRelationKnown.
+func (m *Manager) reconnectPeer(peerID peer.ID, resolvedAddrInfo peer.AddrInfo) (bool, error) {
 	p, has := m.peers[peerID]
 	if !has {
 		return false, nil
@@ -793,10 +834,104 @@ func (m *Manager) reconnectPeer(peerID peer.ID) (bool, error) {
 	}
 
 	m.Events.Reconnecting.Trigger(p)
-	return true, m.connect(peer.AddrInfo{ID: peerID, Addrs: p.Addrs})
+	return true, m.connect(resolvedAddrInfo)
+}
+
+// stores the address of the given outbound connection as last known good address of the given known peer.
+// the address is written to the address book asynchronously, so that the event loop is not blocked by disk writes.
+func (m *Manager) storeGoodAddress(p *Peer, conn network.Conn) {
+	if m.opts.addressBook == nil || p.Relation != PeerRelationKnown || conn.Stat().Direction != network.DirOutbound {
+		return
+	}
+
+	select {
+	case m.goodAddressChan <- &goodaddressmsg{peerID: p.ID, addr: conn.RemoteMultiaddr()}:
+	default:
+		m.Events.Error.Trigger(fmt.Errorf("error storing address of %s: address book queue is full", p.ID.ShortString()))
+	}
+}
+
+// writes the last known good addresses to the address book until the given context is done.
+func (m *Manager) addressBookWriter(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case goodAddressMsg := <-m.goodAddressChan:
+			if err := m.opts.addressBook.AddGoodAddress(goodAddressMsg.peerID, goodAddressMsg.addr); err != nil {
+				m.Events.Error.Trigger(fmt.Errorf("error storing address of %s: %w", goodAddressMsg.peerID.ShortString(), err))
+			}
+		}
+	}
+}
+
+// tells whether the given address is a DNS based address (/dns, /dns4, /dns6).
+func isDNSAddr(addr multiaddr.Multiaddr) bool {
+	protocols := addr.Protocols()
+	if len(protocols) == 0 {
+		return false
+	}
+
+	switch protocols[0].Code {
+	case multiaddr.P_DNS, multiaddr.P_DNS4, multiaddr.P_DNS6:
+		return true
+	default:
+		return false
+	}
+}
+
+// resolves the DNS based addresses (/dns, /dns4, /dns6) of the given peer, so that changed IPs are picked up.
+// the peer store is cleared from previously resolved addresses of the peer.
+// if none of the addresses can be resolved, the last known good addresses of the peer are used instead.
+// this must not be called within the event loop, because resolving the addresses might take up to dnsResolveTimeout.
+func (m *Manager) resolveAddrs(addrInfo peer.AddrInfo) peer.AddrInfo {
+	hasDNSAddrs := false
+	for _, addr := range addrInfo.Addrs {
+		if isDNSAddr(addr) {
+			hasDNSAddrs = true
+			break
+		}
+	}
+
+	if !hasDNSAddrs {
+		return addrInfo
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dnsResolveTimeout)
+	defer cancel()
+
+	resolvedAddrs := make([]multiaddr.Multiaddr, 0, len(addrInfo.Addrs))
+	for _, addr := range addrInfo.Addrs {
+		if !isDNSAddr(addr) {
+			resolvedAddrs = append(resolvedAddrs, addr)
+			continue
+		}
+
+		resolved, err := madns.Resolve(ctx, addr)
+		if err != nil {
+			m.Events.Error.Trigger(fmt.Errorf("error resolving %s of %s: %w", addr, addrInfo.ID.ShortString(), err))
+			continue
+		}
+		resolvedAddrs = append(resolvedAddrs, resolved...)
+	}
+
+	if len(resolvedAddrs) == 0 && m.opts.addressBook != nil {
+		resolvedAddrs = m.opts.addressBook.GoodAddresses(addrInfo.ID)
+	}
+
+	if len(resolvedAddrs) == 0 {
+		// keep the unresolved addresses, libp2p will try to resolve them again on its own
+		return addrInfo
+	}
+
+	// forget previously resolved addresses which might be outdated
+	m.host.Peerstore().ClearAddrs(addrInfo.ID)
+
+	return peer.AddrInfo{ID: addrInfo.ID, Addrs: resolvedAddrs}
 }
 
-// connect does an actual connection attempt to the given peer.
+// connect does an actual connection attempt to the given peer with already resolved addresses.
 // if the connection fails, the peer is either cleared from the Manager if its relation is PeerRelationUnknown
 // or a reconnect attempt is scheduled if it is PeerRelationKnown.
 func (m *Manager) connect(addrInfo peer.AddrInfo) error {
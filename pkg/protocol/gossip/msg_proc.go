@@ -79,6 +79,8 @@ type MessageProcessor struct {
 	requestQueue RequestQueue
 	// used to manage connected peers.
 	peeringManager *p2p.Manager
+	// used to keep track of the reputation of peers.
+	reputationManager *ReputationManager
 	// shared server metrics instance.
 	serverMetrics *metrics.ServerMetrics
 	// holds the message processor options.
@@ -103,16 +105,18 @@ func NewMessageProcessor(
 	syncManager *syncmanager.SyncManager,
 	requestQueue RequestQueue,
 	peeringManager *p2p.Manager,
+	reputationManager *ReputationManager,
 	serverMetrics *metrics.ServerMetrics,
 	opts *Options) (*MessageProcessor, error) {
 
 	proc := &MessageProcessor{
-		storage:        dbStorage,
-		syncManager:    syncManager,
-		requestQueue:   requestQueue,
-		peeringManager: peeringManager,
-		serverMetrics:  serverMetrics,
-		opts:           *opts,
+		storage:           dbStorage,
+		syncManager:       syncManager,
+		requestQueue:      requestQueue,
+		peeringManager:    peeringManager,
+		reputationManager: reputationManager,
+		serverMetrics:     serverMetrics,
+		opts:              *opts,
 		Events: MessageProcessorEvents{
 			MessageProcessed: events.NewEvent(MessageProcessedCaller),
 			BroadcastMessage: events.NewEvent(BroadcastCaller),
@@ -279,6 +283,7 @@ func (proc *MessageProcessor) processMilestoneRequest(p *Protocol, data []byte)
 	msIndex, err := ExtractRequestedMilestoneIndex(data)
 	if err != nil {
 		proc.serverMetrics.InvalidRequests.Inc()
+		proc.reputationManager.Record(p.PeerID, ReputationEventInvalidMessage)
 
 		// drop the connection to the peer
 		_ = proc.peeringManager.DisconnectPeer(p.PeerID, errors.WithMessage(err, "processMilestoneRequest failed"))
@@ -363,19 +368,23 @@ func (proc *MessageProcessor) processWorkUnit(wu *WorkUnit, p *Protocol) {
 		var requests Requests
 
 		// mark the message as received
-		request := proc.requestQueue.Received(msg.MessageID())
+		request := proc.requestQueue.ReceivedFrom(msg.MessageID(), p.PeerID)
 		if request != nil {
 			requests = append(requests, request)
 		}
 
 		if isMilestonePayload {
 			// mark the milestone as received
-			msRequest := proc.requestQueue.Received(milestone.Index(msg.Milestone().Index))
+			msRequest := proc.requestQueue.ReceivedFrom(milestone.Index(msg.Milestone().Index), p.PeerID)
 			if msRequest != nil {
 				requests = append(requests, msRequest)
 			}
 		}
 
+		for _, r := range requests {
+			proc.reputationManager.RequestAnswered(r)
+		}
+
 		wu.requested = requests.HasRequest()
 		return requests
 	}
@@ -391,6 +400,7 @@ func (proc *MessageProcessor) processWorkUnit(wu *WorkUnit, p *Protocol) {
 		wu.processingLock.Unlock()
 
 		proc.serverMetrics.InvalidMessages.Inc()
+		proc.reputationManager.Record(p.PeerID, ReputationEventInvalidMessage)
 
 		// drop the connection to the peer
 		_ = proc.peeringManager.DisconnectPeer(p.PeerID, errors.New("peer sent an invalid message"))
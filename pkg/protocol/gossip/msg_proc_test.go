@@ -50,7 +50,9 @@ func TestMsgProcessorEmit(t *testing.T) {
 
 	networkID := iotago.NetworkIDFromString("testnet4")
 
-	processor, err := gossip.NewMessageProcessor(te.Storage(), te.SyncManager(), gossip.NewRequestQueue(), manager, serverMetrics, &gossip.Options{
+	reputationManager := gossip.NewReputationManager(service, manager)
+
+	processor, err := gossip.NewMessageProcessor(te.Storage(), te.SyncManager(), gossip.NewRequestQueue(), manager, reputationManager, serverMetrics, &gossip.Options{
 		MinPoWScore:       MinPoWScore,
 		NetworkID:         networkID,
 		BelowMaxDepth:     BelowMaxDepth,
@@ -0,0 +1,487 @@
+package gossip
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/p2p"
+	"github.com/gohornet/hornet/pkg/utils"
+	"github.com/iotaledger/hive.go/logger"
+)
+
+const (
+	// the maximum absolute score a peer can have.
+	maxReputationScore = 100
+	// the time after which an entry of a peer with a non-negative score
+	// is removed if there were no further updates.
+	reputationForgetThreshold = 1 * time.Hour
+)
+
+// ReputationEvent is an event which influences the reputation score of a peer.
+type ReputationEvent string
+
+const (
+	// ReputationEventInvalidMessage defines that a peer sent an invalid message or request.
+	ReputationEventInvalidMessage ReputationEvent = "invalidMessage"
+	// ReputationEventUnansweredRequest defines that a peer did not answer a request in time.
+	ReputationEventUnansweredRequest ReputationEvent = "unansweredRequest"
+	// ReputationEventStaleHeartbeat defines that a peer did not send heartbeats anymore.
+	ReputationEventStaleHeartbeat ReputationEvent = "staleHeartbeat"
+	// ReputationEventNewMessage defines that a peer sent a message which was new to the node.
+	ReputationEventNewMessage ReputationEvent = "newMessage"
+)
+
+// the default options applied to the ReputationManager.
+var defaultReputationOptions = []ReputationOption{
+	WithReputationWeights(map[ReputationEvent]int64{
+		ReputationEventInvalidMessage:    -50,
+		ReputationEventUnansweredRequest: -1,
+		ReputationEventStaleHeartbeat:    -20,
+		ReputationEventNewMessage:        1,
+	}),
+	WithReputationThresholds(-20, -50),
+	WithReputationRequestTimeout(10 * time.Second),
+	WithReputationCheckInterval(5 * time.Second),
+	WithReputationRecoveryInterval(1 * time.Minute),
+}
+
+// ReputationOptions define options for a ReputationManager.
+type ReputationOptions struct {
+	// The logger to use to log events.
+	logger *logger.Logger
+	// The score changes applied for every event.
+	weights map[ReputationEvent]int64
+	// The score below which a peer is deprioritized.
+	deprioritizeThreshold int64
+	// The score below which a peer is dropped.
+	disconnectThreshold int64
+	// The time after which a sent request is considered as unanswered.
+	requestTimeout time.Duration
+	// The interval in which the scores are evaluated.
+	checkInterval time.Duration
+	// The interval in which the scores move one point towards zero.
+	recoveryInterval time.Duration
+}
+
+// ReputationOption is a function setting a ReputationOptions option.
+type ReputationOption func(opts *ReputationOptions)
+
+// applies the given ReputationOption.
+func (ro *ReputationOptions) apply(opts ...ReputationOption) {
+	for _, opt := range opts {
+		opt(ro)
+	}
+}
+
+// WithReputationLogger enables logging within the ReputationManager.
+func WithReputationLogger(logger *logger.Logger) ReputationOption {
+	return func(opts *ReputationOptions) {
+		opts.logger = logger
+	}
+}
+
+// WithReputationWeights overwrites the score changes applied for the given events.
+func WithReputationWeights(weights map[ReputationEvent]int64) ReputationOption {
+	return func(opts *ReputationOptions) {
+		if opts.weights == nil {
+			opts.weights = make(map[ReputationEvent]int64)
+		}
+		for event, weight := range weights {
+			opts.weights[event] = weight
+		}
+	}
+}
+
+// WithReputationThresholds defines the score below which a peer is deprioritized by the Requester
+// and the score below which a peer gets dropped.
+func WithReputationThresholds(deprioritize int64, disconnect int64) ReputationOption {
+	return func(opts *ReputationOptions) {
+		opts.deprioritizeThreshold = deprioritize
+		opts.disconnectThreshold = disconnect
+	}
+}
+
+// WithReputationRequestTimeout defines the time after which a sent request is considered as unanswered.
+func WithReputationRequestTimeout(timeout time.Duration) ReputationOption {
+	return func(opts *ReputationOptions) {
+		opts.requestTimeout = timeout
+	}
+}
+
+// WithReputationCheckInterval defines the interval in which the scores are evaluated.
+func WithReputationCheckInterval(interval time.Duration) ReputationOption {
+	return func(opts *ReputationOptions) {
+		opts.checkInterval = interval
+	}
+}
+
+// WithReputationRecoveryInterval defines the interval in which the scores move one point towards zero.
+// If the interval is zero, the scores do not recover and dropped autopeered peers stay disallowed
+// for the lifetime of the process.
+func WithReputationRecoveryInterval(interval time.Duration) ReputationOption {
+	return func(opts *ReputationOptions) {
+		opts.recoveryInterval = interval
+	}
+}
+
+// peerReputation holds the reputation state of a single peer.
+type peerReputation struct {
+	score        int64
+	counts       map[ReputationEvent]uint32
+	lastUpdate   time.Time
+	lastRecovery time.Time
+	// the protocol instance and its new messages metric at the last check.
+	proto           *Protocol
+	lastNewMessages uint32
+}
+
+// PeerReputationSnapshot is a snapshot of the reputation of a peer.
+type PeerReputationSnapshot struct {
+	// The ID of the peer.
+	PeerID peer.ID `json:"-"`
+	// The current score of the peer.
+	Score int64 `json:"score"`
+	// Whether the peer is deprioritized for requests.
+	Deprioritized bool `json:"deprioritized"`
+	// The amount of invalid messages received from the peer.
+	InvalidMessages uint32 `json:"invalidMessages"`
+	// The amount of requests the peer did not answer in time.
+	UnansweredRequests uint32 `json:"unansweredRequests"`
+	// The amount of times the heartbeats of the peer were stale.
+	StaleHeartbeats uint32 `json:"staleHeartbeats"`
+	// The amount of new messages received from the peer.
+	NewMessages uint32 `json:"newMessages"`
+}
+
+// ReputationManager keeps track of a reputation score per peer.
+// Peers below the deprioritize threshold should only be used as a last resort,
+// peers below the disconnect threshold which are not known get dropped.
+// Dropped peers are only dropped again if they reconnect, dropped autopeered peers are allowed again
+// once their score recovered above the disconnect threshold.
+type ReputationManager struct {
+	// the logger used to log events.
+	*utils.WrappedLogger
+
+	// the instance of the gossip service to work with.
+	service *Service
+	// the instance of the peeringManager to work with.
+	peeringManager *p2p.Manager
+	// holds the reputation options.
+	opts *ReputationOptions
+
+	peersLock sync.RWMutex
+	peers     map[peer.ID]*peerReputation
+	// requests which were sent but not answered yet, by request map key.
+	outstandingRequests map[string]map[peer.ID]time.Time
+	// the relation of the peers which were dropped because of their reputation.
+	droppedPeers map[peer.ID]p2p.PeerRelation
+}
+
+// NewReputationManager creates a new ReputationManager.
+func NewReputationManager(service *Service, peeringManager *p2p.Manager, opts ...ReputationOption) *ReputationManager {
+	repOpts := &ReputationOptions{}
+	repOpts.apply(defaultReputationOptions...)
+	repOpts.apply(opts...)
+
+	reputationManager := &ReputationManager{
+		service:             service,
+		peeringManager:      peeringManager,
+		opts:                repOpts,
+		peers:               make(map[peer.ID]*peerReputation),
+		outstandingRequests: make(map[string]map[peer.ID]time.Time),
+		droppedPeers:        make(map[peer.ID]p2p.PeerRelation),
+	}
+	reputationManager.WrappedLogger = utils.NewWrappedLogger(repOpts.logger)
+
+	return reputationManager
+}
+
+// returns the reputation entry for the given peer or creates a new one.
+// write lock must be acquired outside.
+func (r *ReputationManager) entry(peerID peer.ID) *peerReputation {
+	rep, has := r.peers[peerID]
+	if !has {
+		now := time.Now()
+		rep = &peerReputation{
+			counts:       make(map[ReputationEvent]uint32),
+			lastUpdate:   now,
+			lastRecovery: now,
+		}
+		r.peers[peerID] = rep
+	}
+	return rep
+}
+
+// applies the given event to the reputation entry.
+// write lock must be acquired outside.
+func (r *ReputationManager) record(peerID peer.ID, event ReputationEvent, count uint32) {
+	if count == 0 {
+		return
+	}
+
+	rep := r.entry(peerID)
+	rep.counts[event] += count
+	rep.score += r.opts.weights[event] * int64(count)
+	if rep.score > maxReputationScore {
+		rep.score = maxReputationScore
+	}
+	if rep.score < -maxReputationScore {
+		rep.score = -maxReputationScore
+	}
+	rep.lastUpdate = time.Now()
+}
+
+// Record applies the score change of the given event to the reputation of the given peer.
+func (r *ReputationManager) Record(peerID peer.ID, event ReputationEvent) {
+	r.peersLock.Lock()
+	defer r.peersLock.Unlock()
+
+	r.record(peerID, event, 1)
+}
+
+// RequestSent tracks the given request as sent to the given peer.
+func (r *ReputationManager) RequestSent(peerID peer.ID, request *Request) {
+	r.peersLock.Lock()
+	defer r.peersLock.Unlock()
+
+	requestMapKey := request.MapKey()
+	peers, has := r.outstandingRequests[requestMapKey]
+	if !has {
+		peers = make(map[peer.ID]time.Time)
+		r.outstandingRequests[requestMapKey] = peers
+	}
+
+	if _, alreadySent := peers[peerID]; !alreadySent {
+		peers[peerID] = time.Now()
+	}
+}
+
+// RequestAnswered marks the given request as answered.
+// Peers which were asked for the same data are not punished anymore.
+func (r *ReputationManager) RequestAnswered(request *Request) {
+	r.peersLock.Lock()
+	defer r.peersLock.Unlock()
+
+	delete(r.outstandingRequests, request.MapKey())
+}
+
+// PeerDisconnected stops tracking the requests sent to the given peer,
+// since a disconnected peer can't answer them anymore.
+func (r *ReputationManager) PeerDisconnected(peerID peer.ID) {
+	r.peersLock.Lock()
+	defer r.peersLock.Unlock()
+
+	for requestMapKey, peers := range r.outstandingRequests {
+		delete(peers, peerID)
+		if len(peers) == 0 {
+			delete(r.outstandingRequests, requestMapKey)
+		}
+	}
+}
+
+// Score returns the current score of the given peer.
+func (r *ReputationManager) Score(peerID peer.ID) int64 {
+	r.peersLock.RLock()
+	defer r.peersLock.RUnlock()
+
+	rep, has := r.peers[peerID]
+	if !has {
+		return 0
+	}
+	return rep.score
+}
+
+// IsDeprioritized tells whether the given peer is below the deprioritize threshold.
+func (r *ReputationManager) IsDeprioritized(peerID peer.ID) bool {
+	return r.Score(peerID) < r.opts.deprioritizeThreshold
+}
+
+// IsBelowDisconnectThreshold tells whether the given peer is below the disconnect threshold.
+func (r *ReputationManager) IsBelowDisconnectThreshold(peerID peer.ID) bool {
+	return r.Score(peerID) < r.opts.disconnectThreshold
+}
+
+// returns a snapshot of the given reputation entry.
+// read lock must be acquired outside.
+func (r *ReputationManager) snapshot(peerID peer.ID, rep *peerReputation) *PeerReputationSnapshot {
+	return &PeerReputationSnapshot{
+		PeerID:             peerID,
+		Score:              rep.score,
+		Deprioritized:      rep.score < r.opts.deprioritizeThreshold,
+		InvalidMessages:    rep.counts[ReputationEventInvalidMessage],
+		UnansweredRequests: rep.counts[ReputationEventUnansweredRequest],
+		StaleHeartbeats:    rep.counts[ReputationEventStaleHeartbeat],
+		NewMessages:        rep.counts[ReputationEventNewMessage],
+	}
+}
+
+// Snapshot returns a snapshot of the reputation of the given peer.
+func (r *ReputationManager) Snapshot(peerID peer.ID) *PeerReputationSnapshot {
+	r.peersLock.RLock()
+	defer r.peersLock.RUnlock()
+
+	rep, has := r.peers[peerID]
+	if !has {
+		return &PeerReputationSnapshot{PeerID: peerID}
+	}
+	return r.snapshot(peerID, rep)
+}
+
+// Run runs the loop which periodically evaluates the reputation of all peers.
+// This method blocks until the given context is done.
+func (r *ReputationManager) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.opts.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.check()
+		}
+	}
+}
+
+// evaluates the reputation of all peers and drops the peers which fell below the disconnect threshold.
+func (r *ReputationManager) check() {
+	// collect the new messages metric of all ongoing protocols outside the lock
+	protos := make(map[peer.ID]*Protocol)
+	r.service.ForEach(func(proto *Protocol) bool {
+		protos[proto.PeerID] = proto
+		return true
+	})
+
+	var belowThreshold []*PeerReputationSnapshot
+	var recovered []peer.ID
+
+	r.peersLock.Lock()
+
+	now := time.Now()
+
+	// punish the peers which did not answer requests in time
+	for requestMapKey, peers := range r.outstandingRequests {
+		for peerID, sentTime := range peers {
+			if now.Sub(sentTime) < r.opts.requestTimeout {
+				continue
+			}
+			r.record(peerID, ReputationEventUnansweredRequest, 1)
+			delete(peers, peerID)
+		}
+		if len(peers) == 0 {
+			delete(r.outstandingRequests, requestMapKey)
+		}
+	}
+
+	// reward the peers which sent us new messages since the last check
+	for peerID, proto := range protos {
+		rep := r.entry(peerID)
+		if rep.proto != proto {
+			// the protocol was restarted, the metrics start from zero again
+			rep.proto = proto
+			rep.lastNewMessages = 0
+		}
+		newMessages := proto.Metrics.NewMessages.Load()
+		r.record(peerID, ReputationEventNewMessage, newMessages-rep.lastNewMessages)
+		rep.lastNewMessages = newMessages
+	}
+
+	for peerID, rep := range r.peers {
+		// let the scores slowly move towards zero again, so peers can recover
+		if r.opts.recoveryInterval > 0 {
+			for now.Sub(rep.lastRecovery) >= r.opts.recoveryInterval {
+				rep.lastRecovery = rep.lastRecovery.Add(r.opts.recoveryInterval)
+				switch {
+				case rep.score > 0:
+					rep.score--
+				case rep.score < 0:
+					rep.score++
+				}
+			}
+		}
+
+		relation, dropped := r.droppedPeers[peerID]
+
+		if rep.score < r.opts.disconnectThreshold {
+			// peers which were already dropped are only dropped again if they reconnected
+			if _, connected := protos[peerID]; !dropped || connected {
+				belowThreshold = append(belowThreshold, r.snapshot(peerID, rep))
+			}
+			continue
+		}
+
+		if dropped {
+			delete(r.droppedPeers, peerID)
+			if relation == p2p.PeerRelationAutopeered {
+				recovered = append(recovered, peerID)
+			}
+		}
+
+		if _, connected := protos[peerID]; !connected && rep.score >= 0 && now.Sub(rep.lastUpdate) > reputationForgetThreshold {
+			delete(r.peers, peerID)
+		}
+	}
+
+	r.peersLock.Unlock()
+
+	for _, snapshot := range belowThreshold {
+		r.dropPeer(snapshot)
+	}
+
+	for _, peerID := range recovered {
+		r.allowPeer(peerID)
+	}
+}
+
+// allows the given autopeered peer again, which was disallowed because of its reputation.
+func (r *ReputationManager) allowPeer(peerID peer.ID) {
+	if err := r.peeringManager.AllowPeer(peerID); err != nil && !errors.Is(err, p2p.ErrPeerInManagerAlreadyAllowed) {
+		r.LogWarnf("unable to allow peer %s: %s", peerID.ShortString(), err)
+		return
+	}
+	r.LogInfof("allowed peer %s again, its reputation recovered", peerID.ShortString())
+}
+
+// drops the connection to the given peer if the relation to it is not known.
+// autopeered peers are also disallowed, so that the autopeering selection replaces them.
+func (r *ReputationManager) dropPeer(snapshot *PeerReputationSnapshot) {
+	var relation p2p.PeerRelation
+	r.peeringManager.Call(snapshot.PeerID, func(p *p2p.Peer) {
+		relation = p.Relation
+	})
+
+	switch relation {
+	case p2p.PeerRelationKnown:
+		// static peers are only deprioritized, the operator decided to peer with them
+		return
+
+	case p2p.PeerRelationAutopeered:
+		if err := r.peeringManager.DisallowPeer(snapshot.PeerID); err != nil {
+			r.LogWarnf("unable to disallow peer %s: %s", snapshot.PeerID.ShortString(), err)
+		}
+
+	case p2p.PeerRelationUnknown:
+
+	default:
+		// the peer is not known to the peering manager
+		return
+	}
+
+	// the peer is not dropped again until it reconnects, autopeered peers are allowed again once their reputation recovered
+	r.peersLock.Lock()
+	if droppedRelation, dropped := r.droppedPeers[snapshot.PeerID]; !dropped || droppedRelation != p2p.PeerRelationAutopeered {
+		r.droppedPeers[snapshot.PeerID] = relation
+	}
+	r.peersLock.Unlock()
+
+	reason := fmt.Errorf("reputation score %d below threshold %d", snapshot.Score, r.opts.disconnectThreshold)
+	r.LogInfof("dropping peer %s: %s", snapshot.PeerID.ShortString(), reason)
+	if err := r.peeringManager.DisconnectPeer(snapshot.PeerID, reason); err != nil {
+		r.LogWarnf("unable to drop peer %s: %s", snapshot.PeerID.ShortString(), err)
+	}
+}
@@ -0,0 +1,200 @@
+package gossip_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	connmgr "github.com/libp2p/go-libp2p-connmgr"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/metrics"
+	"github.com/gohornet/hornet/pkg/p2p"
+	"github.com/gohornet/hornet/pkg/protocol/gossip"
+	"github.com/iotaledger/hive.go/events"
+)
+
+func newReputationHost(t *testing.T) host.Host {
+	// we use Ed25519 because otherwise it takes longer as the default is RSA
+	sk, _, err := crypto.GenerateKeyPair(crypto.Ed25519, -1)
+	require.NoError(t, err)
+	n, err := libp2p.New(
+		libp2p.Identity(sk),
+		libp2p.ConnectionManager(connmgr.NewConnManager(1, 100, 0)),
+	)
+	require.NoError(t, err)
+	return n
+}
+
+func newReputationManager(ctx context.Context, t *testing.T, opts ...gossip.ReputationOption) (*gossip.ReputationManager, *p2p.Manager) {
+	n := newReputationHost(t)
+
+	manager := p2p.NewManager(n)
+	go manager.Start(ctx)
+
+	service := gossip.NewService(protocolID, n, manager, &metrics.ServerMetrics{})
+	go service.Start(ctx)
+
+	return gossip.NewReputationManager(service, manager, opts...), manager
+}
+
+func TestReputationScores(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reputationManager, _ := newReputationManager(ctx, t, gossip.WithReputationThresholds(-20, -50))
+
+	peerID := peer.ID("peer1")
+
+	// unknown peers start neutral
+	require.EqualValues(t, 0, reputationManager.Score(peerID))
+	require.False(t, reputationManager.IsDeprioritized(peerID))
+	require.EqualValues(t, 0, reputationManager.Snapshot(peerID).Score)
+
+	reputationManager.Record(peerID, gossip.ReputationEventNewMessage)
+	require.EqualValues(t, 1, reputationManager.Score(peerID))
+
+	reputationManager.Record(peerID, gossip.ReputationEventStaleHeartbeat)
+	require.EqualValues(t, -19, reputationManager.Score(peerID))
+	require.False(t, reputationManager.IsDeprioritized(peerID))
+
+	reputationManager.Record(peerID, gossip.ReputationEventUnansweredRequest)
+	reputationManager.Record(peerID, gossip.ReputationEventUnansweredRequest)
+	require.True(t, reputationManager.IsDeprioritized(peerID))
+	require.False(t, reputationManager.IsBelowDisconnectThreshold(peerID))
+
+	reputationManager.Record(peerID, gossip.ReputationEventInvalidMessage)
+	require.True(t, reputationManager.IsBelowDisconnectThreshold(peerID))
+
+	snapshot := reputationManager.Snapshot(peerID)
+	require.EqualValues(t, -71, snapshot.Score)
+	require.True(t, snapshot.Deprioritized)
+	require.EqualValues(t, 1, snapshot.InvalidMessages)
+	require.EqualValues(t, 2, snapshot.UnansweredRequests)
+	require.EqualValues(t, 1, snapshot.StaleHeartbeats)
+	require.EqualValues(t, 1, snapshot.NewMessages)
+
+	// the score is capped
+	for i := 0; i < 10; i++ {
+		reputationManager.Record(peerID, gossip.ReputationEventInvalidMessage)
+	}
+	require.EqualValues(t, -100, reputationManager.Score(peerID))
+}
+
+func TestReputationUnansweredRequests(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reputationManager, _ := newReputationManager(ctx, t,
+		gossip.WithReputationRequestTimeout(50*time.Millisecond),
+		gossip.WithReputationCheckInterval(10*time.Millisecond),
+		gossip.WithReputationRecoveryInterval(0),
+	)
+	go reputationManager.Run(ctx)
+
+	peer1 := peer.ID("peer1")
+	peer2 := peer.ID("peer2")
+
+	answered := gossip.NewMilestoneIndexRequest(1)
+	unanswered := gossip.NewMilestoneIndexRequest(2)
+
+	reputationManager.RequestSent(peer1, answered)
+	reputationManager.RequestSent(peer2, answered)
+	reputationManager.RequestSent(peer2, unanswered)
+
+	// answering a request clears it for all asked peers
+	reputationManager.RequestAnswered(answered)
+
+	require.Eventually(t, func() bool {
+		return reputationManager.Snapshot(peer2).UnansweredRequests == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	require.EqualValues(t, 0, reputationManager.Snapshot(peer1).UnansweredRequests)
+	require.EqualValues(t, -1, reputationManager.Score(peer2))
+}
+
+func TestReputationPeerDisconnected(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reputationManager, _ := newReputationManager(ctx, t,
+		gossip.WithReputationRequestTimeout(50*time.Millisecond),
+		gossip.WithReputationCheckInterval(10*time.Millisecond),
+		gossip.WithReputationRecoveryInterval(0),
+	)
+	go reputationManager.Run(ctx)
+
+	peer1 := peer.ID("peer1")
+	peer2 := peer.ID("peer2")
+
+	request := gossip.NewMilestoneIndexRequest(1)
+	reputationManager.RequestSent(peer1, request)
+	reputationManager.RequestSent(peer2, request)
+
+	// the disconnected peer is not punished for requests it can't answer anymore
+	reputationManager.PeerDisconnected(peer1)
+
+	require.Eventually(t, func() bool {
+		return reputationManager.Snapshot(peer2).UnansweredRequests == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	require.EqualValues(t, 0, reputationManager.Snapshot(peer1).UnansweredRequests)
+	require.EqualValues(t, 0, reputationManager.Score(peer1))
+}
+
+func TestReputationAllowRecoveredPeer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reputationManager, manager := newReputationManager(ctx, t,
+		gossip.WithReputationThresholds(-20, -50),
+		gossip.WithReputationCheckInterval(10*time.Millisecond),
+		gossip.WithReputationRecoveryInterval(5*time.Millisecond),
+	)
+
+	disallowed := make(chan peer.ID, 10)
+	allowed := make(chan peer.ID, 10)
+	manager.Events.Disallowed.Attach(events.NewClosure(func(peerID peer.ID) {
+		disallowed <- peerID
+	}))
+
+	autopeered := newReputationHost(t)
+	require.NoError(t, manager.AllowPeer(autopeered.ID()))
+	require.NoError(t, manager.ConnectPeer(&peer.AddrInfo{ID: autopeered.ID(), Addrs: autopeered.Addrs()[:1]}, p2p.PeerRelationAutopeered))
+
+	manager.Events.Allowed.Attach(events.NewClosure(func(peerID peer.ID) {
+		allowed <- peerID
+	}))
+
+	reputationManager.Record(autopeered.ID(), gossip.ReputationEventInvalidMessage)
+	reputationManager.Record(autopeered.ID(), gossip.ReputationEventInvalidMessage)
+	require.True(t, reputationManager.IsBelowDisconnectThreshold(autopeered.ID()))
+
+	go reputationManager.Run(ctx)
+
+	// the autopeered peer is disallowed because of its bad reputation
+	select {
+	case peerID := <-disallowed:
+		require.Equal(t, autopeered.ID(), peerID)
+	case <-time.After(2 * time.Second):
+		require.FailNow(t, "the peer was not disallowed")
+	}
+
+	// and allowed again once its reputation recovered
+	select {
+	case peerID := <-allowed:
+		require.Equal(t, autopeered.ID(), peerID)
+	case <-time.After(2 * time.Second):
+		require.FailNow(t, "the peer was not allowed again")
+	}
+
+	// the dropped peer was not dropped again while its reputation recovered
+	require.Empty(t, disallowed)
+
+	require.False(t, reputationManager.IsBelowDisconnectThreshold(autopeered.ID()))
+	require.True(t, manager.IsAllowed(autopeered.ID()))
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/libp2p/go-libp2p-core/peer"
+
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/storage"
@@ -15,6 +17,16 @@ type RequesterOptions struct {
 	PendingRequestReEnqueueInterval time.Duration
 	// Defines the max age for requests.
 	DiscardRequestsOlderThan time.Duration
+	// Defines the maximum amount of requests which are awaiting an answer from a single peer.
+	MaxInFlightPerPeer int
+	// Defines the maximum amount of additional peers a request is sent to if the asked peers don't answer in time.
+	MaxHedgedRequests int
+	// Defines the lower bound of the adaptive time to wait for an answer before a request is sent to another peer.
+	HedgeMinTimeout time.Duration
+	// Defines the upper bound of the adaptive time to wait for an answer before a request is sent to another peer.
+	HedgeMaxTimeout time.Duration
+	// Defines the interval in which pending requests are checked for missing answers.
+	HedgeCheckInterval time.Duration
 }
 
 // applies the given RequesterOption.
@@ -31,8 +43,16 @@ type RequestBackPressureFunc func() bool
 var defaultRequesterOpts = []RequesterOption{
 	WithRequesterDiscardRequestsOlderThan(10 * time.Second),
 	WithRequesterPendingRequestReEnqueueInterval(5 * time.Second),
+	WithRequesterMaxInFlightPerPeer(200),
+	WithRequesterHedging(1, 500*time.Millisecond, 3*time.Second),
+	WithRequesterHedgeCheckInterval(100 * time.Millisecond),
 }
 
+const (
+	// the factor applied to the average latency of a peer to get the time to wait for its answer.
+	hedgeLatencyFactor = 3
+)
+
 // RequesterOption is a function which sets an option on a RequesterOptions instance.
 type RequesterOption func(options *RequesterOptions)
 
@@ -50,12 +70,37 @@ func WithRequesterPendingRequestReEnqueueInterval(dur time.Duration) RequesterOp
 	}
 }
 
+// WithRequesterMaxInFlightPerPeer sets the maximum amount of requests which are awaiting an answer from a single peer.
+func WithRequesterMaxInFlightPerPeer(maxInFlight int) RequesterOption {
+	return func(options *RequesterOptions) {
+		options.MaxInFlightPerPeer = maxInFlight
+	}
+}
+
+// WithRequesterHedging sets the maximum amount of additional peers a request is sent to if the asked peers
+// don't answer in time and the bounds of the adaptive time to wait for an answer.
+func WithRequesterHedging(maxHedgedRequests int, minTimeout time.Duration, maxTimeout time.Duration) RequesterOption {
+	return func(options *RequesterOptions) {
+		options.MaxHedgedRequests = maxHedgedRequests
+		options.HedgeMinTimeout = minTimeout
+		options.HedgeMaxTimeout = maxTimeout
+	}
+}
+
+// WithRequesterHedgeCheckInterval sets the interval in which pending requests are checked for missing answers.
+func WithRequesterHedgeCheckInterval(dur time.Duration) RequesterOption {
+	return func(options *RequesterOptions) {
+		options.HedgeCheckInterval = dur
+	}
+}
+
 // Requester handles requesting packets.
 type Requester struct {
-	storage *storage.Storage
-	service *Service
-	rQueue  RequestQueue
-	opts    *RequesterOptions
+	storage           *storage.Storage
+	service           *Service
+	rQueue            RequestQueue
+	reputationManager *ReputationManager
+	opts              *RequesterOptions
 
 	running     bool
 	backPFuncs  []RequestBackPressureFunc
@@ -67,6 +112,7 @@ func NewRequester(
 	dbStorage *storage.Storage,
 	service *Service,
 	rQueue RequestQueue,
+	reputationManager *ReputationManager,
 	opts ...RequesterOption) *Requester {
 
 	reqOpts := &RequesterOptions{}
@@ -74,11 +120,12 @@ func NewRequester(
 	reqOpts.apply(opts...)
 
 	return &Requester{
-		storage:     dbStorage,
-		service:     service,
-		rQueue:      rQueue,
-		opts:        reqOpts,
-		drainSignal: make(chan struct{}, 2),
+		storage:           dbStorage,
+		service:           service,
+		rQueue:            rQueue,
+		reputationManager: reputationManager,
+		opts:              reqOpts,
+		drainSignal:       make(chan struct{}, 2),
 	}
 }
 
@@ -93,50 +140,166 @@ func (r *Requester) RunRequestQueueDrainer(ctx context.Context) {
 
 			// drain request queue
 			for request := r.rQueue.Next(); request != nil; request = r.rQueue.Next() {
+				r.sendToPeers(request, r.rQueue.SentTo(request))
+			}
+		}
+	}
+}
 
-				sendRequest := func(request *Request, proto *Protocol) {
-					switch request.RequestType {
-					case RequestTypeMessageID:
-						proto.SendMessageRequest(request.MessageID)
-					case RequestTypeMilestoneIndex:
-						proto.SendMilestoneRequest(request.MilestoneIndex)
-					default:
-						panic(ErrUnknownRequestType)
-					}
-				}
+// RunRequestHedger runs the loop to periodically send pending requests, which were not answered in time,
+// to other peers which have the data.
+func (r *Requester) RunRequestHedger(ctx context.Context) {
+	r.running = true
+	hedgeTicker := time.NewTicker(r.opts.HedgeCheckInterval)
+	defer hedgeTicker.Stop()
+
+hedgeLoop:
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hedgeTicker.C:
+
+			// check whether we should hold off requesting more data
+			// if the node is currently under a lot of load
+			if r.checkBackPressureFunctions() {
+				continue hedgeLoop
+			}
 
-				requested := false
-				r.service.ForEach(func(proto *Protocol) bool {
-					// we only send a request message if the peer actually has the data
-					// (r.MilestoneIndex > PrunedMilestoneIndex && r.MilestoneIndex <= SolidMilestoneIndex)
-					if !proto.HasDataForMilestone(request.MilestoneIndex) {
-						return true
-					}
-
-					sendRequest(request, proto)
-					requested = true
-					return false
-				})
-
-				if !requested {
-					// we have no neighbor that has the data for sure,
-					// so we ask all neighbors that could have the data
-					// (r.MilestoneIndex > PrunedMilestoneIndex && r.MilestoneIndex <= LatestMilestoneIndex)
-					r.service.ForEach(func(proto *Protocol) bool {
-						// we only send a request message if the peer could have the data
-						if !proto.CouldHaveDataForMilestone(request.MilestoneIndex) {
-							return true
-						}
-
-						sendRequest(request, proto)
-						return true
-					})
+			for _, request := range r.rQueue.Overdue(r.hedgeTimeout, r.opts.MaxHedgedRequests+1) {
+				// only hedge to peers which were not asked yet
+				if proto, _ := r.selectPeer(request, r.rQueue.SentTo(request), true); proto != nil {
+					r.sendRequest(request, proto, true)
 				}
 			}
 		}
 	}
 }
 
+// returns the time to wait for an answer of a peer with the given stats before the request is sent to another peer.
+func (r *Requester) hedgeTimeout(stats PeerRequestStats) time.Duration {
+	if stats.Answered == 0 {
+		return r.opts.HedgeMaxTimeout
+	}
+
+	timeout := hedgeLatencyFactor * stats.AvgLatency
+	if timeout < r.opts.HedgeMinTimeout {
+		return r.opts.HedgeMinTimeout
+	}
+	if timeout > r.opts.HedgeMaxTimeout {
+		return r.opts.HedgeMaxTimeout
+	}
+	return timeout
+}
+
+// sends the given request to the peer with the data which is expected to answer the fastest.
+// if no peer has the data for sure, the request is sent to all peers which could have the data.
+// if all peers with the data are busy, the request stays pending and is sent again after being re-enqueued.
+func (r *Requester) sendToPeers(request *Request, asked []peer.ID) {
+	proto, busy := r.selectPeer(request, asked, false)
+	if proto != nil {
+		r.sendRequest(request, proto, true)
+		return
+	}
+
+	if busy {
+		return
+	}
+
+	// we have no neighbor that has the data for sure,
+	// so we ask all neighbors that could have the data
+	// (r.MilestoneIndex > PrunedMilestoneIndex && r.MilestoneIndex <= LatestMilestoneIndex)
+	r.service.ForEach(func(proto *Protocol) bool {
+		// we only send a request message if the peer could have the data
+		if !proto.CouldHaveDataForMilestone(request.MilestoneIndex) {
+			return true
+		}
+
+		if r.isBusy(proto) {
+			return true
+		}
+
+		// the peer didn't advertise the data, so it is not punished if it doesn't answer
+		r.sendRequest(request, proto, false)
+		return true
+	})
+}
+
+// tells whether the given peer reached the maximum amount of requests awaiting an answer.
+func (r *Requester) isBusy(proto *Protocol) bool {
+	return r.opts.MaxInFlightPerPeer > 0 && r.rQueue.PeerStats(proto.PeerID).InFlight >= r.opts.MaxInFlightPerPeer
+}
+
+// selects the peer which has the data for the given request and is expected to answer the fastest.
+// peers with a bad reputation and peers which were already asked are only selected if there is no other peer.
+// if skipAsked is true, peers which were already asked are never selected.
+// busy tells whether peers with the data were skipped because they reached the maximum amount of requests in flight.
+func (r *Requester) selectPeer(request *Request, asked []peer.ID, skipAsked bool) (selected *Protocol, busy bool) {
+
+	wasAsked := func(peerID peer.ID) bool {
+		for _, askedPeerID := range asked {
+			if askedPeerID == peerID {
+				return true
+			}
+		}
+		return false
+	}
+
+	var selectedRank int
+	var selectedLatency time.Duration
+
+	r.service.ForEach(func(proto *Protocol) bool {
+		// we only send a request message if the peer actually has the data
+		// (r.MilestoneIndex > PrunedMilestoneIndex && r.MilestoneIndex <= SolidMilestoneIndex)
+		if !proto.HasDataForMilestone(request.MilestoneIndex) {
+			return true
+		}
+
+		if r.isBusy(proto) {
+			busy = true
+			return true
+		}
+
+		rank := 0
+		if wasAsked(proto.PeerID) {
+			if skipAsked {
+				return true
+			}
+			rank++
+		}
+		if r.reputationManager.IsDeprioritized(proto.PeerID) {
+			rank += 2
+		}
+
+		latency := r.rQueue.PeerStats(proto.PeerID).ExpectedLatency()
+		if selected == nil || rank < selectedRank || (rank == selectedRank && latency < selectedLatency) {
+			selected = proto
+			selectedRank = rank
+			selectedLatency = latency
+		}
+		return true
+	})
+
+	return selected, busy
+}
+
+// sends the given request to the given peer.
+// if hasData is true, the peer advertised the data and its answer is tracked for its reputation.
+func (r *Requester) sendRequest(request *Request, proto *Protocol, hasData bool) {
+	switch request.RequestType {
+	case RequestTypeMessageID:
+		proto.SendMessageRequest(request.MessageID)
+	case RequestTypeMilestoneIndex:
+		proto.SendMilestoneRequest(request.MilestoneIndex)
+	default:
+		panic(ErrUnknownRequestType)
+	}
+	r.rQueue.Sent(request, proto.PeerID)
+	if hasData {
+		r.reputationManager.RequestSent(proto.PeerID, request)
+	}
+}
+
 // RunPendingRequestEnqueuer runs the loop to periodically re-request pending requests from the RequestQueue.
 func (r *Requester) RunPendingRequestEnqueuer(ctx context.Context) {
 	r.running = true
@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/libp2p/go-libp2p-core/peer"
 	"go.uber.org/atomic"
 
 	"github.com/pkg/errors"
@@ -59,6 +60,17 @@ type RequestQueue interface {
 	// It is added to the processing set.
 	// Returns the origin request which was pending or nil if the data was not requested.
 	Received(data interface{}) *Request
+	// ReceivedFrom works like Received but additionally updates the request statistics of the given peer.
+	ReceivedFrom(data interface{}, peerID peer.ID) *Request
+	// Sent marks the given request as sent to the given peer.
+	Sent(request *Request, peerID peer.ID)
+	// SentTo returns the IDs of the peers the given request was sent to.
+	SentTo(data interface{}) []peer.ID
+	// Overdue returns the pending requests which were sent to less than maxSends peers and
+	// of which no sent request is still awaiting an answer within the timeout of the peer.
+	// Requests which were not sent to any peer yet are not overdue, they are sent again after being re-enqueued.
+	// Sent requests which exceeded the timeout are counted as timed out in the statistics of the peer.
+	Overdue(timeout func(stats PeerRequestStats) time.Duration, maxSends int) []*Request
 	// Processed marks a request as fulfilled and thereby removes it from the processing set.
 	// Returns the origin request which was processing or nil if the data was not requested.
 	Processed(data interface{}) *Request
@@ -74,6 +86,12 @@ type RequestQueue interface {
 	Requests() (queued []*Request, pending []*Request, processing []*Request)
 	// AvgLatency returns the average latency of enqueueing and then receiving a request.
 	AvgLatency() int64
+	// PeerStats returns the request statistics of the given peer.
+	PeerStats(peerID peer.ID) PeerRequestStats
+	// PeersStats returns the request statistics of all peers requests were sent to.
+	PeersStats() map[peer.ID]PeerRequestStats
+	// RemovePeer removes the request statistics of the given peer and forgets the requests sent to it.
+	RemovePeer(peerID peer.ID)
 	// Filter adds the given filter function to the queue. Passing nil resets the current one.
 	// Setting a filter automatically clears all queued and pending requests which do not fulfill
 	// the filter criteria.
@@ -85,6 +103,43 @@ type FilterFunc func(r *Request) bool
 
 const DefaultLatencyResolution = 100
 
+const (
+	// the weight of a new latency sample in the moving average of the latency of a peer.
+	peerLatencySmoothing = 0.2
+	// the latency assumed for peers which did not answer any request yet.
+	defaultPeerLatency = 500 * time.Millisecond
+)
+
+// PeerRequestStats holds the request statistics of a peer.
+type PeerRequestStats struct {
+	// The amount of requests sent to the peer which are neither answered nor timed out.
+	InFlight int
+	// The amount of requests sent to the peer.
+	Sent uint64
+	// The amount of requests answered by the peer.
+	Answered uint64
+	// The amount of requests the peer did not answer in time.
+	TimedOut uint64
+	// The moving average of the time the peer needed to answer a request.
+	AvgLatency time.Duration
+}
+
+// SuccessRate returns the smoothed rate of requests the peer answered in time.
+// Peers without any history are assumed to answer half of the requests.
+func (s PeerRequestStats) SuccessRate() float64 {
+	return float64(s.Answered+1) / float64(s.Answered+s.TimedOut+2)
+}
+
+// ExpectedLatency returns the latency to expect when requesting data from the peer,
+// which penalizes peers that often don't answer requests in time.
+func (s PeerRequestStats) ExpectedLatency() time.Duration {
+	latency := s.AvgLatency
+	if s.Answered == 0 {
+		latency = defaultPeerLatency
+	}
+	return time.Duration(float64(latency) / s.SuccessRate())
+}
+
 // NewRequestQueue creates a new RequestQueue where request are prioritized over their milestone index (lower = higher priority).
 func NewRequestQueue(latencyResolution ...int32) RequestQueue {
 	q := &priorityqueue{
@@ -92,6 +147,7 @@ func NewRequestQueue(latencyResolution ...int32) RequestQueue {
 		queued:     make(map[string]*Request),
 		pending:    make(map[string]*Request),
 		processing: make(map[string]*Request),
+		peerStats:  make(map[peer.ID]*PeerRequestStats),
 	}
 	if len(latencyResolution) == 0 {
 		q.latencyResolution = DefaultLatencyResolution
@@ -116,6 +172,16 @@ type Request struct {
 	// the time at which this request was first enqueued.
 	// do not modify this time
 	EnqueueTime time.Time
+	// the peers this request was sent to, internal to the priority queue.
+	sends map[peer.ID]*requestSend
+}
+
+// a request sent to a peer.
+type requestSend struct {
+	// the time the request was sent to the peer.
+	sentTime time.Time
+	// whether the peer did not answer the request in time.
+	timedOut bool
 }
 
 // NewMessageIDRequest creates a new message request for a specific messageID.
@@ -160,6 +226,7 @@ type priorityqueue struct {
 	latencySum        int64
 	latencyEntries    int64
 	filter            FilterFunc
+	peerStats         map[peer.ID]*PeerRequestStats
 	sync.RWMutex
 }
 
@@ -225,6 +292,20 @@ func (pq *priorityqueue) Received(data interface{}) *Request {
 	pq.Lock()
 	defer pq.Unlock()
 
+	return pq.received(data, "")
+}
+
+func (pq *priorityqueue) ReceivedFrom(data interface{}, peerID peer.ID) *Request {
+	pq.Lock()
+	defer pq.Unlock()
+
+	return pq.received(data, peerID)
+}
+
+// marks the request for the given data as received and updates the statistics
+// of the peers the request was sent to. peerID is the peer the data was received from.
+// write lock must be acquired outside.
+func (pq *priorityqueue) received(data interface{}, peerID peer.ID) *Request {
 	requestMapKey := getRequestMapKey(data)
 
 	if req, wasPending := pq.pending[requestMapKey]; wasPending {
@@ -243,6 +324,7 @@ func (pq *priorityqueue) Received(data interface{}) *Request {
 
 		// add the request to processing
 		pq.processing[requestMapKey] = req
+		pq.releaseSends(req, peerID)
 
 		return req
 	}
@@ -254,6 +336,7 @@ func (pq *priorityqueue) Received(data interface{}) *Request {
 
 		// add the request to processing
 		pq.processing[requestMapKey] = req
+		pq.releaseSends(req, peerID)
 
 		return req
 	}
@@ -261,6 +344,169 @@ func (pq *priorityqueue) Received(data interface{}) *Request {
 	return nil
 }
 
+// returns the statistics of the given peer or creates them.
+// write lock must be acquired outside.
+func (pq *priorityqueue) statsFor(peerID peer.ID) *PeerRequestStats {
+	stats, has := pq.peerStats[peerID]
+	if !has {
+		stats = &PeerRequestStats{}
+		pq.peerStats[peerID] = stats
+	}
+	return stats
+}
+
+// removes all sends of the given request from the in-flight requests of the peers.
+// if answeredBy is one of the peers the request was sent to, its answer is added to its statistics.
+// write lock must be acquired outside.
+func (pq *priorityqueue) releaseSends(r *Request, answeredBy peer.ID) {
+	for peerID, send := range r.sends {
+		stats := pq.statsFor(peerID)
+		if !send.timedOut {
+			stats.InFlight--
+		}
+
+		if peerID != answeredBy {
+			continue
+		}
+
+		latency := time.Since(send.sentTime)
+		if stats.Answered == 0 {
+			stats.AvgLatency = latency
+		} else {
+			stats.AvgLatency += time.Duration(peerLatencySmoothing * float64(latency-stats.AvgLatency))
+		}
+		stats.Answered++
+	}
+	r.sends = nil
+}
+
+func (pq *priorityqueue) Sent(r *Request, peerID peer.ID) {
+	pq.Lock()
+	defer pq.Unlock()
+
+	requestMapKey := r.MapKey()
+	req, pending := pq.pending[requestMapKey]
+	if !pending {
+		// the request was already received or discarded in the meantime
+		return
+	}
+
+	if req.sends == nil {
+		req.sends = make(map[peer.ID]*requestSend)
+	}
+
+	stats := pq.statsFor(peerID)
+	stats.Sent++
+
+	send, alreadySent := req.sends[peerID]
+	if !alreadySent {
+		req.sends[peerID] = &requestSend{sentTime: time.Now()}
+		stats.InFlight++
+		return
+	}
+
+	if send.timedOut {
+		// the peer is asked again, so it gets another chance to answer in time
+		send.sentTime = time.Now()
+		send.timedOut = false
+		stats.InFlight++
+	}
+}
+
+func (pq *priorityqueue) SentTo(data interface{}) []peer.ID {
+	pq.RLock()
+	defer pq.RUnlock()
+
+	requestMapKey := getRequestMapKey(data)
+
+	req, pending := pq.pending[requestMapKey]
+	if !pending {
+		if req, pending = pq.queued[requestMapKey]; !pending {
+			return nil
+		}
+	}
+
+	peerIDs := make([]peer.ID, 0, len(req.sends))
+	for peerID := range req.sends {
+		peerIDs = append(peerIDs, peerID)
+	}
+	return peerIDs
+}
+
+func (pq *priorityqueue) Overdue(timeout func(stats PeerRequestStats) time.Duration, maxSends int) []*Request {
+	pq.Lock()
+	defer pq.Unlock()
+
+	var overdue []*Request
+	now := time.Now()
+	for _, req := range pq.pending {
+		if len(req.sends) == 0 {
+			// the request was not sent yet, e.g. because all peers with the data were busy
+			continue
+		}
+
+		awaitingAnswer := false
+		for peerID, send := range req.sends {
+			if send.timedOut {
+				continue
+			}
+
+			stats := pq.statsFor(peerID)
+			if now.Sub(send.sentTime) < timeout(*stats) {
+				awaitingAnswer = true
+				continue
+			}
+
+			send.timedOut = true
+			stats.InFlight--
+			stats.TimedOut++
+		}
+
+		if awaitingAnswer || len(req.sends) >= maxSends {
+			continue
+		}
+		overdue = append(overdue, req)
+	}
+	return overdue
+}
+
+func (pq *priorityqueue) PeerStats(peerID peer.ID) PeerRequestStats {
+	pq.RLock()
+	defer pq.RUnlock()
+
+	stats, has := pq.peerStats[peerID]
+	if !has {
+		return PeerRequestStats{}
+	}
+	return *stats
+}
+
+func (pq *priorityqueue) PeersStats() map[peer.ID]PeerRequestStats {
+	pq.RLock()
+	defer pq.RUnlock()
+
+	peersStats := make(map[peer.ID]PeerRequestStats, len(pq.peerStats))
+	for peerID, stats := range pq.peerStats {
+		peersStats[peerID] = *stats
+	}
+	return peersStats
+}
+
+func (pq *priorityqueue) RemovePeer(peerID peer.ID) {
+	pq.Lock()
+	defer pq.Unlock()
+
+	delete(pq.peerStats, peerID)
+
+	// the peer will not answer the requests anymore
+	for _, req := range pq.pending {
+		delete(req.sends, peerID)
+	}
+	for _, req := range pq.queued {
+		delete(req.sends, peerID)
+	}
+}
+
 func (pq *priorityqueue) Processed(data interface{}) *Request {
 	pq.Lock()
 	defer pq.Unlock()
@@ -285,6 +531,7 @@ func (pq *priorityqueue) EnqueuePending(discardOlderThan time.Duration) int {
 	for k, v := range pq.pending {
 		if pq.filter != nil && !pq.filter(v) {
 			delete(pq.pending, k)
+			pq.releaseSends(v, "")
 			enqueued--
 			continue
 		}
@@ -296,6 +543,7 @@ func (pq *priorityqueue) EnqueuePending(discardOlderThan time.Duration) int {
 		}
 		// discard request from the queue
 		delete(pq.pending, k)
+		pq.releaseSends(v, "")
 		enqueued--
 	}
 	return enqueued
@@ -357,6 +605,7 @@ func (pq *priorityqueue) Filter(f FilterFunc) {
 		for _, r := range pq.queued {
 			if !f(r) {
 				delete(pq.queued, r.MapKey())
+				pq.releaseSends(r, "")
 				continue
 			}
 			filteredQueue = append(filteredQueue, r)
@@ -365,6 +614,7 @@ func (pq *priorityqueue) Filter(f FilterFunc) {
 		for k, v := range pq.pending {
 			if !f(v) {
 				delete(pq.pending, k)
+				pq.releaseSends(v, "")
 			}
 		}
 	}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"math/rand"
 	"testing"
+	"time"
 
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/gohornet/hornet/pkg/model/hornet"
@@ -178,3 +180,85 @@ func TestRequestQueue(t *testing.T) {
 		assert.Equal(t, req, r)
 	}
 }
+
+func TestRequestQueuePeerStats(t *testing.T) {
+	q := gossip.NewRequestQueue()
+
+	peer1 := peer.ID("peer1")
+	peer2 := peer.ID("peer2")
+
+	requestA := gossip.NewMessageIDRequest(randMessageID(), 5)
+	requestB := gossip.NewMessageIDRequest(randMessageID(), 6)
+
+	assert.True(t, q.Enqueue(requestA))
+	assert.True(t, q.Enqueue(requestB))
+	q.Next()
+	q.Next()
+
+	// requests which were not sent yet are never overdue
+	assert.Empty(t, q.Overdue(func(_ gossip.PeerRequestStats) time.Duration { return 0 }, 3))
+
+	q.Sent(requestA, peer1)
+	q.Sent(requestB, peer1)
+	q.Sent(requestB, peer2)
+
+	assert.ElementsMatch(t, []peer.ID{peer1, peer2}, q.SentTo(requestB))
+	assert.Equal(t, 2, q.PeerStats(peer1).InFlight)
+	assert.Equal(t, 1, q.PeerStats(peer2).InFlight)
+
+	// nothing is overdue as long as the peers have time to answer
+	assert.Empty(t, q.Overdue(func(_ gossip.PeerRequestStats) time.Duration { return time.Hour }, 3))
+
+	// peer1 doesn't answer in time, only request A is overdue since it was only sent to one peer
+	overdue := q.Overdue(func(_ gossip.PeerRequestStats) time.Duration { return 0 }, 2)
+	assert.Equal(t, []*gossip.Request{requestA}, overdue)
+
+	stats1 := q.PeerStats(peer1)
+	assert.Zero(t, stats1.InFlight)
+	assert.EqualValues(t, 2, stats1.TimedOut)
+
+	// request B is answered by peer2
+	assert.Equal(t, requestB, q.ReceivedFrom(requestB.MessageID, peer2))
+
+	stats2 := q.PeerStats(peer2)
+	assert.Zero(t, stats2.InFlight)
+	assert.EqualValues(t, 1, stats2.Answered)
+	assert.EqualValues(t, 1, stats2.TimedOut)
+
+	// the peer which answers is expected to be faster than the peer which never answers
+	assert.Less(t, int64(q.PeerStats(peer2).ExpectedLatency()), int64(q.PeerStats(peer1).ExpectedLatency()))
+
+	// asking a peer again gives it another chance
+	q.Sent(requestA, peer1)
+	assert.Equal(t, 1, q.PeerStats(peer1).InFlight)
+	assert.Len(t, q.PeersStats(), 2)
+
+	// discarding the request releases the in-flight request
+	q.EnqueuePending(time.Nanosecond)
+	assert.Zero(t, q.PeerStats(peer1).InFlight)
+}
+
+func TestRequestQueueRemovePeer(t *testing.T) {
+	q := gossip.NewRequestQueue()
+
+	peer1 := peer.ID("peer1")
+	peer2 := peer.ID("peer2")
+
+	request := gossip.NewMessageIDRequest(randMessageID(), 5)
+	assert.True(t, q.Enqueue(request))
+	q.Next()
+
+	q.Sent(request, peer1)
+	q.Sent(request, peer2)
+	assert.Len(t, q.PeersStats(), 2)
+
+	// the disconnected peer is forgotten
+	q.RemovePeer(peer1)
+	assert.Len(t, q.PeersStats(), 1)
+	assert.Equal(t, []peer.ID{peer2}, q.SentTo(request))
+
+	// the statistics of the removed peer are not created again by releasing the request
+	assert.Equal(t, request, q.ReceivedFrom(request.MessageID, peer2))
+	assert.Len(t, q.PeersStats(), 1)
+	assert.EqualValues(t, 1, q.PeerStats(peer2).Answered)
+}
@@ -104,6 +104,7 @@ func (wu *WorkUnit) punish(reason error) {
 	defer wu.receivedFromLock.Unlock()
 	for _, p := range wu.receivedFrom {
 		wu.messageProcessor.serverMetrics.InvalidMessages.Inc()
+		wu.messageProcessor.reputationManager.Record(p.PeerID, ReputationEventInvalidMessage)
 
 		// drop the connection to the peer
 		_ = wu.messageProcessor.peeringManager.DisconnectPeer(p.PeerID, errors.WithMessagef(reason, "peer was punished"))
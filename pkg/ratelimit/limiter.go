@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+type visitor struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// Limiter is a token bucket rate limiter that keeps a separate bucket for every key (e.g. the IP of a client).
+// Buckets of keys that were not seen for the configured expiry duration are removed.
+type Limiter struct {
+	sync.Mutex
+
+	rate        rate.Limit
+	burst       int
+	expiresIn   time.Duration
+	lastCleanup time.Time
+	visitors    map[string]*visitor
+}
+
+// NewLimiter creates a new Limiter that allows "ratePerSecond" events per second per key with bursts of up to "burst" events.
+func NewLimiter(ratePerSecond float64, burst int, expiresIn time.Duration) *Limiter {
+	return &Limiter{
+		rate:        rate.Limit(ratePerSecond),
+		burst:       burst,
+		expiresIn:   expiresIn,
+		lastCleanup: time.Now(),
+		visitors:    make(map[string]*visitor),
+	}
+}
+
+// Allow is shorthand for AllowAt(key, time.Now()).
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	return l.AllowAt(key, time.Now())
+}
+
+// AllowAt checks whether an event for the given key is allowed at the given time and consumes a token if that is the case.
+// If the event is not allowed, no token is consumed and the duration the client has to wait until the next token is available is returned.
+func (l *Limiter) AllowAt(key string, now time.Time) (bool, time.Duration) {
+	allowed, retryAfter, _ := l.ReserveAt(key, now)
+	return allowed, retryAfter
+}
+
+// Reserve is shorthand for ReserveAt(key, time.Now()).
+func (l *Limiter) Reserve(key string) (bool, time.Duration, func()) {
+	return l.ReserveAt(key, time.Now())
+}
+
+// ReserveAt works like AllowAt, but additionally returns a function that gives the consumed token back
+// if the event is not executed after all (e.g. because it was rejected by another limiter).
+// The returned function is nil if the event is not allowed.
+func (l *Limiter) ReserveAt(key string, now time.Time) (bool, time.Duration, func()) {
+	l.Lock()
+	defer l.Unlock()
+
+	v, exists := l.visitors[key]
+	if !exists {
+		v = &visitor{limiter: rate.NewLimiter(l.rate, l.burst)}
+		l.visitors[key] = v
+	}
+	v.lastSeen = now
+
+	if now.Sub(l.lastCleanup) > l.expiresIn {
+		l.cleanup(now)
+	}
+
+	reservation := v.limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		// the burst is smaller than one, so no events are allowed at all
+		return false, l.expiresIn, nil
+	}
+
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay, nil
+	}
+
+	return true, 0, func() {
+		l.Lock()
+		defer l.Unlock()
+
+		reservation.CancelAt(now)
+	}
+}
+
+// Size returns the amount of keys that are currently tracked.
+func (l *Limiter) Size() int {
+	l.Lock()
+	defer l.Unlock()
+
+	return len(l.visitors)
+}
+
+// cleanup removes the buckets of all keys that were not seen for the expiry duration.
+// write lock must be acquired outside.
+func (l *Limiter) cleanup(now time.Time) {
+	for key, v := range l.visitors {
+		if now.Sub(v.lastSeen) > l.expiresIn {
+			delete(l.visitors, key)
+		}
+	}
+	l.lastCleanup = now
+}
@@ -0,0 +1,75 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/ratelimit"
+)
+
+func TestLimiter(t *testing.T) {
+
+	limiter := ratelimit.NewLimiter(1, 2, time.Minute)
+	now := time.Now()
+
+	// the burst is available immediately
+	allowed, _ := limiter.AllowAt("client1", now)
+	require.True(t, allowed)
+	allowed, _ = limiter.AllowAt("client1", now)
+	require.True(t, allowed)
+
+	// the bucket is empty now
+	allowed, retryAfter := limiter.AllowAt("client1", now)
+	require.False(t, allowed)
+	require.Equal(t, time.Second, retryAfter)
+
+	// denied events do not consume tokens
+	allowed, retryAfter = limiter.AllowAt("client1", now.Add(500*time.Millisecond))
+	require.False(t, allowed)
+	require.Equal(t, 500*time.Millisecond, retryAfter)
+
+	// other clients have their own bucket
+	allowed, _ = limiter.AllowAt("client2", now)
+	require.True(t, allowed)
+	require.Equal(t, 2, limiter.Size())
+
+	// the bucket refills over time
+	allowed, _ = limiter.AllowAt("client1", now.Add(time.Second))
+	require.True(t, allowed)
+
+	// buckets of clients that were not seen for the expiry duration are removed
+	allowed, _ = limiter.AllowAt("client3", now.Add(2*time.Minute))
+	require.True(t, allowed)
+	require.Equal(t, 1, limiter.Size())
+}
+
+func TestLimiterZeroBurst(t *testing.T) {
+
+	limiter := ratelimit.NewLimiter(1, 0, time.Minute)
+
+	allowed, retryAfter := limiter.Allow("client")
+	require.False(t, allowed)
+	require.Equal(t, time.Minute, retryAfter)
+}
+
+func TestLimiterReserve(t *testing.T) {
+
+	limiter := ratelimit.NewLimiter(1, 1, time.Minute)
+	now := time.Now()
+
+	allowed, _, cancel := limiter.ReserveAt("client", now)
+	require.True(t, allowed)
+	require.NotNil(t, cancel)
+
+	// the bucket is empty now
+	allowed, _, cancelDenied := limiter.ReserveAt("client", now)
+	require.False(t, allowed)
+	require.Nil(t, cancelDenied)
+
+	// the token is given back
+	cancel()
+	allowed, _, _ = limiter.ReserveAt("client", now)
+	require.True(t, allowed)
+}
@@ -0,0 +1,30 @@
+package restapi
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/labstack/echo/v4"
+)
+
+// IPExtractor returns an echo.IPExtractor that determines the IP of a client using the X-Forwarded-For header.
+// Only hops added by proxies on the loopback interface or within one of the trusted proxy ranges (CIDR notation)
+// are trusted, so the nearest untrusted hop is used as client IP. This way clients can't spoof their IP
+// by sending the header themselves.
+func IPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	options := []echo.TrustOption{
+		echo.TrustLoopback(true),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+
+	for _, trustedProxy := range trustedProxies {
+		_, ipRange, err := net.ParseCIDR(trustedProxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy range %s: %w", trustedProxy, err)
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/libp2p/go-libp2p-core/peer"
@@ -34,6 +35,9 @@ const (
 
 	// ParameterPeerID is used to identify a peer.
 	ParameterPeerID = "peerID"
+
+	// ParameterTimestamp is used to pass a unix timestamp in seconds.
+	ParameterTimestamp = "timestamp"
 )
 
 var (
@@ -69,10 +73,18 @@ type HTTPOkResponseEnvelope struct {
 type (
 	// AllowedRoute defines a function to allow or disallow routes.
 	AllowedRoute func(echo.Context) bool
+
+	// RouteAuthorized defines a function to check whether a request is authorized to access the given route.
+	RouteAuthorized func(c echo.Context, route string) bool
 )
 
 func ParseMessageIDParam(c echo.Context) (hornet.MessageID, error) {
-	messageIDHex := strings.ToLower(c.Param(ParameterMessageID))
+	return ParseMessageID(c.Param(ParameterMessageID))
+}
+
+// ParseMessageID parses the given hex encoded message ID.
+func ParseMessageID(messageIDParam string) (hornet.MessageID, error) {
+	messageIDHex := strings.ToLower(messageIDParam)
 
 	messageID, err := hornet.MessageIDFromHex(messageIDHex)
 	if err != nil {
@@ -99,7 +111,12 @@ func ParseTransactionIDParam(c echo.Context) (*iotago.TransactionID, error) {
 }
 
 func ParseOutputIDParam(c echo.Context) (*iotago.UTXOInputID, error) {
-	outputIDParam := strings.ToLower(c.Param(ParameterOutputID))
+	return ParseOutputID(c.Param(ParameterOutputID))
+}
+
+// ParseOutputID parses the given hex encoded output ID.
+func ParseOutputID(outputIDParam string) (*iotago.UTXOInputID, error) {
+	outputIDParam = strings.ToLower(outputIDParam)
 
 	outputIDBytes, err := hex.DecodeString(outputIDParam)
 	if err != nil {
@@ -116,7 +133,12 @@ func ParseOutputIDParam(c echo.Context) (*iotago.UTXOInputID, error) {
 }
 
 func ParseBech32AddressParam(c echo.Context, prefix iotago.NetworkPrefix) (iotago.Address, error) {
-	addressParam := strings.ToLower(c.Param(ParameterAddress))
+	return ParseBech32Address(c.Param(ParameterAddress), prefix)
+}
+
+// ParseBech32Address parses the given bech32 encoded address and checks the network prefix.
+func ParseBech32Address(addressParam string, prefix iotago.NetworkPrefix) (iotago.Address, error) {
+	addressParam = strings.ToLower(addressParam)
 
 	hrp, bech32Address, err := iotago.ParseBech32(addressParam)
 	if err != nil {
@@ -161,6 +183,20 @@ func ParseMilestoneIndexParam(c echo.Context) (milestone.Index, error) {
 	return milestone.Index(msIndex), nil
 }
 
+func ParseTimestampParam(c echo.Context) (time.Time, error) {
+	timestampParam := c.Param(ParameterTimestamp)
+	if timestampParam == "" {
+		return time.Time{}, errors.WithMessagef(ErrInvalidParameter, "parameter \"%s\" not specified", ParameterTimestamp)
+	}
+
+	timestamp, err := strconv.ParseInt(timestampParam, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.WithMessagef(ErrInvalidParameter, "invalid timestamp: %s, error: %s", timestampParam, err)
+	}
+
+	return time.Unix(timestamp, 0), nil
+}
+
 func ParsePeerIDParam(c echo.Context) (peer.ID, error) {
 	peerID, err := peer.Decode(c.Param(ParameterPeerID))
 	if err != nil {
@@ -28,8 +28,9 @@ const (
 	PriorityPoWHandler
 	PriorityRestAPI // depends on PriorityPoWHandler
 	PriorityMetricsPublishers
-	PrioritySpammer // depends on PriorityPoWHandler
-	PriorityFaucet  // depends on PriorityPoWHandler
+	PrioritySpammer  // depends on PriorityPoWHandler
+	PriorityFaucet   // depends on PriorityPoWHandler
+	PriorityPromoter // depends on PriorityPoWHandler
 	PriorityParticipation
 	PriorityStatusReport
 	PriorityMigrator
@@ -113,9 +113,7 @@ func (s *SnapshotManager) pruneMilestone(milestoneIndex milestone.Index, receipt
 		return err
 	}
 
-	s.storage.DeleteMilestone(milestoneIndex)
-
-	return nil
+	return s.storage.DeleteMilestone(milestoneIndex)
 }
 
 // pruneMessages removes all the associated data of the given message IDs from the database
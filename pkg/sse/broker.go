@@ -0,0 +1,230 @@
+package sse
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// EventIDFunc returns the ID that is attached to new events.
+type EventIDFunc func() string
+
+// OnSubscribeHandler is called for every topic a new subscription is interested in.
+type OnSubscribeHandler func(topic []byte)
+
+// OnUnsubscribeHandler is called for every topic of a subscription that is closed.
+type OnUnsubscribeHandler func(topic []byte)
+
+// Event is a single server-sent event.
+type Event struct {
+	// ID is used by the clients to resume the stream via the "Last-Event-ID" header.
+	ID string
+	// Topic is sent as the event type.
+	Topic string
+	// Data is the payload of the event.
+	Data []byte
+}
+
+// WriteTo writes the event in the "text/event-stream" format to the given writer.
+func (e *Event) WriteTo(w io.Writer) (int64, error) {
+	var sb strings.Builder
+
+	if e.ID != "" {
+		sb.WriteString(fmt.Sprintf("id: %s\n", e.ID))
+	}
+	if e.Topic != "" {
+		sb.WriteString(fmt.Sprintf("event: %s\n", e.Topic))
+	}
+	for _, line := range strings.Split(string(e.Data), "\n") {
+		sb.WriteString(fmt.Sprintf("data: %s\n", line))
+	}
+	sb.WriteString("\n")
+
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+// Subscription is a client of the broker that is interested in a set of topics.
+type Subscription struct {
+	topics  []string
+	events  chan *Event
+	dropped int
+}
+
+// Topics returns the topics of the subscription.
+func (s *Subscription) Topics() []string {
+	return s.topics
+}
+
+// Events returns the channel on which the events for the subscribed topics are received.
+func (s *Subscription) Events() <-chan *Event {
+	return s.events
+}
+
+// Options define options for the broker.
+type Options struct {
+	// the size of the event buffer per subscription.
+	clientBufferSize int
+}
+
+var defaultOptions = []Option{
+	WithClientBufferSize(1000),
+}
+
+// applies the given Option.
+func (so *Options) apply(opts ...Option) {
+	for _, opt := range opts {
+		opt(so)
+	}
+}
+
+// WithClientBufferSize defines the size of the event buffer per subscription.
+// Events for subscriptions with a full buffer are dropped.
+func WithClientBufferSize(clientBufferSize int) Option {
+	return func(opts *Options) {
+		opts.clientBufferSize = clientBufferSize
+	}
+}
+
+// Option is a function setting a broker option.
+type Option func(opts *Options)
+
+// Broker distributes events to the subscriptions of server-sent event clients.
+type Broker struct {
+	sync.RWMutex
+
+	eventIDFunc   EventIDFunc
+	onSubscribe   OnSubscribeHandler
+	onUnsubscribe OnUnsubscribeHandler
+
+	// subscriptions per topic.
+	subscriptions map[string]map[*Subscription]struct{}
+	// the amount of active subscriptions.
+	subscriptionsCount int
+
+	opts *Options
+}
+
+// NewBroker creates a new broker.
+func NewBroker(eventIDFunc EventIDFunc, onSubscribe OnSubscribeHandler, onUnsubscribe OnUnsubscribeHandler, opts ...Option) *Broker {
+
+	options := &Options{}
+	options.apply(defaultOptions...)
+	options.apply(opts...)
+
+	return &Broker{
+		eventIDFunc:   eventIDFunc,
+		onSubscribe:   onSubscribe,
+		onUnsubscribe: onUnsubscribe,
+		subscriptions: make(map[string]map[*Subscription]struct{}),
+		opts:          options,
+	}
+}
+
+// Subscribe creates a new subscription for the given topics.
+// The subscription has to be closed with Unsubscribe.
+func (b *Broker) Subscribe(topics ...string) *Subscription {
+
+	subscription := &Subscription{
+		topics: topics,
+		events: make(chan *Event, b.opts.clientBufferSize),
+	}
+
+	b.Lock()
+	for _, topic := range topics {
+		if _, exists := b.subscriptions[topic]; !exists {
+			b.subscriptions[topic] = make(map[*Subscription]struct{})
+		}
+		b.subscriptions[topic][subscription] = struct{}{}
+	}
+	b.subscriptionsCount++
+	b.Unlock()
+
+	if b.onSubscribe != nil {
+		for _, topic := range topics {
+			b.onSubscribe([]byte(topic))
+		}
+	}
+
+	return subscription
+}
+
+// Unsubscribe removes the subscription from the broker.
+// It returns the amount of events that were dropped for the subscription.
+func (b *Broker) Unsubscribe(subscription *Subscription) int {
+
+	b.Lock()
+	for _, topic := range subscription.topics {
+		subscriptions, exists := b.subscriptions[topic]
+		if !exists {
+			continue
+		}
+
+		delete(subscriptions, subscription)
+		if len(subscriptions) == 0 {
+			delete(b.subscriptions, topic)
+		}
+	}
+	b.subscriptionsCount--
+	dropped := subscription.dropped
+	b.Unlock()
+
+	if b.onUnsubscribe != nil {
+		for _, topic := range subscription.topics {
+			b.onUnsubscribe([]byte(topic))
+		}
+	}
+
+	return dropped
+}
+
+// HasSubscribers returns whether there are subscriptions for the given topic.
+func (b *Broker) HasSubscribers(topic string) bool {
+	b.RLock()
+	defer b.RUnlock()
+
+	_, exists := b.subscriptions[topic]
+	return exists
+}
+
+// SubscriptionsCount returns the amount of active subscriptions.
+func (b *Broker) SubscriptionsCount() int {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.subscriptionsCount
+}
+
+// Send publishes the payload to all subscriptions of the given topic.
+func (b *Broker) Send(topic string, payload []byte) {
+	b.SendWithID(topic, b.eventIDFunc(), payload)
+}
+
+// SendWithID publishes the payload with the given event ID to all subscriptions of the given topic.
+func (b *Broker) SendWithID(topic string, eventID string, payload []byte) {
+
+	// the lock is needed to modify the dropped counter of the subscriptions
+	b.Lock()
+	defer b.Unlock()
+
+	subscriptions, exists := b.subscriptions[topic]
+	if !exists {
+		return
+	}
+
+	event := &Event{
+		ID:    eventID,
+		Topic: topic,
+		Data:  payload,
+	}
+
+	for subscription := range subscriptions {
+		select {
+		case subscription.events <- event:
+		default:
+			// the client is too slow, drop the event
+			subscription.dropped++
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package sse_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/sse"
+)
+
+func TestBroker(t *testing.T) {
+
+	var subscribed, unsubscribed []string
+	broker := sse.NewBroker(func() string { return "5" }, func(topic []byte) {
+		subscribed = append(subscribed, string(topic))
+	}, func(topic []byte) {
+		unsubscribed = append(unsubscribed, string(topic))
+	}, sse.WithClientBufferSize(1))
+
+	require.False(t, broker.HasSubscribers("milestones/latest"))
+
+	subscription := broker.Subscribe("milestones/latest", "receipts")
+	require.Equal(t, []string{"milestones/latest", "receipts"}, subscribed)
+	require.True(t, broker.HasSubscribers("milestones/latest"))
+	require.True(t, broker.HasSubscribers("receipts"))
+	require.False(t, broker.HasSubscribers("messages"))
+	require.Equal(t, 1, broker.SubscriptionsCount())
+
+	broker.Send("messages", []byte("ignored"))
+	broker.Send("receipts", []byte(`{"a":1}`))
+
+	// the buffer is full, so the next event is dropped
+	broker.SendWithID("milestones/latest", "6", []byte("dropped"))
+
+	event := <-subscription.Events()
+	require.Equal(t, &sse.Event{ID: "5", Topic: "receipts", Data: []byte(`{"a":1}`)}, event)
+
+	require.Equal(t, 1, broker.Unsubscribe(subscription))
+	require.Equal(t, []string{"milestones/latest", "receipts"}, unsubscribed)
+	require.False(t, broker.HasSubscribers("milestones/latest"))
+	require.Equal(t, 0, broker.SubscriptionsCount())
+}
+
+func TestEventWriteTo(t *testing.T) {
+
+	event := &sse.Event{ID: "42", Topic: "milestones/confirmed", Data: []byte("line1\nline2")}
+
+	var buf bytes.Buffer
+	n, err := event.WriteTo(&buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(buf.Len()), n)
+	require.Equal(t, "id: 42\nevent: milestones/confirmed\ndata: line1\ndata: line2\n\n", buf.String())
+}
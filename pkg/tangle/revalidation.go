@@ -204,7 +204,9 @@ func (t *Tangle) cleanupMilestones(info *storage.SnapshotInfo) error {
 		}
 
 		t.storage.DeleteUnreferencedMessages(msIndex)
-		t.storage.DeleteMilestone(msIndex)
+		if err := t.storage.DeleteMilestone(msIndex); err != nil {
+			return err
+		}
 	}
 
 	t.storage.FlushUnreferencedMessagesStorage()
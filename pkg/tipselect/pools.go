@@ -0,0 +1,144 @@
+package tipselect
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/dag"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+)
+
+var (
+	// ErrInvalidTipPool is returned when a tip pool other than the non-lazy or semi-lazy pool is requested.
+	ErrInvalidTipPool = errors.New("invalid tip pool")
+	// ErrTipNotFound is returned when a tip is not part of any tip pool.
+	ErrTipNotFound = errors.New("tip not found")
+)
+
+// TipInfo holds a snapshot of the state of a tip in a tip pool.
+type TipInfo struct {
+	// MessageID is the message ID of the tip.
+	MessageID hornet.MessageID
+	// Score is the score of the tip, which defines its tip pool.
+	Score Score
+	// ChildrenCount is the amount the tip was referenced by other messages.
+	ChildrenCount uint32
+	// TimeAdded is the timestamp the tip was added to the tip pool.
+	TimeAdded time.Time
+	// TimeFirstChild is the timestamp the tip was referenced for the first time by another message.
+	TimeFirstChild time.Time
+	// Own is true if the tip was issued by the node itself.
+	Own bool
+	// YoungestConeRootIndex is the YCRI of the tip.
+	YoungestConeRootIndex milestone.Index
+	// OldestConeRootIndex is the OCRI of the tip.
+	OldestConeRootIndex milestone.Index
+}
+
+// tipsMapWithoutLocking returns the tip pool for the given score without acquiring the lock.
+func (ts *TipSelector) tipsMapWithoutLocking(score Score) (map[string]*Tip, error) {
+	switch score {
+	case ScoreNonLazy:
+		return ts.nonLazyTipsMap, nil
+	case ScoreSemiLazy:
+		return ts.semiLazyTipsMap, nil
+	default:
+		return nil, ErrInvalidTipPool
+	}
+}
+
+// removeTipsFromMetrics removes the given amount of tips from the metrics of the tip pool of the given score.
+func (ts *TipSelector) removeTipsFromMetrics(score Score, count int) {
+	switch score {
+	case ScoreNonLazy:
+		ts.serverMetrics.TipsNonLazy.Sub(uint32(count))
+	case ScoreSemiLazy:
+		ts.serverMetrics.TipsSemiLazy.Sub(uint32(count))
+	}
+}
+
+// PoolTips returns the state of all tips in the tip pool of the given score (non-lazy or semi-lazy).
+// The cone root indexes are calculated in relation to the current CMI.
+func (ts *TipSelector) PoolTips(score Score) ([]*TipInfo, error) {
+
+	ts.tipsLock.Lock()
+	tipsMap, err := ts.tipsMapWithoutLocking(score)
+	if err != nil {
+		ts.tipsLock.Unlock()
+		return nil, err
+	}
+
+	tipInfos := make([]*TipInfo, 0, len(tipsMap))
+	for _, tip := range tipsMap {
+		tipInfos = append(tipInfos, &TipInfo{
+			MessageID:      tip.MessageID,
+			Score:          tip.Score,
+			ChildrenCount:  tip.ChildrenCount.Load(),
+			TimeAdded:      tip.TimeAdded,
+			TimeFirstChild: tip.TimeFirstChild,
+			Own:            tip.Own,
+		})
+	}
+	ts.tipsLock.Unlock()
+
+	// the cone root indexes are calculated without holding the lock, because walking the cones may take a while
+	cmi := ts.syncManager.ConfirmedMilestoneIndex()
+	for _, tipInfo := range tipInfos {
+		cachedMsgMeta := ts.storage.CachedMessageMetadataOrNil(tipInfo.MessageID) // meta +1
+		if cachedMsgMeta == nil {
+			// the message could have been pruned already
+			continue
+		}
+
+		ycri, ocri, err := dag.ConeRootIndexes(ts.shutdownCtx, ts.storage, cachedMsgMeta.Retain(), cmi) // meta +1
+		cachedMsgMeta.Release(true) // meta -1
+		if err != nil {
+			return nil, err
+		}
+
+		tipInfo.YoungestConeRootIndex = ycri
+		tipInfo.OldestConeRootIndex = ocri
+	}
+
+	return tipInfos, nil
+}
+
+// RemoveTip removes the given message from the tip pools.
+func (ts *TipSelector) RemoveTip(messageID hornet.MessageID) error {
+	ts.tipsLock.Lock()
+	defer ts.tipsLock.Unlock()
+
+	for _, score := range []Score{ScoreNonLazy, ScoreSemiLazy} {
+		tipsMap, _ := ts.tipsMapWithoutLocking(score)
+		if ts.removeTipWithoutLocking(tipsMap, messageID) {
+			ts.removeTipsFromMetrics(score, 1)
+			return nil
+		}
+	}
+
+	return ErrTipNotFound
+}
+
+// FlushPool removes all tips from the tip pool of the given score (non-lazy or semi-lazy)
+// and returns the amount of removed tips.
+func (ts *TipSelector) FlushPool(score Score) (int, error) {
+	ts.tipsLock.Lock()
+	defer ts.tipsLock.Unlock()
+
+	tipsMap, err := ts.tipsMapWithoutLocking(score)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, tip := range tipsMap {
+		if ts.removeTipWithoutLocking(tipsMap, tip.MessageID) {
+			count++
+		}
+	}
+	ts.removeTipsFromMetrics(score, count)
+
+	return count, nil
+}
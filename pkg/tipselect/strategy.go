@@ -0,0 +1,112 @@
+package tipselect
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Strategy assigns a weight to every tip in a tip pool.
+// The probability of a tip to be selected is proportional to its weight.
+// Tips with a weight of zero or below are never selected, unless all tips of the pool have such a weight.
+type Strategy interface {
+	// Weight returns the selection weight of the given tip.
+	Weight(tip *Tip, now time.Time) float64
+}
+
+// UniformStrategy selects every tip with the same probability.
+type UniformStrategy struct{}
+
+// Weight returns the same weight for every tip.
+func (UniformStrategy) Weight(_ *Tip, _ time.Time) float64 {
+	return 1
+}
+
+// AgeWeightedStrategy prefers tips that are in the tip pool for a longer time,
+// to get them referenced before they become lazy.
+type AgeWeightedStrategy struct {
+	// Factor is the additional weight per second a tip is in the tip pool.
+	Factor float64
+}
+
+// Weight returns 1 + Factor * age of the tip in seconds.
+func (s AgeWeightedStrategy) Weight(tip *Tip, now time.Time) float64 {
+	if tip.TimeAdded.IsZero() || now.Before(tip.TimeAdded) {
+		return 1
+	}
+	return 1 + s.Factor*now.Sub(tip.TimeAdded).Seconds()
+}
+
+// ChildrenWeightedStrategy prefers tips that were referenced by less messages,
+// to widen the cone of the tangle.
+type ChildrenWeightedStrategy struct {
+	// Factor defines how strong the weight decreases for every child of a tip.
+	Factor float64
+}
+
+// Weight returns 1 / (1 + Factor * children of the tip).
+func (s ChildrenWeightedStrategy) Weight(tip *Tip, _ time.Time) float64 {
+	return 1 / (1 + s.Factor*float64(tip.ChildrenCount.Load()))
+}
+
+// OwnMessagesStrategy prefers tips that were issued by the node itself.
+type OwnMessagesStrategy struct {
+	// Factor is the weight of own tips, all other tips have a weight of 1.
+	Factor float64
+}
+
+// Weight returns Factor for own tips and 1 for all other tips.
+func (s OwnMessagesStrategy) Weight(tip *Tip, _ time.Time) float64 {
+	if tip.Own {
+		return s.Factor
+	}
+	return 1
+}
+
+// CombinedStrategy combines several strategies by multiplying their weights.
+type CombinedStrategy []Strategy
+
+// Weight returns the product of the weights of all strategies.
+func (s CombinedStrategy) Weight(tip *Tip, now time.Time) float64 {
+	weight := 1.0
+	for _, strategy := range s {
+		weight *= strategy.Weight(tip, now)
+	}
+	return weight
+}
+
+// weightedRandomTip picks a tip with a probability proportional to its weight.
+// if all weights are zero or below, a tip is picked uniformly.
+func weightedRandomTip(tips []*Tip, strategy Strategy, rng *rand.Rand, now time.Time) *Tip {
+	if len(tips) == 0 {
+		return nil
+	}
+
+	weights := make([]float64, len(tips))
+	var totalWeight float64
+	for i, tip := range tips {
+		if weight := strategy.Weight(tip, now); weight > 0 {
+			weights[i] = weight
+			totalWeight += weight
+		}
+	}
+
+	if totalWeight <= 0 {
+		return tips[rng.Intn(len(tips))]
+	}
+
+	randWeight := rng.Float64() * totalWeight
+	for i, weight := range weights {
+		randWeight -= weight
+		if randWeight < 0 {
+			return tips[i]
+		}
+	}
+
+	// rounding errors => return the last tip with a positive weight
+	for i := len(tips) - 1; i >= 0; i-- {
+		if weights[i] > 0 {
+			return tips[i]
+		}
+	}
+	return tips[len(tips)-1]
+}
@@ -3,10 +3,12 @@ package test
 import (
 	"context"
 	"math"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
 
 	"github.com/gohornet/hornet/pkg/dag"
 	"github.com/gohornet/hornet/pkg/metrics"
@@ -33,6 +35,28 @@ const (
 	MinPoWScore                           = 1.0
 )
 
+func newTipSelector(te *testsuite.TestEnvironment, serverMetrics *metrics.ServerMetrics, opts ...tipselect.Option) *tipselect.TipSelector {
+	return tipselect.New(
+		context.Background(),
+		te.Storage(),
+		te.SyncManager(),
+		serverMetrics,
+		append([]tipselect.Option{
+			tipselect.WithMaxDeltaMsgYoungestConeRootIndexToCMI(MaxDeltaMsgYoungestConeRootIndexToCMI),
+			tipselect.WithMaxDeltaMsgOldestConeRootIndexToCMI(MaxDeltaMsgOldestConeRootIndexToCMI),
+			tipselect.WithBelowMaxDepth(BelowMaxDepth),
+			tipselect.WithRetentionRulesTipsLimitNonLazy(RetentionRulesTipsLimitNonLazy),
+			tipselect.WithMaxReferencedTipAgeNonLazy(MaxReferencedTipAgeNonLazy),
+			tipselect.WithMaxChildrenNonLazy(uint32(MaxChildrenNonLazy)),
+			tipselect.WithSpammerTipsThresholdNonLazy(SpammerTipsThresholdNonLazy),
+			tipselect.WithRetentionRulesTipsLimitSemiLazy(RetentionRulesTipsLimitSemiLazy),
+			tipselect.WithMaxReferencedTipAgeSemiLazy(MaxReferencedTipAgeSemiLazy),
+			tipselect.WithMaxChildrenSemiLazy(uint32(MaxChildrenSemiLazy)),
+			tipselect.WithSpammerTipsThresholdSemiLazy(SpammerTipsThresholdSemiLazy),
+		}, opts...)...,
+	)
+}
+
 func TestTipSelect(t *testing.T) {
 
 	te := testsuite.SetupTestEnvironment(t, &iotago.Ed25519Address{}, 0, BelowMaxDepth, MinPoWScore, false)
@@ -40,23 +64,7 @@ func TestTipSelect(t *testing.T) {
 
 	serverMetrics := metrics.ServerMetrics{}
 
-	ts := tipselect.New(
-		context.Background(),
-		te.Storage(),
-		te.SyncManager(),
-		&serverMetrics,
-		MaxDeltaMsgYoungestConeRootIndexToCMI,
-		MaxDeltaMsgOldestConeRootIndexToCMI,
-		BelowMaxDepth,
-		RetentionRulesTipsLimitNonLazy,
-		MaxReferencedTipAgeNonLazy,
-		uint32(MaxChildrenNonLazy),
-		SpammerTipsThresholdNonLazy,
-		RetentionRulesTipsLimitSemiLazy,
-		MaxReferencedTipAgeSemiLazy,
-		uint32(MaxChildrenSemiLazy),
-		SpammerTipsThresholdSemiLazy,
-	)
+	ts := newTipSelector(te, &serverMetrics)
 
 	// fill the storage with some messages to fill the tipselect pool
 	msgCount := 0
@@ -151,3 +159,167 @@ func TestTipSelect(t *testing.T) {
 
 	require.Equal(te.TestInterface, 1+100, len(te.Milestones)) // genesis + all created milestones
 }
+
+func TestTipSelectSeeded(t *testing.T) {
+
+	te := testsuite.SetupTestEnvironment(t, &iotago.Ed25519Address{}, 0, BelowMaxDepth, MinPoWScore, false)
+	defer te.CleanupTestEnvironment(true)
+
+	ts1 := newTipSelector(te, &metrics.ServerMetrics{}, tipselect.WithRandomSeed(42))
+	ts2 := newTipSelector(te, &metrics.ServerMetrics{}, tipselect.WithRandomSeed(42))
+	ts3 := newTipSelector(te, &metrics.ServerMetrics{}, tipselect.WithRandomSeed(43))
+
+	for i := 0; i < 50; i++ {
+		msgMeta := te.NewTestMessage(i, hornet.MessageIDs{te.Milestones[0].Milestone().MessageID})
+		ts1.AddTip(msgMeta)
+		ts2.AddTip(msgMeta)
+		ts3.AddTip(msgMeta)
+	}
+
+	// the same seed results in the same tips, a different seed in different ones
+	differentSelections := 0
+	for i := 0; i < 100; i++ {
+		tips1, err := ts1.SelectNonLazyTips()
+		require.NoError(te.TestInterface, err)
+
+		tips2, err := ts2.SelectNonLazyTips()
+		require.NoError(te.TestInterface, err)
+
+		tips3, err := ts3.SelectNonLazyTips()
+		require.NoError(te.TestInterface, err)
+
+		require.Equal(te.TestInterface, tips1, tips2)
+		if !reflect.DeepEqual(tips1, tips3) {
+			differentSelections++
+		}
+	}
+	require.Greater(te.TestInterface, differentSelections, 0)
+}
+
+func TestTipSelectOwnMessages(t *testing.T) {
+
+	te := testsuite.SetupTestEnvironment(t, &iotago.Ed25519Address{}, 0, BelowMaxDepth, MinPoWScore, false)
+	defer te.CleanupTestEnvironment(true)
+
+	ts := newTipSelector(te, &metrics.ServerMetrics{}, tipselect.WithStrategy(tipselect.OwnMessagesStrategy{Factor: 1_000_000}))
+
+	var ownMessageID hornet.MessageID
+	for i := 0; i < 50; i++ {
+		msgMeta := te.NewTestMessage(i, hornet.MessageIDs{te.Milestones[0].Milestone().MessageID})
+		if i == 25 {
+			ownMessageID = msgMeta.MessageID()
+			ts.RegisterOwnMessage(ownMessageID)
+		}
+		ts.AddTip(msgMeta)
+	}
+
+	// the own tip is preferred by far, so it is part of every selection
+	for i := 0; i < 100; i++ {
+		tips, err := ts.SelectNonLazyTips()
+		require.NoError(te.TestInterface, err)
+		require.Contains(te.TestInterface, tips, ownMessageID)
+	}
+}
+
+func TestTipSelectStrategies(t *testing.T) {
+
+	now := time.Now()
+
+	newTip := func(age time.Duration, children uint32, own bool) *tipselect.Tip {
+		return &tipselect.Tip{
+			ChildrenCount: atomic.NewUint32(children),
+			TimeAdded:     now.Add(-age),
+			Own:           own,
+		}
+	}
+
+	oldTip := newTip(10*time.Second, 0, false)
+	youngTip := newTip(0, 0, false)
+	referencedTip := newTip(0, 4, false)
+	ownTip := newTip(0, 0, true)
+
+	require.Equal(t, 1.0, tipselect.UniformStrategy{}.Weight(oldTip, now))
+	require.Equal(t, 1.0, tipselect.UniformStrategy{}.Weight(ownTip, now))
+
+	ageStrategy := tipselect.AgeWeightedStrategy{Factor: 0.5}
+	require.Equal(t, 6.0, ageStrategy.Weight(oldTip, now))
+	require.Equal(t, 1.0, ageStrategy.Weight(youngTip, now))
+
+	childrenStrategy := tipselect.ChildrenWeightedStrategy{Factor: 0.25}
+	require.Equal(t, 1.0, childrenStrategy.Weight(youngTip, now))
+	require.Equal(t, 0.5, childrenStrategy.Weight(referencedTip, now))
+
+	ownStrategy := tipselect.OwnMessagesStrategy{Factor: 3}
+	require.Equal(t, 3.0, ownStrategy.Weight(ownTip, now))
+	require.Equal(t, 1.0, ownStrategy.Weight(youngTip, now))
+
+	combinedStrategy := tipselect.CombinedStrategy{ageStrategy, childrenStrategy, ownStrategy}
+	require.Equal(t, 6.0, combinedStrategy.Weight(oldTip, now))
+	require.Equal(t, 0.5, combinedStrategy.Weight(referencedTip, now))
+	require.Equal(t, 3.0, combinedStrategy.Weight(ownTip, now))
+}
+
+func TestTipPools(t *testing.T) {
+
+	te := testsuite.SetupTestEnvironment(t, &iotago.Ed25519Address{}, 0, BelowMaxDepth, MinPoWScore, false)
+	defer te.CleanupTestEnvironment(true)
+
+	serverMetrics := metrics.ServerMetrics{}
+	ts := newTipSelector(te, &serverMetrics)
+
+	var tipMessageIDs hornet.MessageIDs
+	for i := 0; i < 10; i++ {
+		msgMeta := te.NewTestMessage(i, hornet.MessageIDs{te.Milestones[0].Milestone().MessageID})
+		ts.AddTip(msgMeta)
+		tipMessageIDs = append(tipMessageIDs, msgMeta.MessageID())
+	}
+
+	nonLazyCount, semiLazyCount := ts.TipCount()
+	require.Equal(t, 10, nonLazyCount)
+	require.Equal(t, 0, semiLazyCount)
+	require.Equal(t, uint32(10), serverMetrics.TipsNonLazy.Load())
+
+	tipInfos, err := ts.PoolTips(tipselect.ScoreNonLazy)
+	require.NoError(t, err)
+	require.Len(t, tipInfos, 10)
+
+	cmi := te.SyncManager().ConfirmedMilestoneIndex()
+	for _, tipInfo := range tipInfos {
+		require.Contains(t, tipMessageIDs, tipInfo.MessageID)
+		require.Equal(t, tipselect.ScoreNonLazy, tipInfo.Score)
+		require.Equal(t, uint32(0), tipInfo.ChildrenCount)
+		require.False(t, tipInfo.TimeAdded.IsZero())
+		require.LessOrEqual(t, uint32(tipInfo.OldestConeRootIndex), uint32(tipInfo.YoungestConeRootIndex))
+		require.LessOrEqual(t, uint32(tipInfo.YoungestConeRootIndex), uint32(cmi))
+	}
+
+	tipInfos, err = ts.PoolTips(tipselect.ScoreSemiLazy)
+	require.NoError(t, err)
+	require.Empty(t, tipInfos)
+
+	_, err = ts.PoolTips(tipselect.ScoreLazy)
+	require.ErrorIs(t, err, tipselect.ErrInvalidTipPool)
+
+	// evict a single tip
+	require.NoError(t, ts.RemoveTip(tipMessageIDs[0]))
+	require.ErrorIs(t, ts.RemoveTip(tipMessageIDs[0]), tipselect.ErrTipNotFound)
+
+	nonLazyCount, _ = ts.TipCount()
+	require.Equal(t, 9, nonLazyCount)
+	require.Equal(t, uint32(9), serverMetrics.TipsNonLazy.Load())
+
+	// flush the pool
+	removed, err := ts.FlushPool(tipselect.ScoreNonLazy)
+	require.NoError(t, err)
+	require.Equal(t, 9, removed)
+
+	nonLazyCount, _ = ts.TipCount()
+	require.Equal(t, 0, nonLazyCount)
+	require.Equal(t, uint32(0), serverMetrics.TipsNonLazy.Load())
+
+	_, err = ts.SelectNonLazyTips()
+	require.ErrorIs(t, err, tipselect.ErrNoTipsAvailable)
+
+	_, err = ts.FlushPool(tipselect.ScoreLazy)
+	require.ErrorIs(t, err, tipselect.ErrInvalidTipPool)
+}
@@ -1,8 +1,10 @@
 package tipselect
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"math/rand"
 	"sort"
 	"time"
 
@@ -16,7 +18,6 @@ import (
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/model/syncmanager"
-	"github.com/gohornet/hornet/pkg/utils"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/serializer"
 	"github.com/iotaledger/hive.go/syncutils"
@@ -53,6 +54,11 @@ const (
 	ScoreNonLazy
 )
 
+const (
+	// ownMessagesRetention is the duration an own message is remembered until it is added as a tip.
+	ownMessagesRetention = 1 * time.Minute
+)
+
 var (
 	// ErrNoTipsAvailable is returned when no tips are available in the node.
 	ErrNoTipsAvailable = errors.New("no tips available")
@@ -70,6 +76,10 @@ type Tip struct {
 	TimeFirstChild time.Time
 	// ChildrenCount is the amount the tip was referenced by other messages.
 	ChildrenCount *atomic.Uint32
+	// TimeAdded is the timestamp the tip was added to the tip pool.
+	TimeAdded time.Time
+	// Own is true if the tip was issued by the node itself.
+	Own bool
 }
 
 // Events represents events happening on the tip-selector.
@@ -135,48 +145,205 @@ type TipSelector struct {
 	nonLazyTipsMap map[string]*Tip
 	// semiLazyTipsMap contains only semi-lazy tips.
 	semiLazyTipsMap map[string]*Tip
+	// ownMessagesMap contains the messages issued by the node itself that were not added as tips yet.
+	ownMessagesMap map[string]time.Time
+	// strategy is used to weight the tips during the tip selection.
+	strategy Strategy
+	// seeded is true if the tip selection is reproducible.
+	seeded bool
+	// rng is the source of randomness for the tip selection.
+	rng *rand.Rand
 	// lock for the tipsMaps
 	tipsLock syncutils.Mutex
 	// Events are the events that are triggered by the TipSelector.
 	Events Events
 }
 
+var defaultOptions = []Option{
+	WithMaxDeltaMsgYoungestConeRootIndexToCMI(8),
+	WithMaxDeltaMsgOldestConeRootIndexToCMI(13),
+	WithBelowMaxDepth(15),
+	WithRetentionRulesTipsLimitNonLazy(100),
+	WithMaxReferencedTipAgeNonLazy(3 * time.Second),
+	WithMaxChildrenNonLazy(30),
+	WithSpammerTipsThresholdNonLazy(0),
+	WithRetentionRulesTipsLimitSemiLazy(20),
+	WithMaxReferencedTipAgeSemiLazy(3 * time.Second),
+	WithMaxChildrenSemiLazy(2),
+	WithSpammerTipsThresholdSemiLazy(30),
+	WithStrategy(UniformStrategy{}),
+}
+
+// Options define options for the tip-selector.
+type Options struct {
+	maxDeltaMsgYoungestConeRootIndexToCMI int
+	maxDeltaMsgOldestConeRootIndexToCMI   int
+	belowMaxDepth                         int
+	retentionRulesTipsLimitNonLazy        int
+	maxReferencedTipAgeNonLazy            time.Duration
+	maxChildrenNonLazy                    uint32
+	spammerTipsThresholdNonLazy           int
+	retentionRulesTipsLimitSemiLazy       int
+	maxReferencedTipAgeSemiLazy           time.Duration
+	maxChildrenSemiLazy                   uint32
+	spammerTipsThresholdSemiLazy          int
+	strategy                              Strategy
+	seeded                                bool
+	randomSeed                            int64
+}
+
+// applies the given Option.
+func (so *Options) apply(opts ...Option) {
+	for _, opt := range opts {
+		opt(so)
+	}
+}
+
+// WithMaxDeltaMsgYoungestConeRootIndexToCMI defines the maximum allowed delta
+// value for the YCRI of a given message in relation to the current CMI before it gets lazy.
+func WithMaxDeltaMsgYoungestConeRootIndexToCMI(maxDeltaMsgYoungestConeRootIndexToCMI int) Option {
+	return func(opts *Options) {
+		opts.maxDeltaMsgYoungestConeRootIndexToCMI = maxDeltaMsgYoungestConeRootIndexToCMI
+	}
+}
+
+// WithMaxDeltaMsgOldestConeRootIndexToCMI defines the maximum allowed delta
+// value between OCRI of a given message in relation to the current CMI before it gets semi-lazy.
+func WithMaxDeltaMsgOldestConeRootIndexToCMI(maxDeltaMsgOldestConeRootIndexToCMI int) Option {
+	return func(opts *Options) {
+		opts.maxDeltaMsgOldestConeRootIndexToCMI = maxDeltaMsgOldestConeRootIndexToCMI
+	}
+}
+
+// WithBelowMaxDepth defines the maximum allowed delta
+// value between OCRI of a given message in relation to the current CMI before it gets lazy.
+func WithBelowMaxDepth(belowMaxDepth int) Option {
+	return func(opts *Options) {
+		opts.belowMaxDepth = belowMaxDepth
+	}
+}
+
+// WithRetentionRulesTipsLimitNonLazy defines the maximum amount of current tips for which the retention rules are checked (non-lazy pool).
+func WithRetentionRulesTipsLimitNonLazy(retentionRulesTipsLimit int) Option {
+	return func(opts *Options) {
+		opts.retentionRulesTipsLimitNonLazy = retentionRulesTipsLimit
+	}
+}
+
+// WithMaxReferencedTipAgeNonLazy defines the maximum time a tip remains in the tip pool
+// after it was referenced by the first message (non-lazy pool).
+func WithMaxReferencedTipAgeNonLazy(maxReferencedTipAge time.Duration) Option {
+	return func(opts *Options) {
+		opts.maxReferencedTipAgeNonLazy = maxReferencedTipAge
+	}
+}
+
+// WithMaxChildrenNonLazy defines the maximum amount of references by other messages
+// before the tip is removed from the tip pool (non-lazy pool).
+func WithMaxChildrenNonLazy(maxChildren uint32) Option {
+	return func(opts *Options) {
+		opts.maxChildrenNonLazy = maxChildren
+	}
+}
+
+// WithSpammerTipsThresholdNonLazy defines the maximum amount of tips in the non-lazy tip pool
+// before the spammer tries to reduce these (0 = always).
+func WithSpammerTipsThresholdNonLazy(spammerTipsThreshold int) Option {
+	return func(opts *Options) {
+		opts.spammerTipsThresholdNonLazy = spammerTipsThreshold
+	}
+}
+
+// WithRetentionRulesTipsLimitSemiLazy defines the maximum amount of current tips for which the retention rules are checked (semi-lazy pool).
+func WithRetentionRulesTipsLimitSemiLazy(retentionRulesTipsLimit int) Option {
+	return func(opts *Options) {
+		opts.retentionRulesTipsLimitSemiLazy = retentionRulesTipsLimit
+	}
+}
+
+// WithMaxReferencedTipAgeSemiLazy defines the maximum time a tip remains in the tip pool
+// after it was referenced by the first message (semi-lazy pool).
+func WithMaxReferencedTipAgeSemiLazy(maxReferencedTipAge time.Duration) Option {
+	return func(opts *Options) {
+		opts.maxReferencedTipAgeSemiLazy = maxReferencedTipAge
+	}
+}
+
+// WithMaxChildrenSemiLazy defines the maximum amount of references by other messages
+// before the tip is removed from the tip pool (semi-lazy pool).
+func WithMaxChildrenSemiLazy(maxChildren uint32) Option {
+	return func(opts *Options) {
+		opts.maxChildrenSemiLazy = maxChildren
+	}
+}
+
+// WithSpammerTipsThresholdSemiLazy defines the maximum amount of tips in the semi-lazy tip pool
+// before the spammer tries to reduce these (0 = disable).
+func WithSpammerTipsThresholdSemiLazy(spammerTipsThreshold int) Option {
+	return func(opts *Options) {
+		opts.spammerTipsThresholdSemiLazy = spammerTipsThreshold
+	}
+}
+
+// WithStrategy defines the strategy used to weight the tips during the tip selection.
+func WithStrategy(strategy Strategy) Option {
+	return func(opts *Options) {
+		opts.strategy = strategy
+	}
+}
+
+// WithRandomSeed makes the tip selection reproducible by using the given seed.
+// The same tip pool and the same sequence of calls result in the same selected tips,
+// as long as the used strategy does not depend on the current time.
+func WithRandomSeed(seed int64) Option {
+	return func(opts *Options) {
+		opts.seeded = true
+		opts.randomSeed = seed
+	}
+}
+
+// Option is a function setting a tip-selector option.
+type Option func(opts *Options)
+
 // New creates a new tip-selector.
 func New(
 	shutdownCtx context.Context,
 	dbStorage *storage.Storage,
 	syncManager *syncmanager.SyncManager,
 	serverMetrics *metrics.ServerMetrics,
-	maxDeltaMsgYoungestConeRootIndexToCMI int,
-	maxDeltaMsgOldestConeRootIndexToCMI int,
-	belowMaxDepth int,
-	retentionRulesTipsLimitNonLazy int,
-	maxReferencedTipAgeNonLazy time.Duration,
-	maxChildrenNonLazy uint32,
-	spammerTipsThresholdNonLazy int,
-	retentionRulesTipsLimitSemiLazy int,
-	maxReferencedTipAgeSemiLazy time.Duration,
-	maxChildrenSemiLazy uint32,
-	spammerTipsThresholdSemiLazy int) *TipSelector {
+	opts ...Option) *TipSelector {
+
+	options := &Options{}
+	options.apply(defaultOptions...)
+	options.apply(opts...)
+
+	randomSeed := time.Now().UnixNano()
+	if options.seeded {
+		randomSeed = options.randomSeed
+	}
 
 	return &TipSelector{
 		shutdownCtx:                           shutdownCtx,
 		storage:                               dbStorage,
 		syncManager:                           syncManager,
 		serverMetrics:                         serverMetrics,
-		maxDeltaMsgYoungestConeRootIndexToCMI: milestone.Index(maxDeltaMsgYoungestConeRootIndexToCMI),
-		maxDeltaMsgOldestConeRootIndexToCMI:   milestone.Index(maxDeltaMsgOldestConeRootIndexToCMI),
-		belowMaxDepth:                         milestone.Index(belowMaxDepth),
-		retentionRulesTipsLimitNonLazy:        retentionRulesTipsLimitNonLazy,
-		maxReferencedTipAgeNonLazy:            maxReferencedTipAgeNonLazy,
-		maxChildrenNonLazy:                    maxChildrenNonLazy,
-		spammerTipsThresholdNonLazy:           spammerTipsThresholdNonLazy,
-		retentionRulesTipsLimitSemiLazy:       retentionRulesTipsLimitSemiLazy,
-		maxReferencedTipAgeSemiLazy:           maxReferencedTipAgeSemiLazy,
-		maxChildrenSemiLazy:                   maxChildrenSemiLazy,
-		spammerTipsThresholdSemiLazy:          spammerTipsThresholdSemiLazy,
+		maxDeltaMsgYoungestConeRootIndexToCMI: milestone.Index(options.maxDeltaMsgYoungestConeRootIndexToCMI),
+		maxDeltaMsgOldestConeRootIndexToCMI:   milestone.Index(options.maxDeltaMsgOldestConeRootIndexToCMI),
+		belowMaxDepth:                         milestone.Index(options.belowMaxDepth),
+		retentionRulesTipsLimitNonLazy:        options.retentionRulesTipsLimitNonLazy,
+		maxReferencedTipAgeNonLazy:            options.maxReferencedTipAgeNonLazy,
+		maxChildrenNonLazy:                    options.maxChildrenNonLazy,
+		spammerTipsThresholdNonLazy:           options.spammerTipsThresholdNonLazy,
+		retentionRulesTipsLimitSemiLazy:       options.retentionRulesTipsLimitSemiLazy,
+		maxReferencedTipAgeSemiLazy:           options.maxReferencedTipAgeSemiLazy,
+		maxChildrenSemiLazy:                   options.maxChildrenSemiLazy,
+		spammerTipsThresholdSemiLazy:          options.spammerTipsThresholdSemiLazy,
 		nonLazyTipsMap:                        make(map[string]*Tip),
 		semiLazyTipsMap:                       make(map[string]*Tip),
+		ownMessagesMap:                        make(map[string]time.Time),
+		strategy:                              options.strategy,
+		seeded:                                options.seeded,
+		rng:                                   rand.New(rand.NewSource(randomSeed)),
 		Events: Events{
 			TipAdded:        events.NewEvent(TipCaller),
 			TipRemoved:      events.NewEvent(TipCaller),
@@ -217,11 +384,16 @@ func (ts *TipSelector) AddTip(messageMeta *storage.MessageMetadata) {
 		return
 	}
 
+	_, own := ts.ownMessagesMap[messageIDMapKey]
+	delete(ts.ownMessagesMap, messageIDMapKey)
+
 	tip := &Tip{
 		Score:          score,
 		MessageID:      messageID,
 		TimeFirstChild: time.Time{},
 		ChildrenCount:  atomic.NewUint32(0),
+		TimeAdded:      time.Now(),
+		Own:            own,
 	}
 
 	switch tip.Score {
@@ -297,34 +469,45 @@ func (ts *TipSelector) removeTipWithoutLocking(tipsMap map[string]*Tip, messageI
 	return false
 }
 
-// randomTipWithoutLocking picks a random tip from the pool and checks it's "own" score again without acquiring the lock.
-func (ts *TipSelector) randomTipWithoutLocking(tipsMap map[string]*Tip) (hornet.MessageID, error) {
+// RegisterOwnMessage marks the given message as issued by the node itself.
+// If the message is added as a tip afterwards, the tip is marked as own tip.
+func (ts *TipSelector) RegisterOwnMessage(messageID hornet.MessageID) {
+	ts.tipsLock.Lock()
+	defer ts.tipsLock.Unlock()
 
-	if len(tipsMap) == 0 {
-		// no semi-/non-lazy tips available
-		return nil, ErrNoTipsAvailable
+	ts.ownMessagesMap[messageID.ToMapKey()] = time.Now()
+}
+
+// tipsWithoutLocking returns the tips of the given pool without acquiring the lock.
+// in seeded mode, the tips are sorted by their message ID, because the iteration order of maps is random.
+func (ts *TipSelector) tipsWithoutLocking(tipsMap map[string]*Tip) []*Tip {
+	tips := make([]*Tip, 0, len(tipsMap))
+	for _, tip := range tipsMap {
+		tips = append(tips, tip)
 	}
 
-	// get a random number between 0 and the amount of tips-1
-	randTip := utils.RandomInsecure(0, len(tipsMap)-1)
+	if ts.seeded {
+		sort.Slice(tips, func(i, j int) bool {
+			return bytes.Compare(tips[i].MessageID, tips[j].MessageID) < 0
+		})
+	}
 
-	// iterate over the tipsMap and subtract each tip from randTip
-	for _, tip := range tipsMap {
-		// subtract the tip from randTip
-		randTip--
+	return tips
+}
 
-		// if randTip is below zero, we return the given tip
-		if randTip < 0 {
-			return tip.MessageID, nil
-		}
+// randomTipWithoutLocking picks a random tip from the given tips, weighted by the strategy of the tip-selector, without acquiring the lock.
+func (ts *TipSelector) randomTipWithoutLocking(tips []*Tip) (hornet.MessageID, error) {
+
+	if len(tips) == 0 {
+		// no semi-/non-lazy tips available
+		return nil, ErrNoTipsAvailable
 	}
 
-	// no tips
-	return nil, ErrNoTipsAvailable
+	return weightedRandomTip(tips, ts.strategy, ts.rng, time.Now()).MessageID, nil
 }
 
 // selectTipWithoutLocking selects a tip.
-func (ts *TipSelector) selectTipWithoutLocking(tipsMap map[string]*Tip) (hornet.MessageID, error) {
+func (ts *TipSelector) selectTipWithoutLocking(tips []*Tip) (hornet.MessageID, error) {
 
 	if !ts.syncManager.IsNodeAlmostSynced() {
 		return nil, common.ErrNodeNotSynced
@@ -333,7 +516,7 @@ func (ts *TipSelector) selectTipWithoutLocking(tipsMap map[string]*Tip) (hornet.
 	// record stats
 	start := time.Now()
 
-	tipMessageID, err := ts.randomTipWithoutLocking(tipsMap)
+	tipMessageID, err := ts.randomTipWithoutLocking(tips)
 	ts.Events.TipSelPerformed.Trigger(&TipSelStats{Duration: time.Since(start)})
 
 	return tipMessageID, err
@@ -347,13 +530,15 @@ func (ts *TipSelector) selectTips(tipsMap map[string]*Tip) (hornet.MessageIDs, e
 	tipCount := ts.optimalTipCount()
 	maxRetries := (tipCount - 1) * 10
 
+	tips := ts.tipsWithoutLocking(tipsMap)
+
 	seen := make(map[string]struct{})
 	orderedSlicesWithoutDups := make(serializer.LexicalOrderedByteSlices, tipCount)
 
 	// retry the tipselection several times if parents not unique
 	uniqueElements := 0
 	for i := 0; i < maxRetries; i++ {
-		tip, err := ts.selectTipWithoutLocking(tipsMap)
+		tip, err := ts.selectTipWithoutLocking(tips)
 		if err != nil {
 			if errors.Is(err, ErrNoTipsAvailable) && i != 0 {
 				// do not search other tips if there are none
@@ -461,6 +646,13 @@ func (ts *TipSelector) CleanUpReferencedTips() int {
 		return ts.removeTipWithoutLocking(tipsMap, tip.MessageID)
 	}
 
+	// forget about own messages that were never added as tips
+	for messageIDMapKey, registered := range ts.ownMessagesMap {
+		if time.Since(registered) > ownMessagesRetention {
+			delete(ts.ownMessagesMap, messageIDMapKey)
+		}
+	}
+
 	count := 0
 	for _, tip := range ts.nonLazyTipsMap {
 		if checkTip(ts.nonLazyTipsMap, tip, ts.maxReferencedTipAgeNonLazy) {
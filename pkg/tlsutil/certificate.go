@@ -0,0 +1,171 @@
+package tlsutil
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultReloadInterval is the interval in which the certificate files are checked for changes.
+	DefaultReloadInterval = 10 * time.Second
+)
+
+// CertificateReloader holds a certificate that is loaded from the given files
+// and that is reloaded if the files are changed on disk.
+type CertificateReloader struct {
+	sync.RWMutex
+
+	certPath        string
+	keyPath         string
+	certificate     *tls.Certificate
+	certModTime     time.Time
+	keyModTime      time.Time
+	lastReloadError error
+}
+
+// NewCertificateReloader creates a new CertificateReloader and loads the certificate from the given files.
+func NewCertificateReloader(certPath string, keyPath string) (*CertificateReloader, error) {
+	r := &CertificateReloader{
+		certPath: certPath,
+		keyPath:  keyPath,
+	}
+
+	if _, err := r.ReloadIfChanged(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func modTime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// ReloadIfChanged reloads the certificate if the modification time of one of the files changed.
+// It returns whether the certificate was reloaded.
+// If the new files are invalid, the previous certificate is kept.
+func (r *CertificateReloader) ReloadIfChanged() (bool, error) {
+
+	certModTime, err := modTime(r.certPath)
+	if err != nil {
+		return false, errors.Wrapf(err, "reading certificate file failed")
+	}
+
+	keyModTime, err := modTime(r.keyPath)
+	if err != nil {
+		return false, errors.Wrapf(err, "reading key file failed")
+	}
+
+	r.RLock()
+	unchanged := r.certificate != nil && certModTime.Equal(r.certModTime) && keyModTime.Equal(r.keyModTime)
+	r.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(r.certPath, r.keyPath)
+
+	r.Lock()
+	defer r.Unlock()
+
+	// remember the modification times even if loading failed,
+	// so the error is only reported once until the files change again.
+	r.certModTime = certModTime
+	r.keyModTime = keyModTime
+
+	if err != nil {
+		r.lastReloadError = err
+		return false, errors.Wrapf(err, "loading certificate failed")
+	}
+
+	r.certificate = &certificate
+	r.lastReloadError = nil
+
+	return true, nil
+}
+
+// Run checks the certificate files for changes in the given interval until the context is done.
+// The onReload function is called after every reload attempt.
+func (r *CertificateReloader) Run(ctx context.Context, interval time.Duration, onReload func(err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.ReloadIfChanged()
+			if (reloaded || err != nil) && onReload != nil {
+				onReload(err)
+			}
+		}
+	}
+}
+
+// GetCertificate returns the current certificate. It can be used as tls.Config.GetCertificate.
+func (r *CertificateReloader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	if r.certificate == nil {
+		return nil, fmt.Errorf("no certificate loaded: %w", r.lastReloadError)
+	}
+
+	return r.certificate, nil
+}
+
+// LoadCertPool loads the PEM encoded certificates in the given file into a new certificate pool.
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	pemCerts, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading CA certificate file failed")
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemCerts) {
+		return nil, fmt.Errorf("no valid certificates found in %s", path)
+	}
+
+	return pool, nil
+}
+
+// NewServerConfig creates the TLS configuration for a HTTP server that serves the certificate of the given reloader.
+// HTTP/2 is negotiated via ALPN. If clientCAs are given, client certificates are verified against them if they are sent,
+// but they are not required, so the handlers can decide which routes need a client certificate.
+func NewServerConfig(reloader *CertificateReloader, clientCAs *x509.CertPool) *tls.Config {
+	config := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+
+	if clientCAs != nil {
+		config.ClientCAs = clientCAs
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return config
+}
+
+// VerifiedClientCertificate returns the verified client certificate of the request, or nil if there is none.
+func VerifiedClientCertificate(req *http.Request) *x509.Certificate {
+	if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return req.TLS.VerifiedChains[0][0]
+}
@@ -0,0 +1,49 @@
+package tlsutil
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+)
+
+// ReloadFunc checks the certificate files of a TLS configuration for changes
+// in the DefaultReloadInterval until the context is done.
+// The onReload function is called after every reload attempt.
+type ReloadFunc func(ctx context.Context, onReload func(err error))
+
+// NewReloadingConfig loads the certificate of a server with the given bind address from the given files
+// and creates the TLS configuration of the server, which always serves the latest loaded certificate.
+// If selfSigned is set and the files do not exist, a self-signed certificate is generated first.
+// If a clientCAPath is given, client certificates are verified against the CA certificates in that file.
+// It returns the function that reloads the certificate if the files are changed on disk
+// and whether a self-signed certificate was generated.
+func NewReloadingConfig(certPath string, keyPath string, clientCAPath string, selfSigned bool, bindAddress string) (*tls.Config, ReloadFunc, bool, error) {
+
+	reloader, generated, err := LoadServerCertificate(certPath, keyPath, selfSigned, bindAddress)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	var clientCAs *x509.CertPool
+	if clientCAPath != "" {
+		if clientCAs, err = LoadCertPool(clientCAPath); err != nil {
+			return nil, nil, false, err
+		}
+	}
+
+	reload := func(ctx context.Context, onReload func(err error)) {
+		reloader.Run(ctx, DefaultReloadInterval, onReload)
+	}
+
+	return NewServerConfig(reloader, clientCAs), reload, generated, nil
+}
+
+// LocalProxyTransport returns the transport used to proxy requests to a TLS server on the same host.
+// The certificate of the server is not verified, because the connection never leaves the host
+// and the certificate is usually not issued for "localhost".
+func LocalProxyTransport() http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	return transport
+}
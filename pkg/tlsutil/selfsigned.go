@@ -0,0 +1,188 @@
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultSelfSignedValidity is the validity of generated self-signed certificates.
+	DefaultSelfSignedValidity = 365 * 24 * time.Hour
+
+	selfSignedOrganization = "HORNET self-signed"
+)
+
+// GenerateSelfSignedCertificate creates a new self-signed certificate for the given hosts (DNS names or IPs)
+// and returns the PEM encoded certificate and private key.
+func GenerateSelfSignedCertificate(hosts []string, validFor time.Duration) (certPEM []byte, keyPEM []byte, err error) {
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "generating private key failed")
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "generating serial number failed")
+	}
+
+	notBefore := time.Now().Add(-time.Hour)
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{selfSignedOrganization},
+		},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(validFor),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+			continue
+		}
+		template.DNSNames = append(template.DNSNames, host)
+	}
+
+	if len(hosts) > 0 {
+		template.Subject.CommonName = hosts[0]
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "creating certificate failed")
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "marshaling private key failed")
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+
+	return certPEM, keyPEM, nil
+}
+
+func fileExists(path string) (bool, error) {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// WriteSelfSignedCertificateIfMissing generates a self-signed certificate for the given hosts
+// and writes it to the given files, if neither of the files exists yet.
+// It returns whether a certificate was generated.
+func WriteSelfSignedCertificateIfMissing(certPath string, keyPath string, hosts []string) (bool, error) {
+
+	certExists, err := fileExists(certPath)
+	if err != nil {
+		return false, err
+	}
+
+	keyExists, err := fileExists(keyPath)
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case certExists && keyExists:
+		return false, nil
+	case certExists || keyExists:
+		return false, errors.Errorf("only one of the certificate files exists: %s, %s", certPath, keyPath)
+	}
+
+	certPEM, keyPEM, err := GenerateSelfSignedCertificate(hosts, DefaultSelfSignedValidity)
+	if err != nil {
+		return false, err
+	}
+
+	for _, dir := range []string{filepath.Dir(certPath), filepath.Dir(keyPath)} {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return false, errors.Wrapf(err, "creating directory failed")
+		}
+	}
+
+	if err := ioutil.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		return false, errors.Wrapf(err, "writing key file failed")
+	}
+
+	if err := ioutil.WriteFile(certPath, certPEM, 0644); err != nil {
+		return false, errors.Wrapf(err, "writing certificate file failed")
+	}
+
+	return true, nil
+}
+
+// SelfSignedHosts returns the hosts a self-signed certificate for a server with the given bind address should be valid for.
+// These are the host of the bind address if it is not unspecified, the local hostname and the loopback addresses.
+func SelfSignedHosts(bindAddress string) []string {
+	var hosts []string
+
+	if host, _, err := net.SplitHostPort(bindAddress); err == nil && host != "" {
+		if ip := net.ParseIP(host); ip == nil || !ip.IsUnspecified() {
+			hosts = append(hosts, host)
+		}
+	}
+
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		hosts = append(hosts, hostname)
+	}
+
+	hosts = append(hosts, "localhost", "127.0.0.1", "::1")
+
+	// remove duplicates
+	seen := make(map[string]struct{})
+	result := hosts[:0]
+	for _, host := range hosts {
+		if _, exists := seen[host]; exists {
+			continue
+		}
+		seen[host] = struct{}{}
+		result = append(result, host)
+	}
+
+	return result
+}
+
+// LoadServerCertificate loads the certificate of a server with the given bind address from the given files.
+// If selfSigned is set and the files do not exist, a self-signed certificate is generated first.
+// It returns whether a self-signed certificate was generated.
+func LoadServerCertificate(certPath string, keyPath string, selfSigned bool, bindAddress string) (*CertificateReloader, bool, error) {
+
+	generated := false
+	if selfSigned {
+		var err error
+		generated, err = WriteSelfSignedCertificateIfMissing(certPath, keyPath, SelfSignedHosts(bindAddress))
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "generating self-signed certificate failed")
+		}
+	}
+
+	reloader, err := NewCertificateReloader(certPath, keyPath)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return reloader, generated, nil
+}
@@ -0,0 +1,145 @@
+package tlsutil_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gohornet/hornet/pkg/tlsutil"
+)
+
+func TestSelfSignedCertificate(t *testing.T) {
+
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "tls", "cert.pem")
+	keyPath := filepath.Join(dir, "tls", "key.pem")
+
+	hosts := tlsutil.SelfSignedHosts("0.0.0.0:14265")
+	require.Contains(t, hosts, "localhost")
+	require.Contains(t, hosts, "127.0.0.1")
+	require.NotContains(t, hosts, "0.0.0.0")
+
+	generated, err := tlsutil.WriteSelfSignedCertificateIfMissing(certPath, keyPath, hosts)
+	require.NoError(t, err)
+	require.True(t, generated)
+
+	// existing files are not overwritten
+	generated, err = tlsutil.WriteSelfSignedCertificateIfMissing(certPath, keyPath, hosts)
+	require.NoError(t, err)
+	require.False(t, generated)
+
+	certPEM, err := ioutil.ReadFile(certPath)
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(certPEM)
+	require.NotNil(t, block)
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	require.NoError(t, cert.VerifyHostname("localhost"))
+	require.NoError(t, cert.VerifyHostname("127.0.0.1"))
+
+	// a missing key file is an error
+	require.NoError(t, os.Remove(keyPath))
+	_, err = tlsutil.WriteSelfSignedCertificateIfMissing(certPath, keyPath, hosts)
+	require.Error(t, err)
+}
+
+func TestCertificateReloader(t *testing.T) {
+
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+
+	writeCertificate := func(host string, modTime time.Time) {
+		certPEM, keyPEM, err := tlsutil.GenerateSelfSignedCertificate([]string{host}, time.Hour)
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(certPath, certPEM, 0600))
+		require.NoError(t, ioutil.WriteFile(keyPath, keyPEM, 0600))
+
+		// the modification time is set explicitly, because the resolution of the file system might be too coarse
+		require.NoError(t, os.Chtimes(certPath, modTime, modTime))
+		require.NoError(t, os.Chtimes(keyPath, modTime, modTime))
+	}
+
+	commonName := func(cert *tls.Certificate) string {
+		parsed, err := x509.ParseCertificate(cert.Certificate[0])
+		require.NoError(t, err)
+		return parsed.Subject.CommonName
+	}
+
+	now := time.Now()
+	writeCertificate("first.example", now.Add(-time.Minute))
+
+	reloader, err := tlsutil.NewCertificateReloader(certPath, keyPath)
+	require.NoError(t, err)
+
+	cert, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	require.Equal(t, "first.example", commonName(cert))
+
+	// unchanged files are not reloaded
+	reloaded, err := reloader.ReloadIfChanged()
+	require.NoError(t, err)
+	require.False(t, reloaded)
+
+	writeCertificate("second.example", now)
+
+	reloaded, err = reloader.ReloadIfChanged()
+	require.NoError(t, err)
+	require.True(t, reloaded)
+
+	cert, err = reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	require.Equal(t, "second.example", commonName(cert))
+
+	// invalid files keep the previous certificate
+	require.NoError(t, ioutil.WriteFile(keyPath, []byte("invalid"), 0600))
+	require.NoError(t, os.Chtimes(keyPath, now.Add(time.Minute), now.Add(time.Minute)))
+
+	reloaded, err = reloader.ReloadIfChanged()
+	require.Error(t, err)
+	require.False(t, reloaded)
+
+	cert, err = reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	require.Equal(t, "second.example", commonName(cert))
+}
+
+func TestNewReloadingConfig(t *testing.T) {
+
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+
+	// the certificate files do not exist
+	_, _, _, err := tlsutil.NewReloadingConfig(certPath, keyPath, "", false, "localhost:8080")
+	require.Error(t, err)
+
+	config, reload, generated, err := tlsutil.NewReloadingConfig(certPath, keyPath, "", true, "localhost:8080")
+	require.NoError(t, err)
+	require.True(t, generated)
+	require.NotNil(t, reload)
+	require.Equal(t, tls.NoClientCert, config.ClientAuth)
+
+	cert, err := config.GetCertificate(nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, cert.Certificate)
+
+	// the existing certificate is used as CA certificate for the client authentication
+	config, _, generated, err = tlsutil.NewReloadingConfig(certPath, keyPath, certPath, true, "localhost:8080")
+	require.NoError(t, err)
+	require.False(t, generated)
+	require.Equal(t, tls.VerifyClientCertIfGiven, config.ClientAuth)
+	require.NotNil(t, config.ClientCAs)
+
+	_, _, _, err = tlsutil.NewReloadingConfig(certPath, keyPath, filepath.Join(dir, "missing.pem"), true, "localhost:8080")
+	require.Error(t, err)
+}
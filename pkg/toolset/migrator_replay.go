@@ -0,0 +1,91 @@
+package toolset
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/gohornet/hornet/pkg/model/migrator"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/iotaledger/hive.go/configuration"
+)
+
+func migratorReplay(_ *configuration.Configuration, args []string) error {
+	printUsage := func() {
+		println("Usage:")
+		println(fmt.Sprintf("   %s [RECORDING_PATH] [RECEIPTS_BACKUP_PATH]", ToolMigratorReplay))
+		println()
+		println("   [RECORDING_PATH]       - the path to the JSON file containing the recorded migrations")
+		println("   [RECEIPTS_BACKUP_PATH] - the path to the receipt backups that should be validated (optional)")
+		println("                            if not given, the receipts are generated from the recorded migrations")
+		println()
+		println(fmt.Sprintf("example: %s %s %s", ToolMigratorReplay, "migrations.json", "receipts"))
+	}
+
+	if len(args) < 1 || len(args) > 2 {
+		printUsage()
+		return fmt.Errorf("wrong argument count for '%s'", ToolMigratorReplay)
+	}
+
+	recordingPath := args[0]
+	if _, err := os.Stat(recordingPath); err != nil || os.IsNotExist(err) {
+		return fmt.Errorf("RECORDING_PATH (%s) does not exist", recordingPath)
+	}
+
+	queryer, err := migrator.NewFileQueryerFromFile(recordingPath)
+	if err != nil {
+		return fmt.Errorf("unable to load the recorded migrations: %w", err)
+	}
+
+	var receiptTuples []*utxo.ReceiptTuple
+	if len(args) == 2 {
+		backupPath := args[1]
+		if _, err := os.Stat(backupPath); err != nil || os.IsNotExist(err) {
+			return fmt.Errorf("RECEIPTS_BACKUP_PATH (%s) does not exist", backupPath)
+		}
+
+		if receiptTuples, err = migrator.LoadReceiptBackups(backupPath); err != nil {
+			return fmt.Errorf("unable to load the receipt backups: %w", err)
+		}
+	} else {
+		receipts, err := migrator.GenerateReceipts(context.Background(), queryer, migrator.SensibleMaxEntriesCount)
+		if err != nil {
+			return fmt.Errorf("unable to generate receipts: %w", err)
+		}
+
+		// every receipt is issued in its own milestone
+		for i, receipt := range receipts {
+			receiptTuples = append(receiptTuples, &utxo.ReceiptTuple{Receipt: receipt, MilestoneIndex: milestone.Index(i + 1)})
+		}
+	}
+
+	replayer := migrator.NewReceiptReplayer(queryer)
+
+	invalidCount := 0
+	for _, rt := range receiptTuples {
+		result := "valid"
+		if err := replayer.Validate(rt.Receipt, rt.MilestoneIndex); err != nil {
+			result = fmt.Sprintf("invalid: %s", err)
+			invalidCount++
+		}
+		fmt.Printf("receipt (milestone %d, migrated_at %d, final %v, entries %d): %s\n", rt.MilestoneIndex, rt.Receipt.MigratedAt, rt.Receipt.Final, len(rt.Receipt.Funds), result)
+	}
+
+	fmt.Printf(`>
+	- Latest recorded milestone %d
+	- Receipts %d
+	- Invalid receipts %d`+"\n\n",
+		queryer.LatestMilestoneIndex(),
+		len(receiptTuples),
+		invalidCount,
+	)
+
+	if invalidCount > 0 {
+		return fmt.Errorf("%d of %d receipts failed the validation", invalidCount, len(receiptTuples))
+	}
+
+	fmt.Println("all receipts passed the validation")
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package toolset
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	coreDatabase "github.com/gohornet/hornet/core/database"
+	p2pCore "github.com/gohornet/hornet/core/p2p"
+	"github.com/gohornet/hornet/pkg/database"
+	"github.com/gohornet/hornet/pkg/model/participation"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/model/syncmanager"
+	"github.com/gohornet/hornet/pkg/p2p"
+	"github.com/iotaledger/hive.go/configuration"
+)
+
+const (
+	participationExportVerifyCommand = "verify"
+)
+
+func participationExport(nodeConfig *configuration.Configuration, args []string) error {
+	printUsage := func() {
+		println("Usage:")
+		println(fmt.Sprintf("   %s [DATABASE_PATH] [EVENT_ID] [OUTPUT_FILE_PATH]", ToolParticipationExport))
+		println(fmt.Sprintf("   %s %s [RESULTS_FILE_PATH]", ToolParticipationExport, participationExportVerifyCommand))
+		println()
+		println("   [DATABASE_PATH]     - the path to the database")
+		println("   [EVENT_ID]          - the hex encoded ID of the participation event")
+		println("   [OUTPUT_FILE_PATH]  - the path to the file the results are written to (optional, defaults to stdout)")
+		println("   [RESULTS_FILE_PATH] - the path to the exported results that should be verified")
+		println()
+		println("   the results are signed with the identity key of the node in the p2p database")
+		println()
+		println(fmt.Sprintf("example: %s %s %s %s", ToolParticipationExport, "mainnetdb", "a4b7...", "results.json"))
+		println(fmt.Sprintf("example: %s %s %s", ToolParticipationExport, participationExportVerifyCommand, "results.json"))
+	}
+
+	if len(args) == 2 && args[0] == participationExportVerifyCommand {
+		return participationExportVerify(args[1])
+	}
+
+	if len(args) < 2 || len(args) > 3 {
+		printUsage()
+		return fmt.Errorf("wrong argument count for '%s'", ToolParticipationExport)
+	}
+
+	databasePath := args[0]
+	if _, err := os.Stat(databasePath); err != nil || os.IsNotExist(err) {
+		return fmt.Errorf("DATABASE_PATH (%s) does not exist", databasePath)
+	}
+
+	eventID, err := participation.EventIDFromHex(args[1])
+	if err != nil {
+		return fmt.Errorf("EVENT_ID (%s) is invalid: %w", args[1], err)
+	}
+
+	outputFilePath := ""
+	if len(args) == 3 {
+		outputFilePath = args[2]
+	}
+
+	privKeyFilePath := filepath.Join(nodeConfig.String(p2pCore.CfgP2PDatabasePath), p2p.PrivKeyFileName)
+	privKey, err := p2p.ReadEd25519PrivateKeyFromPEMFile(privKeyFilePath)
+	if err != nil {
+		return fmt.Errorf("reading private key file for peer identity failed: %w", err)
+	}
+
+	nodePrivateKey, err := privKey.Raw()
+	if err != nil {
+		return fmt.Errorf("unable to get raw private key bytes: %w", err)
+	}
+
+	tangleStore, err := database.StoreWithDefaultSettings(filepath.Join(databasePath, coreDatabase.TangleDatabaseDirectoryName), false)
+	if err != nil {
+		return fmt.Errorf("%s database initialization failed: %w", coreDatabase.TangleDatabaseDirectoryName, err)
+	}
+
+	// clean up store
+	defer func() {
+		tangleStore.Shutdown()
+		_ = tangleStore.Close()
+	}()
+
+	utxoStore, err := database.StoreWithDefaultSettings(filepath.Join(databasePath, coreDatabase.UTXODatabaseDirectoryName), false)
+	if err != nil {
+		return fmt.Errorf("%s database initialization failed: %w", coreDatabase.UTXODatabaseDirectoryName, err)
+	}
+
+	// clean up store
+	defer func() {
+		utxoStore.Shutdown()
+		_ = utxoStore.Close()
+	}()
+
+	participationStore, err := database.StoreWithDefaultSettings(filepath.Join(databasePath, participation.DatabaseDirectoryName), false)
+	if err != nil {
+		return fmt.Errorf("%s database initialization failed: %w", participation.DatabaseDirectoryName, err)
+	}
+
+	dbStorage, err := storage.New(tangleStore, utxoStore)
+	if err != nil {
+		return err
+	}
+
+	syncManager, err := syncmanager.New(dbStorage.UTXOManager(), 0)
+	if err != nil {
+		return err
+	}
+
+	participationManager, err := participation.NewManager(dbStorage, syncManager, participationStore)
+	if err != nil {
+		return err
+	}
+
+	// the participation store is closed by the participation manager
+	defer func() { _ = participationManager.CloseDatabase() }()
+
+	export, err := participationManager.ExportResults(eventID, nodePrivateKey)
+	if err != nil {
+		if errors.Is(err, participation.ErrEventNotEnded) {
+			return fmt.Errorf("the event ends at milestone %d, but the database is only synced to milestone %d", participationManager.Event(eventID).EndMilestoneIndex(), syncManager.ConfirmedMilestoneIndex())
+		}
+		return err
+	}
+
+	exportJSON, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal results: %w", err)
+	}
+
+	if outputFilePath == "" {
+		fmt.Println(string(exportJSON))
+		return nil
+	}
+
+	if err := ioutil.WriteFile(outputFilePath, exportJSON, 0644); err != nil {
+		return fmt.Errorf("unable to write results to %s: %w", outputFilePath, err)
+	}
+
+	fmt.Printf(`>
+	- Event ID %s
+	- Milestone index %d
+	- Results hash %s
+	- Public key %s`+"\n\n",
+		export.EventID,
+		export.MilestoneIndex,
+		export.Hash,
+		export.PublicKey,
+	)
+
+	fmt.Printf("successfully exported results to %s\n", outputFilePath)
+
+	return nil
+}
+
+// participationExportVerify verifies the hash and the signature of exported results.
+func participationExportVerify(resultsFilePath string) error {
+	exportJSON, err := ioutil.ReadFile(resultsFilePath)
+	if err != nil {
+		return fmt.Errorf("unable to read results from %s: %w", resultsFilePath, err)
+	}
+
+	export := &participation.ResultsExport{}
+	if err := json.Unmarshal(exportJSON, export); err != nil {
+		return fmt.Errorf("unable to unmarshal results: %w", err)
+	}
+
+	if err := export.Verify(); err != nil {
+		return fmt.Errorf("verification of the results failed: %w", err)
+	}
+
+	fmt.Printf(`>
+	- Event ID %s
+	- Milestone index %d
+	- Results hash %s
+	- Public key %s`+"\n\n",
+		export.EventID,
+		export.MilestoneIndex,
+		export.Hash,
+		export.PublicKey,
+	)
+
+	fmt.Printf("successfully verified the results in %s\n", resultsFilePath)
+
+	return nil
+}
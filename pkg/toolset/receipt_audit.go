@@ -0,0 +1,93 @@
+package toolset
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	coreDatabase "github.com/gohornet/hornet/core/database"
+	"github.com/gohornet/hornet/pkg/database"
+	"github.com/gohornet/hornet/pkg/model/migrator"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/gohornet/hornet/plugins/receipt"
+	"github.com/iotaledger/hive.go/configuration"
+)
+
+func receiptAudit(nodeConfig *configuration.Configuration, args []string) error {
+	printUsage := func() {
+		println("Usage:")
+		println(fmt.Sprintf("   %s [DATABASE_PATH] [RECEIPTS_BACKUP_PATH]", ToolReceiptAudit))
+		println()
+		println("   [DATABASE_PATH]        - the path to the database")
+		println(fmt.Sprintf("   [RECEIPTS_BACKUP_PATH] - the path to the receipt backups (optional, defaults to '%s')", receipt.CfgReceiptsBackupPath))
+		println()
+		println(fmt.Sprintf("example: %s %s %s", ToolReceiptAudit, "mainnetdb", "receipts"))
+	}
+
+	if len(args) < 1 || len(args) > 2 {
+		printUsage()
+		return fmt.Errorf("wrong argument count for '%s'", ToolReceiptAudit)
+	}
+
+	databasePath := args[0]
+	if _, err := os.Stat(databasePath); err != nil || os.IsNotExist(err) {
+		return fmt.Errorf("DATABASE_PATH (%s) does not exist", databasePath)
+	}
+
+	backupPath := nodeConfig.String(receipt.CfgReceiptsBackupPath)
+	if len(args) == 2 {
+		backupPath = args[1]
+	}
+
+	var backups []*utxo.ReceiptTuple
+	if _, err := os.Stat(backupPath); err != nil || os.IsNotExist(err) {
+		fmt.Printf("receipt backups (%s) do not exist, only the database is audited\n", backupPath)
+	} else {
+		if backups, err = migrator.LoadReceiptBackups(backupPath); err != nil {
+			return fmt.Errorf("unable to load the receipt backups: %w", err)
+		}
+	}
+
+	utxoStore, err := database.StoreWithDefaultSettings(filepath.Join(databasePath, coreDatabase.UTXODatabaseDirectoryName), false)
+	if err != nil {
+		return fmt.Errorf("%s database initialization failed: %w", coreDatabase.UTXODatabaseDirectoryName, err)
+	}
+
+	// clean up store
+	defer func() {
+		utxoStore.Shutdown()
+		_ = utxoStore.Close()
+	}()
+
+	report, err := migrator.AuditReceipts(utxo.New(utxoStore), backups)
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range report.Issues {
+		fmt.Println(issue)
+	}
+
+	fmt.Printf(`>
+	- Receipts %d
+	- Receipts with pruned milestone diff %d
+	- Receipt backups %d
+	- Migrated entries %d
+	- Migrated amount %d
+	- Issues %d`+"\n\n",
+		report.ReceiptsCount,
+		report.PrunedCount,
+		report.BackupsCount,
+		report.MigratedEntriesCount,
+		report.MigratedAmount,
+		len(report.Issues),
+	)
+
+	if len(report.Issues) > 0 {
+		return fmt.Errorf("the receipt audit found %d issues", len(report.Issues))
+	}
+
+	fmt.Println("the receipt audit found no issues")
+
+	return nil
+}
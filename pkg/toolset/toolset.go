@@ -26,6 +26,9 @@ const (
 	ToolDatabaseHealth          = "db-health"
 	ToolDatabaseSplit           = "db-split"
 	ToolCoordinatorFixStateFile = "coo-fix-state"
+	ToolParticipationExport     = "participation-export"
+	ToolMigratorReplay          = "migrator-replay"
+	ToolReceiptAudit            = "receipt-audit"
 )
 
 // ShouldHandleTools checks if tools were requested.
@@ -68,6 +71,9 @@ func HandleTools(nodeConfig *configuration.Configuration) {
 		ToolDatabaseHealth:          databaseHealth,
 		ToolDatabaseSplit:           databaseSplit,
 		ToolCoordinatorFixStateFile: coordinatorFixStateFile,
+		ToolParticipationExport:     participationExport,
+		ToolMigratorReplay:          migratorReplay,
+		ToolReceiptAudit:            receiptAudit,
 	}
 
 	tool, exists := tools[strings.ToLower(args[1])]
@@ -103,4 +109,7 @@ func listTools() {
 	fmt.Printf("%-20s checks the health status of the database\n", fmt.Sprintf("%s:", ToolDatabaseHealth))
 	fmt.Printf("%-20s split a legacy database into `tangle` and `utxo`\n", fmt.Sprintf("%s:", ToolDatabaseSplit))
 	fmt.Printf("%-20s applies the latest milestone in the database to the coordinator state file\n", fmt.Sprintf("%s:", ToolCoordinatorFixStateFile))
+	fmt.Printf("%-20s exports the signed final results of a participation event or verifies them\n", fmt.Sprintf("%s:", ToolParticipationExport))
+	fmt.Printf("%-20s validates receipts against recorded migrations without a legacy node\n", fmt.Sprintf("%s:", ToolMigratorReplay))
+	fmt.Printf("%-20s reconciles the stored receipts with the migrated outputs, treasury outputs and receipt backups\n", fmt.Sprintf("%s:", ToolReceiptAudit))
 }
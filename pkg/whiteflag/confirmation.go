@@ -44,6 +44,45 @@ type ConfirmationMetrics struct {
 	DurationTotal                                    time.Duration
 }
 
+// referencedMessages returns the messages referenced by a milestone in the white-flag order,
+// followed by the milestone message itself.
+func referencedMessages(mutations *WhiteFlagMutations, milestoneMessageID hornet.MessageID) []*storage.ReferencedMessage {
+
+	conflicts := make(map[string]storage.Conflict, len(mutations.MessagesExcludedWithConflictingTransactions))
+	for _, conflictedMessage := range mutations.MessagesExcludedWithConflictingTransactions {
+		conflicts[conflictedMessage.MessageID.ToMapKey()] = conflictedMessage.Conflict
+	}
+
+	noTransactions := make(map[string]struct{}, len(mutations.MessagesExcludedWithoutTransactions))
+	for _, messageID := range mutations.MessagesExcludedWithoutTransactions {
+		noTransactions[messageID.ToMapKey()] = struct{}{}
+	}
+
+	result := make([]*storage.ReferencedMessage, 0, len(mutations.MessagesReferenced)+1)
+	for _, messageID := range mutations.MessagesReferenced {
+		referencedMessage := &storage.ReferencedMessage{
+			MessageID:      messageID,
+			InclusionState: storage.LedgerInclusionStateIncluded,
+			Conflict:       storage.ConflictNone,
+		}
+
+		if conflict, isConflicting := conflicts[messageID.ToMapKey()]; isConflicting {
+			referencedMessage.InclusionState = storage.LedgerInclusionStateConflicting
+			referencedMessage.Conflict = conflict
+		} else if _, isNoTransaction := noTransactions[messageID.ToMapKey()]; isNoTransaction {
+			referencedMessage.InclusionState = storage.LedgerInclusionStateNoTransaction
+		}
+
+		result = append(result, referencedMessage)
+	}
+
+	return append(result, &storage.ReferencedMessage{
+		MessageID:      milestoneMessageID,
+		InclusionState: storage.LedgerInclusionStateNoTransaction,
+		Conflict:       storage.ConflictNone,
+	})
+}
+
 // ConfirmMilestone traverses a milestone and collects all unreferenced msg,
 // then the ledger diffs are calculated, the ledger state is checked and all msg are marked as referenced.
 // Additionally, this function also examines the milestone for a receipt and generates new migrated outputs
@@ -255,6 +294,10 @@ func ConfirmMilestone(
 	}
 	timeApplyExcludedWithConflictingTransactions := time.Now()
 
+	if err := dbStorage.StoreReferencedMessages(milestoneIndex, referencedMessages(mutations, milestoneMessageID)); err != nil {
+		return nil, nil, fmt.Errorf("confirmMilestone: storing referenced messages failed: %w", err)
+	}
+
 	for _, output := range newOutputs {
 		forEachNewOutput(milestoneIndex, output)
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/testsuite"
 	"github.com/gohornet/hornet/pkg/testsuite/utils"
+	"github.com/gohornet/hornet/pkg/whiteflag"
 	iotago "github.com/iotaledger/iota.go/v2"
 )
 
@@ -146,7 +147,7 @@ func TestWhiteFlagWithMultipleConflicting(t *testing.T) {
 		Store()
 
 	// Confirming milestone at message C (message D and E are not included)
-	_, confStats := te.IssueAndConfirmMilestoneOnTips(hornet.MessageIDs{messageC.StoredMessageID()}, true)
+	conf, confStats := te.IssueAndConfirmMilestoneOnTips(hornet.MessageIDs{messageC.StoredMessageID()}, true)
 	require.Equal(t, 3+1, confStats.MessagesReferenced) // 3 + milestone itself
 	require.Equal(t, 2, confStats.MessagesIncludedWithTransactions)
 	require.Equal(t, 1, confStats.MessagesExcludedWithConflictingTransactions)
@@ -155,6 +156,22 @@ func TestWhiteFlagWithMultipleConflicting(t *testing.T) {
 	// Verify the messages have the expected conflict reason
 	te.AssertMessageConflictReason(messageC.StoredMessageID(), storage.ConflictInputUTXONotFound)
 
+	// Verify the referenced messages are stored in the white-flag order, followed by the milestone itself
+	referencedMessages, err := te.Storage().ReferencedMessages(conf.MilestoneIndex, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []*storage.ReferencedMessage{
+		{MessageID: messageA.StoredMessageID(), InclusionState: storage.LedgerInclusionStateIncluded, Conflict: storage.ConflictNone},
+		{MessageID: messageB.StoredMessageID(), InclusionState: storage.LedgerInclusionStateIncluded, Conflict: storage.ConflictNone},
+		{MessageID: messageC.StoredMessageID(), InclusionState: storage.LedgerInclusionStateConflicting, Conflict: storage.ConflictInputUTXONotFound},
+		{MessageID: conf.MilestoneMessageID, InclusionState: storage.LedgerInclusionStateNoTransaction, Conflict: storage.ConflictNone},
+	}, referencedMessages)
+
+	referencedMessages, err = te.Storage().ReferencedMessages(conf.MilestoneIndex, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, referencedMessages, 2)
+	require.Equal(t, messageB.StoredMessageID(), referencedMessages[0].MessageID)
+	require.Equal(t, messageC.StoredMessageID(), referencedMessages[1].MessageID)
+
 	// Verify balances
 	te.AssertWalletBalance(seed1Wallet, 2_779_530_280_277_761)
 	te.AssertWalletBalance(seed2Wallet, 3_000_000)
@@ -648,3 +665,57 @@ func TestWhiteFlagWithOnlyZeroTx(t *testing.T) {
 	require.Equal(t, 0, confStats.MessagesExcludedWithConflictingTransactions)
 	require.Equal(t, 3+1, confStats.MessagesExcludedWithoutTransactions) // 1 is for the milestone itself
 }
+
+func TestWhiteFlagValidateTransaction(t *testing.T) {
+
+	seed1Wallet := utils.NewHDWallet("Seed1", seed1, 0)
+	seed2Wallet := utils.NewHDWallet("Seed2", seed2, 0)
+	seed3Wallet := utils.NewHDWallet("Seed3", seed3, 0)
+
+	genesisAddress := seed1Wallet.Address()
+
+	te := testsuite.SetupTestEnvironment(t, genesisAddress, 2, BelowMaxDepth, MinPoWScore, showConfirmationGraphs)
+	defer te.CleanupTestEnvironment(!showConfirmationGraphs)
+
+	//Add token supply to our local HDWallet
+	seed1Wallet.BookOutput(te.GenesisOutput)
+
+	// Valid transfer from seed1 (iotago.TokenSupply) with remainder seed1 (2_779_530_282_277_761) to seed2 (1_000_000)
+	messageA := te.NewMessageBuilder("A").
+		Parents(hornet.MessageIDs{te.Milestones[0].Milestone().MessageID, te.Milestones[1].Milestone().MessageID}).
+		FromWallet(seed1Wallet).
+		ToWallet(seed2Wallet).
+		Amount(1_000_000).
+		Build().
+		Store().
+		BookOnWallets()
+
+	transactionA := messageA.IotaMessage().Payload.(*iotago.Transaction)
+
+	// the transaction is valid against the current ledger state
+	conflict, err := whiteflag.ValidateTransaction(te.UTXOManager(), transactionA)
+	require.NoError(t, err)
+	require.Equal(t, storage.ConflictNone, conflict)
+
+	// Invalid transfer from seed3 (0) to seed2 (100_000) (invalid input)
+	messageB := te.NewMessageBuilder("B").
+		Parents(hornet.MessageIDs{messageA.StoredMessageID()}).
+		FromWallet(seed3Wallet).
+		ToWallet(seed2Wallet).
+		Amount(100_000).
+		FakeInputs().
+		Build()
+
+	conflict, err = whiteflag.ValidateTransaction(te.UTXOManager(), messageB.IotaMessage().Payload.(*iotago.Transaction))
+	require.NoError(t, err)
+	require.Equal(t, storage.Conflict(storage.ConflictInputUTXONotFound), conflict)
+
+	// Confirming milestone at message A
+	_, confStats := te.IssueAndConfirmMilestoneOnTips(hornet.MessageIDs{messageA.StoredMessageID()}, true)
+	require.Equal(t, 1, confStats.MessagesIncludedWithTransactions)
+
+	// the inputs of the transaction were spent by the milestone
+	conflict, err = whiteflag.ValidateTransaction(te.UTXOManager(), transactionA)
+	require.NoError(t, err)
+	require.Equal(t, storage.Conflict(storage.ConflictInputUTXOAlreadySpent), conflict)
+}
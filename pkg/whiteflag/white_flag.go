@@ -108,8 +108,6 @@ func ComputeWhiteFlagMutations(ctx context.Context, dbStorage *storage.Storage,
 			return nil
 		}
 
-		var conflict = storage.ConflictNone
-
 		transaction := message.Transaction()
 		transactionID, err := transaction.ID()
 		if err != nil {
@@ -122,79 +120,9 @@ func ComputeWhiteFlagMutations(ctx context.Context, dbStorage *storage.Storage,
 			return err
 		}
 
-		inputs := message.TransactionEssenceUTXOInputs()
-
-		// go through all the inputs and validate that they are still unspent, in the ledger or were created during confirmation
-		inputOutputs := utxo.Outputs{}
-		for _, input := range inputs {
-
-			// check if this input was already spent during the confirmation
-			_, hasSpent := wfConf.NewSpents[string(input[:])]
-			if hasSpent {
-				// UTXO already spent, so mark as conflict
-				conflict = storage.ConflictInputUTXOAlreadySpentInThisMilestone
-				break
-			}
-
-			// check if this input was newly created during the confirmation
-			output, hasOutput := wfConf.NewOutputs[string(input[:])]
-			if hasOutput {
-				// UTXO is in the current ledger mutation, so use it
-				inputOutputs = append(inputOutputs, output)
-				continue
-			}
-
-			// check current ledger for this input
-			output, err = dbStorage.UTXOManager().ReadOutputByOutputIDWithoutLocking(input)
-			if err != nil {
-				if errors.Is(err, kvstore.ErrKeyNotFound) {
-					// input not found, so mark as invalid tx
-					conflict = storage.ConflictInputUTXONotFound
-					break
-				}
-				return err
-			}
-
-			// check if this output is unspent
-			unspent, err := dbStorage.UTXOManager().IsOutputUnspentWithoutLocking(output)
-			if err != nil {
-				return err
-			}
-
-			if !unspent {
-				// output is already spent, so mark as conflict
-				conflict = storage.ConflictInputUTXOAlreadySpent
-				break
-			}
-
-			inputOutputs = append(inputOutputs, output)
-		}
-
-		if conflict == storage.ConflictNone {
-			// Dust validation
-			dustValidation := iotago.NewDustSemanticValidation(iotago.DustAllowanceDivisor, iotago.MaxDustOutputsOnAddress, func(addr iotago.Address) (dustAllowanceSum uint64, amountDustOutputs int64, err error) {
-				return dbStorage.UTXOManager().ReadDustForAddress(addr, wfConf.dustAllowanceDiff)
-			})
-
-			// Verify that all outputs consume all inputs and have valid signatures. Also verify that the amounts match.
-			mapping, err := inputOutputs.InputToOutputMapping()
-			if err != nil {
-				return err
-			}
-			if err := transaction.SemanticallyValidate(mapping, dustValidation); err != nil {
-
-				if errors.Is(err, iotago.ErrMissingUTXO) {
-					conflict = storage.ConflictInputUTXONotFound
-				} else if errors.Is(err, iotago.ErrInputOutputSumMismatch) {
-					conflict = storage.ConflictInputOutputSumMismatch
-				} else if errors.Is(err, iotago.ErrEd25519SignatureInvalid) || errors.Is(err, iotago.ErrEd25519PubKeyAndAddrMismatch) {
-					conflict = storage.ConflictInvalidSignature
-				} else if errors.Is(err, iotago.ErrInvalidDustAllowance) {
-					conflict = storage.ConflictInvalidDustAllowance
-				} else {
-					conflict = storage.ConflictSemanticValidationFailed
-				}
-			}
+		inputOutputs, conflict, err := checkTransaction(dbStorage.UTXOManager(), transaction, message.TransactionEssenceUTXOInputs(), wfConf.NewSpents, wfConf.NewOutputs, wfConf.dustAllowanceDiff)
+		if err != nil {
+			return err
 		}
 
 		// go through all deposits and generate unspent outputs
@@ -285,3 +213,121 @@ func ComputeWhiteFlagMutations(ctx context.Context, dbStorage *storage.Storage,
 
 	return wfConf, nil
 }
+
+// checkTransaction validates the given transaction against the ledger state and the given ledger mutations in accordance to the white-flag rules.
+// It returns the outputs referenced by the inputs of the transaction and the conflict which prevents the transaction from being applied.
+// The ledger state must be locked while this function is getting called in order to ensure consistency.
+func checkTransaction(utxoManager *utxo.Manager, transaction *iotago.Transaction, inputs []*iotago.UTXOInputID, newSpents map[string]*utxo.Spent, newOutputs map[string]*utxo.Output, dustAllowanceDiff *utxo.BalanceDiff) (utxo.Outputs, storage.Conflict, error) {
+
+	var conflict = storage.ConflictNone
+
+	// go through all the inputs and validate that they are still unspent, in the ledger or were created during confirmation
+	inputOutputs := utxo.Outputs{}
+	for _, input := range inputs {
+
+		// check if this input was already spent during the confirmation
+		_, hasSpent := newSpents[string(input[:])]
+		if hasSpent {
+			// UTXO already spent, so mark as conflict
+			conflict = storage.ConflictInputUTXOAlreadySpentInThisMilestone
+			break
+		}
+
+		// check if this input was newly created during the confirmation
+		output, hasOutput := newOutputs[string(input[:])]
+		if hasOutput {
+			// UTXO is in the current ledger mutation, so use it
+			inputOutputs = append(inputOutputs, output)
+			continue
+		}
+
+		// check current ledger for this input
+		output, err := utxoManager.ReadOutputByOutputIDWithoutLocking(input)
+		if err != nil {
+			if errors.Is(err, kvstore.ErrKeyNotFound) {
+				// input not found, so mark as invalid tx
+				conflict = storage.ConflictInputUTXONotFound
+				break
+			}
+			return nil, storage.ConflictNone, err
+		}
+
+		// check if this output is unspent
+		unspent, err := utxoManager.IsOutputUnspentWithoutLocking(output)
+		if err != nil {
+			return nil, storage.ConflictNone, err
+		}
+
+		if !unspent {
+			// output is already spent, so mark as conflict
+			conflict = storage.ConflictInputUTXOAlreadySpent
+			break
+		}
+
+		inputOutputs = append(inputOutputs, output)
+	}
+
+	if conflict == storage.ConflictNone {
+		// Dust validation
+		dustValidation := iotago.NewDustSemanticValidation(iotago.DustAllowanceDivisor, iotago.MaxDustOutputsOnAddress, func(addr iotago.Address) (dustAllowanceSum uint64, amountDustOutputs int64, err error) {
+			return utxoManager.ReadDustForAddress(addr, dustAllowanceDiff)
+		})
+
+		// Verify that all outputs consume all inputs and have valid signatures. Also verify that the amounts match.
+		mapping, err := inputOutputs.InputToOutputMapping()
+		if err != nil {
+			return nil, storage.ConflictNone, err
+		}
+		if err := transaction.SemanticallyValidate(mapping, dustValidation); err != nil {
+
+			if errors.Is(err, iotago.ErrMissingUTXO) {
+				conflict = storage.ConflictInputUTXONotFound
+			} else if errors.Is(err, iotago.ErrInputOutputSumMismatch) {
+				conflict = storage.ConflictInputOutputSumMismatch
+			} else if errors.Is(err, iotago.ErrEd25519SignatureInvalid) || errors.Is(err, iotago.ErrEd25519PubKeyAndAddrMismatch) {
+				conflict = storage.ConflictInvalidSignature
+			} else if errors.Is(err, iotago.ErrInvalidDustAllowance) {
+				conflict = storage.ConflictInvalidDustAllowance
+			} else {
+				conflict = storage.ConflictSemanticValidationFailed
+			}
+		}
+	}
+
+	return inputOutputs, conflict, nil
+}
+
+// ValidateTransaction validates the given transaction against the current ledger state in accordance to the white-flag rules,
+// without applying any ledger mutations. It returns the conflict the transaction would cause if it was referenced by a milestone.
+// Transactions which are not syntactically valid return an error.
+func ValidateTransaction(utxoManager *utxo.Manager, transaction *iotago.Transaction) (storage.Conflict, error) {
+
+	if err := transaction.SyntacticallyValidate(); err != nil {
+		return storage.ConflictNone, err
+	}
+
+	essence, ok := transaction.Essence.(*iotago.TransactionEssence)
+	if !ok {
+		return storage.ConflictNone, iotago.ErrUnknownTransactionEssenceType
+	}
+
+	inputs := make([]*iotago.UTXOInputID, 0, len(essence.Inputs))
+	for _, input := range essence.Inputs {
+		utxoInput, ok := input.(*iotago.UTXOInput)
+		if !ok {
+			return storage.ConflictNone, iotago.ErrUnknownInputType
+		}
+		id := utxoInput.ID()
+		inputs = append(inputs, &id)
+	}
+
+	utxoManager.ReadLockLedger()
+	defer utxoManager.ReadUnlockLedger()
+
+	_, conflict, err := checkTransaction(utxoManager, transaction, inputs, make(map[string]*utxo.Spent), make(map[string]*utxo.Output), utxo.NewBalanceDiff())
+	if err != nil {
+		return storage.ConflictNone, err
+	}
+
+	return conflict, nil
+}
@@ -0,0 +1,128 @@
+package autopeering
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/p2p"
+	"github.com/gohornet/hornet/pkg/p2p/autopeering"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/iotaledger/hive.go/timeutil"
+)
+
+const (
+	// the interval in which the connected autopeers are checked against the acceptance filters.
+	checkFiltersInterval = 5 * time.Second
+)
+
+var (
+	// ErrNoHeartbeat is returned if an autopeer didn't send a heartbeat on the gossip protocol of the node's network.
+	ErrNoHeartbeat = errors.New("no heartbeat received on the gossip protocol of the node's network")
+	// ErrNotSynced is returned if the solid milestone of an autopeer lags too far behind the latest milestone.
+	ErrNotSynced = errors.New("autopeer is not synced")
+)
+
+var (
+	filtersHeartbeatTimeout time.Duration
+	filtersMaxMilestoneLag  milestone.Index
+)
+
+func configureFilters() {
+	filtersHeartbeatTimeout = deps.NodeConfig.Duration(CfgNetAutopeeringFiltersHeartbeatTimeout)
+	filtersMaxMilestoneLag = milestone.Index(deps.NodeConfig.Int(CfgNetAutopeeringFiltersMaxMilestoneLag))
+}
+
+func runFilters() {
+	// the filters are based on the gossip protocol of the autopeers
+	if deps.AutopeeringManager.Selection() == nil || deps.GossipService == nil {
+		return
+	}
+
+	if filtersHeartbeatTimeout == 0 && filtersMaxMilestoneLag == 0 {
+		return
+	}
+
+	if err := Plugin.Daemon().BackgroundWorker("Autopeering filters", func(ctx context.Context) {
+		ticker := timeutil.NewTicker(checkFilters, checkFiltersInterval, ctx)
+		ticker.WaitForGracefulShutdown()
+	}, shutdown.PriorityAutopeering); err != nil {
+		Plugin.LogPanicf("failed to start worker: %s", err)
+	}
+}
+
+// checkFilters checks all connected autopeers against the acceptance filters
+// and rejects the autopeers which didn't pass them.
+func checkFilters() {
+
+	var autopeers []*p2p.Peer
+	deps.PeeringManager.ForEach(func(p *p2p.Peer) bool {
+		autopeers = append(autopeers, p)
+		return true
+	}, p2p.PeerRelationAutopeered)
+
+	for _, p := range autopeers {
+		if err := checkAutopeer(p.ID); err != nil {
+			rejectAutopeer(p, err)
+		}
+	}
+}
+
+// checks whether the given autopeer passes the acceptance filters.
+func checkAutopeer(peerID peer.ID) error {
+
+	conns := deps.Host.Network().ConnsToPeer(peerID)
+	if len(conns) == 0 {
+		return nil
+	}
+
+	connectedSince := conns[0].Stat().Opened
+	for _, conn := range conns[1:] {
+		if conn.Stat().Opened.Before(connectedSince) {
+			connectedSince = conn.Stat().Opened
+		}
+	}
+
+	// the gossip protocol ID contains the network ID, therefore
+	// only peers of the same network are able to send heartbeats.
+	proto := deps.GossipService.Protocol(peerID)
+	if filtersHeartbeatTimeout != 0 && time.Since(connectedSince) > filtersHeartbeatTimeout {
+		if proto == nil || proto.HeartbeatReceivedTime.IsZero() {
+			return ErrNoHeartbeat
+		}
+	}
+
+	if filtersMaxMilestoneLag != 0 && deps.SyncManager != nil && proto != nil {
+		heartbeat := proto.LatestHeartbeat
+		if heartbeat == nil {
+			return nil
+		}
+
+		latestMilestoneIndex := deps.SyncManager.LatestMilestoneIndex()
+		if latestMilestoneIndex > heartbeat.SolidMilestoneIndex+filtersMaxMilestoneLag {
+			return fmt.Errorf("%w: solid milestone %d, latest milestone %d", ErrNotSynced, heartbeat.SolidMilestoneIndex, latestMilestoneIndex)
+		}
+	}
+
+	return nil
+}
+
+// removes the given autopeer from the autopeering selection and disconnects it.
+func rejectAutopeer(p *p2p.Peer, reason error) {
+	Plugin.LogInfof("rejecting autopeer %s: %s", p.ID.ShortString(), reason)
+
+	if id := autopeering.ConvertPeerIDToHiveIdentityOrLog(p, Plugin.LogWarnf); id != nil {
+		deps.AutopeeringManager.RejectNeighbor(id.ID(), reason)
+	}
+
+	// error is ignored because only incoming autopeers are allowed
+	_ = deps.PeeringManager.DisallowPeer(p.ID)
+
+	if err := deps.PeeringManager.DisconnectPeer(p.ID, reason); err != nil {
+		Plugin.LogWarnf("couldn't disconnect rejected autopeer %s: %s", p.ID.ShortString(), err)
+	}
+}
@@ -0,0 +1,21 @@
+package autopeering
+
+import (
+	"net/http"
+
+	"github.com/gohornet/hornet/pkg/openapi"
+)
+
+const routeGroupPrefix = "/api/plugins/autopeering"
+
+// openAPIRoutes returns the descriptions of all routes of the plugin for the OpenAPI document.
+func openAPIRoutes() []*openapi.Route {
+	return []*openapi.Route{
+		{
+			Method:   http.MethodGet,
+			Path:     routeGroupPrefix + RouteAutopeeringPeers,
+			Summary:  "Returns the peers discovered by the autopeering.",
+			Response: &discoveredPeersResponse{},
+		},
+	}
+}
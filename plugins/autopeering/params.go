@@ -23,6 +23,16 @@ const (
 	CfgNetAutopeeringOutboundPeers = "p2p.autopeering.outboundPeers"
 	// CfgNetAutopeeringSaltLifetime lifetime of the private and public local salt.
 	CfgNetAutopeeringSaltLifetime = "p2p.autopeering.saltLifetime"
+	// CfgNetAutopeeringFiltersHeartbeatTimeout the time in which an autopeer needs to send a heartbeat on the gossip protocol of the node's network (0 = disabled).
+	CfgNetAutopeeringFiltersHeartbeatTimeout = "p2p.autopeering.filters.heartbeatTimeout"
+	// CfgNetAutopeeringFiltersMaxMilestoneLag the maximum amount of milestones the solid milestone of an autopeer may lag behind the latest milestone (0 = disabled).
+	CfgNetAutopeeringFiltersMaxMilestoneLag = "p2p.autopeering.filters.maxMilestoneLag"
+	// CfgNetAutopeeringFiltersExcludedPeers the peer IDs which are never accepted as autopeers.
+	CfgNetAutopeeringFiltersExcludedPeers = "p2p.autopeering.filters.excludedPeers"
+	// CfgNetAutopeeringFiltersExcludedSubnets the subnets (CIDR notation) of which peers are never accepted as autopeers.
+	CfgNetAutopeeringFiltersExcludedSubnets = "p2p.autopeering.filters.excludedSubnets"
+	// CfgNetAutopeeringFiltersRejectionDuration the duration for which autopeers which didn't pass the filters are not accepted again.
+	CfgNetAutopeeringFiltersRejectionDuration = "p2p.autopeering.filters.rejectionDuration"
 )
 
 var params = &node.PluginParams{
@@ -36,6 +46,11 @@ var params = &node.PluginParams{
 			fs.Int(CfgNetAutopeeringInboundPeers, 2, "the number of inbound autopeers")
 			fs.Int(CfgNetAutopeeringOutboundPeers, 2, "the number of outbound autopeers")
 			fs.Duration(CfgNetAutopeeringSaltLifetime, 2*time.Hour, "lifetime of the private and public local salt")
+			fs.Duration(CfgNetAutopeeringFiltersHeartbeatTimeout, time.Minute, "the time in which an autopeer needs to send a heartbeat on the gossip protocol of the node's network (0 = disabled)")
+			fs.Int(CfgNetAutopeeringFiltersMaxMilestoneLag, 0, "the maximum amount of milestones the solid milestone of an autopeer may lag behind the latest milestone (0 = disabled)")
+			fs.StringSlice(CfgNetAutopeeringFiltersExcludedPeers, []string{}, "the peer IDs which are never accepted as autopeers")
+			fs.StringSlice(CfgNetAutopeeringFiltersExcludedSubnets, []string{}, "the subnets (CIDR notation) of which peers are never accepted as autopeers")
+			fs.Duration(CfgNetAutopeeringFiltersRejectionDuration, 30*time.Minute, "the duration for which autopeers which didn't pass the filters are not accepted again")
 			return fs
 		}(),
 	},
@@ -0,0 +1,69 @@
+package autopeering
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/p2p/autopeering"
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/identity"
+)
+
+const (
+	neighborIncoming = "incoming"
+	neighborOutgoing = "outgoing"
+)
+
+func discoveredPeers(_ echo.Context) (*discoveredPeersResponse, error) {
+
+	if deps.AutopeeringManager.Discovery() == nil {
+		return nil, errors.WithMessage(echo.ErrServiceUnavailable, "autopeering discovery is not running")
+	}
+
+	neighbors := make(map[identity.ID]string)
+	if deps.AutopeeringManager.Selection() != nil {
+		for _, p := range deps.AutopeeringManager.Selection().GetIncomingNeighbors() {
+			neighbors[p.ID()] = neighborIncoming
+		}
+		for _, p := range deps.AutopeeringManager.Selection().GetOutgoingNeighbors() {
+			neighbors[p.ID()] = neighborOutgoing
+		}
+	}
+
+	verifiedPeers := deps.AutopeeringManager.Discovery().GetVerifiedPeers()
+
+	results := make([]*DiscoveredPeerResponse, 0, len(verifiedPeers))
+	for _, p := range verifiedPeers {
+		result, err := wrapDiscoveredPeer(p, neighbors[p.ID()])
+		if err != nil {
+			Plugin.LogWarnf("unable to convert discovered autopeering peer: %s", err)
+			continue
+		}
+		results = append(results, result)
+	}
+
+	return &discoveredPeersResponse{Peers: results}, nil
+}
+
+func wrapDiscoveredPeer(p *peer.Peer, neighbor string) (*DiscoveredPeerResponse, error) {
+	peerID, err := autopeering.HivePeerToPeerID(p)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DiscoveredPeerResponse{
+		ID:        peerID.String(),
+		PublicKey: p.PublicKey().String(),
+		Address:   p.Address().String(),
+		Neighbor:  neighbor,
+		Accepted:  deps.AutopeeringManager.IsValidNeighbor(p),
+	}
+
+	if p.Services().Get(deps.AutopeeringManager.P2PServiceKey()) != nil {
+		if multiAddress, err := autopeering.MultiAddrFromPeeringService(p, deps.AutopeeringManager.P2PServiceKey()); err == nil {
+			result.MultiAddress = multiAddress.String()
+		}
+	}
+
+	return result, nil
+}
@@ -2,10 +2,13 @@ package autopeering
 
 import (
 	"context"
+	"net/http"
 	"strings"
 	"time"
 
+	"github.com/labstack/echo/v4"
 	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/host"
 	libp2p "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/pkg/errors"
 	"go.uber.org/dig"
@@ -18,9 +21,13 @@ import (
 	"github.com/gohornet/hornet/core/snapshot"
 	"github.com/gohornet/hornet/core/tangle"
 	"github.com/gohornet/hornet/pkg/database"
+	"github.com/gohornet/hornet/pkg/model/syncmanager"
 	"github.com/gohornet/hornet/pkg/node"
+	"github.com/gohornet/hornet/pkg/openapi"
 	"github.com/gohornet/hornet/pkg/p2p"
 	"github.com/gohornet/hornet/pkg/p2p/autopeering"
+	gossippkg "github.com/gohornet/hornet/pkg/protocol/gossip"
+	restapipkg "github.com/gohornet/hornet/pkg/restapi"
 	"github.com/gohornet/hornet/pkg/shutdown"
 	"github.com/gohornet/hornet/plugins/coordinator"
 	"github.com/gohornet/hornet/plugins/dashboard"
@@ -43,6 +50,12 @@ import (
 	"github.com/iotaledger/hive.go/events"
 )
 
+const (
+	// RouteAutopeeringPeers is the route for getting the peers discovered by autopeering.
+	// GET returns the discovered peers.
+	RouteAutopeeringPeers = "/peers"
+)
+
 func init() {
 	Plugin = &node.Plugin{
 		Status: node.StatusDisabled,
@@ -85,6 +98,12 @@ type dependencies struct {
 	AutopeeringRunAsEntryNode bool                         `name:"autopeeringRunAsEntryNode"`
 	PeeringManager            *p2p.Manager                 `optional:"true"`
 	AutopeeringManager        *autopeering.AutopeeringManager
+	ReputationManager         *gossippkg.ReputationManager `optional:"true"`
+	GossipService             *gossippkg.Service           `optional:"true"`
+	SyncManager               *syncmanager.SyncManager     `optional:"true"`
+	Host                      host.Host
+	Echo                      *echo.Echo        `optional:"true"`
+	OpenAPIRegistry           *openapi.Registry `optional:"true"`
 }
 
 func preProvide(c *dig.Container, configs map[string]*configuration.Configuration, initConfig *node.InitConfig) {
@@ -169,12 +188,29 @@ func provide(c *dig.Container) {
 	}
 
 	if err := c.Provide(func(deps autopeeringDeps) *autopeering.AutopeeringManager {
+		var excludedPeers []libp2p.ID
+		for _, excludedPeer := range deps.NodeConfig.Strings(CfgNetAutopeeringFiltersExcludedPeers) {
+			peerID, err := libp2p.Decode(excludedPeer)
+			if err != nil {
+				Plugin.LogPanicf("invalid excluded peer '%s': %s", excludedPeer, err)
+			}
+			excludedPeers = append(excludedPeers, peerID)
+		}
+
+		excludedSubnets, err := p2p.ParseCIDRs(deps.NodeConfig.Strings(CfgNetAutopeeringFiltersExcludedSubnets))
+		if err != nil {
+			Plugin.LogPanicf("invalid excluded subnets: %s", err)
+		}
+
 		return autopeering.NewAutopeeringManager(
 			Plugin.Logger(),
 			deps.NodeConfig.String(CfgNetAutopeeringBindAddr),
 			deps.NodeConfig.Strings(CfgNetAutopeeringEntryNodes),
 			deps.NodeConfig.Bool(CfgNetAutopeeringEntryNodesPreferIPv6),
 			service.Key(deps.NetworkIDName),
+			autopeering.WithAutopeeringExcludedPeers(excludedPeers),
+			autopeering.WithAutopeeringExcludedSubnets(excludedSubnets),
+			autopeering.WithAutopeeringRejectionDuration(deps.NodeConfig.Duration(CfgNetAutopeeringFiltersRejectionDuration)),
 		)
 	}); err != nil {
 		Plugin.LogPanic(err)
@@ -226,6 +262,22 @@ func configure() {
 
 	deps.AutopeeringManager.Init(localPeerContainer, initSelection)
 	configureEvents()
+	configureFilters()
+
+	if deps.Echo != nil {
+		routeGroup := deps.Echo.Group(routeGroupPrefix)
+
+		routeGroup.GET(RouteAutopeeringPeers, func(c echo.Context) error {
+			resp, err := discoveredPeers(c)
+			if err != nil {
+				return err
+			}
+
+			return restapipkg.JSONResponse(c, http.StatusOK, resp)
+		})
+
+		deps.OpenAPIRegistry.Register(openAPIRoutes()...)
+	}
 }
 
 func run() {
@@ -236,6 +288,8 @@ func run() {
 	}, shutdown.PriorityAutopeering); err != nil {
 		Plugin.LogPanicf("failed to start worker: %s", err)
 	}
+
+	runFilters()
 }
 
 func configureEvents() {
@@ -384,10 +438,22 @@ func handleSelection(ev *selection.PeeringEvent, addrInfo *libp2p.AddrInfo, noRe
 		handleAlreadyAutopeered(addrInfo)
 
 	default:
+		if deps.ReputationManager != nil && deps.ReputationManager.IsBelowDisconnectThreshold(addrInfo.ID) {
+			clearBadReputationFromAutopeeringSelector(ev, addrInfo)
+			return
+		}
 		noRelationFunc()
 	}
 }
 
+// clears a peer with a bad reputation from the autopeering selector, so that it gets replaced by another peer.
+func clearBadReputationFromAutopeeringSelector(ev *selection.PeeringEvent, addrInfo *libp2p.AddrInfo) {
+	if deps.AutopeeringManager.Selection() != nil {
+		Plugin.LogInfof("peer %s has a bad reputation, removing from autopeering selection protocol", addrInfo.ID.ShortString())
+		deps.AutopeeringManager.Selection().RemoveNeighbor(ev.Peer.ID())
+	}
+}
+
 // logs a warning about a from the selector seen peer which is already autopeered.
 func handleAlreadyAutopeered(addrInfo *libp2p.AddrInfo) {
 	Plugin.LogWarnf("peer is already autopeered %s", addrInfo.ID.ShortString())
@@ -0,0 +1,23 @@
+package autopeering
+
+// DiscoveredPeerResponse defines the response of a discovered autopeering peer.
+type DiscoveredPeerResponse struct {
+	// The libp2p identifier of the peer.
+	ID string `json:"id"`
+	// The base58 encoded autopeering public key of the peer.
+	PublicKey string `json:"publicKey"`
+	// The autopeering address of the peer.
+	Address string `json:"address"`
+	// The multi address of the peering service of the peer.
+	MultiAddress string `json:"multiAddress,omitempty"`
+	// The direction of the neighborhood if the peer was selected as a neighbor ("incoming" or "outgoing").
+	Neighbor string `json:"neighbor,omitempty"`
+	// Whether the peer passes the acceptance filters.
+	Accepted bool `json:"accepted"`
+}
+
+// discoveredPeersResponse defines the response of a GET discovered peers REST API call.
+type discoveredPeersResponse struct {
+	// The peers discovered by autopeering.
+	Peers []*DiscoveredPeerResponse `json:"peers"`
+}
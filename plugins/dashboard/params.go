@@ -24,6 +24,14 @@ const (
 	CfgDashboardAuthPasswordHash = "dashboard.auth.passwordHash"
 	// the auth salt used for hashing the password
 	CfgDashboardAuthPasswordSalt = "dashboard.auth.passwordSalt"
+	// whether the dashboard is served via TLS
+	CfgDashboardTLSEnabled = "dashboard.tls.enabled"
+	// the path to the PEM encoded TLS certificate of the dashboard
+	CfgDashboardTLSCertPath = "dashboard.tls.certPath"
+	// the path to the PEM encoded private key of the TLS certificate of the dashboard
+	CfgDashboardTLSKeyPath = "dashboard.tls.keyPath"
+	// whether a self-signed certificate is generated if the certificate files do not exist
+	CfgDashboardTLSSelfSigned = "dashboard.tls.selfSigned"
 
 	maxDashboardAuthUsernameSize = 25
 )
@@ -39,6 +47,10 @@ var params = &node.PluginParams{
 			fs.String(CfgDashboardAuthUsername, "admin", fmt.Sprintf("the auth username (max %d chars)", maxDashboardAuthUsernameSize))
 			fs.String(CfgDashboardAuthPasswordHash, "0000000000000000000000000000000000000000000000000000000000000000", "the auth password+salt as a scrypt hash")
 			fs.String(CfgDashboardAuthPasswordSalt, "0000000000000000000000000000000000000000000000000000000000000000", "the auth salt used for hashing the password")
+			fs.Bool(CfgDashboardTLSEnabled, false, "whether the dashboard is served via TLS")
+			fs.String(CfgDashboardTLSCertPath, "tls/dashboard_cert.pem", "the path to the PEM encoded TLS certificate of the dashboard")
+			fs.String(CfgDashboardTLSKeyPath, "tls/dashboard_key.pem", "the path to the PEM encoded private key of the TLS certificate of the dashboard")
+			fs.Bool(CfgDashboardTLSSelfSigned, false, "whether a self-signed certificate is generated if the certificate files do not exist")
 			return fs
 		}(),
 	},
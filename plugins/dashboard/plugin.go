@@ -90,6 +90,7 @@ type dependencies struct {
 	TipSelector              *tipselect.TipSelector       `optional:"true"`
 	NodeConfig               *configuration.Configuration `name:"nodeConfig"`
 	RestAPIBindAddress       string                       `name:"restAPIBindAddress"`
+	RestAPITLSEnabled        bool                         `name:"restAPITLSEnabled"`
 	AppInfo                  *app.AppInfo
 	Host                     host.Host
 	NodePrivateKey           crypto.PrivKey          `name:"nodePrivateKey"`
@@ -176,11 +177,16 @@ func run() {
 
 	setupRoutes(e)
 	bindAddr := deps.NodeConfig.String(CfgDashboardBindAddress)
+	tlsConfig := loadTLSConfig(bindAddr)
 
 	go func() {
-		Plugin.LogInfof("You can now access the dashboard using: http://%s", bindAddr)
+		scheme := "http"
+		if tlsConfig != nil {
+			scheme = "https"
+		}
+		Plugin.LogInfof("You can now access the dashboard using: %s://%s", scheme, bindAddr)
 
-		if err := e.Start(bindAddr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		if err := e.StartServer(&http.Server{Addr: bindAddr, TLSConfig: tlsConfig}); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			Plugin.LogWarnf("Stopped dashboard server due to an error (%s)", err)
 		}
 	}()
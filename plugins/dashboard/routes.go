@@ -91,7 +91,12 @@ func apiMiddlewares() []echo.MiddlewareFunc {
 		Plugin.LogFatalf("wrong REST API bind address: %s", err)
 	}
 
-	apiURL, err := url.Parse(fmt.Sprintf("http://localhost:%s", apiBindPort))
+	apiScheme := "http"
+	if deps.RestAPITLSEnabled {
+		apiScheme = "https"
+	}
+
+	apiURL, err := url.Parse(fmt.Sprintf("%s://localhost:%s", apiScheme, apiBindPort))
 	if err != nil {
 		Plugin.LogFatalf("wrong dashboard API url: %s", err)
 	}
@@ -103,8 +108,9 @@ func apiMiddlewares() []echo.MiddlewareFunc {
 	})
 
 	config := middleware.ProxyConfig{
-		Skipper:  proxySkipper,
-		Balancer: balancer,
+		Skipper:   proxySkipper,
+		Balancer:  balancer,
+		Transport: restAPIProxyTransport(),
 	}
 
 	// Protect this routes with JWT even if the API is not protected
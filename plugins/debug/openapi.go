@@ -0,0 +1,87 @@
+package debug
+
+import (
+	"net/http"
+
+	"github.com/gohornet/hornet/pkg/openapi"
+	restapipkg "github.com/gohornet/hornet/pkg/restapi"
+)
+
+const routeGroupPrefix = "/api/plugins/debug"
+
+var outputTypeParam = openapi.QueryParameter("type", "Filters the outputs by the output type.", "integer", false)
+
+// openAPIRoutes returns the descriptions of all routes of the plugin for the OpenAPI document.
+func openAPIRoutes() []*openapi.Route {
+	return []*openapi.Route{
+		{
+			Method:   http.MethodPost,
+			Path:     routeGroupPrefix + RouteDebugComputeWhiteFlag,
+			Summary:  "Computes the white flag confirmation for the cone of the given parents.",
+			Request:  &computeWhiteFlagMutationsRequest{},
+			Response: &computeWhiteFlagMutationsResponse{},
+		},
+		{
+			Method:         http.MethodPost,
+			Path:           routeGroupPrefix + RouteDebugSolidifier,
+			Summary:        "Triggers the solidifier.",
+			ResponseStatus: http.StatusNoContent,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteDebugOutputs,
+			Summary:    "Returns the output IDs of all outputs.",
+			Parameters: []*openapi.Parameter{outputTypeParam},
+			Response:   &outputIDsResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteDebugOutputsUnspent,
+			Summary:    "Returns the output IDs of all unspent outputs.",
+			Parameters: []*openapi.Parameter{outputTypeParam},
+			Response:   &outputIDsResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     routeGroupPrefix + RouteDebugOutputsSpent,
+			Summary:  "Returns the output IDs of all spent outputs.",
+			Response: &outputIDsResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     routeGroupPrefix + RouteDebugAddresses,
+			Summary:  "Returns all known addresses.",
+			Response: &addressesResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     routeGroupPrefix + RouteDebugAddressesEd25519,
+			Summary:  "Returns all known ed25519 addresses.",
+			Response: &addressesResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    routeGroupPrefix + RouteDebugMilestoneDiffs,
+			Summary: "Returns the UTXO diff (new outputs and spents) of a milestone.",
+			Parameters: []*openapi.Parameter{
+				openapi.PathParameter(restapipkg.ParameterMilestoneIndex, "The milestone index."),
+			},
+			Response: &milestoneDiffResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     routeGroupPrefix + RouteDebugRequests,
+			Summary:  "Returns all pending requests.",
+			Response: &requestsResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    routeGroupPrefix + RouteDebugMessageCone,
+			Summary: "Traverses the parents of a message until they reference an older milestone than the message.",
+			Parameters: []*openapi.Parameter{
+				openapi.PathParameter(restapipkg.ParameterMessageID, "The hex encoded message ID."),
+			},
+			Response: &messageConeResponse{},
+		},
+	}
+}
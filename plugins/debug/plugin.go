@@ -11,6 +11,7 @@ import (
 	"github.com/gohornet/hornet/pkg/model/syncmanager"
 	"github.com/gohornet/hornet/pkg/model/utxo"
 	"github.com/gohornet/hornet/pkg/node"
+	"github.com/gohornet/hornet/pkg/openapi"
 	"github.com/gohornet/hornet/pkg/protocol/gossip"
 	restapipkg "github.com/gohornet/hornet/pkg/restapi"
 	"github.com/gohornet/hornet/pkg/tangle"
@@ -83,13 +84,14 @@ var (
 
 type dependencies struct {
 	dig.In
-	Storage      *storage.Storage
-	SyncManager  *syncmanager.SyncManager
-	Tangle       *tangle.Tangle
-	RequestQueue gossip.RequestQueue
-	UTXOManager  *utxo.Manager
-	NodeConfig   *configuration.Configuration `name:"nodeConfig"`
-	Echo         *echo.Echo                   `optional:"true"`
+	Storage         *storage.Storage
+	SyncManager     *syncmanager.SyncManager
+	Tangle          *tangle.Tangle
+	RequestQueue    gossip.RequestQueue
+	UTXOManager     *utxo.Manager
+	NodeConfig      *configuration.Configuration `name:"nodeConfig"`
+	Echo            *echo.Echo                   `optional:"true"`
+	OpenAPIRegistry *openapi.Registry            `optional:"true"`
 }
 
 func configure() {
@@ -101,7 +103,7 @@ func configure() {
 
 	whiteflagParentsSolidTimeout = deps.NodeConfig.Duration(CfgDebugWhiteFlagParentsSolidTimeout)
 
-	routeGroup := deps.Echo.Group("/api/plugins/debug")
+	routeGroup := deps.Echo.Group(routeGroupPrefix)
 
 	routeGroup.POST(RouteDebugComputeWhiteFlag, func(c echo.Context) error {
 		resp, err := computeWhiteFlagMutations(c)
@@ -189,4 +191,6 @@ func configure() {
 
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
+
+	deps.OpenAPIRegistry.Register(openAPIRoutes()...)
 }
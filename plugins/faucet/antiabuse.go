@@ -0,0 +1,170 @@
+package faucet
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/database"
+	"github.com/gohornet/hornet/pkg/model/faucet"
+	"github.com/gohornet/hornet/pkg/restapi"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/timeutil"
+)
+
+const (
+	captchaVerifierSiteVerify = "siteVerify"
+	captchaVerifierStub       = "stub"
+
+	// the interval in which expired cooldown windows are removed from the rate limiter database.
+	rateLimiterPruneInterval = 10 * time.Minute
+)
+
+var (
+	rateLimiterStore kvstore.KVStore
+	rateLimiter      *faucet.RateLimiter
+	powChallenger    *faucet.PoWChallenger
+	captchaVerifier  faucet.CaptchaVerifier
+)
+
+// configureAntiAbuse initializes the enabled anti-abuse modules of the faucet.
+func configureAntiAbuse() {
+
+	if deps.NodeConfig.Bool(CfgFaucetRateLimitEnabled) {
+		var err error
+		rateLimiterStore, err = database.StoreWithDefaultSettings(filepath.Join(deps.DatabasePath, faucet.RateLimiterDatabaseDirectoryName), true, deps.DatabaseEngine)
+		if err != nil {
+			Plugin.LogPanicf("failed to open faucet rate limiter database: %s", err)
+		}
+
+		rateLimiter = faucet.NewRateLimiter(rateLimiterStore,
+			faucet.RateLimit{
+				MaxRequests: deps.NodeConfig.Int(CfgFaucetRateLimitIPMaxRequests),
+				Interval:    deps.NodeConfig.Duration(CfgFaucetRateLimitIPInterval),
+			},
+			faucet.RateLimit{
+				MaxRequests: deps.NodeConfig.Int(CfgFaucetRateLimitSubnetMaxRequests),
+				Interval:    deps.NodeConfig.Duration(CfgFaucetRateLimitSubnetInterval),
+			},
+		)
+	}
+
+	if deps.NodeConfig.Bool(CfgFaucetPoWChallengeEnabled) {
+		var err error
+		powChallenger, err = faucet.NewPoWChallenger(deps.NodeConfig.Int(CfgFaucetPoWChallengeDifficulty), deps.NodeConfig.Duration(CfgFaucetPoWChallengeValidity))
+		if err != nil {
+			Plugin.LogPanicf("failed to create faucet PoW challenger: %s", err)
+		}
+	}
+
+	if deps.NodeConfig.Bool(CfgFaucetCaptchaEnabled) {
+		switch verifier := deps.NodeConfig.String(CfgFaucetCaptchaVerifier); verifier {
+		case captchaVerifierSiteVerify:
+			secret := deps.NodeConfig.String(CfgFaucetCaptchaSecret)
+			if secret == "" {
+				Plugin.LogPanicf("%s is required for the %s captcha verifier", CfgFaucetCaptchaSecret, captchaVerifierSiteVerify)
+			}
+			captchaVerifier = faucet.NewSiteVerifyCaptchaVerifier(deps.NodeConfig.String(CfgFaucetCaptchaVerifyURL), secret, deps.NodeConfig.Duration(CfgFaucetCaptchaTimeout))
+		case captchaVerifierStub:
+			stubToken := deps.NodeConfig.String(CfgFaucetCaptchaStubToken)
+			if stubToken == "" {
+				Plugin.LogPanicf("%s is required for the %s captcha verifier", CfgFaucetCaptchaStubToken, captchaVerifierStub)
+			}
+			Plugin.LogWarnf("Using the %s captcha verifier. Do not use it in public networks!", captchaVerifierStub)
+			captchaVerifier = faucet.NewStubCaptchaVerifier(stubToken)
+		default:
+			Plugin.LogPanicf("unknown captcha verifier: %s", verifier)
+		}
+	}
+}
+
+// runAntiAbuse starts the background workers of the enabled anti-abuse modules.
+func runAntiAbuse() {
+	if rateLimiter == nil {
+		return
+	}
+
+	if err := Plugin.Daemon().BackgroundWorker("Faucet rate limiter pruning", func(ctx context.Context) {
+		ticker := timeutil.NewTicker(func() {
+			if err := rateLimiter.PruneExpired(time.Now()); err != nil {
+				Plugin.LogWarnf("failed to prune faucet rate limiter database: %s", err)
+			}
+		}, rateLimiterPruneInterval, ctx)
+		ticker.WaitForGracefulShutdown()
+	}, shutdown.PriorityFaucet); err != nil {
+		Plugin.LogPanicf("failed to start worker: %s", err)
+	}
+
+	if err := Plugin.Daemon().BackgroundWorker("Close faucet rate limiter database", func(ctx context.Context) {
+		<-ctx.Done()
+
+		Plugin.LogInfo("Syncing faucet rate limiter database to disk...")
+		if err := rateLimiterStore.Flush(); err != nil {
+			Plugin.LogErrorf("Syncing faucet rate limiter database to disk... failed: %s", err)
+		}
+		if err := rateLimiterStore.Close(); err != nil {
+			Plugin.LogErrorf("Closing faucet rate limiter database... failed: %s", err)
+		}
+		Plugin.LogInfo("Syncing faucet rate limiter database to disk... done")
+	}, shutdown.PriorityCloseDatabase); err != nil {
+		Plugin.LogPanicf("failed to start worker: %s", err)
+	}
+}
+
+// checkAntiAbuse runs the enabled anti-abuse checks for the given faucet request.
+// The rate limit is checked last, so requests that fail the other checks do not count towards the limit.
+func checkAntiAbuse(c echo.Context, request *faucetEnqueueRequest) error {
+
+	if captchaVerifier != nil {
+		if err := captchaVerifier.Verify(c.Request().Context(), request.CaptchaToken, c.RealIP()); err != nil {
+			if errors.Is(err, faucet.ErrCaptchaInvalid) {
+				return errors.WithMessage(restapi.ErrInvalidParameter, "Invalid captcha. Please try again!")
+			}
+			Plugin.LogWarnf("captcha verification failed: %s", err)
+			return errors.WithMessage(echo.ErrInternalServerError, "Captcha verification failed. Please try again later!")
+		}
+	}
+
+	if powChallenger != nil {
+		nonce, err := strconv.ParseUint(request.PoWNonce, 10, 64)
+		if err != nil {
+			return errors.WithMessage(restapi.ErrInvalidParameter, "Invalid proof of work nonce provided!")
+		}
+		if err := powChallenger.Verify(request.PoWChallenge, request.Address, nonce, time.Now()); err != nil {
+			return errors.WithMessagef(restapi.ErrInvalidParameter, "Invalid proof of work! Error: %s", err)
+		}
+	}
+
+	if rateLimiter != nil {
+		// the IP is determined by the IPExtractor of the REST API, so it can't be spoofed via the X-Forwarded-For header
+		ip := net.ParseIP(c.RealIP())
+		if ip == nil {
+			return errors.WithMessage(restapi.ErrInvalidParameter, "Unable to determine your IP address!")
+		}
+
+		cooldown, err := rateLimiter.Allow(ip, time.Now())
+		if err != nil {
+			if errors.Is(err, faucet.ErrRateLimitExceeded) {
+				c.Response().Header().Set("Retry-After", strconv.Itoa(int(cooldown.Round(time.Second).Seconds())))
+				return errors.WithMessagef(echo.ErrTooManyRequests, "Too many requests. Please try again in %s!", cooldown.Round(time.Second))
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getPoWChallenge(_ echo.Context) (*faucet.PoWChallenge, error) {
+	if powChallenger == nil {
+		return nil, errors.WithMessage(echo.ErrNotFound, "proof of work challenges are disabled")
+	}
+
+	return powChallenger.Issue(time.Now())
+}
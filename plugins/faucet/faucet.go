@@ -1,6 +1,8 @@
 package faucet
 
 import (
+	"strconv"
+
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 
@@ -19,6 +21,10 @@ func addFaucetOutputToQueue(c echo.Context) (*faucet.FaucetEnqueueResponse, erro
 		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "Invalid Request! Error: %s", err)
 	}
 
+	if err := checkAntiAbuse(c, request); err != nil {
+		return nil, err
+	}
+
 	response, err := deps.Faucet.Enqueue(request.Address)
 	if err != nil {
 		return nil, err
@@ -26,3 +32,39 @@ func addFaucetOutputToQueue(c echo.Context) (*faucet.FaucetEnqueueResponse, erro
 
 	return response, nil
 }
+
+func getFaucetRequest(c echo.Context) (*faucet.FaucetRequestResponse, error) {
+	return deps.Faucet.RequestStatus(c.Param(ParameterRequestID))
+}
+
+func parseIntQueryParam(c echo.Context, name string, defaultValue int) (int, error) {
+	param := c.QueryParam(name)
+	if param == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.ParseUint(param, 10, 31)
+	if err != nil {
+		return 0, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid %s: %s, error: %s", name, param, err)
+	}
+
+	return int(value), nil
+}
+
+func getFaucetRequests(c echo.Context) (*faucet.FaucetRequestHistoryResponse, error) {
+
+	offset, err := parseIntQueryParam(c, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := parseIntQueryParam(c, "limit", maxRequestHistoryResults)
+	if err != nil {
+		return nil, err
+	}
+	if limit == 0 || limit > maxRequestHistoryResults {
+		limit = maxRequestHistoryResults
+	}
+
+	return deps.Faucet.RequestHistory(c.QueryParam("address"), offset, limit)
+}
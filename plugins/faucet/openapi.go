@@ -0,0 +1,54 @@
+package faucet
+
+import (
+	"net/http"
+
+	"github.com/gohornet/hornet/pkg/model/faucet"
+	"github.com/gohornet/hornet/pkg/openapi"
+)
+
+const routeGroupPrefix = "/api/plugins/faucet"
+
+// openAPIRoutes returns the descriptions of all routes of the plugin for the OpenAPI document.
+func openAPIRoutes() []*openapi.Route {
+	return []*openapi.Route{
+		{
+			Method:   http.MethodGet,
+			Path:     routeGroupPrefix + RouteFaucetInfo,
+			Summary:  "Returns the address and the balance of the faucet.",
+			Response: &faucet.FaucetInfoResponse{},
+		},
+		{
+			Method:         http.MethodPost,
+			Path:           routeGroupPrefix + RouteFaucetEnqueue,
+			Summary:        "Adds an address to the queue of the faucet.",
+			Request:        &faucetEnqueueRequest{},
+			Response:       &faucet.FaucetEnqueueResponse{},
+			ResponseStatus: http.StatusAccepted,
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     routeGroupPrefix + RouteFaucetPoWChallenge,
+			Summary:  "Returns a new proof of work challenge that has to be solved before requesting funds.",
+			Response: &faucet.PoWChallenge{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteFaucetRequest,
+			Summary:    "Returns the status of a faucet request.",
+			Parameters: []*openapi.Parameter{openapi.PathParameter(ParameterRequestID, "The ID of the request.")},
+			Response:   &faucet.FaucetRequestResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    routeGroupPrefix + RouteFaucetRequests,
+			Summary: "Returns the known faucet requests, newest first.",
+			Parameters: []*openapi.Parameter{
+				openapi.QueryParameter("address", "Only return the requests for this bech32 address.", "string", false),
+				openapi.QueryParameter("offset", "The position of the first returned request.", "integer", false),
+				openapi.QueryParameter("limit", "The maximum amount of returned requests.", "integer", false),
+			},
+			Response: &faucet.FaucetRequestHistoryResponse{},
+		},
+	}
+}
@@ -24,10 +24,52 @@ const (
 	CfgFaucetBatchTimeout = "faucet.batchTimeout"
 	// the amount of workers used for calculating PoW when issuing faucet messages.
 	CfgFaucetPoWWorkerCount = "faucet.powWorkerCount"
+	// the amount of wallets derived from the faucet seed (FAUCET_SEED)
+	CfgFaucetWalletCount = "faucet.walletCount"
+	// the amount of unspent outputs on a faucet address at which they are consolidated into a single output
+	CfgFaucetConsolidationThreshold = "faucet.consolidationThreshold"
+	// the amount of finished requests the faucet keeps track of
+	CfgFaucetRequestHistorySize = "faucet.requestHistorySize"
 	// the bind address on which the faucet website can be accessed from
 	CfgFaucetWebsiteBindAddress = "faucet.website.bindAddress"
 	// whether to host the faucet website
 	CfgFaucetWebsiteEnabled = "faucet.website.enabled"
+	// whether the faucet website is served via TLS
+	CfgFaucetWebsiteTLSEnabled = "faucet.website.tls.enabled"
+	// the path to the PEM encoded TLS certificate of the faucet website
+	CfgFaucetWebsiteTLSCertPath = "faucet.website.tls.certPath"
+	// the path to the PEM encoded private key of the TLS certificate of the faucet website
+	CfgFaucetWebsiteTLSKeyPath = "faucet.website.tls.keyPath"
+	// whether a self-signed certificate is generated if the certificate files do not exist
+	CfgFaucetWebsiteTLSSelfSigned = "faucet.website.tls.selfSigned"
+	// whether faucet requests are throttled per IP and per subnet
+	CfgFaucetRateLimitEnabled = "faucet.rateLimit.enabled"
+	// the maximum amount of faucet requests per IP within the IP interval (0 = unlimited)
+	CfgFaucetRateLimitIPMaxRequests = "faucet.rateLimit.ipMaxRequests"
+	// the cooldown interval for the requests of a single IP
+	CfgFaucetRateLimitIPInterval = "faucet.rateLimit.ipInterval"
+	// the maximum amount of faucet requests per /24 (IPv4) or /64 (IPv6) subnet within the subnet interval (0 = unlimited)
+	CfgFaucetRateLimitSubnetMaxRequests = "faucet.rateLimit.subnetMaxRequests"
+	// the cooldown interval for the requests of a subnet
+	CfgFaucetRateLimitSubnetInterval = "faucet.rateLimit.subnetInterval"
+	// whether clients need to solve a proof of work challenge before requesting funds
+	CfgFaucetPoWChallengeEnabled = "faucet.powChallenge.enabled"
+	// the amount of leading zero bits the hash of a challenge solution must have
+	CfgFaucetPoWChallengeDifficulty = "faucet.powChallenge.difficulty"
+	// the duration after which an issued challenge expires
+	CfgFaucetPoWChallengeValidity = "faucet.powChallenge.validity"
+	// whether clients need to solve a captcha before requesting funds
+	CfgFaucetCaptchaEnabled = "faucet.captcha.enabled"
+	// the captcha verifier to use ("siteVerify" or "stub")
+	CfgFaucetCaptchaVerifier = "faucet.captcha.verifier"
+	// the URL of the siteverify API of the captcha provider
+	CfgFaucetCaptchaVerifyURL = "faucet.captcha.verifyURL"
+	// the secret key for the siteverify API of the captcha provider
+	CfgFaucetCaptchaSecret = "faucet.captcha.secret"
+	// the timeout for requests to the siteverify API of the captcha provider
+	CfgFaucetCaptchaTimeout = "faucet.captcha.timeout"
+	// the token accepted by the stub verifier (only for tests and private networks)
+	CfgFaucetCaptchaStubToken = "faucet.captcha.stubToken"
 )
 
 var params = &node.PluginParams{
@@ -41,10 +83,31 @@ var params = &node.PluginParams{
 			fs.String(CfgFaucetIndexationMessage, "HORNET FAUCET", "the faucet transaction indexation payload")
 			fs.Duration(CfgFaucetBatchTimeout, 2*time.Second, "the maximum duration for collecting faucet batches")
 			fs.Int(CfgFaucetPoWWorkerCount, 0, "the amount of workers used for calculating PoW when issuing faucet messages")
+			fs.Int(CfgFaucetWalletCount, 1, "the amount of wallets derived from the faucet seed (FAUCET_SEED)")
+			fs.Int(CfgFaucetConsolidationThreshold, iotago.MaxInputsCount, "the amount of unspent outputs on a faucet address at which they are consolidated into a single output")
+			fs.Int(CfgFaucetRequestHistorySize, 1000, "the amount of finished requests the faucet keeps track of")
 			fs.String(CfgFaucetWebsiteBindAddress, "localhost:8091", "the bind address on which the faucet website can be accessed from")
 			fs.Bool(CfgFaucetWebsiteEnabled, false, "whether to host the faucet website")
+			fs.Bool(CfgFaucetWebsiteTLSEnabled, false, "whether the faucet website is served via TLS")
+			fs.String(CfgFaucetWebsiteTLSCertPath, "tls/faucet_cert.pem", "the path to the PEM encoded TLS certificate of the faucet website")
+			fs.String(CfgFaucetWebsiteTLSKeyPath, "tls/faucet_key.pem", "the path to the PEM encoded private key of the TLS certificate of the faucet website")
+			fs.Bool(CfgFaucetWebsiteTLSSelfSigned, false, "whether a self-signed certificate is generated if the certificate files do not exist")
+			fs.Bool(CfgFaucetRateLimitEnabled, false, "whether faucet requests are throttled per IP and per subnet")
+			fs.Int(CfgFaucetRateLimitIPMaxRequests, 1, "the maximum amount of faucet requests per IP within the IP interval (0 = unlimited)")
+			fs.Duration(CfgFaucetRateLimitIPInterval, 1*time.Hour, "the cooldown interval for the requests of a single IP")
+			fs.Int(CfgFaucetRateLimitSubnetMaxRequests, 10, "the maximum amount of faucet requests per /24 (IPv4) or /64 (IPv6) subnet within the subnet interval (0 = unlimited)")
+			fs.Duration(CfgFaucetRateLimitSubnetInterval, 1*time.Hour, "the cooldown interval for the requests of a subnet")
+			fs.Bool(CfgFaucetPoWChallengeEnabled, false, "whether clients need to solve a proof of work challenge before requesting funds")
+			fs.Int(CfgFaucetPoWChallengeDifficulty, 20, "the amount of leading zero bits the hash of a challenge solution must have")
+			fs.Duration(CfgFaucetPoWChallengeValidity, 5*time.Minute, "the duration after which an issued challenge expires")
+			fs.Bool(CfgFaucetCaptchaEnabled, false, "whether clients need to solve a captcha before requesting funds")
+			fs.String(CfgFaucetCaptchaVerifier, captchaVerifierSiteVerify, "the captcha verifier to use (\"siteVerify\" or \"stub\")")
+			fs.String(CfgFaucetCaptchaVerifyURL, "https://hcaptcha.com/siteverify", "the URL of the siteverify API of the captcha provider")
+			fs.String(CfgFaucetCaptchaSecret, "", "the secret key for the siteverify API of the captcha provider")
+			fs.Duration(CfgFaucetCaptchaTimeout, 5*time.Second, "the timeout for requests to the siteverify API of the captcha provider")
+			fs.String(CfgFaucetCaptchaStubToken, "", "the token accepted by the stub verifier (only for tests and private networks)")
 			return fs
 		}(),
 	},
-	Masked: nil,
+	Masked: []string{CfgFaucetCaptchaSecret},
 }
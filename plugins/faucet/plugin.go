@@ -2,6 +2,7 @@ package faucet
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -15,11 +16,13 @@ import (
 	"golang.org/x/time/rate"
 
 	"github.com/gohornet/hornet/pkg/common"
+	"github.com/gohornet/hornet/pkg/database"
 	"github.com/gohornet/hornet/pkg/model/faucet"
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/model/syncmanager"
 	"github.com/gohornet/hornet/pkg/model/utxo"
 	"github.com/gohornet/hornet/pkg/node"
+	"github.com/gohornet/hornet/pkg/openapi"
 	"github.com/gohornet/hornet/pkg/pow"
 	"github.com/gohornet/hornet/pkg/protocol/gossip"
 	"github.com/gohornet/hornet/pkg/restapi"
@@ -35,6 +38,14 @@ import (
 	"github.com/iotaledger/iota.go/v2/ed25519"
 )
 
+const (
+	// ParameterRequestID is used to identify a faucet request by its ID.
+	ParameterRequestID = "requestID"
+
+	// maxRequestHistoryResults is the maximum amount of requests returned by RouteFaucetRequests.
+	maxRequestHistoryResults = 1000
+)
+
 const (
 
 	// RouteFaucetInfo is the route to give info about the faucet address.
@@ -44,6 +55,19 @@ const (
 	// RouteFaucetEnqueue is the route to tell the faucet to pay out some funds to the given address.
 	// POST enqueues a new request.
 	RouteFaucetEnqueue = "/enqueue"
+
+	// RouteFaucetPoWChallenge is the route to get a proof of work challenge that has to be solved before requesting funds.
+	// GET returns a new challenge.
+	RouteFaucetPoWChallenge = "/challenge"
+
+	// RouteFaucetRequest is the route to get the status of a faucet request.
+	// GET returns the state of the request and the message that pays it.
+	RouteFaucetRequest = "/requests/:" + ParameterRequestID
+
+	// RouteFaucetRequests is the route to get the history of the faucet requests.
+	// GET returns the known requests, newest first, paginated by the query parameters "offset" and "limit".
+	// The query parameter "address" filters the requests by a bech32 address.
+	RouteFaucetRequests = "/requests"
 )
 
 func init() {
@@ -71,15 +95,35 @@ var (
 type dependencies struct {
 	dig.In
 	NodeConfig            *configuration.Configuration `name:"nodeConfig"`
+	DatabasePath          string                       `name:"databasePath"`
+	DatabaseEngine        database.Engine              `name:"databaseEngine"`
 	RestAPIBindAddress    string                       `name:"restAPIBindAddress"`
+	RestAPITLSEnabled     bool                         `name:"restAPITLSEnabled"`
 	FaucetAllowedAPIRoute restapi.AllowedRoute         `name:"faucetAllowedAPIRoute"`
 	Faucet                *faucet.Faucet
 	Tangle                *tangle.Tangle
 	Echo                  *echo.Echo
+	OpenAPIRegistry       *openapi.Registry
 	ShutdownHandler       *shutdown.ShutdownHandler
 }
 
-func provide(c *dig.Container) {
+// loadFaucetWallets loads the wallets of the faucet.
+// If a seed is given, the wallets are derived from it, otherwise the single private key is used.
+func loadFaucetWallets(walletCount int) []*faucet.Wallet {
+
+	if seedHex, err := utils.LoadStringFromEnvironment("FAUCET_SEED"); err == nil {
+		seed, err := hex.DecodeString(seedHex)
+		if err != nil {
+			Plugin.LogPanicf("loading faucet seed failed, err: %s", err)
+		}
+
+		wallets, err := faucet.WalletsFromSeed(seed, walletCount)
+		if err != nil {
+			Plugin.LogPanicf("deriving faucet wallets failed, err: %s", err)
+		}
+
+		return wallets
+	}
 
 	privateKeys, err := utils.LoadEd25519PrivateKeysFromEnvironment("FAUCET_PRV_KEY")
 	if err != nil {
@@ -99,8 +143,10 @@ func provide(c *dig.Container) {
 		Plugin.LogPanic("loading faucet private key failed, err: wrong private key length")
 	}
 
-	faucetAddress := iotago.AddressFromEd25519PubKey(privateKey.Public().(ed25519.PublicKey))
-	faucetSigner := iotago.NewInMemoryAddressSigner(iotago.NewAddressKeysForEd25519Address(&faucetAddress, privateKey))
+	return []*faucet.Wallet{faucet.NewWallet(privateKey)}
+}
+
+func provide(c *dig.Container) {
 
 	type faucetDeps struct {
 		dig.In
@@ -124,8 +170,7 @@ func provide(c *dig.Container) {
 			deps.NetworkID,
 			deps.BelowMaxDepth,
 			deps.UTXOManager,
-			&faucetAddress,
-			faucetSigner,
+			loadFaucetWallets(deps.NodeConfig.Int(CfgFaucetWalletCount)),
 			deps.TipSelector.SelectNonLazyTips,
 			deps.PowHandler,
 			deps.MessageProcessor.Emit,
@@ -138,6 +183,8 @@ func provide(c *dig.Container) {
 			faucet.WithIndexationMessage(deps.NodeConfig.String(CfgFaucetIndexationMessage)),
 			faucet.WithBatchTimeout(deps.NodeConfig.Duration(CfgFaucetBatchTimeout)),
 			faucet.WithPowWorkerCount(deps.NodeConfig.Int(CfgFaucetPoWWorkerCount)),
+			faucet.WithConsolidationThreshold(deps.NodeConfig.Int(CfgFaucetConsolidationThreshold)),
+			faucet.WithRequestHistorySize(deps.NodeConfig.Int(CfgFaucetRequestHistorySize)),
 		)
 	}); err != nil {
 		Plugin.LogPanic(err)
@@ -147,11 +194,13 @@ func provide(c *dig.Container) {
 func configure() {
 	restapiv1.AddFeature(Plugin.Name)
 
-	routeGroup := deps.Echo.Group("/api/plugins/faucet")
+	routeGroup := deps.Echo.Group(routeGroupPrefix)
 
 	allowedRoutes := map[string][]string{
 		http.MethodGet: {
 			"/api/plugins/faucet/info",
+			"/api/plugins/faucet/challenge",
+			"/api/plugins/faucet/requests",
 		},
 	}
 
@@ -213,7 +262,7 @@ func configure() {
 			var e *echo.HTTPError
 			if errors.As(err, &e) {
 				statusCode = e.Code
-				if errors.Is(err, restapi.ErrInvalidParameter) {
+				if errors.Is(err, restapi.ErrInvalidParameter) || errors.Is(err, echo.ErrTooManyRequests) {
 					message = strings.Replace(err.Error(), ": "+errors.Unwrap(err).Error(), "", 1)
 				} else {
 					message = err.Error()
@@ -229,6 +278,36 @@ func configure() {
 		return restapi.JSONResponse(c, http.StatusAccepted, resp)
 	})
 
+	routeGroup.GET(RouteFaucetPoWChallenge, func(c echo.Context) error {
+		resp, err := getPoWChallenge(c)
+		if err != nil {
+			return err
+		}
+
+		return restapi.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteFaucetRequest, func(c echo.Context) error {
+		resp, err := getFaucetRequest(c)
+		if err != nil {
+			return err
+		}
+
+		return restapi.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteFaucetRequests, func(c echo.Context) error {
+		resp, err := getFaucetRequests(c)
+		if err != nil {
+			return err
+		}
+
+		return restapi.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	deps.OpenAPIRegistry.Register(openAPIRoutes()...)
+
+	configureAntiAbuse()
 	configureEvents()
 }
 
@@ -244,6 +323,8 @@ func run() {
 		Plugin.LogPanicf("failed to start worker: %s", err)
 	}
 
+	runAntiAbuse()
+
 	websiteEnabled := deps.NodeConfig.Bool(CfgFaucetWebsiteEnabled)
 
 	if websiteEnabled {
@@ -251,14 +332,22 @@ func run() {
 
 		e := echo.New()
 		e.HideBanner = true
+		// the website is not meant to run behind a proxy, the API calls are proxied to the REST API,
+		// which determines the client IP from the X-Forwarded-For header added by the proxy.
+		e.IPExtractor = echo.ExtractIPDirect()
 		e.Use(middleware.Recover())
 
 		setupRoutes(e)
+		tlsConfig := loadTLSConfig(bindAddr)
 
 		go func() {
-			Plugin.LogInfof("You can now access the faucet website using: http://%s", bindAddr)
+			scheme := "http"
+			if tlsConfig != nil {
+				scheme = "https"
+			}
+			Plugin.LogInfof("You can now access the faucet website using: %s://%s", scheme, bindAddr)
 
-			if err := e.Start(bindAddr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			if err := e.StartServer(&http.Server{Addr: bindAddr, TLSConfig: tlsConfig}); err != nil && !errors.Is(err, http.ErrServerClosed) {
 				Plugin.LogWarnf("Stopped faucet website server due to an error (%s)", err)
 			}
 		}()
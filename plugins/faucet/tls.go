@@ -0,0 +1,56 @@
+package faucet
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/pkg/tlsutil"
+)
+
+// loadTLSConfig loads the TLS certificate of the faucet website and starts a worker that reloads it if the files are changed on disk.
+// It returns nil if TLS is disabled.
+func loadTLSConfig(bindAddr string) *tls.Config {
+
+	if !deps.NodeConfig.Bool(CfgFaucetWebsiteTLSEnabled) {
+		return nil
+	}
+
+	certPath := deps.NodeConfig.String(CfgFaucetWebsiteTLSCertPath)
+	tlsConfig, reload, generated, err := tlsutil.NewReloadingConfig(
+		certPath,
+		deps.NodeConfig.String(CfgFaucetWebsiteTLSKeyPath),
+		"",
+		deps.NodeConfig.Bool(CfgFaucetWebsiteTLSSelfSigned),
+		bindAddr,
+	)
+	if err != nil {
+		Plugin.LogPanicf("loading TLS certificate failed: %s", err)
+	}
+	if generated {
+		Plugin.LogInfof("Generated self-signed TLS certificate: %s", certPath)
+	}
+
+	if err := Plugin.Daemon().BackgroundWorker("Faucet website certificate reloader", func(ctx context.Context) {
+		reload(ctx, func(err error) {
+			if err != nil {
+				Plugin.LogWarnf("reloading TLS certificate failed: %s", err)
+				return
+			}
+			Plugin.LogInfo("Reloaded TLS certificate")
+		})
+	}, shutdown.PriorityFaucet); err != nil {
+		Plugin.LogPanicf("failed to start worker: %s", err)
+	}
+
+	return tlsConfig
+}
+
+// restAPIProxyTransport returns the transport used to proxy the API calls to the REST API.
+func restAPIProxyTransport() http.RoundTripper {
+	if !deps.RestAPITLSEnabled {
+		return nil
+	}
+	return tlsutil.LocalProxyTransport()
+}
@@ -4,4 +4,10 @@ package faucet
 type faucetEnqueueRequest struct {
 	// The bech32 address.
 	Address string `json:"address"`
+	// The captcha token, if captchas are enabled.
+	CaptchaToken string `json:"captchaToken,omitempty"`
+	// The proof of work challenge received via RouteFaucetPoWChallenge, if proof of work challenges are enabled.
+	PoWChallenge string `json:"powChallenge,omitempty"`
+	// The nonce that solves the proof of work challenge, as a decimal string.
+	PoWNonce string `json:"powNonce,omitempty"`
 }
@@ -1,6 +1,8 @@
 package mqtt
 
 import (
+	"time"
+
 	flag "github.com/spf13/pflag"
 
 	"github.com/gohornet/hornet/pkg/node"
@@ -15,6 +17,12 @@ const (
 	CfgMQTTWorkerCount = "mqtt.workerCount"
 	// the number of deleted topics that trigger a garbage collection of the topic manager.
 	CfgMQTTTopicCleanupThreshold = "mqtt.topicCleanupThreshold"
+	// the size of the event buffer per server-sent events client.
+	CfgMQTTSSEClientBufferSize = "mqtt.sse.clientBufferSize"
+	// the interval in which keep-alive comments are sent to server-sent events clients.
+	CfgMQTTSSEKeepAliveInterval = "mqtt.sse.keepAliveInterval"
+	// the maximum amount of milestones that are replayed to server-sent events clients that reconnect with a Last-Event-ID.
+	CfgMQTTSSEMaxReplay = "mqtt.sse.maxReplay"
 )
 
 var params = &node.PluginParams{
@@ -25,6 +33,9 @@ var params = &node.PluginParams{
 			fs.Int(CfgMQTTWSPort, 1888, "port of the WebSocket MQTT broker")
 			fs.Int(CfgMQTTWorkerCount, 100, "number of parallel workers the MQTT broker uses to publish messages")
 			fs.Int(CfgMQTTTopicCleanupThreshold, 10000, "number of deleted topics that trigger a garbage collection of the topic manager")
+			fs.Int(CfgMQTTSSEClientBufferSize, 1000, "size of the event buffer per server-sent events client")
+			fs.Duration(CfgMQTTSSEKeepAliveInterval, 15*time.Second, "interval in which keep-alive comments are sent to server-sent events clients")
+			fs.Int(CfgMQTTSSEMaxReplay, 1000, "maximum amount of milestones that are replayed to server-sent events clients that reconnect with a Last-Event-ID")
 			return fs
 		}(),
 	},
@@ -4,18 +4,24 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"go.uber.org/dig"
 
 	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/promoter"
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/model/syncmanager"
 	"github.com/gohornet/hornet/pkg/model/utxo"
 	mqttpkg "github.com/gohornet/hornet/pkg/mqtt"
 	"github.com/gohornet/hornet/pkg/node"
+	"github.com/gohornet/hornet/pkg/openapi"
+	restapipkg "github.com/gohornet/hornet/pkg/restapi"
 	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/pkg/sse"
 	"github.com/gohornet/hornet/pkg/tangle"
 	"github.com/gohornet/hornet/plugins/restapi"
 	"github.com/iotaledger/hive.go/configuration"
@@ -57,10 +63,14 @@ var (
 	messageMetadataWorkerPool *workerpool.WorkerPool
 	utxoOutputWorkerPool      *workerpool.WorkerPool
 	receiptWorkerPool         *workerpool.WorkerPool
+	promoterWorkerPool        *workerpool.WorkerPool
 
 	topicSubscriptionWorkerPool *workerpool.WorkerPool
 
 	wasSyncBefore = false
+
+	sseKeepAliveInterval time.Duration
+	sseMaxReplay         milestone.Index
 )
 
 type dependencies struct {
@@ -75,6 +85,10 @@ type dependencies struct {
 	Bech32HRP                             iotago.NetworkPrefix         `name:"bech32HRP"`
 	Echo                                  *echo.Echo                   `optional:"true"`
 	MQTTBroker                            *mqttpkg.Broker
+	SSEBroker                             *sse.Broker
+	OpenAPIRegistry                       *openapi.Registry          `optional:"true"`
+	RestAPIRouteAuthorized                restapipkg.RouteAuthorized `name:"restAPIRouteAuthorized"`
+	Promoter                              *promoter.Promoter         `optional:"true"`
 }
 
 func provide(c *dig.Container) {
@@ -98,6 +112,26 @@ func provide(c *dig.Container) {
 	}); err != nil {
 		Plugin.LogPanic(err)
 	}
+
+	type sseBrokerDeps struct {
+		dig.In
+		NodeConfig  *configuration.Configuration `name:"nodeConfig"`
+		SyncManager *syncmanager.SyncManager
+	}
+
+	if err := c.Provide(func(deps sseBrokerDeps) *sse.Broker {
+		// the event IDs are based on the confirmed milestone index, so clients can resume the stream
+		return sse.NewBroker(func() string {
+			return strconv.FormatUint(uint64(deps.SyncManager.ConfirmedMilestoneIndex()), 10)
+		}, func(topic []byte) {
+			Plugin.LogDebugf("Subscribe to topic via server-sent events: %s", string(topic))
+			topicSubscriptionWorkerPool.TrySubmit(topic)
+		}, func(topic []byte) {
+			Plugin.LogDebugf("Unsubscribe from topic via server-sent events: %s", string(topic))
+		}, sse.WithClientBufferSize(deps.NodeConfig.Int(CfgMQTTSSEClientBufferSize)))
+	}); err != nil {
+		Plugin.LogPanic(err)
+	}
 }
 
 func configure() {
@@ -136,12 +170,28 @@ func configure() {
 		task.Return(nil)
 	}, workerpool.WorkerCount(workerCount), workerpool.QueueSize(workerQueueSize))
 
+	promoterWorkerPool = workerpool.New(func(task workerpool.Task) {
+		publishPromoterMessageStatus(task.Param(0).(*promoter.MessageStatusResponse))
+		task.Return(nil)
+	}, workerpool.WorkerCount(workerCount), workerpool.QueueSize(workerQueueSize))
+
 	topicSubscriptionWorkerPool = workerpool.New(func(task workerpool.Task) {
 		defer task.Return(nil)
 
 		topic := task.Param(0).([]byte)
 		topicName := string(topic)
 
+		if messageID := promoterMessageIDFromTopic(topicName); messageID != nil {
+			if deps.Promoter == nil {
+				return
+			}
+
+			if status, err := deps.Promoter.Status(messageID); err == nil {
+				promoterWorkerPool.TrySubmit(status)
+			}
+			return
+		}
+
 		if messageID := messageIDFromTopic(topicName); messageID != nil {
 			if cachedMsgMeta := deps.Storage.CachedMessageMetadataOrNil(messageID); cachedMsgMeta != nil {
 				if _, added := messageMetadataWorkerPool.TrySubmit(cachedMsgMeta); added {
@@ -208,7 +258,11 @@ func configure() {
 
 	}, workerpool.WorkerCount(workerCount), workerpool.QueueSize(workerQueueSize), workerpool.FlushTasksAtShutdown(true))
 
+	sseKeepAliveInterval = deps.NodeConfig.Duration(CfgMQTTSSEKeepAliveInterval)
+	sseMaxReplay = milestone.Index(deps.NodeConfig.Int(CfgMQTTSSEMaxReplay))
+
 	setupWebSocketRoute()
+	setupSSERoute()
 }
 
 func setupWebSocketRoute() {
@@ -307,6 +361,10 @@ func run() {
 		receiptWorkerPool.TrySubmit(receipt)
 	})
 
+	onPromoterStatusChanged := events.NewClosure(func(status *promoter.MessageStatusResponse) {
+		promoterWorkerPool.TrySubmit(status)
+	})
+
 	if err := Plugin.Daemon().BackgroundWorker("MQTT Broker", func(ctx context.Context) {
 		go func() {
 			deps.MQTTBroker.Start()
@@ -343,6 +401,10 @@ func run() {
 
 		deps.Tangle.Events.NewReceipt.Attach(onReceipt)
 
+		if deps.Promoter != nil {
+			deps.Promoter.Events.StatusChanged.Attach(onPromoterStatusChanged)
+		}
+
 		messagesWorkerPool.Start()
 		newLatestMilestoneWorkerPool.Start()
 		newConfirmedMilestoneWorkerPool.Start()
@@ -350,6 +412,7 @@ func run() {
 		topicSubscriptionWorkerPool.Start()
 		utxoOutputWorkerPool.Start()
 		receiptWorkerPool.Start()
+		promoterWorkerPool.Start()
 
 		<-ctx.Done()
 
@@ -365,6 +428,10 @@ func run() {
 
 		deps.Tangle.Events.NewReceipt.Detach(onReceipt)
 
+		if deps.Promoter != nil {
+			deps.Promoter.Events.StatusChanged.Detach(onPromoterStatusChanged)
+		}
+
 		messagesWorkerPool.StopAndWait()
 		newLatestMilestoneWorkerPool.StopAndWait()
 		newConfirmedMilestoneWorkerPool.StopAndWait()
@@ -372,6 +439,7 @@ func run() {
 		topicSubscriptionWorkerPool.StopAndWait()
 		utxoOutputWorkerPool.StopAndWait()
 		receiptWorkerPool.StopAndWait()
+		promoterWorkerPool.StopAndWait()
 
 		Plugin.LogInfo("Stopping MQTT Events ... done")
 	}, shutdown.PriorityMetricsPublishers); err != nil {
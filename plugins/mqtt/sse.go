@@ -0,0 +1,335 @@
+package mqtt
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/promoter"
+	"github.com/gohornet/hornet/pkg/model/utxo"
+	"github.com/gohornet/hornet/pkg/openapi"
+	"github.com/gohornet/hornet/pkg/restapi"
+	"github.com/gohornet/hornet/pkg/sse"
+	restapiv1 "github.com/gohornet/hornet/plugins/restapi/v1"
+)
+
+const (
+	// RouteSSE is the route for subscribing to the MQTT topics via server-sent events.
+	// GET streams the events of the topics given by the "topics" query parameter.
+	// The stream can be resumed by passing the last received event ID (milestone index) in the "Last-Event-ID" header.
+	RouteSSE = "/api/v1/events"
+
+	// QueryParameterTopics is used to pass the topics for the server-sent events.
+	// Topics can be passed comma separated or by repeating the query parameter.
+	QueryParameterTopics = "topics"
+
+	headerLastEventID = "Last-Event-ID"
+)
+
+func setupSSERoute() {
+	deps.Echo.GET(RouteSSE, func(c echo.Context) error {
+		return streamEvents(c)
+	})
+
+	deps.OpenAPIRegistry.Register(&openapi.Route{
+		Method:  http.MethodGet,
+		Path:    RouteSSE,
+		Summary: "Streams the events of the given MQTT topics as server-sent events.",
+		Tags:    []string{"events"},
+		Parameters: []*openapi.Parameter{
+			openapi.QueryParameter(QueryParameterTopics, "The topics to subscribe to, comma separated or repeated.", "string", true),
+			{
+				Name:        headerLastEventID,
+				In:          openapi.ParameterInHeader,
+				Description: "The milestone index of the last received event to resume the stream.",
+				Schema:      &openapi.Schema{Type: "integer"},
+			},
+		},
+		ResponseContentType: openapi.MIMETextEventStream,
+	})
+}
+
+// restAPIRouteForTopic returns the REST API route that gives access to the same data as the given topic.
+// The public and protected route rules of the REST API for this route are applied to the topic.
+func restAPIRouteForTopic(topic string) (string, error) {
+	switch {
+	case topic == topicMilestonesLatest, topic == topicMilestonesConfirmed:
+		return "/api/v1" + restapiv1.RouteMilestone, nil
+
+	case topic == topicMessages:
+		return "/api/v1" + restapiv1.RouteMessageData, nil
+
+	case topic == topicMessagesReferenced:
+		return "/api/v1" + restapiv1.RouteMessageMetadata, nil
+
+	case strings.HasPrefix(topic, "messages/indexation/"):
+		if _, err := hex.DecodeString(strings.TrimPrefix(topic, "messages/indexation/")); err != nil {
+			return "", errors.WithMessagef(restapi.ErrInvalidParameter, "invalid index in topic: %s", topic)
+		}
+		return "/api/v1" + restapiv1.RouteMessages, nil
+
+	case messageIDFromTopic(topic) != nil:
+		return "/api/v1" + restapiv1.RouteMessageMetadata, nil
+
+	case transactionIDFromTopic(topic) != nil:
+		return "/api/v1" + restapiv1.RouteTransactionsIncludedMessage, nil
+
+	case outputIDFromTopic(topic) != nil:
+		return "/api/v1" + restapiv1.RouteOutput, nil
+
+	case topic == topicReceipts:
+		return "/api/v1" + restapiv1.RouteReceipts, nil
+
+	case addressFromTopic(topic, "addresses/ed25519/") != "":
+		return "/api/v1" + restapiv1.RouteAddressEd25519Outputs, nil
+
+	case addressFromTopic(topic, "addresses/") != "":
+		return "/api/v1" + restapiv1.RouteAddressBech32Outputs, nil
+
+	case promoterMessageIDFromTopic(topic) != nil:
+		return promoter.RouteGroupPrefix + promoter.RouteMessage, nil
+
+	default:
+		return "", errors.WithMessagef(restapi.ErrInvalidParameter, "unknown topic: %s", topic)
+	}
+}
+
+func addressFromTopic(topicName string, prefix string) string {
+	if strings.HasPrefix(topicName, prefix) && strings.HasSuffix(topicName, "/outputs") {
+		address := strings.TrimSuffix(strings.TrimPrefix(topicName, prefix), "/outputs")
+		if address == "" || strings.Contains(address, "/") {
+			return ""
+		}
+		return address
+	}
+	return ""
+}
+
+func parseSSETopics(c echo.Context) ([]string, error) {
+
+	topicsMap := make(map[string]struct{})
+	var topics []string
+	for _, param := range c.QueryParams()[QueryParameterTopics] {
+		for _, topic := range strings.Split(param, ",") {
+			topic = strings.TrimSpace(topic)
+			if topic == "" {
+				continue
+			}
+
+			if _, exists := topicsMap[topic]; exists {
+				continue
+			}
+			topicsMap[topic] = struct{}{}
+			topics = append(topics, topic)
+		}
+	}
+
+	if len(topics) == 0 {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "no topics given in query parameter: %s", QueryParameterTopics)
+	}
+
+	return topics, nil
+}
+
+func streamEvents(c echo.Context) error {
+
+	topics, err := parseSSETopics(c)
+	if err != nil {
+		return err
+	}
+
+	for _, topic := range topics {
+		route, err := restAPIRouteForTopic(topic)
+		if err != nil {
+			return err
+		}
+
+		if !deps.RestAPIRouteAuthorized(c, route) {
+			return errors.WithMessagef(echo.ErrForbidden, "access to topic denied: %s", topic)
+		}
+	}
+
+	var lastEventIndex *milestone.Index
+	if lastEventID := c.Request().Header.Get(headerLastEventID); lastEventID != "" {
+		index, err := strconv.ParseUint(lastEventID, 10, 32)
+		if err != nil {
+			return errors.WithMessagef(restapi.ErrInvalidParameter, "invalid %s: %s, error: %s", headerLastEventID, lastEventID, err)
+		}
+		msIndex := milestone.Index(index)
+		if cmi := deps.SyncManager.ConfirmedMilestoneIndex(); msIndex > cmi {
+			return errors.WithMessagef(restapi.ErrInvalidParameter, "invalid %s: %d is above the confirmed milestone index %d", headerLastEventID, msIndex, cmi)
+		}
+		lastEventIndex = &msIndex
+	}
+
+	resp := c.Response()
+	resp.Header().Set(echo.HeaderContentType, "text/event-stream")
+	resp.Header().Set("Cache-Control", "no-cache")
+	resp.Header().Set("Connection", "keep-alive")
+	// disable the response buffering of reverse proxies
+	resp.Header().Set("X-Accel-Buffering", "no")
+	resp.WriteHeader(http.StatusOK)
+
+	// subscribe before replaying the missed events, so no events get lost in between.
+	// events that were already replayed are skipped in the subscription.
+	subscription := deps.SSEBroker.Subscribe(topics...)
+	defer func() {
+		if dropped := deps.SSEBroker.Unsubscribe(subscription); dropped > 0 {
+			Plugin.LogDebugf("Dropped %d events for server-sent events client %s", dropped, c.RealIP())
+		}
+	}()
+
+	var replayedIndex milestone.Index
+	if lastEventIndex != nil {
+		replayedIndex, err = replayEvents(resp, topics, *lastEventIndex)
+		if err != nil {
+			// the client disconnected
+			return nil
+		}
+	}
+	resp.Flush()
+
+	keepAliveTicker := time.NewTicker(sseKeepAliveInterval)
+	defer keepAliveTicker.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+
+		case <-Plugin.Daemon().ContextStopped().Done():
+			return nil
+
+		case <-keepAliveTicker.C:
+			if _, err := fmt.Fprint(resp, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			resp.Flush()
+
+		case event := <-subscription.Events():
+			if isReplayedEvent(event, replayedIndex) {
+				continue
+			}
+			if _, err := event.WriteTo(resp); err != nil {
+				return nil
+			}
+			resp.Flush()
+		}
+	}
+}
+
+// isReplayedEvent tells whether the event of the subscription was already sent by replayEvents.
+func isReplayedEvent(event *sse.Event, replayedIndex milestone.Index) bool {
+	if event.Topic != topicMilestonesConfirmed && event.Topic != topicReceipts {
+		return false
+	}
+
+	index, err := strconv.ParseUint(event.ID, 10, 32)
+	if err != nil {
+		return false
+	}
+	return milestone.Index(index) <= replayedIndex
+}
+
+// replayEvents sends the confirmed milestones and receipts that were issued
+// after the milestone index of the last event the client received.
+// At most the last sseMaxReplay confirmed milestones are replayed.
+// Events of other topics can't be replayed, since they are not indexed by milestone.
+// It returns the highest replayed milestone index, or 0 if no events were replayed.
+func replayEvents(resp *echo.Response, topics []string, lastEventIndex milestone.Index) (milestone.Index, error) {
+
+	var replayMilestones, replayReceipts bool
+	for _, topic := range topics {
+		switch topic {
+		case topicMilestonesConfirmed:
+			replayMilestones = true
+		case topicReceipts:
+			replayReceipts = true
+		}
+	}
+
+	if !replayMilestones && !replayReceipts {
+		return 0, nil
+	}
+
+	endIndex := deps.SyncManager.ConfirmedMilestoneIndex()
+
+	startIndex := lastEventIndex + 1
+	if endIndex >= sseMaxReplay && startIndex <= endIndex-sseMaxReplay {
+		// limit the amount of replayed milestones
+		startIndex = endIndex - sseMaxReplay + 1
+	}
+	if snapshotInfo := deps.Storage.SnapshotInfo(); snapshotInfo != nil && startIndex <= snapshotInfo.PruningIndex {
+		// older milestones were already pruned
+		startIndex = snapshotInfo.PruningIndex + 1
+	}
+
+	if startIndex > endIndex {
+		return 0, nil
+	}
+
+	receiptsByIndex := make(map[milestone.Index][]*utxo.ReceiptTuple)
+	if replayReceipts {
+		if err := deps.Storage.UTXOManager().ForEachReceiptTuple(func(rt *utxo.ReceiptTuple) bool {
+			if rt.MilestoneIndex >= startIndex && rt.MilestoneIndex <= endIndex {
+				receiptsByIndex[rt.MilestoneIndex] = append(receiptsByIndex[rt.MilestoneIndex], rt)
+			}
+			return true
+		}); err != nil {
+			Plugin.LogWarnf("Replaying receipts for server-sent events failed: %s", err)
+		}
+	}
+
+	writeEvent := func(topic string, index milestone.Index, payload interface{}) error {
+		jsonPayload, err := json.Marshal(payload)
+		if err != nil {
+			Plugin.LogWarn(err)
+			return nil
+		}
+
+		event := &sse.Event{
+			ID:    strconv.FormatUint(uint64(index), 10),
+			Topic: topic,
+			Data:  jsonPayload,
+		}
+		_, err = event.WriteTo(resp)
+		return err
+	}
+
+	for index := startIndex; index <= endIndex; index++ {
+		if replayMilestones {
+			if cachedMs := deps.Storage.CachedMilestoneOrNil(index); cachedMs != nil { // milestone +1
+				payload := &milestonePayload{
+					Index: uint32(cachedMs.Milestone().Index),
+					Time:  cachedMs.Milestone().Timestamp.Unix(),
+				}
+				cachedMs.Release(true) // milestone -1
+
+				if err := writeEvent(topicMilestonesConfirmed, index, payload); err != nil {
+					return 0, err
+				}
+			}
+		}
+
+		receipts := receiptsByIndex[index]
+		sort.Slice(receipts, func(i, j int) bool {
+			return receipts[i].Receipt.MigratedAt < receipts[j].Receipt.MigratedAt
+		})
+		for _, rt := range receipts {
+			if err := writeEvent(topicReceipts, index, rt.Receipt); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return endIndex, nil
+}
@@ -18,4 +18,6 @@ const (
 
 	topicAddressesOutput        = "addresses/{address}/outputs"
 	topicAddressesEd25519Output = "addresses/ed25519/{address}/outputs"
+
+	topicPromoterMessages = "promoter/messages/{messageId}"
 )
@@ -12,12 +12,39 @@ import (
 	"github.com/gohornet/hornet/pkg/dag"
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/promoter"
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/model/utxo"
 	"github.com/iotaledger/hive.go/serializer"
 	iotago "github.com/iotaledger/iota.go/v2"
 )
 
+// hasSubscribers returns whether there are MQTT or SSE subscribers for the given topic.
+func hasSubscribers(topic string) bool {
+	return deps.MQTTBroker.HasSubscribers(topic) || deps.SSEBroker.HasSubscribers(topic)
+}
+
+// send publishes the JSON payload to the MQTT and SSE subscribers of the given topic.
+func send(topic string, payload []byte) {
+	if deps.MQTTBroker.HasSubscribers(topic) {
+		deps.MQTTBroker.Send(topic, payload)
+	}
+	if deps.SSEBroker.HasSubscribers(topic) {
+		deps.SSEBroker.Send(topic, payload)
+	}
+}
+
+// sendBinary publishes the binary payload to the MQTT and SSE subscribers of the given topic.
+// The payload is hex encoded for SSE subscribers, since server-sent events only support text.
+func sendBinary(topic string, payload []byte) {
+	if deps.MQTTBroker.HasSubscribers(topic) {
+		deps.MQTTBroker.Send(topic, payload)
+	}
+	if deps.SSEBroker.HasSubscribers(topic) {
+		deps.SSEBroker.Send(topic, []byte(hex.EncodeToString(payload)))
+	}
+}
+
 func publishOnTopic(topic string, payload interface{}) {
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
@@ -25,7 +52,7 @@ func publishOnTopic(topic string, payload interface{}) {
 		return
 	}
 
-	deps.MQTTBroker.Send(topic, jsonPayload)
+	send(topic, jsonPayload)
 }
 
 func publishConfirmedMilestone(cachedMs *storage.CachedMilestone) {
@@ -39,7 +66,7 @@ func publishLatestMilestone(cachedMs *storage.CachedMilestone) {
 }
 
 func publishMilestoneOnTopic(topic string, milestone *storage.Milestone) {
-	if deps.MQTTBroker.HasSubscribers(topic) {
+	if hasSubscribers(topic) {
 		publishOnTopic(topic, &milestonePayload{
 			Index: uint32(milestone.Index),
 			Time:  milestone.Timestamp.Unix(),
@@ -48,33 +75,40 @@ func publishMilestoneOnTopic(topic string, milestone *storage.Milestone) {
 }
 
 func publishReceipt(r *iotago.Receipt) {
-	if deps.MQTTBroker.HasSubscribers(topicReceipts) {
+	if hasSubscribers(topicReceipts) {
 		publishOnTopic(topicReceipts, r)
 	}
 }
 
+func publishPromoterMessageStatus(status *promoter.MessageStatusResponse) {
+	promoterTopic := strings.ReplaceAll(topicPromoterMessages, "{messageId}", status.MessageID)
+	if hasSubscribers(promoterTopic) {
+		publishOnTopic(promoterTopic, status)
+	}
+}
+
 func publishMessage(cachedMessage *storage.CachedMessage) {
 	defer cachedMessage.Release(true)
 
-	if deps.MQTTBroker.HasSubscribers(topicMessages) {
-		deps.MQTTBroker.Send(topicMessages, cachedMessage.Message().Data())
+	if hasSubscribers(topicMessages) {
+		sendBinary(topicMessages, cachedMessage.Message().Data())
 	}
 
 	indexation := cachedMessage.Message().Indexation()
 	if indexation != nil {
 		indexationTopic := strings.ReplaceAll(topicMessagesIndexation, "{index}", hex.EncodeToString(indexation.Index))
-		if deps.MQTTBroker.HasSubscribers(indexationTopic) {
-			deps.MQTTBroker.Send(indexationTopic, cachedMessage.Message().Data())
+		if hasSubscribers(indexationTopic) {
+			sendBinary(indexationTopic, cachedMessage.Message().Data())
 		}
 	}
 }
 
 func publishTransactionIncludedMessage(transactionID *iotago.TransactionID, messageID hornet.MessageID) {
 	transactionTopic := strings.ReplaceAll(topicTransactionsIncludedMessage, "{transactionId}", hex.EncodeToString(transactionID[:]))
-	if deps.MQTTBroker.HasSubscribers(transactionTopic) {
+	if hasSubscribers(transactionTopic) {
 		cachedMessage := deps.Storage.CachedMessageOrNil(messageID)
 		if cachedMessage != nil {
-			deps.MQTTBroker.Send(transactionTopic, cachedMessage.Message().Data())
+			sendBinary(transactionTopic, cachedMessage.Message().Data())
 			cachedMessage.Release(true)
 		}
 	}
@@ -87,9 +121,9 @@ func publishMessageMetadata(cachedMetadata *storage.CachedMetadata) {
 
 	messageID := metadata.MessageID().ToHex()
 	singleMessageTopic := strings.ReplaceAll(topicMessagesMetadata, "{messageId}", messageID)
-	hasSingleMessageTopicSubscriber := deps.MQTTBroker.HasSubscribers(singleMessageTopic)
+	hasSingleMessageTopicSubscriber := hasSubscribers(singleMessageTopic)
 
-	hasAllMessagesTopicSubscriber := deps.MQTTBroker.HasSubscribers(topicMessagesReferenced)
+	hasAllMessagesTopicSubscriber := hasSubscribers(topicMessagesReferenced)
 
 	if hasSingleMessageTopicSubscriber || hasAllMessagesTopicSubscriber {
 
@@ -171,10 +205,10 @@ func publishMessageMetadata(cachedMetadata *storage.CachedMetadata) {
 		}
 
 		if hasSingleMessageTopicSubscriber {
-			deps.MQTTBroker.Send(singleMessageTopic, jsonPayload)
+			send(singleMessageTopic, jsonPayload)
 		}
 		if hasAllMessagesTopicSubscriber {
-			deps.MQTTBroker.Send(topicMessagesReferenced, jsonPayload)
+			send(topicMessagesReferenced, jsonPayload)
 		}
 	}
 }
@@ -217,13 +251,13 @@ func payloadForOutput(ledgerIndex milestone.Index, output *utxo.Output, spent bo
 func publishOutput(ledgerIndex milestone.Index, output *utxo.Output, spent bool) {
 
 	outputsTopic := strings.ReplaceAll(topicOutputs, "{outputId}", output.OutputID().ToHex())
-	outputsTopicHasSubscribers := deps.MQTTBroker.HasSubscribers(outputsTopic)
+	outputsTopicHasSubscribers := hasSubscribers(outputsTopic)
 
 	addressBech32Topic := strings.ReplaceAll(topicAddressesOutput, "{address}", output.Address().Bech32(deps.Bech32HRP))
-	addressBech32TopicHasSubscribers := deps.MQTTBroker.HasSubscribers(addressBech32Topic)
+	addressBech32TopicHasSubscribers := hasSubscribers(addressBech32Topic)
 
 	addressEd25519Topic := strings.ReplaceAll(topicAddressesEd25519Output, "{address}", output.Address().String())
-	addressEd25519TopicHasSubscribers := deps.MQTTBroker.HasSubscribers(addressEd25519Topic)
+	addressEd25519TopicHasSubscribers := hasSubscribers(addressEd25519Topic)
 
 	if outputsTopicHasSubscribers || addressEd25519TopicHasSubscribers || addressBech32TopicHasSubscribers {
 		if payload := payloadForOutput(ledgerIndex, output, spent); payload != nil {
@@ -236,15 +270,15 @@ func publishOutput(ledgerIndex milestone.Index, output *utxo.Output, spent bool)
 			}
 
 			if outputsTopicHasSubscribers {
-				deps.MQTTBroker.Send(outputsTopic, jsonPayload)
+				send(outputsTopic, jsonPayload)
 			}
 
 			if addressBech32TopicHasSubscribers {
-				deps.MQTTBroker.Send(addressBech32Topic, jsonPayload)
+				send(addressBech32Topic, jsonPayload)
 			}
 
 			if addressEd25519TopicHasSubscribers {
-				deps.MQTTBroker.Send(addressEd25519Topic, jsonPayload)
+				send(addressEd25519Topic, jsonPayload)
 			}
 		}
 	}
@@ -273,6 +307,19 @@ func messageIDFromTopic(topicName string) hornet.MessageID {
 	return nil
 }
 
+func promoterMessageIDFromTopic(topicName string) hornet.MessageID {
+	if strings.HasPrefix(topicName, "promoter/messages/") {
+		messageIDHex := strings.Replace(topicName, "promoter/messages/", "", 1)
+
+		messageID, err := hornet.MessageIDFromHex(messageIDHex)
+		if err != nil {
+			return nil
+		}
+		return messageID
+	}
+	return nil
+}
+
 func transactionIDFromTopic(topicName string) *iotago.TransactionID {
 	if strings.HasPrefix(topicName, "transactions/") && strings.HasSuffix(topicName, "/included-message") {
 		transactionIDHex := strings.Replace(topicName, "transactions/", "", 1)
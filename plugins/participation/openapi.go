@@ -0,0 +1,114 @@
+package participation
+
+import (
+	"net/http"
+
+	"github.com/gohornet/hornet/pkg/model/participation"
+	"github.com/gohornet/hornet/pkg/openapi"
+	"github.com/gohornet/hornet/pkg/restapi"
+)
+
+const routeGroupPrefix = "/api/plugins/participation"
+
+var (
+	eventIDParam        = openapi.PathParameter(ParameterParticipationEventID, "The hex encoded event ID.")
+	outputIDParam       = openapi.PathParameter(restapi.ParameterOutputID, "The hex encoded output ID (transaction ID + output index).")
+	bech32AddressParam  = openapi.PathParameter(restapi.ParameterAddress, "The bech32 encoded address.")
+	ed25519AddressParam = openapi.PathParameter(restapi.ParameterAddress, "The hex encoded ed25519 address.")
+)
+
+// openAPIRoutes returns the descriptions of all routes of the plugin for the OpenAPI document.
+func openAPIRoutes() []*openapi.Route {
+	return []*openapi.Route{
+		{
+			Method:  http.MethodGet,
+			Path:    routeGroupPrefix + RouteParticipationEvents,
+			Summary: "Returns the IDs of all events known to the node.",
+			Parameters: []*openapi.Parameter{
+				openapi.QueryParameter("type", "Filters the events by the payload type. Can be repeated.", "integer", false),
+			},
+			Response: &EventsResponse{},
+		},
+		{
+			Method:         http.MethodPost,
+			Path:           routeGroupPrefix + RouteAdminCreateEvent,
+			Summary:        "Adds an event to track.",
+			Request:        &participation.Event{},
+			Response:       &CreateEventResponse{},
+			ResponseStatus: http.StatusCreated,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteParticipationEvent,
+			Summary:    "Returns an event without its current standings.",
+			Parameters: []*openapi.Parameter{eventIDParam},
+			Response:   &participation.Event{},
+		},
+		{
+			Method:         http.MethodDelete,
+			Path:           routeGroupPrefix + RouteAdminDeleteEvent,
+			Summary:        "Removes a tracked event.",
+			Parameters:     []*openapi.Parameter{eventIDParam},
+			ResponseStatus: http.StatusNoContent,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    routeGroupPrefix + RouteParticipationEventStatus,
+			Summary: "Returns the status of an event.",
+			Parameters: []*openapi.Parameter{
+				eventIDParam,
+				openapi.QueryParameter(restapi.ParameterMilestoneIndex, "Returns the status at the given milestone index.", "integer", false),
+			},
+			Response: &participation.EventStatus{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteOutputStatus,
+			Summary:    "Returns the participations of an output.",
+			Parameters: []*openapi.Parameter{outputIDParam},
+			Response:   &OutputStatusResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteAddressBech32Status,
+			Summary:    "Returns the staking rewards of a bech32 encoded address.",
+			Parameters: []*openapi.Parameter{bech32AddressParam},
+			Response:   &AddressRewardsResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteAddressEd25519Status,
+			Summary:    "Returns the staking rewards of an ed25519 address.",
+			Parameters: []*openapi.Parameter{ed25519AddressParam},
+			Response:   &AddressRewardsResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteAdminActiveParticipations,
+			Summary:    "Returns all active participations of an event.",
+			Parameters: []*openapi.Parameter{eventIDParam},
+			Response:   &ParticipationsResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteAdminPastParticipations,
+			Summary:    "Returns all past participations of an event.",
+			Parameters: []*openapi.Parameter{eventIDParam},
+			Response:   &ParticipationsResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteAdminRewards,
+			Summary:    "Returns the rewards of a staking event.",
+			Parameters: []*openapi.Parameter{eventIDParam},
+			Response:   &RewardsResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteAdminResults,
+			Summary:    "Returns the final results of an event at its end milestone index.",
+			Parameters: []*openapi.Parameter{eventIDParam},
+			Response:   &participation.ResultsExport{},
+		},
+	}
+}
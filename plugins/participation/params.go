@@ -0,0 +1,29 @@
+package participation
+
+import (
+	flag "github.com/spf13/pflag"
+
+	"github.com/gohornet/hornet/pkg/node"
+)
+
+const (
+	// whether the node imports the events announced in the event registry
+	CfgParticipationRegistryEnabled = "participation.registry.enabled"
+	// the indexation of the event registry messages
+	CfgParticipationRegistryIndexation = "participation.registry.indexation"
+	// the ed25519 public keys (hex) that are allowed to sign event announcements of the registry
+	CfgParticipationRegistryPublicKeys = "participation.registry.publicKeys"
+)
+
+var params = &node.PluginParams{
+	Params: map[string]*flag.FlagSet{
+		"nodeConfig": func() *flag.FlagSet {
+			fs := flag.NewFlagSet("", flag.ContinueOnError)
+			fs.Bool(CfgParticipationRegistryEnabled, false, "whether the node imports the events announced in the event registry")
+			fs.String(CfgParticipationRegistryIndexation, "PARTICIPATION-REGISTRY", "the indexation of the event registry messages")
+			fs.StringSlice(CfgParticipationRegistryPublicKeys, []string{}, "the ed25519 public keys (hex) that are allowed to sign event announcements of the registry")
+			return fs
+		}(),
+	},
+	Masked: nil,
+}
@@ -4,7 +4,6 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -21,19 +20,7 @@ import (
 
 // EventIDFromHex creates a EventID from a hex string representation.
 func EventIDFromHex(hexString string) (participation.EventID, error) {
-
-	b, err := hex.DecodeString(hexString)
-	if err != nil {
-		return participation.NullEventID, err
-	}
-
-	if len(b) != participation.EventIDLength {
-		return participation.NullEventID, fmt.Errorf("unknown eventID length (%d)", len(b))
-	}
-
-	var eventID participation.EventID
-	copy(eventID[:], b)
-	return eventID, nil
+	return participation.EventIDFromHex(hexString)
 }
 
 func parseEventTypeQueryParam(c echo.Context) ([]uint32, error) {
@@ -53,6 +40,7 @@ func parseEventTypeQueryParam(c echo.Context) ([]uint32, error) {
 		switch eventType {
 		case participation.BallotPayloadTypeID:
 		case participation.StakingPayloadTypeID:
+		case participation.ChoiceBallotPayloadTypeID:
 		default:
 			return []uint32{}, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid event type: %s", typeParam)
 		}
@@ -327,6 +315,32 @@ func getRewards(c echo.Context) (*RewardsResponse, error) {
 	return response, nil
 }
 
+func getResults(c echo.Context) (*participation.ResultsExport, error) {
+	eventID, err := parseEventIDParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// the results are signed with the identity key of the node
+	nodePrivateKey, err := deps.NodePrivateKey.Raw()
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading the node identity key failed: %s", err)
+	}
+
+	export, err := deps.ParticipationManager.ExportResults(eventID, nodePrivateKey)
+	if err != nil {
+		if errors.Is(err, participation.ErrEventNotFound) {
+			return nil, errors.WithMessagef(echo.ErrNotFound, "event not found: %s", hex.EncodeToString(eventID[:]))
+		}
+		if errors.Is(err, participation.ErrEventNotEnded) {
+			return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "event did not end yet: %s", hex.EncodeToString(eventID[:]))
+		}
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "exporting results failed: %s", err)
+	}
+
+	return export, nil
+}
+
 func getActiveParticipations(c echo.Context) (*ParticipationsResponse, error) {
 	eventID, err := parseEventIDParam(c)
 	if err != nil {
@@ -2,27 +2,38 @@ package participation
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"path/filepath"
 
 	"github.com/labstack/echo/v4"
+	"github.com/libp2p/go-libp2p-core/crypto"
 	"go.uber.org/dig"
 
 	"github.com/gohornet/hornet/pkg/database"
+	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/participation"
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/model/syncmanager"
 	"github.com/gohornet/hornet/pkg/model/utxo"
 	"github.com/gohornet/hornet/pkg/node"
+	"github.com/gohornet/hornet/pkg/openapi"
 	"github.com/gohornet/hornet/pkg/restapi"
 	"github.com/gohornet/hornet/pkg/shutdown"
 	"github.com/gohornet/hornet/pkg/tangle"
+	"github.com/gohornet/hornet/pkg/utils"
 	restapiv1 "github.com/gohornet/hornet/plugins/restapi/v1"
 	"github.com/iotaledger/hive.go/configuration"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/workerpool"
 	iotago "github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+)
+
+const (
+	registryWorkerQueueSize = 1000
 )
 
 const (
@@ -73,6 +84,10 @@ const (
 	// RouteAdminRewards is the route the node operator can use to get the rewards for a staking event.
 	// GET retrieves the staking event rewards.
 	RouteAdminRewards = "/admin/events/:" + ParameterParticipationEventID + "/rewards"
+
+	// RouteAdminResults is the route the node operator can use to export the final results of an event.
+	// GET returns the final answer tally or reward list at the end milestone index of the event, together with a hash over the results.
+	RouteAdminResults = "/admin/events/:" + ParameterParticipationEventID + "/results"
 )
 
 func init() {
@@ -81,6 +96,7 @@ func init() {
 		Pluggable: node.Pluggable{
 			Name:      "Participation",
 			DepsFunc:  func(cDeps dependencies) { deps = cDeps },
+			Params:    params,
 			Provide:   provide,
 			Configure: configure,
 			Run:       run,
@@ -95,6 +111,8 @@ var (
 	onUTXOOutput                     *events.Closure
 	onUTXOSpent                      *events.Closure
 	onConfirmedMilestoneIndexChanged *events.Closure
+
+	registryWorkerPool *workerpool.WorkerPool
 )
 
 type dependencies struct {
@@ -104,8 +122,10 @@ type dependencies struct {
 	SyncManager          *syncmanager.SyncManager
 	Tangle               *tangle.Tangle
 	Echo                 *echo.Echo
+	OpenAPIRegistry      *openapi.Registry
 	Bech32HRP            iotago.NetworkPrefix `name:"bech32HRP"`
 	ShutdownHandler      *shutdown.ShutdownHandler
+	NodePrivateKey       crypto.PrivKey `name:"nodePrivateKey"`
 }
 
 func provide(c *dig.Container) {
@@ -121,15 +141,32 @@ func provide(c *dig.Container) {
 
 	if err := c.Provide(func(deps participationDeps) *participation.ParticipationManager {
 
-		participationStore, err := database.StoreWithDefaultSettings(filepath.Join(deps.DatabasePath, "participation"), true, deps.DatabaseEngine)
+		participationStore, err := database.StoreWithDefaultSettings(filepath.Join(deps.DatabasePath, participation.DatabaseDirectoryName), true, deps.DatabaseEngine)
 		if err != nil {
 			Plugin.LogPanic(err)
 		}
 
+		var opts []participation.Option
+		if deps.NodeConfig.Bool(CfgParticipationRegistryEnabled) {
+			var publicKeys []ed25519.PublicKey
+			for _, key := range deps.NodeConfig.Strings(CfgParticipationRegistryPublicKeys) {
+				publicKey, err := utils.ParseEd25519PublicKeyFromString(key)
+				if err != nil {
+					Plugin.LogPanicf("invalid registry public key %s: %s", key, err)
+				}
+				publicKeys = append(publicKeys, publicKey)
+			}
+			if len(publicKeys) == 0 {
+				Plugin.LogPanic("the event registry needs at least one public key")
+			}
+			opts = append(opts, participation.WithRegistry(deps.NodeConfig.String(CfgParticipationRegistryIndexation), publicKeys))
+		}
+
 		pm, err := participation.NewManager(
 			deps.Storage,
 			deps.SyncManager,
 			participationStore,
+			opts...,
 		)
 		if err != nil {
 			Plugin.LogPanic(err)
@@ -143,7 +180,7 @@ func provide(c *dig.Container) {
 func configure() {
 	restapiv1.AddFeature(Plugin.Name)
 
-	routeGroup := deps.Echo.Group("/api/plugins/participation")
+	routeGroup := deps.Echo.Group(routeGroupPrefix)
 
 	routeGroup.GET(RouteParticipationEvents, func(c echo.Context) error {
 		resp, err := getEvents(c)
@@ -238,6 +275,16 @@ func configure() {
 		return restapi.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.GET(RouteAdminResults, func(c echo.Context) error {
+		resp, err := getResults(c)
+		if err != nil {
+			return err
+		}
+		return restapi.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	deps.OpenAPIRegistry.Register(openAPIRoutes()...)
+
 	if err := Plugin.Node.Daemon().BackgroundWorker("Close Participation database", func(ctx context.Context) {
 		<-ctx.Done()
 
@@ -250,16 +297,43 @@ func configure() {
 		Plugin.LogPanicf("failed to start worker: %s", err)
 	}
 
+	if deps.ParticipationManager.RegistryEnabled() {
+		registryWorkerPool = workerpool.New(func(task workerpool.Task) {
+			importRegistryEvents(task.Param(0).(milestone.Index))
+			task.Return(nil)
+		}, workerpool.WorkerCount(1), workerpool.QueueSize(registryWorkerQueueSize), workerpool.FlushTasksAtShutdown(true))
+	}
+
 	configureEvents()
 }
 
+// importRegistryEvents imports the events announced in the registry for all milestones that were not searched yet.
+// Failed milestones are searched again on the next confirmed milestone.
+func importRegistryEvents(confirmedMilestoneIndex milestone.Index) {
+	if err := deps.ParticipationManager.ImportRegistryEventsUntil(confirmedMilestoneIndex, func(eventID participation.EventID) {
+		Plugin.LogInfof("imported event %s from the registry", hex.EncodeToString(eventID[:]))
+	}, func(messageID hornet.MessageID, err error) {
+		Plugin.LogWarnf("rejected event announcement in message %s: %s", messageID.ToHex(), err)
+	}); err != nil {
+		Plugin.LogWarn(err)
+	}
+}
+
 func run() {
 	// create a background worker that handles the participation events
 	if err := Plugin.Daemon().BackgroundWorker("Participation", func(ctx context.Context) {
 		Plugin.LogInfo("Starting Participation ... done")
+		if registryWorkerPool != nil {
+			registryWorkerPool.Start()
+			// search the milestones that were confirmed while the node was offline
+			registryWorkerPool.TrySubmit(deps.SyncManager.ConfirmedMilestoneIndex())
+		}
 		attachEvents()
 		<-ctx.Done()
 		detachEvents()
+		if registryWorkerPool != nil {
+			registryWorkerPool.StopAndWait()
+		}
 		Plugin.LogInfo("Stopping Participation ... done")
 	}, shutdown.PriorityParticipation); err != nil {
 		Plugin.LogPanicf("failed to start worker: %s", err)
@@ -284,6 +358,11 @@ func configureEvents() {
 		if err := deps.ParticipationManager.ApplyNewConfirmedMilestoneIndex(index); err != nil {
 			deps.ShutdownHandler.SelfShutdown(fmt.Sprintf("participation plugin hit a critical error while applying new confirmed milestone index: %s", err.Error()))
 		}
+
+		if registryWorkerPool != nil {
+			// the events are imported asynchronously, since the ledger is locked during the confirmation
+			registryWorkerPool.TrySubmit(index)
+		}
 	})
 }
 
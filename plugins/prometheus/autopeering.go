@@ -0,0 +1,112 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/hive.go/autopeering/discover"
+	"github.com/iotaledger/hive.go/autopeering/selection"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+)
+
+var (
+	autopeeringKnownPeers      prometheus.Gauge
+	autopeeringNeighbors       *prometheus.GaugeVec
+	autopeeringDiscoveryEvents *prometheus.CounterVec
+	autopeeringSelectionEvents *prometheus.CounterVec
+)
+
+func configureAutopeering() {
+
+	autopeeringKnownPeers = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "iota",
+			Subsystem: "autopeering",
+			Name:      "known_peers",
+			Help:      "Number of verified peers known by the peer discovery.",
+		})
+
+	autopeeringNeighbors = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "iota",
+			Subsystem: "autopeering",
+			Name:      "neighbors",
+			Help:      "Number of neighbors chosen by the peer selection.",
+		},
+		[]string{"direction"},
+	)
+
+	autopeeringDiscoveryEvents = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "iota",
+			Subsystem: "autopeering",
+			Name:      "discovery_events_count",
+			Help:      "The count of peer discovery events.",
+		},
+		[]string{"type"},
+	)
+
+	autopeeringSelectionEvents = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "iota",
+			Subsystem: "autopeering",
+			Name:      "selection_events_count",
+			Help:      "The count of peer selection events.",
+		},
+		[]string{"type"},
+	)
+
+	registry.MustRegister(autopeeringKnownPeers)
+	registry.MustRegister(autopeeringNeighbors)
+	registry.MustRegister(autopeeringDiscoveryEvents)
+	registry.MustRegister(autopeeringSelectionEvents)
+
+	deps.AutopeeringManager.Discovery().Events().PeerDiscovered.Attach(events.NewClosure(func(_ *discover.DiscoveredEvent) {
+		autopeeringDiscoveryEvents.WithLabelValues("discovered").Inc()
+	}))
+
+	deps.AutopeeringManager.Discovery().Events().PeerDeleted.Attach(events.NewClosure(func(_ *discover.DeletedEvent) {
+		autopeeringDiscoveryEvents.WithLabelValues("deleted").Inc()
+	}))
+
+	if deps.AutopeeringManager.Selection() != nil {
+		deps.AutopeeringManager.Selection().Events().OutgoingPeering.Attach(events.NewClosure(func(ev *selection.PeeringEvent) {
+			if !ev.Status {
+				autopeeringSelectionEvents.WithLabelValues("denied").Inc()
+				return
+			}
+			autopeeringSelectionEvents.WithLabelValues("chosen").Inc()
+		}))
+
+		deps.AutopeeringManager.Selection().Events().IncomingPeering.Attach(events.NewClosure(func(ev *selection.PeeringEvent) {
+			if !ev.Status {
+				return
+			}
+			autopeeringSelectionEvents.WithLabelValues("accepted").Inc()
+		}))
+
+		deps.AutopeeringManager.Selection().Events().Dropped.Attach(events.NewClosure(func(_ *selection.DroppedEvent) {
+			autopeeringSelectionEvents.WithLabelValues("dropped").Inc()
+		}))
+
+		deps.AutopeeringManager.Selection().Events().SaltUpdated.Attach(events.NewClosure(func(_ *selection.SaltUpdatedEvent) {
+			autopeeringSelectionEvents.WithLabelValues("salt_updated").Inc()
+		}))
+	}
+
+	deps.AutopeeringManager.Events.NeighborRejected.Attach(events.NewClosure(func(_ identity.ID, _ error) {
+		autopeeringSelectionEvents.WithLabelValues("rejected").Inc()
+	}))
+
+	addCollect(collectAutopeering)
+}
+
+func collectAutopeering() {
+	autopeeringKnownPeers.Set(float64(len(deps.AutopeeringManager.Discovery().GetVerifiedPeers())))
+
+	autopeeringNeighbors.Reset()
+	if deps.AutopeeringManager.Selection() != nil {
+		autopeeringNeighbors.WithLabelValues("incoming").Set(float64(len(deps.AutopeeringManager.Selection().GetIncomingNeighbors())))
+		autopeeringNeighbors.WithLabelValues("outgoing").Set(float64(len(deps.AutopeeringManager.Selection().GetOutgoingNeighbors())))
+	}
+}
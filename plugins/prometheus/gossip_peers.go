@@ -10,6 +10,8 @@ var (
 	gossipPeersHeartbeats     *prometheus.GaugeVec
 	gossipPeersDroppedPackets *prometheus.GaugeVec
 	gossipPeersConnected      *prometheus.GaugeVec
+	gossipPeersReputation     *prometheus.GaugeVec
+	gossipPeersRequestLatency *prometheus.GaugeVec
 )
 
 func configureGossipPeers() {
@@ -64,11 +66,33 @@ func configureGossipPeers() {
 		[]string{"address", "alias", "id"},
 	)
 
+	gossipPeersReputation = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "iota",
+			Subsystem: "gossip_peers",
+			Name:      "reputation_score",
+			Help:      "Reputation score by peer.",
+		},
+		[]string{"address", "alias", "id"},
+	)
+
+	gossipPeersRequestLatency = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "iota",
+			Subsystem: "gossip_peers",
+			Name:      "request_latency_seconds",
+			Help:      "Average time a peer needed to answer a request.",
+		},
+		[]string{"address", "alias", "id"},
+	)
+
 	registry.MustRegister(gossipPeersMessages)
 	registry.MustRegister(gossipPeersRequests)
 	registry.MustRegister(gossipPeersHeartbeats)
 	registry.MustRegister(gossipPeersDroppedPackets)
 	registry.MustRegister(gossipPeersConnected)
+	registry.MustRegister(gossipPeersReputation)
+	registry.MustRegister(gossipPeersRequestLatency)
 
 	addCollect(collectGossipPeers)
 }
@@ -79,6 +103,8 @@ func collectGossipPeers() {
 	gossipPeersHeartbeats.Reset()
 	gossipPeersDroppedPackets.Reset()
 	gossipPeersConnected.Reset()
+	gossipPeersReputation.Reset()
+	gossipPeersRequestLatency.Reset()
 
 	for _, peer := range deps.PeeringManager.PeerInfoSnapshots() {
 
@@ -97,6 +123,11 @@ func collectGossipPeers() {
 			}
 		}
 
+		gossipPeersReputation.With(peerLabels).Set(float64(deps.ReputationManager.Score(peer.Peer.ID)))
+
+		requestStats := deps.RequestQueue.PeerStats(peer.Peer.ID)
+		gossipPeersRequestLatency.With(peerLabels).Set(requestStats.AvgLatency.Seconds())
+
 		gossipProto := deps.GossipService.Protocol(peer.Peer.ID)
 		if gossipProto == nil {
 			continue
@@ -111,6 +142,9 @@ func collectGossipPeers() {
 		gossipPeersRequests.With(getLabels("received_milestone")).Set(float64(gossipProto.Metrics.ReceivedMilestoneRequests.Load()))
 		gossipPeersRequests.With(getLabels("sent_message")).Set(float64(gossipProto.Metrics.SentMessageRequests.Load()))
 		gossipPeersRequests.With(getLabels("sent_milestone")).Set(float64(gossipProto.Metrics.SentMilestoneRequests.Load()))
+		gossipPeersRequests.With(getLabels("in_flight")).Set(float64(requestStats.InFlight))
+		gossipPeersRequests.With(getLabels("answered")).Set(float64(requestStats.Answered))
+		gossipPeersRequests.With(getLabels("timed_out")).Set(float64(requestStats.TimedOut))
 
 		gossipPeersHeartbeats.With(getLabels("received")).Set(float64(gossipProto.Metrics.ReceivedHeartbeats.Load()))
 		gossipPeersHeartbeats.With(getLabels("sent")).Set(float64(gossipProto.Metrics.SentHeartbeats.Load()))
@@ -31,6 +31,8 @@ const (
 	CfgPrometheusCoordinator = "prometheus.coordinatorMetrics"
 	// include MQTT broker metrics.
 	CfgPrometheusMQTTBroker = "prometheus.mqttBrokerMetrics"
+	// include autopeering metrics.
+	CfgPrometheusAutopeering = "prometheus.autopeeringMetrics"
 	// include debug metrics.
 	CfgPrometheusDebug = "prometheus.debugMetrics"
 	// include go metrics.
@@ -57,6 +59,7 @@ var params = &node.PluginParams{
 			fs.Bool(CfgPrometheusMigration, true, "include migration metrics")
 			fs.Bool(CfgPrometheusCoordinator, true, "include coordinator metrics")
 			fs.Bool(CfgPrometheusMQTTBroker, true, "include MQTT broker metrics")
+			fs.Bool(CfgPrometheusAutopeering, true, "include autopeering metrics")
 			fs.Bool(CfgPrometheusDebug, false, "include debug metrics")
 			fs.Bool(CfgPrometheusGoMetrics, false, "include go metrics")
 			fs.Bool(CfgPrometheusProcessMetrics, false, "include process metrics")
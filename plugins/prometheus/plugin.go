@@ -28,6 +28,7 @@ import (
 	"github.com/gohornet/hornet/pkg/mqtt"
 	"github.com/gohornet/hornet/pkg/node"
 	"github.com/gohornet/hornet/pkg/p2p"
+	"github.com/gohornet/hornet/pkg/p2p/autopeering"
 	"github.com/gohornet/hornet/pkg/protocol/gossip"
 	"github.com/gohornet/hornet/pkg/shutdown"
 	"github.com/gohornet/hornet/pkg/snapshot"
@@ -78,6 +79,7 @@ type dependencies struct {
 	UTXODatabaseMetrics   *metrics.DatabaseMetrics `name:"utxoDatabaseMetrics"`
 	RestAPIMetrics        *metrics.RestAPIMetrics  `optional:"true"`
 	GossipService         *gossip.Service
+	ReputationManager     *gossip.ReputationManager
 	ReceiptService        *migrator.ReceiptService `optional:"true"`
 	Tangle                *tangle.Tangle
 	MigratorService       *migrator.MigratorService `optional:"true"`
@@ -86,8 +88,9 @@ type dependencies struct {
 	MessageProcessor      *gossip.MessageProcessor
 	TipSelector           *tipselect.TipSelector `optional:"true"`
 	SnapshotManager       *snapshot.SnapshotManager
-	Coordinator           *coordinator.Coordinator `optional:"true"`
-	MQTTBroker            *mqtt.Broker             `optional:"true"`
+	Coordinator           *coordinator.Coordinator        `optional:"true"`
+	MQTTBroker            *mqtt.Broker                    `optional:"true"`
+	AutopeeringManager    *autopeering.AutopeeringManager `optional:"true"`
 }
 
 func configure() {
@@ -123,6 +126,9 @@ func configure() {
 	if deps.NodeConfig.Bool(CfgPrometheusMQTTBroker) && deps.MQTTBroker != nil {
 		configureMQTTBroker()
 	}
+	if deps.NodeConfig.Bool(CfgPrometheusAutopeering) && deps.AutopeeringManager != nil && deps.AutopeeringManager.Discovery() != nil {
+		configureAutopeering()
+	}
 	if deps.NodeConfig.Bool(CfgPrometheusDebug) {
 		configureDebug()
 	}
@@ -5,7 +5,8 @@ import (
 )
 
 var (
-	restapiHTTPErrorCount prometheus.Gauge
+	restapiHTTPErrorCount       prometheus.Gauge
+	restapiHTTPRateLimitedCount *prometheus.GaugeVec
 )
 
 func configureRestAPI() {
@@ -18,11 +19,25 @@ func configureRestAPI() {
 		},
 	)
 
+	restapiHTTPRateLimitedCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "iota",
+			Subsystem: "restapi",
+			Name:      "http_request_rate_limited_count",
+			Help:      "The amount of HTTP requests that were rejected by the rate limiter.",
+		},
+		[]string{"budget"},
+	)
+
 	registry.MustRegister(restapiHTTPErrorCount)
+	registry.MustRegister(restapiHTTPRateLimitedCount)
 
 	addCollect(collectRestAPI)
 }
 
 func collectRestAPI() {
 	restapiHTTPErrorCount.Set(float64(deps.RestAPIMetrics.HTTPRequestErrorCounter.Load()))
+	restapiHTTPRateLimitedCount.WithLabelValues("ip").Set(float64(deps.RestAPIMetrics.HTTPRequestRateLimitedIPCounter.Load()))
+	restapiHTTPRateLimitedCount.WithLabelValues("jwt").Set(float64(deps.RestAPIMetrics.HTTPRequestRateLimitedJWTCounter.Load()))
+	restapiHTTPRateLimitedCount.WithLabelValues("expensive").Set(float64(deps.RestAPIMetrics.HTTPRequestRateLimitedExpensiveCounter.Load()))
 }
@@ -0,0 +1,45 @@
+package promoter
+
+import (
+	"net/http"
+
+	"github.com/gohornet/hornet/pkg/model/promoter"
+	"github.com/gohornet/hornet/pkg/openapi"
+	"github.com/gohornet/hornet/pkg/restapi"
+)
+
+var messageIDParam = openapi.PathParameter(restapi.ParameterMessageID, "The hex encoded message ID.")
+
+// openAPIRoutes returns the descriptions of all routes of the plugin for the OpenAPI document.
+func openAPIRoutes() []*openapi.Route {
+	return []*openapi.Route{
+		{
+			Method:   http.MethodGet,
+			Path:     promoter.RouteGroupPrefix + promoter.RouteMessages,
+			Summary:  "Returns the status of all registered messages.",
+			Response: &messageStatusesResponse{},
+		},
+		{
+			Method:         http.MethodPost,
+			Path:           promoter.RouteGroupPrefix + promoter.RouteMessages,
+			Summary:        "Registers a message to get promoted or reattached until it is referenced by a milestone.",
+			Request:        &registerMessageRequest{},
+			Response:       &promoter.MessageStatusResponse{},
+			ResponseStatus: http.StatusAccepted,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       promoter.RouteGroupPrefix + promoter.RouteMessage,
+			Summary:    "Returns the status of a registered message.",
+			Parameters: []*openapi.Parameter{messageIDParam},
+			Response:   &promoter.MessageStatusResponse{},
+		},
+		{
+			Method:         http.MethodDelete,
+			Path:           promoter.RouteGroupPrefix + promoter.RouteMessage,
+			Summary:        "Removes a message from the promoter.",
+			Parameters:     []*openapi.Parameter{messageIDParam},
+			ResponseStatus: http.StatusNoContent,
+		},
+	}
+}
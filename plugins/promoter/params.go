@@ -0,0 +1,34 @@
+package promoter
+
+import (
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/gohornet/hornet/pkg/node"
+)
+
+const (
+	// the maximum amount of messages that can be registered at the same time.
+	CfgPromoterMaxMessages = "promoter.maxMessages"
+	// how often a message is reattached before the promoter gives up.
+	CfgPromoterMaxReattachments = "promoter.maxReattachments"
+	// how long the status of referenced or failed messages is kept.
+	CfgPromoterRetention = "promoter.retention"
+	// the amount of workers used for calculating PoW when issuing promotions and reattachments.
+	CfgPromoterPoWWorkerCount = "promoter.powWorkerCount"
+)
+
+var params = &node.PluginParams{
+	Params: map[string]*flag.FlagSet{
+		"nodeConfig": func() *flag.FlagSet {
+			fs := flag.NewFlagSet("", flag.ContinueOnError)
+			fs.Int(CfgPromoterMaxMessages, 1000, "the maximum amount of messages that can be registered at the same time")
+			fs.Int(CfgPromoterMaxReattachments, 10, "how often a message is reattached before the promoter gives up")
+			fs.Duration(CfgPromoterRetention, 1*time.Hour, "how long the status of referenced or failed messages is kept")
+			fs.Int(CfgPromoterPoWWorkerCount, 0, "the amount of workers used for calculating PoW when issuing promotions and reattachments")
+			return fs
+		}(),
+	},
+	Masked: nil,
+}
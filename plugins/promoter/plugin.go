@@ -0,0 +1,170 @@
+package promoter
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/dig"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/promoter"
+	"github.com/gohornet/hornet/pkg/model/storage"
+	"github.com/gohornet/hornet/pkg/model/syncmanager"
+	"github.com/gohornet/hornet/pkg/node"
+	"github.com/gohornet/hornet/pkg/openapi"
+	"github.com/gohornet/hornet/pkg/pow"
+	"github.com/gohornet/hornet/pkg/protocol/gossip"
+	"github.com/gohornet/hornet/pkg/restapi"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/pkg/tangle"
+	"github.com/gohornet/hornet/pkg/tipselect"
+	restapiv1 "github.com/gohornet/hornet/plugins/restapi/v1"
+	"github.com/gohornet/hornet/plugins/urts"
+	"github.com/iotaledger/hive.go/configuration"
+	"github.com/iotaledger/hive.go/events"
+)
+
+func init() {
+	Plugin = &node.Plugin{
+		Status: node.StatusDisabled,
+		Pluggable: node.Pluggable{
+			Name:      "Promoter",
+			DepsFunc:  func(cDeps dependencies) { deps = cDeps },
+			Params:    params,
+			Provide:   provide,
+			Configure: configure,
+			Run:       run,
+		},
+	}
+}
+
+var (
+	Plugin *node.Plugin
+	deps   dependencies
+
+	// Closures
+	onConfirmedMilestoneIndexChanged *events.Closure
+)
+
+type dependencies struct {
+	dig.In
+	Promoter        *promoter.Promoter
+	Tangle          *tangle.Tangle
+	Echo            *echo.Echo
+	OpenAPIRegistry *openapi.Registry
+}
+
+func provide(c *dig.Container) {
+
+	// check if URTS plugin is disabled
+	if Plugin.Node.IsSkipped(urts.Plugin) {
+		Plugin.LogPanic("URTS plugin needs to be enabled to use the Promoter plugin")
+	}
+
+	type promoterDeps struct {
+		dig.In
+		Storage                               *storage.Storage
+		SyncManager                           *syncmanager.SyncManager
+		PowHandler                            *pow.Handler
+		TipSelector                           *tipselect.TipSelector
+		MessageProcessor                      *gossip.MessageProcessor
+		NodeConfig                            *configuration.Configuration `name:"nodeConfig"`
+		NetworkID                             uint64                       `name:"networkId"`
+		BelowMaxDepth                         int                          `name:"belowMaxDepth"`
+		MaxDeltaMsgYoungestConeRootIndexToCMI int                          `name:"maxDeltaMsgYoungestConeRootIndexToCMI"`
+		MaxDeltaMsgOldestConeRootIndexToCMI   int                          `name:"maxDeltaMsgOldestConeRootIndexToCMI"`
+	}
+
+	if err := c.Provide(func(deps promoterDeps) *promoter.Promoter {
+		return promoter.New(
+			deps.Storage,
+			deps.SyncManager,
+			deps.NetworkID,
+			deps.BelowMaxDepth,
+			deps.MaxDeltaMsgYoungestConeRootIndexToCMI,
+			deps.MaxDeltaMsgOldestConeRootIndexToCMI,
+			deps.TipSelector.SelectNonLazyTips,
+			deps.PowHandler,
+			deps.MessageProcessor.Emit,
+			promoter.WithLogger(Plugin.Logger()),
+			promoter.WithMaxMessages(deps.NodeConfig.Int(CfgPromoterMaxMessages)),
+			promoter.WithMaxReattachments(deps.NodeConfig.Int(CfgPromoterMaxReattachments)),
+			promoter.WithRetention(deps.NodeConfig.Duration(CfgPromoterRetention)),
+			promoter.WithPowWorkerCount(deps.NodeConfig.Int(CfgPromoterPoWWorkerCount)),
+		)
+	}); err != nil {
+		Plugin.LogPanic(err)
+	}
+}
+
+func configure() {
+	restapiv1.AddFeature(Plugin.Name)
+
+	routeGroup := deps.Echo.Group(promoter.RouteGroupPrefix)
+
+	routeGroup.GET(promoter.RouteMessages, func(c echo.Context) error {
+		resp, err := messageStatuses(c)
+		if err != nil {
+			return err
+		}
+
+		return restapi.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.POST(promoter.RouteMessages, func(c echo.Context) error {
+		resp, err := registerMessage(c)
+		if err != nil {
+			return err
+		}
+
+		return restapi.JSONResponse(c, http.StatusAccepted, resp)
+	})
+
+	routeGroup.GET(promoter.RouteMessage, func(c echo.Context) error {
+		resp, err := messageStatus(c)
+		if err != nil {
+			return err
+		}
+
+		return restapi.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.DELETE(promoter.RouteMessage, func(c echo.Context) error {
+		if err := deregisterMessage(c); err != nil {
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	deps.OpenAPIRegistry.Register(openAPIRoutes()...)
+
+	configureEvents()
+}
+
+func run() {
+	// create a background worker that promotes and reattaches the registered messages
+	if err := Plugin.Daemon().BackgroundWorker("Promoter", func(ctx context.Context) {
+		attachEvents()
+		deps.Promoter.RunPromoterLoop(ctx)
+		detachEvents()
+	}, shutdown.PriorityPromoter); err != nil {
+		Plugin.LogPanicf("failed to start worker: %s", err)
+	}
+}
+
+func configureEvents() {
+	onConfirmedMilestoneIndexChanged = events.NewClosure(func(_ milestone.Index) {
+		// the laziness of the registered messages only changes with new confirmed milestones
+		deps.Promoter.TriggerCheck()
+	})
+}
+
+func attachEvents() {
+	deps.Tangle.Events.ConfirmedMilestoneIndexChanged.Attach(onConfirmedMilestoneIndexChanged)
+}
+
+func detachEvents() {
+	deps.Tangle.Events.ConfirmedMilestoneIndexChanged.Detach(onConfirmedMilestoneIndexChanged)
+}
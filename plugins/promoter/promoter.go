@@ -0,0 +1,86 @@
+package promoter
+
+import (
+	"sort"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/common"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/promoter"
+	"github.com/gohornet/hornet/pkg/restapi"
+)
+
+// maps the errors of the promoter to REST API errors.
+func promoterError(err error) error {
+	switch {
+	case errors.Is(err, promoter.ErrMessageNotFound), errors.Is(err, promoter.ErrMessageNotRegistered):
+		return errors.WithMessage(echo.ErrNotFound, err.Error())
+	case errors.Is(err, promoter.ErrMessageAlreadyRegistered):
+		return errors.WithMessage(restapi.ErrInvalidParameter, err.Error())
+	case errors.Is(err, promoter.ErrMaxMessagesReached), errors.Is(err, common.ErrNodeNotSynced):
+		return errors.WithMessage(echo.ErrServiceUnavailable, err.Error())
+	default:
+		return err
+	}
+}
+
+func registerMessage(c echo.Context) (*promoter.MessageStatusResponse, error) {
+
+	request := &registerMessageRequest{}
+	if err := c.Bind(request); err != nil {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid request, error: %s", err)
+	}
+
+	messageID, err := hornet.MessageIDFromHex(request.MessageID)
+	if err != nil {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid message ID: %s, error: %s", request.MessageID, err)
+	}
+
+	status, err := deps.Promoter.Register(messageID)
+	if err != nil {
+		return nil, promoterError(err)
+	}
+
+	return status, nil
+}
+
+func messageStatuses(_ echo.Context) (*messageStatusesResponse, error) {
+
+	statuses := deps.Promoter.Statuses()
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].RegisteredAt < statuses[j].RegisteredAt
+	})
+
+	return &messageStatusesResponse{Messages: statuses}, nil
+}
+
+func messageStatus(c echo.Context) (*promoter.MessageStatusResponse, error) {
+
+	messageID, err := restapi.ParseMessageIDParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := deps.Promoter.Status(messageID)
+	if err != nil {
+		return nil, promoterError(err)
+	}
+
+	return status, nil
+}
+
+func deregisterMessage(c echo.Context) error {
+
+	messageID, err := restapi.ParseMessageIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	if err := deps.Promoter.Deregister(messageID); err != nil {
+		return promoterError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package promoter
+
+import (
+	"github.com/gohornet/hornet/pkg/model/promoter"
+)
+
+// registerMessageRequest defines the request for a POST RouteMessages REST API call.
+type registerMessageRequest struct {
+	// The hex encoded message ID.
+	MessageID string `json:"messageId"`
+}
+
+// messageStatusesResponse defines the response of a GET RouteMessages REST API call.
+type messageStatusesResponse struct {
+	// The status of all registered messages.
+	Messages []*promoter.MessageStatusResponse `json:"messages"`
+}
@@ -10,6 +10,18 @@ import (
 	"github.com/gohornet/hornet/pkg/jwt"
 )
 
+const (
+	// queryParameterToken is used to pass a JWT for routes that can not set the "Authorization" header (e.g. EventSource).
+	queryParameterToken = "token"
+
+	authSchemeBearer = "Bearer "
+)
+
+var (
+	publicRoutes    []*regexp.Regexp
+	protectedRoutes []*regexp.Regexp
+)
+
 func compileRouteAsRegex(route string) *regexp.Regexp {
 
 	r := regexp.QuoteMeta(route)
@@ -36,27 +48,26 @@ func compileRoutesAsRegexes(routes []string) []*regexp.Regexp {
 	return regexes
 }
 
+func matchRoute(regexes []*regexp.Regexp, route string) bool {
+	for _, reg := range regexes {
+		if reg.MatchString(strings.ToLower(route)) {
+			return true
+		}
+	}
+	return false
+}
+
 func apiMiddleware() echo.MiddlewareFunc {
 
-	publicRoutes := compileRoutesAsRegexes(deps.NodeConfig.Strings(CfgRestAPIPublicRoutes))
-	protectedRoutes := compileRoutesAsRegexes(deps.NodeConfig.Strings(CfgRestAPIProtectedRoutes))
+	publicRoutes = compileRoutesAsRegexes(deps.NodeConfig.Strings(CfgRestAPIPublicRoutes))
+	protectedRoutes = compileRoutesAsRegexes(deps.NodeConfig.Strings(CfgRestAPIProtectedRoutes))
 
 	matchPublic := func(c echo.Context) bool {
-		for _, reg := range publicRoutes {
-			if reg.MatchString(strings.ToLower(c.Path())) {
-				return true
-			}
-		}
-		return false
+		return matchRoute(publicRoutes, c.Path())
 	}
 
 	matchExposed := func(c echo.Context) bool {
-		for _, reg := range append(publicRoutes, protectedRoutes...) {
-			if reg.MatchString(strings.ToLower(c.Path())) {
-				return true
-			}
-		}
-		return false
+		return matchRoute(publicRoutes, c.Path()) || matchRoute(protectedRoutes, c.Path())
 	}
 
 	// configure JWT auth
@@ -106,6 +117,11 @@ func apiMiddleware() echo.MiddlewareFunc {
 
 			// Check if the route should be exposed (public or protected) or is required by the dashboard
 			if matchExposed(c) || dashboardAllowedAPIRoute(c) {
+				// Clients with a valid client certificate do not need a JWT
+				if _, ok := clientCertificateSubject(c); ok && matchExposed(c) {
+					return next(c)
+				}
+
 				// Apply JWT middleware
 				return jwtMiddlewareHandler(c)
 			}
@@ -115,6 +131,41 @@ func apiMiddleware() echo.MiddlewareFunc {
 	}
 }
 
+// routeAuthorized checks whether the request is allowed to access the given route
+// according to the public and protected routes of the REST API.
+// Public routes are always allowed, protected routes need a valid client certificate or a valid JWT for the API,
+// which is either passed in the "Authorization" header or in the "token" query parameter.
+func routeAuthorized(c echo.Context, route string) bool {
+	if matchRoute(publicRoutes, route) {
+		return true
+	}
+
+	if !matchRoute(protectedRoutes, route) {
+		return false
+	}
+
+	if _, ok := clientCertificateSubject(c); ok {
+		return true
+	}
+
+	token := requestToken(c)
+	if token == "" || jwtAuth == nil {
+		return false
+	}
+
+	return jwtAuth.VerifyJWT(token, func(claims *jwt.AuthClaims) bool {
+		return claims.API && claims.VerifySubject(deps.NodeConfig.String(CfgRestAPIJWTAuthSalt))
+	})
+}
+
+// requestToken returns the JWT of the request, which is either passed in the "Authorization" header or in the "token" query parameter.
+func requestToken(c echo.Context) string {
+	if auth := c.Request().Header.Get(echo.HeaderAuthorization); strings.HasPrefix(auth, authSchemeBearer) {
+		return strings.TrimPrefix(auth, authSchemeBearer)
+	}
+	return c.QueryParam(queryParameterToken)
+}
+
 var dashboardAllowedRoutes = map[string][]string{
 	http.MethodGet: {
 		"/api/v1/addresses",
@@ -141,6 +192,8 @@ var dashboardAllowedRoutes = map[string][]string{
 var faucetAllowedRoutes = map[string][]string{
 	http.MethodGet: {
 		"/api/plugins/faucet/info",
+		"/api/plugins/faucet/challenge",
+		"/api/plugins/faucet/requests",
 	},
 	http.MethodPost: {
 		"/api/plugins/faucet/enqueue",
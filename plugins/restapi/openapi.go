@@ -0,0 +1,59 @@
+package restapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/gohornet/hornet/pkg/openapi"
+)
+
+const (
+	// RouteOpenAPI is the route for getting the OpenAPI document of the REST API.
+	// GET returns the OpenAPI 3 document of all routes of the enabled plugins.
+	RouteOpenAPI = "/api/openapi.json"
+)
+
+func setupOpenAPIRoute() {
+
+	deps.OpenAPIRegistry.Register(
+		&openapi.Route{
+			Method:  http.MethodGet,
+			Path:    nodeAPIHealthRoute,
+			Summary: "Returns 200 if the node is healthy, 503 otherwise.",
+			Tags:    []string{"node"},
+		},
+		&openapi.Route{
+			Method:              http.MethodGet,
+			Path:                RouteOpenAPI,
+			Summary:             "Returns the OpenAPI document of all routes of the enabled plugins.",
+			Tags:                []string{"node"},
+			ResponseContentType: echo.MIMEApplicationJSON,
+		},
+	)
+
+	deps.Echo.GET(RouteOpenAPI, func(c echo.Context) error {
+		return c.JSON(http.StatusOK, openAPIDocument())
+	})
+}
+
+// openAPIDocument creates the OpenAPI document for all REST API routes registered at the echo instance.
+// The document is created on every request, so it always reflects the routes of the enabled plugins.
+func openAPIDocument() *openapi.Document {
+
+	var routes []*echo.Route
+	for _, route := range deps.Echo.Routes() {
+		if route.Path == nodeAPIHealthRoute || strings.HasPrefix(route.Path, "/api/") {
+			routes = append(routes, route)
+		}
+	}
+
+	return deps.OpenAPIRegistry.Document(&openapi.Info{
+		Title:       deps.AppInfo.Name + " REST API",
+		Description: "The REST API of the node. Only the routes of the enabled plugins are included.",
+		Version:     deps.AppInfo.Version,
+	}, routes, func(path string) bool {
+		return matchRoute(publicRoutes, path)
+	})
+}
@@ -1,6 +1,8 @@
 package restapi
 
 import (
+	"time"
+
 	flag "github.com/spf13/pflag"
 
 	"github.com/gohornet/hornet/pkg/node"
@@ -9,6 +11,8 @@ import (
 const (
 	// the bind address on which the REST API listens on
 	CfgRestAPIBindAddress = "restAPI.bindAddress"
+	// the IP ranges (CIDR notation) of the reverse proxies in front of the REST API whose X-Forwarded-For entries are trusted
+	CfgRestAPITrustedProxies = "restAPI.trustedProxies"
 	// the HTTP REST routes which can be called without authorization. Wildcards using * are allowed
 	CfgRestAPIPublicRoutes = "restAPI.publicRoutes"
 	// the HTTP REST routes which need to be called with authorization. Wildcards using * are allowed
@@ -23,6 +27,38 @@ const (
 	CfgRestAPILimitsMaxBodyLength = "restAPI.limits.bodyLength"
 	// the maximum number of results that may be returned by an endpoint
 	CfgRestAPILimitsMaxResults = "restAPI.limits.maxResults"
+	// the maximum duration an API call may block while waiting for a message to be referenced
+	CfgRestAPILimitsMaxWaitTimeout = "restAPI.limits.maxWaitTimeout"
+	// whether the rate limiting of API calls is enabled
+	CfgRestAPIRateLimitEnabled = "restAPI.rateLimit.enabled"
+	// the duration after which the rate limit state of an inactive client is removed
+	CfgRestAPIRateLimitExpiresIn = "restAPI.rateLimit.expiresIn"
+	// the allowed API calls per second for clients without a JWT or client certificate, identified by their IP
+	CfgRestAPIRateLimitIPRate = "restAPI.rateLimit.ip.rate"
+	// the maximum amount of API calls that can be done at once by clients without a JWT or client certificate
+	CfgRestAPIRateLimitIPBurst = "restAPI.rateLimit.ip.burst"
+	// the allowed API calls per second for clients with a valid JWT or client certificate, identified by their token or certificate subject
+	CfgRestAPIRateLimitJWTRate = "restAPI.rateLimit.jwt.rate"
+	// the maximum amount of API calls that can be done at once by clients with a valid JWT or client certificate
+	CfgRestAPIRateLimitJWTBurst = "restAPI.rateLimit.jwt.burst"
+	// the allowed calls per second of every expensive route per client
+	CfgRestAPIRateLimitExpensiveRate = "restAPI.rateLimit.expensive.rate"
+	// the maximum amount of calls of every expensive route that can be done at once per client
+	CfgRestAPIRateLimitExpensiveBurst = "restAPI.rateLimit.expensive.burst"
+	// the expensive HTTP REST routes ("METHOD route") which have a separate budget per client. Wildcards using * are allowed
+	CfgRestAPIRateLimitExpensiveRoutes = "restAPI.rateLimit.expensive.routes"
+	// whether the REST API is served via TLS
+	CfgRestAPITLSEnabled = "restAPI.tls.enabled"
+	// the path to the PEM encoded TLS certificate of the REST API
+	CfgRestAPITLSCertPath = "restAPI.tls.certPath"
+	// the path to the PEM encoded private key of the TLS certificate of the REST API
+	CfgRestAPITLSKeyPath = "restAPI.tls.keyPath"
+	// whether a self-signed certificate is generated if the certificate files do not exist
+	CfgRestAPITLSSelfSigned = "restAPI.tls.selfSigned"
+	// whether clients with a valid client certificate are allowed to call the protected routes without a JWT
+	CfgRestAPITLSClientAuthEnabled = "restAPI.tls.clientAuth.enabled"
+	// the path to the PEM encoded CA certificates used to verify client certificates
+	CfgRestAPITLSClientAuthCACertPath = "restAPI.tls.clientAuth.caCertPath"
 )
 
 var params = &node.PluginParams{
@@ -30,10 +66,13 @@ var params = &node.PluginParams{
 		"nodeConfig": func() *flag.FlagSet {
 			fs := flag.NewFlagSet("", flag.ContinueOnError)
 			fs.String(CfgRestAPIBindAddress, "0.0.0.0:14265", "the bind address on which the REST API listens on")
+			fs.StringSlice(CfgRestAPITrustedProxies, []string{}, "the IP ranges (CIDR notation) of the reverse proxies in front of the REST API whose X-Forwarded-For entries are trusted")
 			fs.StringSlice(CfgRestAPIPublicRoutes,
 				[]string{
 					"/health",
+					"/api/openapi.json",
 					"/mqtt",
+					"/api/v1/events",
 					"/api/v1/info",
 					"/api/v1/tips",
 					"/api/v1/messages*",
@@ -57,6 +96,31 @@ var params = &node.PluginParams{
 			fs.Int(CfgRestAPIPoWWorkerCount, 1, "the amount of workers used for calculating PoW when issuing messages via API")
 			fs.String(CfgRestAPILimitsMaxBodyLength, "1M", "the maximum number of characters that the body of an API call may contain")
 			fs.Int(CfgRestAPILimitsMaxResults, 1000, "the maximum number of results that may be returned by an endpoint")
+			fs.Duration(CfgRestAPILimitsMaxWaitTimeout, 2*time.Minute, "the maximum duration an API call may block while waiting for a message to be referenced")
+			fs.Bool(CfgRestAPIRateLimitEnabled, false, "whether the rate limiting of API calls is enabled")
+			fs.Duration(CfgRestAPIRateLimitExpiresIn, 3*time.Minute, "the duration after which the rate limit state of an inactive client is removed")
+			fs.Float64(CfgRestAPIRateLimitIPRate, 20, "the allowed API calls per second for clients without a JWT or client certificate, identified by their IP")
+			fs.Int(CfgRestAPIRateLimitIPBurst, 50, "the maximum amount of API calls that can be done at once by clients without a JWT or client certificate")
+			fs.Float64(CfgRestAPIRateLimitJWTRate, 100, "the allowed API calls per second for clients with a valid JWT or client certificate, identified by their token or certificate subject")
+			fs.Int(CfgRestAPIRateLimitJWTBurst, 200, "the maximum amount of API calls that can be done at once by clients with a valid JWT or client certificate")
+			fs.Float64(CfgRestAPIRateLimitExpensiveRate, 1, "the allowed calls per second of every expensive route per client")
+			fs.Int(CfgRestAPIRateLimitExpensiveBurst, 5, "the maximum amount of calls of every expensive route that can be done at once per client")
+			fs.StringSlice(CfgRestAPIRateLimitExpensiveRoutes,
+				[]string{
+					"GET /api/v1/messages",
+					"GET /api/v1/addresses*/outputs",
+					"POST /api/v1/messages",
+					"POST /api/v1/messages/wait",
+					"POST /api/v1/messages/metadata",
+					"POST /api/v1/outputs",
+					"POST /api/v1/addresses/balances",
+				}, "the expensive HTTP REST routes (\"METHOD route\") which have a separate budget per client. Wildcards using * are allowed")
+			fs.Bool(CfgRestAPITLSEnabled, false, "whether the REST API is served via TLS")
+			fs.String(CfgRestAPITLSCertPath, "tls/restapi_cert.pem", "the path to the PEM encoded TLS certificate of the REST API")
+			fs.String(CfgRestAPITLSKeyPath, "tls/restapi_key.pem", "the path to the PEM encoded private key of the TLS certificate of the REST API")
+			fs.Bool(CfgRestAPITLSSelfSigned, false, "whether a self-signed certificate is generated if the certificate files do not exist")
+			fs.Bool(CfgRestAPITLSClientAuthEnabled, false, "whether clients with a valid client certificate are allowed to call the protected routes without a JWT")
+			fs.String(CfgRestAPITLSClientAuthCACertPath, "tls/client_ca.pem", "the path to the PEM encoded CA certificates used to verify client certificates")
 			return fs
 		}(),
 	},
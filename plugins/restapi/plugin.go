@@ -14,9 +14,11 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/dig"
 
+	"github.com/gohornet/hornet/pkg/app"
 	"github.com/gohornet/hornet/pkg/jwt"
 	"github.com/gohornet/hornet/pkg/metrics"
 	"github.com/gohornet/hornet/pkg/node"
+	"github.com/gohornet/hornet/pkg/openapi"
 	"github.com/gohornet/hornet/pkg/restapi"
 	"github.com/gohornet/hornet/pkg/shutdown"
 	"github.com/gohornet/hornet/pkg/tangle"
@@ -54,8 +56,11 @@ type dependencies struct {
 	RestAPIMetrics        *metrics.RestAPIMetrics
 	Host                  host.Host
 	RestAPIBindAddress    string         `name:"restAPIBindAddress"`
+	RestAPITLSEnabled     bool           `name:"restAPITLSEnabled"`
 	NodePrivateKey        crypto.PrivKey `name:"nodePrivateKey"`
 	DashboardAuthUsername string         `name:"dashboardAuthUsername" optional:"true"`
+	OpenAPIRegistry       *openapi.Registry
+	AppInfo               *app.AppInfo
 }
 
 func initConfigPars(c *dig.Container) {
@@ -68,12 +73,14 @@ func initConfigPars(c *dig.Container) {
 	type cfgResult struct {
 		dig.Out
 		RestAPIBindAddress      string `name:"restAPIBindAddress"`
+		RestAPITLSEnabled       bool   `name:"restAPITLSEnabled"`
 		RestAPILimitsMaxResults int    `name:"restAPILimitsMaxResults"`
 	}
 
 	if err := c.Provide(func(deps cfgDeps) cfgResult {
 		return cfgResult{
 			RestAPIBindAddress:      deps.NodeConfig.String(CfgRestAPIBindAddress),
+			RestAPITLSEnabled:       deps.NodeConfig.Bool(CfgRestAPITLSEnabled),
 			RestAPILimitsMaxResults: deps.NodeConfig.Int(CfgRestAPILimitsMaxResults),
 		}
 	}); err != nil {
@@ -97,13 +104,23 @@ func provide(c *dig.Container) {
 	type echoResult struct {
 		dig.Out
 		Echo                     *echo.Echo
-		DashboardAllowedAPIRoute restapi.AllowedRoute `name:"dashboardAllowedAPIRoute"`
-		FaucetAllowedAPIRoute    restapi.AllowedRoute `name:"faucetAllowedAPIRoute"`
+		DashboardAllowedAPIRoute restapi.AllowedRoute    `name:"dashboardAllowedAPIRoute"`
+		FaucetAllowedAPIRoute    restapi.AllowedRoute    `name:"faucetAllowedAPIRoute"`
+		RestAPIRouteAuthorized   restapi.RouteAuthorized `name:"restAPIRouteAuthorized"`
+		OpenAPIRegistry          *openapi.Registry
 	}
 
 	if err := c.Provide(func(deps echoDeps) echoResult {
 		e := echo.New()
 		e.HideBanner = true
+
+		// proxies on the loopback interface are always trusted
+		ipExtractor, err := restapi.IPExtractor(deps.NodeConfig.Strings(CfgRestAPITrustedProxies))
+		if err != nil {
+			Plugin.LogPanic(err)
+		}
+		e.IPExtractor = ipExtractor
+
 		e.Use(middleware.Recover())
 		e.Use(middleware.CORS())
 		e.Use(middleware.Gzip())
@@ -113,6 +130,8 @@ func provide(c *dig.Container) {
 			Echo:                     e,
 			DashboardAllowedAPIRoute: dashboardAllowedAPIRoute,
 			FaucetAllowedAPIRoute:    faucetAllowedAPIRoute,
+			RestAPIRouteAuthorized:   routeAuthorized,
+			OpenAPIRegistry:          openapi.NewRegistry(),
 		}
 	}); err != nil {
 		Plugin.LogPanic(err)
@@ -120,7 +139,11 @@ func provide(c *dig.Container) {
 }
 
 func configure() {
+	configureTLS()
 	deps.Echo.Use(apiMiddleware())
+	if deps.NodeConfig.Bool(CfgRestAPIRateLimitEnabled) {
+		deps.Echo.Use(rateLimitMiddleware())
+	}
 	setupRoutes()
 }
 
@@ -128,15 +151,25 @@ func run() {
 
 	Plugin.LogInfo("Starting REST-API server ...")
 
+	runCertificateReloader()
+
 	if err := Plugin.Daemon().BackgroundWorker("REST-API server", func(ctx context.Context) {
 		Plugin.LogInfo("Starting REST-API server ... done")
 
 		bindAddr := deps.RestAPIBindAddress
-		server := &http.Server{Addr: bindAddr, Handler: deps.Echo}
+		server := &http.Server{Addr: bindAddr, Handler: deps.Echo, TLSConfig: tlsConfig}
 
 		go func() {
-			Plugin.LogInfof("You can now access the API using: http://%s", bindAddr)
-			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			listenAndServe := server.ListenAndServe
+			scheme := "http"
+			if tlsConfig != nil {
+				// the certificate is provided by the TLS config
+				listenAndServe = func() error { return server.ListenAndServeTLS("", "") }
+				scheme = "https"
+			}
+
+			Plugin.LogInfof("You can now access the API using: %s://%s", scheme, bindAddr)
+			if err := listenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 				Plugin.LogWarnf("Stopped REST-API server due to an error (%s)", err)
 			}
 		}()
@@ -179,4 +212,5 @@ func setupRoutes() {
 	}
 
 	setupHealthRoute()
+	setupOpenAPIRoute()
 }
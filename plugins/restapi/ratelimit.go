@@ -0,0 +1,141 @@
+package restapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/atomic"
+
+	"github.com/gohornet/hornet/pkg/jwt"
+	"github.com/gohornet/hornet/pkg/ratelimit"
+)
+
+const (
+	headerRetryAfter = "Retry-After"
+)
+
+// expensiveRoute is a route with a separate rate limit budget per client.
+type expensiveRoute struct {
+	method  string
+	regex   *regexp.Regexp
+	limiter *ratelimit.Limiter
+}
+
+func parseExpensiveRoutes(routes []string, ratePerSecond float64, burst int, expiresIn time.Duration) []*expensiveRoute {
+	var expensiveRoutes []*expensiveRoute
+	for _, route := range routes {
+		parts := strings.Fields(route)
+		if len(parts) != 2 {
+			Plugin.LogFatalf("Invalid expensive route in config, expected \"METHOD route\": %s", route)
+			continue
+		}
+
+		reg := compileRouteAsRegex(parts[1])
+		if reg == nil {
+			Plugin.LogFatalf("Invalid expensive route in config: %s", route)
+			continue
+		}
+
+		expensiveRoutes = append(expensiveRoutes, &expensiveRoute{
+			method:  strings.ToUpper(parts[0]),
+			regex:   reg,
+			limiter: ratelimit.NewLimiter(ratePerSecond, burst, expiresIn),
+		})
+	}
+	return expensiveRoutes
+}
+
+// rateLimitClient returns the key that identifies the client of the request for the rate limiting.
+// Clients with a valid client certificate are identified by the subject, clients with a valid JWT by the hash
+// of the token (all tokens of a node share the same subject), and all other clients by their IP.
+func rateLimitClient(c echo.Context) (key string, authenticated bool) {
+	if subject, ok := clientCertificateSubject(c); ok {
+		return fmt.Sprintf("cert:%s", subject), true
+	}
+
+	if token := requestToken(c); token != "" && jwtAuth != nil {
+		if jwtAuth.VerifyJWT(token, func(claims *jwt.AuthClaims) bool {
+			return claims.API || claims.Dashboard
+		}) {
+			tokenHash := sha256.Sum256([]byte(token))
+			return fmt.Sprintf("jwt:%s", hex.EncodeToString(tokenHash[:])), true
+		}
+	}
+
+	// the IP is determined by the IPExtractor of echo, so it can't be spoofed via the X-Forwarded-For header
+	return fmt.Sprintf("ip:%s", c.RealIP()), false
+}
+
+// tooManyRequests sets the "Retry-After" header and returns the error for rejected requests.
+func tooManyRequests(c echo.Context, retryAfter time.Duration, counter *atomic.Uint32) error {
+	counter.Inc()
+	c.Response().Header().Set(headerRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	return echo.NewHTTPError(http.StatusTooManyRequests, "rate limit exceeded")
+}
+
+// rateLimitMiddleware limits the API calls per client using token buckets.
+// Every client has a general budget, and a separate budget for every expensive route.
+func rateLimitMiddleware() echo.MiddlewareFunc {
+
+	expiresIn := deps.NodeConfig.Duration(CfgRestAPIRateLimitExpiresIn)
+
+	ipLimiter := ratelimit.NewLimiter(deps.NodeConfig.Float64(CfgRestAPIRateLimitIPRate), deps.NodeConfig.Int(CfgRestAPIRateLimitIPBurst), expiresIn)
+	jwtLimiter := ratelimit.NewLimiter(deps.NodeConfig.Float64(CfgRestAPIRateLimitJWTRate), deps.NodeConfig.Int(CfgRestAPIRateLimitJWTBurst), expiresIn)
+	expensiveRoutes := parseExpensiveRoutes(
+		deps.NodeConfig.Strings(CfgRestAPIRateLimitExpensiveRoutes),
+		deps.NodeConfig.Float64(CfgRestAPIRateLimitExpensiveRate),
+		deps.NodeConfig.Int(CfgRestAPIRateLimitExpensiveBurst),
+		expiresIn,
+	)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+
+			if c.Path() == nodeAPIHealthRoute {
+				// the health route is used by load balancers and container orchestration
+				return next(c)
+			}
+
+			client, authenticated := rateLimitClient(c)
+
+			limiter, counter := ipLimiter, &deps.RestAPIMetrics.HTTPRequestRateLimitedIPCounter
+			if authenticated {
+				limiter, counter = jwtLimiter, &deps.RestAPIMetrics.HTTPRequestRateLimitedJWTCounter
+			}
+
+			allowed, retryAfter, cancel := limiter.Reserve(client)
+			if !allowed {
+				return tooManyRequests(c, retryAfter, counter)
+			}
+
+			// the consumed tokens are given back if the request is rejected by an expensive route limiter,
+			// so clients retrying an expensive route don't exhaust their general budget.
+			cancels := []func(){cancel}
+			path := strings.ToLower(c.Path())
+			for _, route := range expensiveRoutes {
+				if route.method != c.Request().Method || !route.regex.MatchString(path) {
+					continue
+				}
+
+				allowed, retryAfter, cancel := route.limiter.Reserve(client)
+				if !allowed {
+					for _, cancel := range cancels {
+						cancel()
+					}
+					return tooManyRequests(c, retryAfter, &deps.RestAPIMetrics.HTTPRequestRateLimitedExpensiveCounter)
+				}
+				cancels = append(cancels, cancel)
+			}
+
+			return next(c)
+		}
+	}
+}
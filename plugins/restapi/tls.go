@@ -0,0 +1,84 @@
+package restapi
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/pkg/tlsutil"
+)
+
+var (
+	tlsConfig             *tls.Config
+	reloadCertificate     tlsutil.ReloadFunc
+	clientCertAuthEnabled bool
+)
+
+// configureTLS loads the TLS certificate of the REST API and the CA certificates for the client authentication.
+// A self-signed certificate is generated if enabled and the certificate files do not exist.
+func configureTLS() {
+
+	if !deps.RestAPITLSEnabled {
+		return
+	}
+
+	clientCAPath := ""
+	if deps.NodeConfig.Bool(CfgRestAPITLSClientAuthEnabled) {
+		clientCAPath = deps.NodeConfig.String(CfgRestAPITLSClientAuthCACertPath)
+		clientCertAuthEnabled = true
+	}
+
+	certPath := deps.NodeConfig.String(CfgRestAPITLSCertPath)
+	var generated bool
+	var err error
+	tlsConfig, reloadCertificate, generated, err = tlsutil.NewReloadingConfig(
+		certPath,
+		deps.NodeConfig.String(CfgRestAPITLSKeyPath),
+		clientCAPath,
+		deps.NodeConfig.Bool(CfgRestAPITLSSelfSigned),
+		deps.RestAPIBindAddress,
+	)
+	if err != nil {
+		Plugin.LogPanicf("loading TLS configuration failed: %s", err)
+	}
+	if generated {
+		Plugin.LogInfof("Generated self-signed TLS certificate: %s", certPath)
+	}
+}
+
+// runCertificateReloader reloads the TLS certificate if the files are changed on disk.
+func runCertificateReloader() {
+
+	if reloadCertificate == nil {
+		return
+	}
+
+	if err := Plugin.Daemon().BackgroundWorker("REST-API certificate reloader", func(ctx context.Context) {
+		reloadCertificate(ctx, func(err error) {
+			if err != nil {
+				Plugin.LogWarnf("reloading TLS certificate failed: %s", err)
+				return
+			}
+			Plugin.LogInfo("Reloaded TLS certificate")
+		})
+	}, shutdown.PriorityRestAPI); err != nil {
+		Plugin.LogPanicf("failed to start worker: %s", err)
+	}
+}
+
+// clientCertificateSubject returns the common name of the verified client certificate of the request,
+// if the client certificate authentication is enabled.
+func clientCertificateSubject(c echo.Context) (string, bool) {
+	if !clientCertAuthEnabled {
+		return "", false
+	}
+
+	cert := tlsutil.VerifiedClientCertificate(c.Request())
+	if cert == nil {
+		return "", false
+	}
+
+	return cert.Subject.CommonName, true
+}
@@ -0,0 +1,188 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/restapi"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+// checkBatchSize checks that the given amount of requested items is within the allowed range.
+func checkBatchSize(name string, count int) error {
+	if count == 0 {
+		return errors.WithMessagef(restapi.ErrInvalidParameter, "invalid request, error: no %s given", name)
+	}
+
+	if count > deps.RestAPILimitsMaxResults {
+		return errors.WithMessagef(restapi.ErrInvalidParameter, "invalid request, error: too many %s given: %d, max: %d", name, count, deps.RestAPILimitsMaxResults)
+	}
+
+	return nil
+}
+
+func messagesMetadataByIDs(c echo.Context) (*messagesMetadataResponse, error) {
+
+	if !deps.SyncManager.IsNodeAlmostSynced() {
+		return nil, errors.WithMessage(echo.ErrServiceUnavailable, "node is not synced")
+	}
+
+	request := &messagesMetadataRequest{}
+	if err := c.Bind(request); err != nil {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid request, error: %s", err)
+	}
+
+	if err := checkBatchSize("message IDs", len(request.MessageIDs)); err != nil {
+		return nil, err
+	}
+
+	messageIDs := make(hornet.MessageIDs, len(request.MessageIDs))
+	for i, messageIDHex := range request.MessageIDs {
+		messageID, err := restapi.ParseMessageID(messageIDHex)
+		if err != nil {
+			return nil, err
+		}
+		messageIDs[i] = messageID
+	}
+
+	// we need to lock the ledger here to have the same ledger index for all messages.
+	deps.UTXOManager.ReadLockLedger()
+	defer deps.UTXOManager.ReadUnlockLedger()
+
+	ledgerIndex, err := deps.UTXOManager.ReadLedgerIndexWithoutLocking()
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading ledger index failed, error: %s", err)
+	}
+
+	response := &messagesMetadataResponse{
+		Messages:    make([]*messageMetadataResponse, 0, len(messageIDs)),
+		NotFound:    make([]string, 0),
+		LedgerIndex: ledgerIndex,
+	}
+
+	for _, messageID := range messageIDs {
+		metadata, err := messageMetadataByMessageID(messageID)
+		if err != nil {
+			if errors.Is(err, echo.ErrNotFound) {
+				response.NotFound = append(response.NotFound, messageID.ToHex())
+				continue
+			}
+			return nil, err
+		}
+		response.Messages = append(response.Messages, metadata)
+	}
+
+	return response, nil
+}
+
+func outputsByIDs(c echo.Context) (*outputsByIDsResponse, error) {
+
+	request := &outputsByIDsRequest{}
+	if err := c.Bind(request); err != nil {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid request, error: %s", err)
+	}
+
+	if err := checkBatchSize("output IDs", len(request.OutputIDs)); err != nil {
+		return nil, err
+	}
+
+	outputIDs := make([]*iotago.UTXOInputID, len(request.OutputIDs))
+	for i, outputIDHex := range request.OutputIDs {
+		outputID, err := restapi.ParseOutputID(outputIDHex)
+		if err != nil {
+			return nil, err
+		}
+		outputIDs[i] = outputID
+	}
+
+	// we need to lock the ledger here to have the same ledger index for all outputs.
+	deps.UTXOManager.ReadLockLedger()
+	defer deps.UTXOManager.ReadUnlockLedger()
+
+	ledgerIndex, err := deps.UTXOManager.ReadLedgerIndexWithoutLocking()
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading ledger index failed, error: %s", err)
+	}
+
+	response := &outputsByIDsResponse{
+		Outputs:     make([]*OutputResponse, 0, len(outputIDs)),
+		NotFound:    make([]string, 0),
+		LedgerIndex: ledgerIndex,
+	}
+
+	for _, outputID := range outputIDs {
+		output, err := outputByIDWithoutLocking(outputID, ledgerIndex)
+		if err != nil {
+			if errors.Is(err, echo.ErrNotFound) {
+				response.NotFound = append(response.NotFound, outputID.ToHex())
+				continue
+			}
+			return nil, err
+		}
+		response.Outputs = append(response.Outputs, output)
+	}
+
+	return response, nil
+}
+
+func balancesByBech32Addresses(c echo.Context) (*addressesBalancesResponse, error) {
+
+	if !deps.SyncManager.WaitForNodeSynced(waitForNodeSyncedTimeout) {
+		return nil, errors.WithMessage(echo.ErrServiceUnavailable, "node is not synced")
+	}
+
+	request := &addressesBalancesRequest{}
+	if err := c.Bind(request); err != nil {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid request, error: %s", err)
+	}
+
+	if err := checkBatchSize("addresses", len(request.Addresses)); err != nil {
+		return nil, err
+	}
+
+	addresses := make([]*iotago.Ed25519Address, len(request.Addresses))
+	for i, addressBech32 := range request.Addresses {
+		bech32Address, err := restapi.ParseBech32Address(addressBech32, deps.Bech32HRP)
+		if err != nil {
+			return nil, err
+		}
+
+		address, ok := bech32Address.(*iotago.Ed25519Address)
+		if !ok {
+			return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid address: %s, error: unknown address type", addressBech32)
+		}
+		addresses[i] = address
+	}
+
+	// we need to lock the ledger here to have the same ledger index for all balances.
+	deps.UTXOManager.ReadLockLedger()
+	defer deps.UTXOManager.ReadUnlockLedger()
+
+	ledgerIndex, err := deps.UTXOManager.ReadLedgerIndexWithoutLocking()
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading ledger index failed, error: %s", err)
+	}
+
+	response := &addressesBalancesResponse{
+		Balances:    make([]*addressBalanceResponse, len(addresses)),
+		LedgerIndex: ledgerIndex,
+	}
+
+	for i, address := range addresses {
+		balance, dustAllowed, err := deps.UTXOManager.AddressBalanceWithoutLocking(address)
+		if err != nil {
+			return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading address balance failed: %s, error: %s", address, err)
+		}
+
+		response.Balances[i] = &addressBalanceResponse{
+			AddressType: address.Type(),
+			Address:     address.String(),
+			Balance:     balance,
+			DustAllowed: dustAllowed,
+			LedgerIndex: ledgerIndex,
+		}
+	}
+
+	return response, nil
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/gohornet/hornet/pkg/common"
 	"github.com/gohornet/hornet/pkg/dag"
+	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/pow"
@@ -26,6 +27,8 @@ import (
 
 var (
 	messageProcessedTimeout = 1 * time.Second
+	// the maximum duration a submit-and-wait call may block, set by the node config.
+	maxWaitTimeout = 2 * time.Minute
 )
 
 func messageMetadataByID(c echo.Context) (*messageMetadataResponse, error) {
@@ -39,6 +42,11 @@ func messageMetadataByID(c echo.Context) (*messageMetadataResponse, error) {
 		return nil, err
 	}
 
+	return messageMetadataByMessageID(messageID)
+}
+
+func messageMetadataByMessageID(messageID hornet.MessageID) (*messageMetadataResponse, error) {
+
 	cachedMsgMeta := deps.Storage.CachedMessageMetadataOrNil(messageID)
 	if cachedMsgMeta == nil {
 		return nil, errors.WithMessagef(echo.ErrNotFound, "message not found: %s", messageID.ToHex())
@@ -188,12 +196,8 @@ func messageIDsByIndex(c echo.Context) (*messageIDsByIndexResponse, error) {
 	}, nil
 }
 
-func sendMessage(c echo.Context) (*messageCreatedResponse, error) {
-
-	if !deps.SyncManager.IsNodeAlmostSynced() {
-		return nil, errors.WithMessage(echo.ErrServiceUnavailable, "node is not synced")
-	}
-
+// parses the message in the request body, either JSON or binary encoded depending on the content type.
+func parseMessageRequest(c echo.Context) (*iotago.Message, error) {
 	msg := &iotago.Message{}
 
 	contentType := c.Request().Header.Get(echo.HeaderContentType)
@@ -218,6 +222,17 @@ func sendMessage(c echo.Context) (*messageCreatedResponse, error) {
 		}
 	}
 
+	return msg, nil
+}
+
+// parses the message in the request body, fills in missing fields and does the PoW if needed.
+func prepareMessage(c echo.Context) (*storage.Message, error) {
+
+	msg, err := parseMessageRequest(c)
+	if err != nil {
+		return nil, err
+	}
+
 	if msg.NetworkID == 0 && msg.Nonce != 0 {
 		// Message was PoWed without the correct networkId being set, so reject it
 		return nil, errors.WithMessage(restapi.ErrInvalidParameter, "invalid message, error: PoW done but networkId missing")
@@ -273,11 +288,17 @@ func sendMessage(c echo.Context) (*messageCreatedResponse, error) {
 		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid message, error: %s", err)
 	}
 
+	return message, nil
+}
+
+// emits the message and waits for at most "messageProcessedTimeout" for the message to be processed.
+func emitMessage(message *storage.Message) error {
+
 	msgProcessedChan := deps.Tangle.RegisterMessageProcessedEvent(message.MessageID())
 
 	if err := deps.MessageProcessor.Emit(message); err != nil {
 		deps.Tangle.DeregisterMessageProcessedEvent(message.MessageID())
-		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid message, error: %s", err)
+		return errors.WithMessagef(restapi.ErrInvalidParameter, "invalid message, error: %s", err)
 	}
 
 	// wait for at most "messageProcessedTimeout" for the message to be processed
@@ -288,7 +309,125 @@ func sendMessage(c echo.Context) (*messageCreatedResponse, error) {
 		deps.Tangle.DeregisterMessageProcessedEvent(message.MessageID())
 	}
 
+	return nil
+}
+
+func sendMessage(c echo.Context) (*messageCreatedResponse, error) {
+
+	if !deps.SyncManager.IsNodeAlmostSynced() {
+		return nil, errors.WithMessage(echo.ErrServiceUnavailable, "node is not synced")
+	}
+
+	message, err := prepareMessage(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := emitMessage(message); err != nil {
+		return nil, err
+	}
+
 	return &messageCreatedResponse{
 		MessageID: message.MessageID().ToHex(),
 	}, nil
 }
+
+func sendMessageAndWait(c echo.Context) (*messageMetadataResponse, error) {
+
+	if !deps.SyncManager.IsNodeAlmostSynced() {
+		return nil, errors.WithMessage(echo.ErrServiceUnavailable, "node is not synced")
+	}
+
+	timeout := maxWaitTimeout
+	if timeoutParam := c.QueryParam("timeout"); timeoutParam != "" {
+		var err error
+		timeout, err = time.ParseDuration(timeoutParam)
+		if err != nil {
+			return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid timeout: %s, error: %s", timeoutParam, err)
+		}
+
+		if timeout <= 0 || timeout > maxWaitTimeout {
+			return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid timeout: %s, must be between 0 and %v", timeoutParam, maxWaitTimeout)
+		}
+	}
+
+	message, err := prepareMessage(c)
+	if err != nil {
+		return nil, err
+	}
+	messageID := message.MessageID()
+
+	// register the event before emitting the message to prevent race conditions.
+	// the event is not deregistered on timeout, because this would also fire the event for other listeners
+	// of the same message. the event is freed as soon as the message gets solid.
+	msgSolidEventChan := deps.Tangle.RegisterMessageSolidEvent(messageID)
+
+	if err := emitMessage(message); err != nil {
+		return nil, err
+	}
+
+	// the message could already be known and solid, in that case the event would not fire again
+	if cachedMsgMeta := deps.Storage.CachedMessageMetadataOrNil(messageID); cachedMsgMeta != nil { // meta +1
+		if cachedMsgMeta.Metadata().IsSolid() {
+			// deregister the event, because the message is already solid (this also fires the event)
+			deps.Tangle.DeregisterMessageSolidEvent(messageID)
+		}
+		cachedMsgMeta.Release(true) // meta -1
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), timeout)
+	defer cancel()
+
+	mergedCtx, mergedCtxCancel := utils.MergeContexts(ctx, Plugin.Daemon().ContextStopped())
+	defer mergedCtxCancel()
+
+	if err := waitForMessageReferenced(mergedCtx, messageID, msgSolidEventChan); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		if errors.Is(err, echo.ErrNotFound) {
+			return nil, err
+		}
+		return nil, errors.WithMessage(echo.ErrServiceUnavailable, err.Error())
+	}
+
+	// the metadata contains the final ledger inclusion state if the message was referenced in time
+	return messageMetadataByMessageID(messageID)
+}
+
+// waits until the message is solid and referenced by a milestone.
+func waitForMessageReferenced(ctx context.Context, messageID hornet.MessageID, msgSolidEventChan chan struct{}) error {
+
+	// wait until the message is solid
+	if err := utils.WaitForChannelClosed(ctx, msgSolidEventChan); err != nil {
+		return err
+	}
+
+	for {
+		msIndex := deps.SyncManager.ConfirmedMilestoneIndex() + 1
+
+		// register the event before checking the metadata to prevent race conditions.
+		// the event is not deregistered on timeout, because this would also fire the event for other listeners
+		// (e.g. the coordinator). the event is freed as soon as the milestone gets confirmed.
+		milestoneConfirmedEventChan := deps.Tangle.RegisterMilestoneConfirmedEvent(msIndex)
+
+		cachedMsgMeta := deps.Storage.CachedMessageMetadataOrNil(messageID) // meta +1
+		if cachedMsgMeta == nil {
+			return errors.WithMessagef(echo.ErrNotFound, "message not found: %s", messageID.ToHex())
+		}
+		referenced := cachedMsgMeta.Metadata().IsReferenced()
+		cachedMsgMeta.Release(true) // meta -1
+
+		if referenced {
+			return nil
+		}
+
+		if deps.SyncManager.ConfirmedMilestoneIndex() >= msIndex {
+			// the milestone was confirmed in the meantime, check again
+			deps.Tangle.DeregisterMilestoneConfirmedEvent(msIndex)
+			continue
+		}
+
+		// wait until the next milestone is confirmed
+		if err := utils.WaitForChannelClosed(ctx, milestoneConfirmedEventChan); err != nil {
+			return err
+		}
+	}
+}
@@ -1,14 +1,36 @@
 package v1
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/restapi"
 
 	"github.com/iotaledger/hive.go/kvstore"
 )
 
+const (
+	// milestoneSearchModeClosest returns the milestone with the timestamp closest to the given timestamp.
+	milestoneSearchModeClosest = "closest"
+	// milestoneSearchModeBefore returns the youngest milestone with a timestamp before or equal to the given timestamp.
+	milestoneSearchModeBefore = "before"
+	// milestoneSearchModeAfter returns the oldest milestone with a timestamp after or equal to the given timestamp.
+	milestoneSearchModeAfter = "after"
+)
+
+func newMilestoneResponse(ms *storage.Milestone) *milestoneResponse {
+	return &milestoneResponse{
+		Index:     uint32(ms.Index),
+		MessageID: ms.MessageID.ToHex(),
+		Time:      ms.Timestamp.Unix(),
+	}
+}
+
 func milestoneByIndex(c echo.Context) (*milestoneResponse, error) {
 
 	msIndex, err := restapi.ParseMilestoneIndexParam(c)
@@ -22,13 +44,203 @@ func milestoneByIndex(c echo.Context) (*milestoneResponse, error) {
 	}
 	defer cachedMilestone.Release(true)
 
-	return &milestoneResponse{
-		Index:     uint32(cachedMilestone.Milestone().Index),
-		MessageID: cachedMilestone.Milestone().MessageID.ToHex(),
-		Time:      cachedMilestone.Milestone().Timestamp.Unix(),
+	return newMilestoneResponse(cachedMilestone.Milestone()), nil
+}
+
+// availableMilestoneRange returns the range of confirmed milestones that were not pruned yet.
+func availableMilestoneRange() (pruningIndex milestone.Index, startIndex milestone.Index, endIndex milestone.Index) {
+	if snapshotInfo := deps.Storage.SnapshotInfo(); snapshotInfo != nil {
+		pruningIndex = snapshotInfo.PruningIndex
+	}
+	return pruningIndex, pruningIndex + 1, deps.SyncManager.ConfirmedMilestoneIndex()
+}
+
+func milestoneByTimestamp(c echo.Context) (*milestoneResponse, error) {
+
+	timestamp, err := restapi.ParseTimestampParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var mode storage.MilestoneSearchMode
+	switch modeParam := strings.ToLower(c.QueryParam("mode")); modeParam {
+	case "", milestoneSearchModeClosest:
+		mode = storage.MilestoneSearchClosest
+	case milestoneSearchModeBefore:
+		mode = storage.MilestoneSearchBefore
+	case milestoneSearchModeAfter:
+		mode = storage.MilestoneSearchAfter
+	default:
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid mode: %s, error: unknown search mode", modeParam)
+	}
+
+	_, startIndex, endIndex := availableMilestoneRange()
+
+	msIndex, err := deps.Storage.SearchMilestoneIndexByTimestamp(timestamp, startIndex, endIndex, mode)
+	if err != nil {
+		if errors.Is(err, storage.ErrMilestoneNotFound) {
+			return nil, errors.WithMessagef(echo.ErrNotFound, "milestone not found for timestamp: %d, error: %s", timestamp.Unix(), err)
+		}
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "searching milestone failed, error: %s", err)
+	}
+
+	cachedMilestone := deps.Storage.CachedMilestoneOrNil(msIndex) // milestone +1
+	if cachedMilestone == nil {
+		return nil, errors.WithMessagef(echo.ErrNotFound, "milestone not found: %d", msIndex)
+	}
+	defer cachedMilestone.Release(true)
+
+	return newMilestoneResponse(cachedMilestone.Milestone()), nil
+}
+
+func parseUint32QueryParam(c echo.Context, name string, defaultValue uint32) (uint32, error) {
+	param := c.QueryParam(name)
+	if param == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.ParseUint(param, 10, 32)
+	if err != nil {
+		return 0, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid %s: %s, error: %s", name, param, err)
+	}
+
+	return uint32(value), nil
+}
+
+func milestoneReferencedMessagesByIndex(c echo.Context) (*milestoneReferencedMessagesResponse, error) {
+
+	msIndex, err := restapi.ParseMilestoneIndexParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	maxResults := uint32(deps.RestAPILimitsMaxResults)
+
+	offset, err := parseUint32QueryParam(c, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := parseUint32QueryParam(c, "limit", maxResults)
+	if err != nil {
+		return nil, err
+	}
+	if limit == 0 || limit > maxResults {
+		limit = maxResults
+	}
+
+	total, exists, err := deps.Storage.ReferencedMessagesCount(msIndex)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "can't load referenced messages for index: %d, error: %s", msIndex, err)
+	}
+	if !exists {
+		return nil, errors.WithMessagef(echo.ErrNotFound, "referenced messages not found for index: %d", msIndex)
+	}
+
+	referencedMessages, err := deps.Storage.ReferencedMessages(msIndex, offset, limit)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "can't load referenced messages for index: %d, error: %s", msIndex, err)
+	}
+
+	messages := make([]*referencedMessageResponse, len(referencedMessages))
+	for i, referencedMessage := range referencedMessages {
+		messages[i] = &referencedMessageResponse{
+			MessageID:            referencedMessage.MessageID.ToHex(),
+			LedgerInclusionState: referencedMessage.InclusionState.String(),
+		}
+
+		if referencedMessage.InclusionState == storage.LedgerInclusionStateConflicting {
+			conflict := referencedMessage.Conflict
+			messages[i].ConflictReason = &conflict
+		}
+	}
+
+	return &milestoneReferencedMessagesResponse{
+		Index:    msIndex,
+		Offset:   offset,
+		Limit:    limit,
+		Count:    uint32(len(messages)),
+		Total:    total,
+		Messages: messages,
 	}, nil
 }
 
+func parseMilestoneIndexQueryParam(c echo.Context, name string) (milestone.Index, bool, error) {
+	param := c.QueryParam(name)
+	if param == "" {
+		return 0, false, nil
+	}
+
+	msIndex, err := strconv.ParseUint(param, 10, 32)
+	if err != nil {
+		return 0, false, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid %s: %s, error: %s", name, param, err)
+	}
+
+	return milestone.Index(msIndex), true, nil
+}
+
+func milestones(c echo.Context) (*milestonesResponse, error) {
+
+	maxResults := deps.RestAPILimitsMaxResults
+
+	requestedStartIndex, hasStartIndex, err := parseMilestoneIndexQueryParam(c, "start")
+	if err != nil {
+		return nil, err
+	}
+
+	requestedEndIndex, hasEndIndex, err := parseMilestoneIndexQueryParam(c, "end")
+	if err != nil {
+		return nil, err
+	}
+
+	if hasStartIndex && hasEndIndex && requestedStartIndex > requestedEndIndex {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid range: start index %d is bigger than end index %d", requestedStartIndex, requestedEndIndex)
+	}
+
+	pruningIndex, startIndex, endIndex := availableMilestoneRange()
+
+	if hasEndIndex && requestedEndIndex < endIndex {
+		endIndex = requestedEndIndex
+	}
+
+	switch {
+	case hasStartIndex:
+		if requestedStartIndex > startIndex {
+			startIndex = requestedStartIndex
+		}
+		// the range is limited from the start index
+		if endIndex >= startIndex && int(endIndex-startIndex) >= maxResults {
+			endIndex = startIndex + milestone.Index(maxResults) - 1
+		}
+
+	default:
+		// without a start index, the youngest milestones in the range are returned
+		if endIndex >= startIndex && int(endIndex-startIndex) >= maxResults {
+			startIndex = endIndex - milestone.Index(maxResults) + 1
+		}
+	}
+
+	response := &milestonesResponse{
+		StartIndex:   startIndex,
+		EndIndex:     endIndex,
+		PruningIndex: pruningIndex,
+		MaxResults:   uint32(maxResults),
+		Milestones:   make([]*milestoneResponse, 0),
+	}
+
+	for msIndex := startIndex; msIndex <= endIndex && msIndex >= startIndex; msIndex++ {
+		cachedMilestone := deps.Storage.CachedMilestoneOrNil(msIndex) // milestone +1
+		if cachedMilestone == nil {
+			continue
+		}
+		response.Milestones = append(response.Milestones, newMilestoneResponse(cachedMilestone.Milestone()))
+		cachedMilestone.Release(true) // milestone -1
+	}
+	response.Count = uint32(len(response.Milestones))
+
+	return response, nil
+}
+
 func milestoneUTXOChangesByIndex(c echo.Context) (*milestoneUTXOChangesResponse, error) {
 
 	msIndex, err := restapi.ParseMilestoneIndexParam(c)
@@ -0,0 +1,329 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/gohornet/hornet/pkg/openapi"
+	restapipkg "github.com/gohornet/hornet/pkg/restapi"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+const routeGroupPrefix = "/api/v1"
+
+var (
+	messageIDParam      = openapi.PathParameter(restapipkg.ParameterMessageID, "The hex encoded message ID.")
+	transactionIDParam  = openapi.PathParameter(restapipkg.ParameterTransactionID, "The hex encoded transaction ID.")
+	outputIDParam       = openapi.PathParameter(restapipkg.ParameterOutputID, "The hex encoded output ID (transaction ID + output index).")
+	milestoneIndexParam = openapi.PathParameter(restapipkg.ParameterMilestoneIndex, "The milestone index.")
+	peerIDParam         = openapi.PathParameter(restapipkg.ParameterPeerID, "The libp2p peer ID.")
+	bech32AddressParam  = openapi.PathParameter(restapipkg.ParameterAddress, "The bech32 encoded address.")
+	ed25519AddressParam = openapi.PathParameter(restapipkg.ParameterAddress, "The hex encoded ed25519 address.")
+
+	includeSpentParam = openapi.QueryParameter("include-spent", "Whether spent outputs should be included.", "boolean", false)
+	outputTypeParam   = openapi.QueryParameter("type", "Filters the outputs by the output type.", "integer", false)
+
+	messageContentTypes = []string{echo.MIMEApplicationJSON, openapi.MIMEApplicationVendorIOTASerializerV1}
+)
+
+// openAPIRoutes returns the descriptions of all routes of the plugin for the OpenAPI document.
+func openAPIRoutes() []*openapi.Route {
+	return []*openapi.Route{
+		{
+			Method:   http.MethodGet,
+			Path:     routeGroupPrefix + RouteInfo,
+			Summary:  "Returns the node info.",
+			Response: &infoResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    routeGroupPrefix + RouteTips,
+			Summary: "Returns tips that are ideal for attaching a message.",
+			Parameters: []*openapi.Parameter{
+				openapi.QueryParameter("spammerTips", "Whether the tips of the spammer tip selection should be returned.", "boolean", false),
+			},
+			Response: &tipsResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteMessageMetadata,
+			Summary:    "Returns the metadata of a message.",
+			Parameters: []*openapi.Parameter{messageIDParam},
+			Response:   &messageMetadataResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteMessageData,
+			Summary:    "Returns a message.",
+			Parameters: []*openapi.Parameter{messageIDParam},
+			Response:   &iotago.Message{},
+		},
+		{
+			Method:              http.MethodGet,
+			Path:                routeGroupPrefix + RouteMessageBytes,
+			Summary:             "Returns the binary encoded message.",
+			Parameters:          []*openapi.Parameter{messageIDParam},
+			ResponseContentType: echo.MIMEOctetStream,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteMessageChildren,
+			Summary:    "Returns the message IDs of the children of a message.",
+			Parameters: []*openapi.Parameter{messageIDParam},
+			Response:   &childrenResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    routeGroupPrefix + RouteMessages,
+			Summary: "Returns the message IDs of messages with the given indexation.",
+			Parameters: []*openapi.Parameter{
+				openapi.QueryParameter("index", "The hex encoded index of the indexation payload.", "string", true),
+			},
+			Response: &messageIDsByIndexResponse{},
+		},
+		{
+			Method:              http.MethodPost,
+			Path:                routeGroupPrefix + RouteMessages,
+			Summary:             "Submits a message. Missing fields are filled in by the node.",
+			Request:             &iotago.Message{},
+			RequestContentTypes: messageContentTypes,
+			Response:            &messageCreatedResponse{},
+			ResponseStatus:      http.StatusCreated,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    routeGroupPrefix + RouteMessagesWait,
+			Summary: "Submits a message and waits until it is referenced by a milestone. Returns 202 if the timeout expired before.",
+			Parameters: []*openapi.Parameter{
+				openapi.QueryParameter("timeout", "The maximum duration to wait (e.g. \"30s\").", "string", false),
+			},
+			Request:             &iotago.Message{},
+			RequestContentTypes: messageContentTypes,
+			Response:            &messageMetadataResponse{},
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     routeGroupPrefix + RouteMessagesMetadata,
+			Summary:  "Returns the metadata of several messages at the same ledger index.",
+			Request:  &messagesMetadataRequest{},
+			Response: &messagesMetadataResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteTransactionsIncludedMessage,
+			Summary:    "Returns the message that included the transaction in the ledger.",
+			Parameters: []*openapi.Parameter{transactionIDParam},
+			Response:   &iotago.Message{},
+		},
+		{
+			Method:              http.MethodPost,
+			Path:                routeGroupPrefix + RouteTransactionsValidate,
+			Summary:             "Validates the transaction of a message against the current ledger state without broadcasting it.",
+			Request:             &iotago.Message{},
+			RequestContentTypes: messageContentTypes,
+			Response:            &validateTransactionResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteMilestone,
+			Summary:    "Returns a milestone.",
+			Parameters: []*openapi.Parameter{milestoneIndexParam},
+			Response:   &milestoneResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteMilestoneUTXOChanges,
+			Summary:    "Returns the output IDs of all UTXO changes of a milestone.",
+			Parameters: []*openapi.Parameter{milestoneIndexParam},
+			Response:   &milestoneUTXOChangesResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    routeGroupPrefix + RouteMilestoneReferencedMessages,
+			Summary: "Returns the messages referenced by a milestone in the white-flag order.",
+			Parameters: []*openapi.Parameter{
+				milestoneIndexParam,
+				openapi.QueryParameter("offset", "The position of the first returned message.", "integer", false),
+				openapi.QueryParameter("limit", "The maximum amount of returned messages.", "integer", false),
+			},
+			Response: &milestoneReferencedMessagesResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    routeGroupPrefix + RouteMilestoneByTimestamp,
+			Summary: "Returns the milestone that was issued closest to a unix timestamp.",
+			Parameters: []*openapi.Parameter{
+				openapi.PathParameter(restapipkg.ParameterTimestamp, "The unix timestamp in seconds."),
+				openapi.QueryParameter("mode", "The search mode (closest, before or after).", "string", false),
+			},
+			Response: &milestoneResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    routeGroupPrefix + RouteMilestones,
+			Summary: "Returns the milestones in an index range.",
+			Parameters: []*openapi.Parameter{
+				openapi.QueryParameter("start", "The first milestone index of the range.", "integer", false),
+				openapi.QueryParameter("end", "The last milestone index of the range.", "integer", false),
+			},
+			Response: &milestonesResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteOutput,
+			Summary:    "Returns an output.",
+			Parameters: []*openapi.Parameter{outputIDParam},
+			Response:   &OutputResponse{},
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     routeGroupPrefix + RouteOutputs,
+			Summary:  "Returns several outputs at the same ledger index.",
+			Request:  &outputsByIDsRequest{},
+			Response: &outputsByIDsResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteAddressBech32Balance,
+			Summary:    "Returns the balance of a bech32 encoded address.",
+			Parameters: []*openapi.Parameter{bech32AddressParam},
+			Response:   &addressBalanceResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteAddressEd25519Balance,
+			Summary:    "Returns the balance of an ed25519 address.",
+			Parameters: []*openapi.Parameter{ed25519AddressParam},
+			Response:   &addressBalanceResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteAddressBech32Outputs,
+			Summary:    "Returns the output IDs of a bech32 encoded address.",
+			Parameters: []*openapi.Parameter{bech32AddressParam, includeSpentParam, outputTypeParam},
+			Response:   &addressOutputsResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteAddressEd25519Outputs,
+			Summary:    "Returns the output IDs of an ed25519 address.",
+			Parameters: []*openapi.Parameter{ed25519AddressParam, includeSpentParam, outputTypeParam},
+			Response:   &addressOutputsResponse{},
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     routeGroupPrefix + RouteAddressesBalances,
+			Summary:  "Returns the balances of several bech32 encoded addresses at the same ledger index.",
+			Request:  &addressesBalancesRequest{},
+			Response: &addressesBalancesResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     routeGroupPrefix + RouteTreasury,
+			Summary:  "Returns the current treasury output.",
+			Response: &treasuryResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     routeGroupPrefix + RouteReceipts,
+			Summary:  "Returns all stored receipts.",
+			Response: &receiptsResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RouteReceiptsMigratedAtIndex,
+			Summary:    "Returns all receipts for the given migrated at index.",
+			Parameters: []*openapi.Parameter{milestoneIndexParam},
+			Response:   &receiptsResponse{},
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       routeGroupPrefix + RoutePeer,
+			Summary:    "Returns a peer.",
+			Parameters: []*openapi.Parameter{peerIDParam},
+			Response:   &PeerResponse{},
+		},
+		{
+			Method:         http.MethodDelete,
+			Path:           routeGroupPrefix + RoutePeer,
+			Summary:        "Removes a peer.",
+			Parameters:     []*openapi.Parameter{peerIDParam},
+			ResponseStatus: http.StatusNoContent,
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     routeGroupPrefix + RoutePeers,
+			Summary:  "Returns all peers of the node.",
+			Response: []*PeerResponse{},
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     routeGroupPrefix + RoutePeers,
+			Summary:  "Adds a peer.",
+			Request:  &addPeerRequest{},
+			Response: &PeerResponse{},
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     routeGroupPrefix + RouteControlDatabasePrune,
+			Summary:  "Prunes the database.",
+			Request:  &pruneDatabaseRequest{},
+			Response: &pruneDatabaseResponse{},
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     routeGroupPrefix + RouteControlSnapshotsCreate,
+			Summary:  "Creates a full and/or delta snapshot.",
+			Request:  &createSnapshotsRequest{},
+			Response: &createSnapshotsResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     routeGroupPrefix + RouteControlPeersBans,
+			Summary:  "Returns all active bans of peers and subnets.",
+			Response: &banListResponse{},
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     routeGroupPrefix + RouteControlPeersBans,
+			Summary:  "Bans a peer or a subnet and closes all its connections.",
+			Request:  &addBanRequest{},
+			Response: &BanResponse{},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    routeGroupPrefix + RouteControlPeersBans,
+			Summary: "Removes a ban.",
+			Parameters: []*openapi.Parameter{
+				openapi.QueryParameter("target", "The banned peer ID, IP address or CIDR.", "string", true),
+			},
+			ResponseStatus: http.StatusNoContent,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    routeGroupPrefix + RouteControlTips,
+			Summary: "Returns the tips of the tip pools of the tip-selection.",
+			Parameters: []*openapi.Parameter{
+				openapi.QueryParameter("pool", "The tip pool (nonLazy or semiLazy), both pools are returned if not given.", "string", false),
+			},
+			Response: &tipPoolsResponse{},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    routeGroupPrefix + RouteControlTips,
+			Summary: "Removes all tips from a tip pool.",
+			Parameters: []*openapi.Parameter{
+				openapi.QueryParameter("pool", "The tip pool (nonLazy or semiLazy).", "string", true),
+			},
+			Response: &flushTipPoolResponse{},
+		},
+		{
+			Method:         http.MethodDelete,
+			Path:           routeGroupPrefix + RouteControlTip,
+			Summary:        "Removes a tip from the tip pools.",
+			Parameters:     []*openapi.Parameter{messageIDParam},
+			ResponseStatus: http.StatusNoContent,
+		},
+	}
+}
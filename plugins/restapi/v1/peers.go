@@ -1,9 +1,12 @@
 package v1
 
 import (
+	"time"
+
 	"github.com/labstack/echo/v4"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
 	"github.com/pkg/errors"
 
 	"github.com/gohornet/hornet/pkg/p2p"
@@ -37,6 +40,7 @@ func WrapInfoSnapshot(info *p2p.PeerInfoSnapshot) *PeerResponse {
 		Relation:       info.Relation,
 		Connected:      info.Connected,
 		Gossip:         gossipInfo,
+		Reputation:     deps.ReputationManager.Snapshot(info.Peer.ID),
 	}
 }
 
@@ -63,6 +67,9 @@ func removePeer(c echo.Context) error {
 	// error is ignored because we don't care about the config here
 	_ = deps.PeeringConfigManager.RemovePeer(peerID)
 
+	// error is ignored because the peer was maybe not added via the API
+	_ = deps.AddressBook.RemovePeer(peerID)
+
 	return deps.PeeringManager.DisconnectPeer(peerID, errors.New("peer was removed via API"))
 }
 
@@ -110,5 +117,89 @@ func addPeer(c echo.Context) (*PeerResponse, error) {
 	// error is ignored because we don't care about the config here
 	_ = deps.PeeringConfigManager.AddPeer(multiAddr, alias)
 
+	// persist the peer in the address book, so that it survives restarts even if the peering config can't be written
+	if err := deps.AddressBook.AddPeer(multiAddr, alias); err != nil {
+		Plugin.LogWarnf("unable to add peer %s to the address book: %s", addrInfo.ID.ShortString(), err)
+	}
+
 	return WrapInfoSnapshot(info), nil
 }
+
+func wrapBan(ban *p2p.Ban) *BanResponse {
+	return &BanResponse{
+		Target:  ban.Target(),
+		Expires: ban.Expires.Unix(),
+	}
+}
+
+func listBans(_ echo.Context) (*banListResponse, error) {
+
+	bans := deps.ConnectionGater.Bans()
+
+	results := make([]*BanResponse, len(bans))
+	for i, ban := range bans {
+		results[i] = wrapBan(ban)
+	}
+
+	return &banListResponse{Bans: results}, nil
+}
+
+func addBan(c echo.Context) (*BanResponse, error) {
+
+	request := &addBanRequest{}
+
+	if err := c.Bind(request); err != nil {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid addBanRequest, error: %s", err)
+	}
+
+	var duration time.Duration
+	if request.Duration != nil {
+		var err error
+		if duration, err = time.ParseDuration(*request.Duration); err != nil {
+			return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid duration, error: %s", err)
+		}
+	}
+
+	ban, err := deps.ConnectionGater.Ban(request.Target, duration)
+	if err != nil {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid addBanRequest, error: %s", err)
+	}
+
+	// close all existing connections of the banned peer or subnet.
+	// known peers are not removed, their reconnects are rejected by the gater until the ban expires.
+	for _, conn := range deps.Host.Network().Conns() {
+		if ban.IPNet == nil {
+			if conn.RemotePeer() == ban.PeerID {
+				_ = conn.Close()
+			}
+			continue
+		}
+
+		ip, err := manet.ToIP(conn.RemoteMultiaddr())
+		if err != nil {
+			continue
+		}
+		if ban.IPNet.Contains(ip) {
+			_ = conn.Close()
+		}
+	}
+
+	return wrapBan(ban), nil
+}
+
+func removeBan(c echo.Context) error {
+
+	target := c.QueryParam("target")
+	if target == "" {
+		return errors.WithMessage(restapi.ErrInvalidParameter, "target has to be specified")
+	}
+
+	if err := deps.ConnectionGater.Unban(target); err != nil {
+		if errors.Is(err, p2p.ErrBanNotFound) {
+			return errors.WithMessagef(echo.ErrNotFound, "ban not found, target: %s", target)
+		}
+		return errors.WithMessagef(restapi.ErrInvalidParameter, "invalid target, error: %s", err)
+	}
+
+	return nil
+}
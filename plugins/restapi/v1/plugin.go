@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/libp2p/go-libp2p-core/host"
 	"github.com/pkg/errors"
 	"go.uber.org/dig"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/gohornet/hornet/pkg/model/syncmanager"
 	"github.com/gohornet/hornet/pkg/model/utxo"
 	"github.com/gohornet/hornet/pkg/node"
+	"github.com/gohornet/hornet/pkg/openapi"
 	"github.com/gohornet/hornet/pkg/p2p"
 	"github.com/gohornet/hornet/pkg/pow"
 	"github.com/gohornet/hornet/pkg/protocol/gossip"
@@ -59,10 +61,22 @@ const (
 	// POST creates a single new message and returns the new message ID.
 	RouteMessages = "/messages"
 
+	// RouteMessagesWait is the route for creating new messages and waiting until they are referenced by a milestone.
+	// POST creates a single new message and returns the metadata as soon as it is referenced or the timeout expired (query parameters: "timeout").
+	RouteMessagesWait = "/messages/wait"
+
+	// RouteMessagesMetadata is the route for getting the metadata of several messages at once.
+	// POST returns the metadata of all given messages at the same ledger index.
+	RouteMessagesMetadata = "/messages/metadata"
+
 	// RouteTransactionsIncludedMessage is the route for getting the message that was included in the ledger for a given transaction ID.
 	// GET returns message data (json).
 	RouteTransactionsIncludedMessage = "/transactions/:" + restapipkg.ParameterTransactionID + "/included-message"
 
+	// RouteTransactionsValidate is the route for validating a transaction against the current ledger state without broadcasting it.
+	// POST validates the transaction payload of the given message and returns the conflict reason.
+	RouteTransactionsValidate = "/transactions/validate"
+
 	// RouteMilestone is the route for getting a milestone by it's milestoneIndex.
 	// GET returns the milestone.
 	RouteMilestone = "/milestones/:" + restapipkg.ParameterMilestoneIndex
@@ -71,10 +85,26 @@ const (
 	// GET returns the output IDs of all UTXO changes.
 	RouteMilestoneUTXOChanges = "/milestones/:" + restapipkg.ParameterMilestoneIndex + "/utxo-changes"
 
+	// RouteMilestoneReferencedMessages is the route for getting the messages referenced by a milestone by its milestoneIndex.
+	// GET returns the message IDs in the white-flag order with their ledger inclusion state, paginated by the query parameters "offset" and "limit".
+	RouteMilestoneReferencedMessages = "/milestones/:" + restapipkg.ParameterMilestoneIndex + "/referenced-messages"
+
+	// RouteMilestoneByTimestamp is the route for getting the milestone that was issued closest to a unix timestamp.
+	// GET returns the milestone. The optional query parameter "mode" (closest, before, after) selects the search mode.
+	RouteMilestoneByTimestamp = "/milestones/by-timestamp/:" + restapipkg.ParameterTimestamp
+
+	// RouteMilestones is the route for getting the milestones in an index range.
+	// GET returns the milestones between the optional query parameters "start" and "end", limited to the max results.
+	RouteMilestones = "/milestones"
+
 	// RouteOutput is the route for getting outputs by their outputID (transactionHash + outputIndex).
 	// GET returns the output.
 	RouteOutput = "/outputs/:" + restapipkg.ParameterOutputID
 
+	// RouteOutputs is the route for getting several outputs at once by their outputIDs.
+	// POST returns all given outputs at the same ledger index.
+	RouteOutputs = "/outputs"
+
 	// RouteAddressBech32Balance is the route for getting the total balance of all unspent outputs of an address.
 	// The address must be encoded in bech32.
 	// GET returns the balance of all unspent outputs of this address.
@@ -95,6 +125,11 @@ const (
 	// GET returns the outputIDs for all outputs of this address (optional query parameters: "include-spent").
 	RouteAddressEd25519Outputs = "/addresses/ed25519/:" + restapipkg.ParameterAddress + "/outputs"
 
+	// RouteAddressesBalances is the route for getting the balances of several addresses at once.
+	// The addresses must be encoded in bech32.
+	// POST returns the balances of all given addresses at the same ledger index.
+	RouteAddressesBalances = "/addresses/balances"
+
 	// RouteTreasury is the route for getting the current treasury output.
 	RouteTreasury = "/treasury"
 
@@ -121,6 +156,21 @@ const (
 	// RouteControlSnapshotsCreate is the control route to manually create a snapshot files.
 	// POST creates a snapshot (full, delta or both).
 	RouteControlSnapshotsCreate = "/control/snapshots/create"
+
+	// RouteControlPeersBans is the control route to manage the temporarily banned peers and subnets.
+	// GET returns all active bans.
+	// POST bans a peer or a subnet and closes all its connections.
+	// DELETE removes a ban (query parameters: "target").
+	RouteControlPeersBans = "/control/peers/bans"
+
+	// RouteControlTips is the control route to inspect and flush the tip pools of the tip-selection.
+	// GET returns the tips of the non-lazy and semi-lazy pool (optional query parameters: "pool").
+	// DELETE removes all tips from a pool (query parameters: "pool").
+	RouteControlTips = "/control/tips"
+
+	// RouteControlTip is the control route to manually evict a tip from the tip pools.
+	// DELETE removes the tip.
+	RouteControlTip = "/control/tips/:" + restapipkg.ParameterMessageID
 )
 
 func init() {
@@ -153,6 +203,7 @@ type dependencies struct {
 	Tangle                                *tangle.Tangle
 	PeeringManager                        *p2p.Manager
 	GossipService                         *gossip.Service
+	ReputationManager                     *gossip.ReputationManager
 	UTXOManager                           *utxo.Manager
 	PoWHandler                            *pow.Handler
 	MessageProcessor                      *gossip.MessageProcessor
@@ -160,6 +211,9 @@ type dependencies struct {
 	AppInfo                               *app.AppInfo
 	NodeConfig                            *configuration.Configuration `name:"nodeConfig"`
 	PeeringConfigManager                  *p2p.ConfigManager
+	AddressBook                           *p2p.AddressBook
+	ConnectionGater                       *p2p.ConnectionGater
+	Host                                  host.Host
 	NetworkID                             uint64                 `name:"networkId"`
 	NetworkIDName                         string                 `name:"networkIdName"`
 	MaxDeltaMsgYoungestConeRootIndexToCMI int                    `name:"maxDeltaMsgYoungestConeRootIndexToCMI"`
@@ -172,6 +226,7 @@ type dependencies struct {
 	SnapshotsDeltaPath                    string                 `name:"snapshotsDeltaPath"`
 	TipSelector                           *tipselect.TipSelector `optional:"true"`
 	Echo                                  *echo.Echo             `optional:"true"`
+	OpenAPIRegistry                       *openapi.Registry      `optional:"true"`
 }
 
 func configure() {
@@ -180,10 +235,11 @@ func configure() {
 		Plugin.LogPanic("RestAPI plugin needs to be enabled to use the RestAPIV1 plugin")
 	}
 
-	routeGroup := deps.Echo.Group("/api/v1")
+	routeGroup := deps.Echo.Group(routeGroupPrefix)
 
 	powEnabled = deps.NodeConfig.Bool(restapi.CfgRestAPIPoWEnabled)
 	powWorkerCount = deps.NodeConfig.Int(restapi.CfgRestAPIPoWWorkerCount)
+	maxWaitTimeout = deps.NodeConfig.Duration(restapi.CfgRestAPILimitsMaxWaitTimeout)
 
 	// Check for features
 	features = []string{}
@@ -262,6 +318,29 @@ func configure() {
 		return restapipkg.JSONResponse(c, http.StatusCreated, resp)
 	})
 
+	routeGroup.POST(RouteMessagesWait, func(c echo.Context) error {
+		resp, err := sendMessageAndWait(c)
+		if err != nil {
+			return err
+		}
+		c.Response().Header().Set(echo.HeaderLocation, resp.MessageID)
+
+		if resp.ReferencedByMilestoneIndex == nil {
+			// the message was not referenced before the timeout expired
+			return restapipkg.JSONResponse(c, http.StatusAccepted, resp)
+		}
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.POST(RouteMessagesMetadata, func(c echo.Context) error {
+		resp, err := messagesMetadataByIDs(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.GET(RouteTransactionsIncludedMessage, func(c echo.Context) error {
 		resp, err := messageByTransactionID(c)
 		if err != nil {
@@ -271,6 +350,15 @@ func configure() {
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.POST(RouteTransactionsValidate, func(c echo.Context) error {
+		resp, err := validateTransaction(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.GET(RouteMilestone, func(c echo.Context) error {
 		resp, err := milestoneByIndex(c)
 		if err != nil {
@@ -289,6 +377,33 @@ func configure() {
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.GET(RouteMilestoneReferencedMessages, func(c echo.Context) error {
+		resp, err := milestoneReferencedMessagesByIndex(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteMilestoneByTimestamp, func(c echo.Context) error {
+		resp, err := milestoneByTimestamp(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteMilestones, func(c echo.Context) error {
+		resp, err := milestones(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.GET(RouteOutput, func(c echo.Context) error {
 		resp, err := outputByID(c)
 		if err != nil {
@@ -298,6 +413,15 @@ func configure() {
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.POST(RouteOutputs, func(c echo.Context) error {
+		resp, err := outputsByIDs(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.GET(RouteAddressBech32Balance, func(c echo.Context) error {
 		resp, err := balanceByBech32Address(c)
 		if err != nil {
@@ -334,6 +458,15 @@ func configure() {
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.POST(RouteAddressesBalances, func(c echo.Context) error {
+		resp, err := balancesByBech32Addresses(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.GET(RouteTreasury, func(c echo.Context) error {
 		resp, err := treasury(c)
 		if err != nil {
@@ -413,6 +546,63 @@ func configure() {
 
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
+
+	routeGroup.GET(RouteControlPeersBans, func(c echo.Context) error {
+		resp, err := listBans(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.POST(RouteControlPeersBans, func(c echo.Context) error {
+		resp, err := addBan(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.DELETE(RouteControlPeersBans, func(c echo.Context) error {
+		if err := removeBan(c); err != nil {
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	// only handle tip pool api calls if the URTS plugin is enabled
+	if deps.TipSelector != nil {
+		routeGroup.GET(RouteControlTips, func(c echo.Context) error {
+			resp, err := tipPools(c)
+			if err != nil {
+				return err
+			}
+
+			return restapipkg.JSONResponse(c, http.StatusOK, resp)
+		})
+
+		routeGroup.DELETE(RouteControlTips, func(c echo.Context) error {
+			resp, err := flushTipPool(c)
+			if err != nil {
+				return err
+			}
+
+			return restapipkg.JSONResponse(c, http.StatusOK, resp)
+		})
+
+		routeGroup.DELETE(RouteControlTip, func(c echo.Context) error {
+			if err := removeTip(c); err != nil {
+				return err
+			}
+
+			return c.NoContent(http.StatusNoContent)
+		})
+	}
+
+	deps.OpenAPIRegistry.Register(openAPIRoutes()...)
 }
 
 // AddFeature adds a feature for the RouteInfo endpoint.
@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/common"
+	"github.com/gohornet/hornet/pkg/restapi"
+	"github.com/gohornet/hornet/pkg/tipselect"
+)
+
+const (
+	// tipPoolNonLazy is the name of the non-lazy tip pool.
+	tipPoolNonLazy = "nonLazy"
+	// tipPoolSemiLazy is the name of the semi-lazy tip pool.
+	tipPoolSemiLazy = "semiLazy"
+)
+
+// parseTipPoolQueryParam returns the scores of the tip pools given by the query parameter "pool".
+// If the parameter is not mandatory and not given, both pools are returned.
+func parseTipPoolQueryParam(c echo.Context, mandatory bool) ([]tipselect.Score, error) {
+	pool := c.QueryParam("pool")
+
+	switch strings.ToLower(pool) {
+	case "":
+		if mandatory {
+			return nil, errors.WithMessage(restapi.ErrInvalidParameter, "pool has to be specified")
+		}
+		return []tipselect.Score{tipselect.ScoreNonLazy, tipselect.ScoreSemiLazy}, nil
+	case strings.ToLower(tipPoolNonLazy):
+		return []tipselect.Score{tipselect.ScoreNonLazy}, nil
+	case strings.ToLower(tipPoolSemiLazy):
+		return []tipselect.Score{tipselect.ScoreSemiLazy}, nil
+	default:
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid pool: %s, must be %s or %s", pool, tipPoolNonLazy, tipPoolSemiLazy)
+	}
+}
+
+func tipPoolName(score tipselect.Score) string {
+	if score == tipselect.ScoreSemiLazy {
+		return tipPoolSemiLazy
+	}
+	return tipPoolNonLazy
+}
+
+func tipPools(c echo.Context) (*tipPoolsResponse, error) {
+
+	scores, err := parseTipPoolQueryParam(c, false)
+	if err != nil {
+		return nil, err
+	}
+
+	nonLazyCount, semiLazyCount := deps.TipSelector.TipCount()
+
+	now := time.Now()
+	tips := []*tipPoolTipResponse{}
+	for _, score := range scores {
+		tipInfos, err := deps.TipSelector.PoolTips(score)
+		if err != nil {
+			if errors.Is(err, common.ErrOperationAborted) {
+				return nil, errors.WithMessage(echo.ErrServiceUnavailable, err.Error())
+			}
+			return nil, errors.WithMessagef(echo.ErrInternalServerError, "collecting tips failed, error: %s", err)
+		}
+
+		for _, tipInfo := range tipInfos {
+			tip := &tipPoolTipResponse{
+				MessageID:             tipInfo.MessageID.ToHex(),
+				Pool:                  tipPoolName(tipInfo.Score),
+				ChildrenCount:         tipInfo.ChildrenCount,
+				Own:                   tipInfo.Own,
+				YoungestConeRootIndex: tipInfo.YoungestConeRootIndex,
+				OldestConeRootIndex:   tipInfo.OldestConeRootIndex,
+			}
+			if !tipInfo.TimeAdded.IsZero() {
+				tip.Age = int64(now.Sub(tipInfo.TimeAdded).Seconds())
+			}
+			if !tipInfo.TimeFirstChild.IsZero() {
+				tip.FirstReferencedAt = tipInfo.TimeFirstChild.Unix()
+			}
+			tips = append(tips, tip)
+		}
+	}
+
+	return &tipPoolsResponse{
+		NonLazyCount:  nonLazyCount,
+		SemiLazyCount: semiLazyCount,
+		Tips:          tips,
+	}, nil
+}
+
+func removeTip(c echo.Context) error {
+
+	messageID, err := restapi.ParseMessageIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	if err := deps.TipSelector.RemoveTip(messageID); err != nil {
+		if errors.Is(err, tipselect.ErrTipNotFound) {
+			return errors.WithMessagef(echo.ErrNotFound, "tip not found: %s", messageID.ToHex())
+		}
+		return errors.WithMessagef(echo.ErrInternalServerError, "removing tip failed, error: %s", err)
+	}
+
+	return nil
+}
+
+func flushTipPool(c echo.Context) (*flushTipPoolResponse, error) {
+
+	scores, err := parseTipPoolQueryParam(c, true)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := deps.TipSelector.FlushPool(scores[0])
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "flushing tip pool failed, error: %s", err)
+	}
+
+	return &flushTipPoolResponse{
+		Pool:    tipPoolName(scores[0]),
+		Removed: removed,
+	}, nil
+}
@@ -6,7 +6,9 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 
+	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/restapi"
+	"github.com/gohornet/hornet/pkg/whiteflag"
 	"github.com/iotaledger/hive.go/kvstore"
 	iotago "github.com/iotaledger/iota.go/v2"
 )
@@ -37,3 +39,42 @@ func messageByTransactionID(c echo.Context) (*iotago.Message, error) {
 
 	return cachedMsg.Message().Message(), nil
 }
+
+func validateTransaction(c echo.Context) (*validateTransactionResponse, error) {
+
+	if !deps.SyncManager.IsNodeAlmostSynced() {
+		return nil, errors.WithMessage(echo.ErrServiceUnavailable, "node is not synced")
+	}
+
+	msg, err := parseMessageRequest(c)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, ok := msg.Payload.(*iotago.Transaction)
+	if !ok {
+		return nil, errors.WithMessage(restapi.ErrInvalidParameter, "invalid message, error: message contains no transaction payload")
+	}
+
+	transactionID, err := transaction.ID()
+	if err != nil {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid transaction, error: %s", err)
+	}
+
+	conflict, err := whiteflag.ValidateTransaction(deps.UTXOManager, transaction)
+	if err != nil {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid transaction, error: %s", err)
+	}
+
+	resp := &validateTransactionResponse{
+		TransactionID: hex.EncodeToString(transactionID[:]),
+		Valid:         conflict == storage.ConflictNone,
+	}
+
+	if conflict != storage.ConflictNone {
+		resp.ConflictReason = &conflict
+		resp.ConflictDescription = conflict.String()
+	}
+
+	return resp, nil
+}
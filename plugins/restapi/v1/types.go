@@ -47,6 +47,44 @@ type tipsResponse struct {
 	Tips []string `json:"tipMessageIds"`
 }
 
+// tipPoolTipResponse defines the state of a single tip in a tip pool.
+type tipPoolTipResponse struct {
+	// The hex encoded message ID of the tip.
+	MessageID string `json:"messageId"`
+	// The tip pool of the tip (nonLazy or semiLazy).
+	Pool string `json:"pool"`
+	// The amount of messages that reference the tip.
+	ChildrenCount uint32 `json:"childrenCount"`
+	// The time in seconds since the tip was added to the tip pool.
+	Age int64 `json:"age"`
+	// The unix timestamp at which the tip was referenced for the first time, if it was referenced.
+	FirstReferencedAt int64 `json:"firstReferencedAt,omitempty"`
+	// Whether the tip was issued by the node itself.
+	Own bool `json:"own"`
+	// The youngest cone root index of the tip.
+	YoungestConeRootIndex milestone.Index `json:"youngestConeRootIndex"`
+	// The oldest cone root index of the tip.
+	OldestConeRootIndex milestone.Index `json:"oldestConeRootIndex"`
+}
+
+// tipPoolsResponse defines the response of a GET control tips REST API call.
+type tipPoolsResponse struct {
+	// The amount of tips in the non-lazy pool.
+	NonLazyCount int `json:"nonLazyCount"`
+	// The amount of tips in the semi-lazy pool.
+	SemiLazyCount int `json:"semiLazyCount"`
+	// The tips of the requested pools.
+	Tips []*tipPoolTipResponse `json:"tips"`
+}
+
+// flushTipPoolResponse defines the response of a DELETE control tips REST API call.
+type flushTipPoolResponse struct {
+	// The flushed tip pool (nonLazy or semiLazy).
+	Pool string `json:"pool"`
+	// The amount of removed tips.
+	Removed int `json:"removed"`
+}
+
 // receiptsResponse defines the response of a receipts REST API call.
 type receiptsResponse struct {
 	Receipts []*utxo.ReceiptTuple `json:"receipts"`
@@ -74,6 +112,22 @@ type messageMetadataResponse struct {
 	ShouldReattach *bool `json:"shouldReattach,omitempty"`
 }
 
+// messagesMetadataRequest defines the request of a POST messages metadata REST API call.
+type messagesMetadataRequest struct {
+	// The hex encoded message IDs of the messages.
+	MessageIDs []string `json:"messageIds"`
+}
+
+// messagesMetadataResponse defines the response of a POST messages metadata REST API call.
+type messagesMetadataResponse struct {
+	// The metadata of the found messages in the order of the request.
+	Messages []*messageMetadataResponse `json:"messages"`
+	// The hex encoded message IDs of the messages that were not found.
+	NotFound []string `json:"notFound"`
+	// The ledger index at which the metadata was queried at.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+}
+
 // messageCreatedResponse defines the response of a POST messages REST API call.
 type messageCreatedResponse struct {
 	// The hex encoded message ID of the message.
@@ -104,6 +158,18 @@ type messageIDsByIndexResponse struct {
 	MessageIDs []string `json:"messageIds"`
 }
 
+// validateTransactionResponse defines the response of a POST validate transaction REST API call.
+type validateTransactionResponse struct {
+	// The hex encoded transaction ID.
+	TransactionID string `json:"transactionId"`
+	// Whether the transaction would mutate the ledger if it was referenced by a milestone.
+	Valid bool `json:"valid"`
+	// The reason why the transaction would be marked as conflicting.
+	ConflictReason *storage.Conflict `json:"conflictReason,omitempty"`
+	// The human readable description of the conflict reason.
+	ConflictDescription string `json:"conflictDescription,omitempty"`
+}
+
 // milestoneResponse defines the response of a GET milestones REST API call.
 type milestoneResponse struct {
 	// The index of the milestone.
@@ -114,6 +180,22 @@ type milestoneResponse struct {
 	Time int64 `json:"timestamp"`
 }
 
+// milestonesResponse defines the response of a GET milestones REST API call.
+type milestonesResponse struct {
+	// The index of the first listed milestone.
+	StartIndex milestone.Index `json:"startIndex"`
+	// The index of the last listed milestone.
+	EndIndex milestone.Index `json:"endIndex"`
+	// The index of the youngest pruned milestone. Older milestones are not available.
+	PruningIndex milestone.Index `json:"pruningIndex"`
+	// The maximum count of results that are returned by the node.
+	MaxResults uint32 `json:"maxResults"`
+	// The actual count of results that are returned.
+	Count uint32 `json:"count"`
+	// The milestones in the range.
+	Milestones []*milestoneResponse `json:"milestones"`
+}
+
 // milestoneUTXOChangesResponse defines the response of a GET milestone UTXO changes REST API call.
 type milestoneUTXOChangesResponse struct {
 	// The index of the milestone.
@@ -124,6 +206,32 @@ type milestoneUTXOChangesResponse struct {
 	ConsumedOutputs []string `json:"consumedOutputs"`
 }
 
+// referencedMessageResponse defines a message that was referenced by a milestone.
+type referencedMessageResponse struct {
+	// The hex encoded message ID of the message.
+	MessageID string `json:"messageId"`
+	// The ledger inclusion state of the transaction payload.
+	LedgerInclusionState string `json:"ledgerInclusionState"`
+	// The reason why this message is marked as conflicting.
+	ConflictReason *storage.Conflict `json:"conflictReason,omitempty"`
+}
+
+// milestoneReferencedMessagesResponse defines the response of a GET milestone referenced messages REST API call.
+type milestoneReferencedMessagesResponse struct {
+	// The index of the milestone.
+	Index milestone.Index `json:"index"`
+	// The position of the first returned message in the white-flag order.
+	Offset uint32 `json:"offset"`
+	// The maximum amount of returned messages.
+	Limit uint32 `json:"limit"`
+	// The amount of returned messages.
+	Count uint32 `json:"count"`
+	// The total amount of messages referenced by the milestone.
+	Total uint32 `json:"total"`
+	// The messages referenced by the milestone in the white-flag order, followed by the milestone message itself.
+	Messages []*referencedMessageResponse `json:"messages"`
+}
+
 // OutputResponse defines the response of a GET outputs REST API call.
 type OutputResponse struct {
 	// The hex encoded message ID of the message.
@@ -140,6 +248,22 @@ type OutputResponse struct {
 	RawOutput *json.RawMessage `json:"output"`
 }
 
+// outputsByIDsRequest defines the request of a POST outputs REST API call.
+type outputsByIDsRequest struct {
+	// The hex encoded output IDs (transaction hash + output index) of the outputs.
+	OutputIDs []string `json:"outputIds"`
+}
+
+// outputsByIDsResponse defines the response of a POST outputs REST API call.
+type outputsByIDsResponse struct {
+	// The found outputs in the order of the request.
+	Outputs []*OutputResponse `json:"outputs"`
+	// The hex encoded output IDs of the outputs that were not found.
+	NotFound []string `json:"notFound"`
+	// The ledger index at which the outputs were queried at.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+}
+
 // addressBalanceResponse defines the response of a GET addresses REST API call.
 type addressBalanceResponse struct {
 	// The type of the address (0=Ed25519).
@@ -154,6 +278,20 @@ type addressBalanceResponse struct {
 	LedgerIndex milestone.Index `json:"ledgerIndex"`
 }
 
+// addressesBalancesRequest defines the request of a POST addresses balances REST API call.
+type addressesBalancesRequest struct {
+	// The bech32 encoded addresses.
+	Addresses []string `json:"addresses"`
+}
+
+// addressesBalancesResponse defines the response of a POST addresses balances REST API call.
+type addressesBalancesResponse struct {
+	// The balances of the addresses in the order of the request.
+	Balances []*addressBalanceResponse `json:"balances"`
+	// The ledger index at which the balances were queried at.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+}
+
 // addressOutputsResponse defines the response of a GET outputs by address REST API call.
 type addressOutputsResponse struct {
 	// The type of the address (0=Ed25519).
@@ -198,6 +336,30 @@ type PeerResponse struct {
 	Connected bool `json:"connected"`
 	// The gossip protocol information of the peer.
 	Gossip *gossip.Info `json:"gossip,omitempty"`
+	// The reputation of the peer.
+	Reputation *gossip.PeerReputationSnapshot `json:"reputation,omitempty"`
+}
+
+// addBanRequest defines the request for a POST ban REST API call.
+type addBanRequest struct {
+	// The peer ID, IP address or CIDR to ban.
+	Target string `json:"target"`
+	// The duration of the ban (e.g. "1h30m"), the default ban duration is used if empty.
+	Duration *string `json:"duration,omitempty"`
+}
+
+// BanResponse defines the response of a ban REST API call.
+type BanResponse struct {
+	// The banned peer ID or CIDR.
+	Target string `json:"target"`
+	// The unix timestamp at which the ban expires.
+	Expires int64 `json:"expires"`
+}
+
+// banListResponse defines the response of a GET bans REST API call.
+type banListResponse struct {
+	// The active bans.
+	Bans []*BanResponse `json:"bans"`
 }
 
 // pruneDatabaseRequest defines the request of a prune database REST API call.
@@ -68,6 +68,13 @@ func outputByID(c echo.Context) (*OutputResponse, error) {
 		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading output failed: %s, error: %s", outputID.ToHex(), err)
 	}
 
+	return outputByIDWithoutLocking(outputID, ledgerIndex)
+}
+
+// outputByIDWithoutLocking returns the output with the given ID.
+// ledger read lock must be acquired outside.
+func outputByIDWithoutLocking(outputID *iotago.UTXOInputID, ledgerIndex milestone.Index) (*OutputResponse, error) {
+
 	output, err := deps.UTXOManager.ReadOutputByOutputIDWithoutLocking(outputID)
 	if err != nil {
 		if errors.Is(err, kvstore.ErrKeyNotFound) {
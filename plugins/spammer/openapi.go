@@ -0,0 +1,32 @@
+package spammer
+
+import (
+	"net/http"
+
+	"github.com/gohornet/hornet/pkg/openapi"
+)
+
+// openAPIRoutes returns the descriptions of all routes of the plugin for the OpenAPI document.
+func openAPIRoutes() []*openapi.Route {
+	return []*openapi.Route{
+		{
+			Method:   http.MethodGet,
+			Path:     RouteSpammer + RouteSpammerStatus,
+			Summary:  "Returns the current status of the spammer.",
+			Response: &spammerStatus{},
+		},
+		{
+			Method:         http.MethodPost,
+			Path:           RouteSpammer + RouteSpammerStart,
+			Summary:        "Starts the spammer with optionally changed settings.",
+			Request:        &startCommand{},
+			ResponseStatus: http.StatusAccepted,
+		},
+		{
+			Method:         http.MethodPost,
+			Path:           RouteSpammer + RouteSpammerStop,
+			Summary:        "Stops the spammer.",
+			ResponseStatus: http.StatusAccepted,
+		},
+	}
+}
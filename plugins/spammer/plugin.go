@@ -17,6 +17,7 @@ import (
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/model/syncmanager"
 	"github.com/gohornet/hornet/pkg/node"
+	"github.com/gohornet/hornet/pkg/openapi"
 	"github.com/gohornet/hornet/pkg/p2p"
 	"github.com/gohornet/hornet/pkg/pow"
 	"github.com/gohornet/hornet/pkg/protocol/gossip"
@@ -90,6 +91,7 @@ type dependencies struct {
 	NodeConfig       *configuration.Configuration `name:"nodeConfig"`
 	NetworkID        uint64                       `name:"networkId"`
 	Echo             *echo.Echo                   `optional:"true"`
+	OpenAPIRegistry  *openapi.Registry            `optional:"true"`
 }
 
 func configure() {
@@ -104,6 +106,7 @@ func configure() {
 	}
 
 	setupRoutes(deps.Echo.Group(RouteSpammer))
+	deps.OpenAPIRegistry.Register(openAPIRoutes()...)
 
 	spammerAvgHeap = utils.NewTimeHeap()
 
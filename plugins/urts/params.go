@@ -35,6 +35,17 @@ const (
 	// CfgTipSelSpammerTipsThreshold is the maximum amount of tips in a tip-pool before the spammer tries to reduce these (0 = disable (semi-lazy), 0 = always (non-lazy))
 	// this is used to support the network if someone attacks the tangle by spamming a lot of tips
 	CfgTipSelSpammerTipsThreshold = "spammerTipsThreshold"
+	// CfgTipSelStrategyAgeFactor is the additional weight per second a tip is in the tip pool (0 = disable)
+	// this is used to get older tips referenced before they become lazy.
+	CfgTipSelStrategyAgeFactor = "tipsel.strategy.ageFactor"
+	// CfgTipSelStrategyChildrenFactor defines how strong the weight of a tip decreases for every child (0 = disable)
+	// this is used to widen the cone of the tangle.
+	CfgTipSelStrategyChildrenFactor = "tipsel.strategy.childrenFactor"
+	// CfgTipSelStrategyOwnMessagesFactor is the weight of tips issued by the node itself (0 = disable)
+	CfgTipSelStrategyOwnMessagesFactor = "tipsel.strategy.ownMessagesFactor"
+	// CfgTipSelRandomSeed is the seed used to make the tip selection reproducible (0 = random)
+	// this should only be used for tests and private tangles.
+	CfgTipSelRandomSeed = "tipsel.randomSeed"
 )
 
 var params = &node.PluginParams{
@@ -61,6 +72,10 @@ var params = &node.PluginParams{
 				"before the tip is removed from the tip pool (semi-lazy)")
 			fs.Int(CfgTipSelSemiLazy+CfgTipSelSpammerTipsThreshold, 30, "the maximum amount of tips in a tip-pool (semi-lazy) before "+
 				"the spammer tries to reduce these (0 = disable)")
+			fs.Float64(CfgTipSelStrategyAgeFactor, 0, "the additional weight per second a tip is in the tip pool (0 = disable)")
+			fs.Float64(CfgTipSelStrategyChildrenFactor, 0, "defines how strong the weight of a tip decreases for every child (0 = disable)")
+			fs.Float64(CfgTipSelStrategyOwnMessagesFactor, 0, "the weight of tips issued by the node itself (0 = disable)")
+			fs.Int64(CfgTipSelRandomSeed, 0, "the seed used to make the tip selection reproducible (0 = random)")
 			return fs
 		}(),
 	},
@@ -12,6 +12,7 @@ import (
 	"github.com/gohornet/hornet/pkg/model/storage"
 	"github.com/gohornet/hornet/pkg/model/syncmanager"
 	"github.com/gohornet/hornet/pkg/node"
+	"github.com/gohornet/hornet/pkg/protocol/gossip"
 	"github.com/gohornet/hornet/pkg/shutdown"
 	"github.com/gohornet/hornet/pkg/tangle"
 	"github.com/gohornet/hornet/pkg/tipselect"
@@ -42,14 +43,17 @@ var (
 	// Closures
 	onMessageSolid       *events.Closure
 	onMilestoneConfirmed *events.Closure
+	onMessageProcessed   *events.Closure
 )
 
 type dependencies struct {
 	dig.In
-	TipSelector     *tipselect.TipSelector
-	SyncManager     *syncmanager.SyncManager
-	Tangle          *tangle.Tangle
-	ShutdownHandler *shutdown.ShutdownHandler
+	TipSelector      *tipselect.TipSelector
+	SyncManager      *syncmanager.SyncManager
+	Tangle           *tangle.Tangle
+	MessageProcessor *gossip.MessageProcessor
+	ShutdownHandler  *shutdown.ShutdownHandler
+	NodeConfig       *configuration.Configuration `name:"nodeConfig"`
 }
 
 func initConfigPars(c *dig.Container) {
@@ -91,31 +95,67 @@ func provide(c *dig.Container) {
 	}
 
 	if err := c.Provide(func(deps tipselDeps) *tipselect.TipSelector {
+		opts := []tipselect.Option{
+			tipselect.WithMaxDeltaMsgYoungestConeRootIndexToCMI(deps.MaxDeltaMsgYoungestConeRootIndexToCMI),
+			tipselect.WithMaxDeltaMsgOldestConeRootIndexToCMI(deps.MaxDeltaMsgOldestConeRootIndexToCMI),
+			tipselect.WithBelowMaxDepth(deps.BelowMaxDepth),
+
+			tipselect.WithRetentionRulesTipsLimitNonLazy(deps.NodeConfig.Int(CfgTipSelNonLazy + CfgTipSelRetentionRulesTipsLimit)),
+			tipselect.WithMaxReferencedTipAgeNonLazy(deps.NodeConfig.Duration(CfgTipSelNonLazy + CfgTipSelMaxReferencedTipAge)),
+			tipselect.WithMaxChildrenNonLazy(uint32(deps.NodeConfig.Int64(CfgTipSelNonLazy + CfgTipSelMaxChildren))),
+			tipselect.WithSpammerTipsThresholdNonLazy(deps.NodeConfig.Int(CfgTipSelNonLazy + CfgTipSelSpammerTipsThreshold)),
+
+			tipselect.WithRetentionRulesTipsLimitSemiLazy(deps.NodeConfig.Int(CfgTipSelSemiLazy + CfgTipSelRetentionRulesTipsLimit)),
+			tipselect.WithMaxReferencedTipAgeSemiLazy(deps.NodeConfig.Duration(CfgTipSelSemiLazy + CfgTipSelMaxReferencedTipAge)),
+			tipselect.WithMaxChildrenSemiLazy(uint32(deps.NodeConfig.Int64(CfgTipSelSemiLazy + CfgTipSelMaxChildren))),
+			tipselect.WithSpammerTipsThresholdSemiLazy(deps.NodeConfig.Int(CfgTipSelSemiLazy + CfgTipSelSpammerTipsThreshold)),
+
+			tipselect.WithStrategy(strategyFromConfig(deps.NodeConfig)),
+		}
+
+		if seed := deps.NodeConfig.Int64(CfgTipSelRandomSeed); seed != 0 {
+			Plugin.LogWarnf("tip selection is reproducible with random seed %d, this should only be used for tests and private tangles", seed)
+			opts = append(opts, tipselect.WithRandomSeed(seed))
+		}
+
 		return tipselect.New(
 			Plugin.Daemon().ContextStopped(),
 			deps.Storage,
 			deps.SyncManager,
 			deps.ServerMetrics,
-
-			deps.MaxDeltaMsgYoungestConeRootIndexToCMI,
-			deps.MaxDeltaMsgOldestConeRootIndexToCMI,
-			deps.BelowMaxDepth,
-
-			deps.NodeConfig.Int(CfgTipSelNonLazy+CfgTipSelRetentionRulesTipsLimit),
-			deps.NodeConfig.Duration(CfgTipSelNonLazy+CfgTipSelMaxReferencedTipAge),
-			uint32(deps.NodeConfig.Int64(CfgTipSelNonLazy+CfgTipSelMaxChildren)),
-			deps.NodeConfig.Int(CfgTipSelNonLazy+CfgTipSelSpammerTipsThreshold),
-
-			deps.NodeConfig.Int(CfgTipSelSemiLazy+CfgTipSelRetentionRulesTipsLimit),
-			deps.NodeConfig.Duration(CfgTipSelSemiLazy+CfgTipSelMaxReferencedTipAge),
-			uint32(deps.NodeConfig.Int64(CfgTipSelSemiLazy+CfgTipSelMaxChildren)),
-			deps.NodeConfig.Int(CfgTipSelSemiLazy+CfgTipSelSpammerTipsThreshold),
+			opts...,
 		)
 	}); err != nil {
 		Plugin.LogPanic(err)
 	}
 }
 
+// strategyFromConfig creates the tip selection strategy out of the configured strategy weights.
+func strategyFromConfig(nodeConfig *configuration.Configuration) tipselect.Strategy {
+	var strategies tipselect.CombinedStrategy
+
+	for _, key := range []string{CfgTipSelStrategyAgeFactor, CfgTipSelStrategyChildrenFactor, CfgTipSelStrategyOwnMessagesFactor} {
+		if factor := nodeConfig.Float64(key); factor < 0 {
+			Plugin.LogPanicf("invalid tip selection strategy factor '%s': %v, must not be negative", key, factor)
+		}
+	}
+
+	if factor := nodeConfig.Float64(CfgTipSelStrategyAgeFactor); factor != 0 {
+		strategies = append(strategies, tipselect.AgeWeightedStrategy{Factor: factor})
+	}
+	if factor := nodeConfig.Float64(CfgTipSelStrategyChildrenFactor); factor != 0 {
+		strategies = append(strategies, tipselect.ChildrenWeightedStrategy{Factor: factor})
+	}
+	if factor := nodeConfig.Float64(CfgTipSelStrategyOwnMessagesFactor); factor != 0 {
+		strategies = append(strategies, tipselect.OwnMessagesStrategy{Factor: factor})
+	}
+
+	if len(strategies) == 0 {
+		return tipselect.UniformStrategy{}
+	}
+	return strategies
+}
+
 func configure() {
 	configureEvents()
 }
@@ -171,14 +211,29 @@ func configureEvents() {
 		}
 		Plugin.LogDebugf("UpdateScores finished, removed: %d, took: %v", removedTipCount, time.Since(ts).Truncate(time.Millisecond))
 	})
+
+	onMessageProcessed = events.NewClosure(func(msg *storage.Message, _ gossip.Requests, proto *gossip.Protocol) {
+		if proto != nil {
+			// the message was received via gossip
+			return
+		}
+
+		// the message was issued by the node itself
+		deps.TipSelector.RegisterOwnMessage(msg.MessageID())
+	})
 }
 
 func attachEvents() {
 	deps.Tangle.Events.MessageSolid.Attach(onMessageSolid)
 	deps.Tangle.Events.MilestoneConfirmed.Attach(onMilestoneConfirmed)
+	if deps.NodeConfig.Float64(CfgTipSelStrategyOwnMessagesFactor) != 0 {
+		// own messages are only needed if they are preferred by the tip selection
+		deps.MessageProcessor.Events.MessageProcessed.Attach(onMessageProcessed)
+	}
 }
 
 func detachEvents() {
 	deps.Tangle.Events.MessageSolid.Detach(onMessageSolid)
 	deps.Tangle.Events.MilestoneConfirmed.Detach(onMilestoneConfirmed)
+	deps.MessageProcessor.Events.MessageProcessed.Detach(onMessageProcessed)
 }